@@ -0,0 +1,287 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+区块归档文件的格式:
+magic | 区块1 | 区块2 | ... | index | index offset | magic
+每个区块为 4 字节长度前缀 + gzip 压缩之后的 BlockDetail, 按照高度顺序写入
+index 为 BlockArchiveIndex, 记录每个区块的高度, hash, 位置以及压缩数据的 sha256
+*/
+
+//archiveMagic 归档文件开头以及结尾的标记
+var archiveMagic = []byte("C33ARCH1")
+
+const (
+	//ArchivePid 从归档文件导入的区块使用的 pid
+	ArchivePid = "archive"
+	//ArchiveTrustedPid 从可信的归档文件导入, 不检查签名的区块使用的 pid, 只有这个 pid 的区块执行时不检查签名
+	ArchiveTrustedPid = "archive-trusted"
+)
+
+//ArchiveWriter 按照高度顺序写入区块, Close 的时候写入索引
+type ArchiveWriter struct {
+	file   *os.File
+	w      *bufio.Writer
+	offset int64
+	index  types.BlockArchiveIndex
+}
+
+//NewArchiveWriter 创建归档文件
+func NewArchiveWriter(name string) (*ArchiveWriter, error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	w := &ArchiveWriter{file: file, w: bufio.NewWriter(file)}
+	if err = w.write(archiveMagic); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *ArchiveWriter) write(data []byte) error {
+	n, err := w.w.Write(data)
+	w.offset += int64(n)
+	return err
+}
+
+//Write 写入一个区块
+func (w *ArchiveWriter) Write(detail *types.BlockDetail) error {
+	var buf bytes.Buffer
+	g := gzip.NewWriter(&buf)
+	if _, err := g.Write(types.Encode(detail)); err != nil {
+		return err
+	}
+	if err := g.Close(); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	if err := w.write(size[:]); err != nil {
+		return err
+	}
+	entry := &types.BlockArchiveEntry{
+		Height:   detail.Block.Height,
+		Hash:     detail.Block.Hash(),
+		Offset:   w.offset,
+		Size:     int32(len(data)),
+		Checksum: common.Sha256(data),
+	}
+	if err := w.write(data); err != nil {
+		return err
+	}
+	w.index.Entries = append(w.index.Entries, entry)
+	return nil
+}
+
+//Close 写入索引并关闭文件
+func (w *ArchiveWriter) Close() error {
+	defer w.file.Close()
+	indexOffset := w.offset
+	if err := w.write(types.Encode(&w.index)); err != nil {
+		return err
+	}
+	var footer [8]byte
+	binary.BigEndian.PutUint64(footer[:], uint64(indexOffset))
+	if err := w.write(footer[:]); err != nil {
+		return err
+	}
+	if err := w.write(archiveMagic); err != nil {
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+//ArchiveReader 读取归档文件, 打开的时候先读取索引
+type ArchiveReader struct {
+	file  *os.File
+	index types.BlockArchiveIndex
+}
+
+//OpenArchive 打开归档文件, 没有索引的文件(导出时中断)不能使用
+func OpenArchive(name string) (*ArchiveReader, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	r := &ArchiveReader{file: file}
+	if err = r.readIndex(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *ArchiveReader) readIndex() error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	footerSize := int64(8 + len(archiveMagic))
+	if info.Size() < int64(len(archiveMagic))+footerSize {
+		return types.ErrArchiveFormat
+	}
+	head := make([]byte, len(archiveMagic))
+	if _, err = r.file.ReadAt(head, 0); err != nil {
+		return err
+	}
+	footer := make([]byte, footerSize)
+	if _, err = r.file.ReadAt(footer, info.Size()-footerSize); err != nil {
+		return err
+	}
+	if !bytes.Equal(head, archiveMagic) || !bytes.Equal(footer[8:], archiveMagic) {
+		return types.ErrArchiveFormat
+	}
+	indexOffset := int64(binary.BigEndian.Uint64(footer[:8]))
+	if indexOffset < int64(len(archiveMagic)) || indexOffset > info.Size()-footerSize {
+		return types.ErrArchiveFormat
+	}
+	data := make([]byte, info.Size()-footerSize-indexOffset)
+	if _, err = r.file.ReadAt(data, indexOffset); err != nil {
+		return err
+	}
+	if err = types.Decode(data, &r.index); err != nil {
+		return types.ErrArchiveFormat
+	}
+	return nil
+}
+
+//Entries 归档文件中所有区块的索引, 按照高度排序
+func (r *ArchiveReader) Entries() []*types.BlockArchiveEntry {
+	return r.index.Entries
+}
+
+//Read 读取一个区块, 校验压缩数据的 checksum 以及区块的 hash
+func (r *ArchiveReader) Read(entry *types.BlockArchiveEntry) (*types.BlockDetail, error) {
+	data := make([]byte, entry.Size)
+	if _, err := r.file.ReadAt(data, entry.Offset); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(common.Sha256(data), entry.Checksum) {
+		return nil, types.ErrArchiveChecksum
+	}
+	g, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer g.Close()
+	raw, err := ioutil.ReadAll(io.LimitReader(g, int64(types.MaxBlockSize)*2))
+	if err != nil {
+		return nil, err
+	}
+	var detail types.BlockDetail
+	if err = types.Decode(raw, &detail); err != nil {
+		return nil, err
+	}
+	if detail.Block == nil || detail.Block.Height != entry.Height || !bytes.Equal(detail.Block.Hash(), entry.Hash) {
+		return nil, types.ErrArchiveChecksum
+	}
+	return &detail, nil
+}
+
+//Close 关闭归档文件
+func (r *ArchiveReader) Close() error {
+	return r.file.Close()
+}
+
+//ExportBlocks 按照高度顺序导出 start 到 end 的区块到归档文件, end 小于 0 时导出到最新的高度
+func (bs *BlockStore) ExportBlocks(name string, start, end int64) error {
+	height := bs.Height()
+	if end < 0 || end > height {
+		end = height
+	}
+	if start < 0 || start > end {
+		return types.ErrStartBigThanEnd
+	}
+	w, err := NewArchiveWriter(name)
+	if err != nil {
+		return err
+	}
+	for i := start; i <= end; i++ {
+		detail, err := bs.LoadBlockByHeight(i)
+		if err != nil {
+			w.file.Close()
+			return err
+		}
+		if err = w.Write(detail); err != nil {
+			w.file.Close()
+			return err
+		}
+		if i%1000 == 0 {
+			storeLog.Info("ExportBlocks", "height", i, "end", end)
+		}
+	}
+	return w.Close()
+}
+
+//ImportBlocks 从归档文件导入区块, 每个区块都通过 ProcessBlock 执行和验证.
+//本地已经有的区块只检查 hash 是否一致, 所以导入中断之后可以重新执行继续导入.
+//skipSign 为 true 时不检查区块和交易的签名, 只用于可信的归档文件
+func (chain *BlockChain) ImportBlocks(name string, skipSign bool) error {
+	r, err := OpenArchive(name)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	//是否检查签名跟随区块的 pid, 不影响同时从其他节点同步的区块
+	pid := ArchivePid
+	if skipSign {
+		pid = ArchiveTrustedPid
+	}
+	for _, entry := range r.Entries() {
+		if entry.Height <= chain.GetBlockHeight() {
+			hash, err := chain.blockStore.GetBlockHashByHeight(entry.Height)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(hash, entry.Hash) {
+				chainlog.Error("ImportBlocks hash not match", "height", entry.Height, "hash", common.ToHex(hash), "archive", common.ToHex(entry.Hash))
+				return types.ErrBlockHashNoMatch
+			}
+			continue
+		}
+		detail, err := r.Read(entry)
+		if err != nil {
+			chainlog.Error("ImportBlocks read", "height", entry.Height, "err", err)
+			return err
+		}
+		//之前执行失败的区块还在 index 中, 删除之后重新执行
+		if chain.index.HaveBlock(entry.Hash) {
+			chain.index.DelNode(entry.Hash)
+		}
+		_, isMainChain, isOrphan, err := chain.ProcessBlock(false, detail, pid, true, -1)
+		if err != nil {
+			chainlog.Error("ImportBlocks ProcessBlock", "height", entry.Height, "err", err)
+			return err
+		}
+		//归档文件中的区块必须能够连接到本地的主链上
+		if isOrphan || !isMainChain {
+			return types.ErrBlockHashNoMatch
+		}
+		if entry.Height%1000 == 0 {
+			chainlog.Info("ImportBlocks", "height", entry.Height)
+		}
+	}
+	return nil
+}
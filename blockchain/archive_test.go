@@ -0,0 +1,147 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/consensus"
+	"github.com/33cn/chain33/executor"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportBlocks(t *testing.T) {
+	log.SetLogLevel("crit")
+	dir, err := ioutil.TempDir("", "archive")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "blocks.archive")
+
+	mock33 := testnode.New("", nil)
+	for i := int64(1); i <= 3; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	store := mock33.GetBlockChain().GetStore()
+	assert.Equal(t, types.ErrStartBigThanEnd, store.ExportBlocks(name, 4, -1))
+	require.Nil(t, store.ExportBlocks(name, 0, -1))
+	last := mock33.GetLastBlock()
+	mock33.Close()
+
+	r, err := blockchain.OpenArchive(name)
+	require.Nil(t, err)
+	entries := r.Entries()
+	require.Equal(t, 4, len(entries))
+	detail, err := r.Read(entries[3])
+	require.Nil(t, err)
+	assert.Equal(t, last.Hash(), detail.Block.Hash())
+
+	//修改交易的签名不会改变区块的 hash, 只有检查签名的时候才能发现
+	unsigned := filepath.Join(dir, "unsigned.archive")
+	w, err := blockchain.NewArchiveWriter(unsigned)
+	require.Nil(t, err)
+	for _, entry := range entries {
+		detail, err := r.Read(entry)
+		require.Nil(t, err)
+		if entry.Height == 2 {
+			detail.Block.Txs[0].Signature.Signature[0] ^= 0xff
+		}
+		require.Nil(t, w.Write(detail))
+	}
+	require.Nil(t, w.Close())
+	r.Close()
+
+	//修改压缩的数据之后 checksum 验证失败
+	data, err := ioutil.ReadFile(name)
+	require.Nil(t, err)
+	bad := filepath.Join(dir, "bad.archive")
+	data[entries[2].Offset+10] ^= 0xff
+	require.Nil(t, ioutil.WriteFile(bad, data, 0644))
+	//没有索引的文件不能导入
+	truncated := filepath.Join(dir, "truncated.archive")
+	require.Nil(t, ioutil.WriteFile(truncated, data[:entries[3].Offset], 0644))
+
+	mock33 = testnode.New("", nil)
+	defer mock33.Close()
+	require.Nil(t, mock33.WaitHeight(0))
+	chain := mock33.GetBlockChain()
+	assert.Equal(t, types.ErrArchiveFormat, chain.ImportBlocks(truncated, false))
+	assert.Equal(t, types.ErrArchiveChecksum, chain.ImportBlocks(bad, false))
+	assert.Equal(t, int64(1), chain.GetBlockHeight())
+
+	assert.Equal(t, types.ErrSign, chain.ImportBlocks(unsigned, false))
+	assert.Equal(t, int64(1), chain.GetBlockHeight())
+
+	//已经导入的区块只检查 hash, 继续导入剩下的区块
+	require.Nil(t, chain.ImportBlocks(unsigned, true))
+	assert.Equal(t, int64(3), chain.GetBlockHeight())
+	assert.Equal(t, last.Hash(), mock33.GetLastBlock().Hash())
+	require.Nil(t, chain.ImportBlocks(name, false))
+}
+
+func TestImportBlocksNoMining(t *testing.T) {
+	log.SetLogLevel("crit")
+	dir, err := ioutil.TempDir("", "archive")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "blocks.archive")
+
+	mock33 := testnode.New("", nil)
+	for i := int64(1); i <= 2; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	require.Nil(t, mock33.GetBlockChain().GetStore().ExportBlocks(name, 0, -1))
+	last := mock33.GetLastBlock()
+	mock33.Close()
+
+	//和 import-blocks 命令一样不启动钱包, 共识模块不挖矿
+	cfg, sub := testnode.GetDefaultConfig()
+	cfg.Consensus.Minerstart = false
+	types.Init(cfg.Title, cfg)
+	q := queue.New("channel")
+	exec := executor.New(cfg.Exec, sub.Exec)
+	exec.SetQueueClient(q.Client())
+	s := store.New(cfg.Store, sub.Store)
+	s.SetQueueClient(q.Client())
+	chain := blockchain.New(cfg.BlockChain)
+	chain.SetQueueClient(q.Client())
+	cs := consensus.New(cfg.Consensus, sub.Consensus)
+	cs.SetQueueClient(q.Client())
+	for _, topic := range []string{"wallet", "mempool", "rpc"} {
+		client := q.Client()
+		client.Sub(topic)
+		go func() {
+			for range client.Recv() {
+			}
+		}()
+	}
+	go q.Start()
+	defer func() {
+		chain.Close()
+		exec.Close()
+		s.Close()
+		cs.Close()
+		q.Close()
+	}()
+	for chain.GetBlockHeight() < 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Nil(t, chain.ImportBlocks(name, false))
+	assert.Equal(t, int64(2), chain.GetBlockHeight())
+	assert.Equal(t, last.Hash(), chain.GetStore().LastHeader().Hash)
+}
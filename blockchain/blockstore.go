@@ -36,6 +36,7 @@ var (
 	HashToSeqPerfix       = []byte("HashToSeq:")
 	seqCBPrefix           = []byte("SCB:")
 	seqCBLastNumPrefix    = []byte("SCBL:")
+	seqCBStatePrefix      = []byte("SCBS:")
 	storeLog              = chainlog.New("submodule", "store")
 	lastheaderlock        sync.Mutex
 	AddBlock              int64 = 1
@@ -47,6 +48,7 @@ func GetLocalDBKeyList() [][]byte {
 	return [][]byte{
 		blockLastHeight, bodyPerfix, LastSequence, headerPerfix, heightToHeaderPerfix,
 		hashPerfix, tdPerfix, heightToHashKeyPerfix, seqToHashKey, HashToSeqPerfix,
+		blockPruneHeight, reorgRecordPerfix, reorgLastIndex,
 	}
 }
 
@@ -65,6 +67,11 @@ func caclSeqCBLastNumKey(name []byte) []byte {
 	return append(append([]byte{}, seqCBLastNumPrefix...), name...)
 }
 
+//并发访问的可能性(每次开辟新内存)
+func calcSeqCBStateKey(name []byte) []byte {
+	return append(append([]byte{}, seqCBStatePrefix...), name...)
+}
+
 //存储block hash对应的header信息
 func calcHashToBlockHeaderKey(hash []byte) []byte {
 	return append(headerPerfix, hash...)
@@ -105,6 +112,12 @@ type BlockStore struct {
 	client    queue.Client
 	height    int64
 	lastBlock *types.Block
+	//轻节点没有区块体, 不能通过 lastBlock 计算区块hash
+	lastHeader *types.Header
+	//低于这个高度的区块已经被裁剪
+	pruneHeight int64
+	//通过状态快照同步的节点, 快照高度以下没有区块序列号
+	snapshotHeight int64
 }
 
 //NewBlockStore new
@@ -127,12 +140,28 @@ func NewBlockStore(db dbm.DB, client queue.Client) *BlockStore {
 			blockStore.saveQuickIndexFlag()
 		}
 	} else {
-		blockdetail, err := blockStore.LoadBlockByHeight(height)
+		blockStore.pruneHeight, err = blockStore.loadFlag(blockPruneHeight)
+		if err != nil {
+			panic(err)
+		}
+		blockStore.snapshotHeight, err = blockStore.loadFlag(blockSnapshotHeight)
 		if err != nil {
+			panic(err)
+		}
+		blockdetail, err := blockStore.LoadBlockByHeight(height)
+		if err == types.ErrBlockPruned {
+			//轻节点只有区块头
+			header, err := blockStore.GetBlockHeaderByHeight(height)
+			if err != nil {
+				panic(err)
+			}
+			blockStore.UpdateLastHeader(header)
+		} else if err != nil {
 			chainlog.Error("init::LoadBlockByHeight::database may be crash")
 			panic(err)
+		} else {
+			blockStore.lastBlock = blockdetail.GetBlock()
 		}
-		blockStore.lastBlock = blockdetail.GetBlock()
 		flag, err := blockStore.loadFlag(types.FlagTxQuickIndex)
 		if err != nil {
 			panic(err)
@@ -204,9 +233,41 @@ func (bs *BlockStore) addBlockSeqCB(cb *types.BlockSeqCB) error {
 	if len(cb.Name) > 128 || len(cb.URL) > 1024 {
 		return types.ErrInvalidParam
 	}
+	if cb.BatchSize < 0 || cb.BatchSize > MaxSeqCBBatch || cb.MaxRetry < 0 {
+		return types.ErrInvalidParam
+	}
+	switch cb.Transport {
+	case "", types.SeqCBTransportHTTP, types.SeqCBTransportGRPC, types.SeqCBTransportFile:
+	default:
+		return types.ErrInvalidParam
+	}
 	return bs.db.SetSync(calcSeqCBKey([]byte(cb.Name)), types.Encode(cb))
 }
 
+func (bs *BlockStore) getBlockSeqCB(name []byte) (*types.BlockSeqCB, error) {
+	value, err := bs.db.Get(calcSeqCBKey(name))
+	if err != nil || value == nil {
+		return nil, types.ErrSeqCBNotFound
+	}
+	var cb types.BlockSeqCB
+	err = types.Decode(value, &cb)
+	if err != nil {
+		return nil, err
+	}
+	return &cb, nil
+}
+
+//删除订阅以及推送的进度和状态, memdb 的 batch 删除会留下空值, 所以逐个删除
+func (bs *BlockStore) delBlockSeqCB(name []byte) error {
+	keys := [][]byte{calcSeqCBKey(name), caclSeqCBLastNumKey(name), calcSeqCBStateKey(name)}
+	for _, key := range keys {
+		if err := bs.db.DeleteSync(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (bs *BlockStore) listSeqCB() (cbs []*types.BlockSeqCB, err error) {
 	values := dbm.NewListHelper(bs.db).PrefixScan(seqCBPrefix)
 	if values == nil {
@@ -338,6 +399,9 @@ func (bs *BlockStore) LastHeader() *types.Header {
 	lastheaderlock.Lock()
 	defer lastheaderlock.Unlock()
 
+	if bs.lastHeader != nil {
+		return proto.Clone(bs.lastHeader).(*types.Header)
+	}
 	// 通过lastBlock获取lastheader
 	var blockheader = types.Header{}
 	if bs.lastBlock != nil {
@@ -367,6 +431,7 @@ func (bs *BlockStore) UpdateLastBlock(hash []byte) {
 	defer lastheaderlock.Unlock()
 	if blockdetail != nil {
 		bs.lastBlock = blockdetail.Block
+		bs.lastHeader = nil
 	}
 	storeLog.Debug("UpdateLastBlock", "UpdateLastBlock", blockdetail.Block.Height, "LastHederhash", common.ToHex(blockdetail.Block.Hash()))
 }
@@ -376,9 +441,28 @@ func (bs *BlockStore) UpdateLastBlock2(block *types.Block) {
 	lastheaderlock.Lock()
 	defer lastheaderlock.Unlock()
 	bs.lastBlock = block
+	bs.lastHeader = nil
 	storeLog.Debug("UpdateLastBlock", "UpdateLastBlock", block.Height, "LastHederhash", common.ToHex(block.Hash()))
 }
 
+//UpdateLastHeader 轻节点只有区块头, 更新最新的区块头, LastBlock 中没有交易
+func (bs *BlockStore) UpdateLastHeader(header *types.Header) {
+	lastheaderlock.Lock()
+	defer lastheaderlock.Unlock()
+	bs.lastHeader = header
+	bs.lastBlock = &types.Block{
+		Version:    header.Version,
+		ParentHash: header.ParentHash,
+		TxHash:     header.TxHash,
+		StateHash:  header.StateHash,
+		Height:     header.Height,
+		BlockTime:  header.BlockTime,
+		Signature:  header.Signature,
+		Difficulty: header.Difficulty,
+	}
+	storeLog.Debug("UpdateLastHeader", "height", header.Height, "hash", common.ToHex(header.Hash))
+}
+
 //LastBlock 获取最新的block信息
 func (bs *BlockStore) LastBlock() *types.Block {
 	lastheaderlock.Lock()
@@ -436,6 +520,9 @@ func (bs *BlockStore) LoadBlockByHash(hash []byte) (*types.BlockDetail, error) {
 		if err != dbm.ErrNotFoundInDb {
 			storeLog.Error("LoadBlockByHash calcHashToBlockBodyKey ", "err", err)
 		}
+		if bs.isPruned(blockheader.Height) {
+			return nil, types.ErrBlockPruned
+		}
 		return nil, types.ErrHashNotExist
 	}
 	err = proto.Unmarshal(body, &blockbody)
@@ -527,6 +614,22 @@ func (bs *BlockStore) SaveBlock(storeBatch dbm.Batch, blockdetail *types.BlockDe
 	return nil
 }
 
+//SaveBlockHeader 只保存区块头以及height和hash的对应关系, 快照同步时快照高度以下的区块没有区块体
+func (bs *BlockStore) SaveBlockHeader(storeBatch dbm.Batch, header *types.Header) error {
+	data, err := proto.Marshal(header)
+	if err != nil {
+		storeLog.Error("SaveBlockHeader Marshal blockheader", "height", header.Height, "hash", common.ToHex(header.Hash), "error", err)
+		return err
+	}
+	storeBatch.Set(calcHashToBlockHeaderKey(header.Hash), data)
+	storeBatch.Set(calcHeightToBlockHeaderKey(header.Height), data)
+
+	heightbytes := types.Encode(&types.Int64{Data: header.Height})
+	storeBatch.Set(calcHashToHeightKey(header.Hash), heightbytes)
+	storeBatch.Set(calcHeightToHashKey(header.Height), header.Hash)
+	return nil
+}
+
 //DelBlock 删除block信息从db数据库中
 func (bs *BlockStore) DelBlock(storeBatch dbm.Batch, blockdetail *types.BlockDetail, sequence int64) error {
 
@@ -577,6 +680,10 @@ func (bs *BlockStore) GetTx(hash []byte) (*types.TxResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	//区块裁剪之后只保留交易的高度和索引
+	if txResult.Tx == nil {
+		return nil, types.ErrTxPruned
+	}
 	return &txResult, nil
 }
 
@@ -889,6 +996,26 @@ func (bs *BlockStore) getSeqCBLastNum(name []byte) int64 {
 	return n
 }
 
+func (bs *BlockStore) setSeqCBState(name []byte, state *types.BlockSeqCBState) error {
+	return bs.db.SetSync(calcSeqCBStateKey(name), types.Encode(state))
+}
+
+func (bs *BlockStore) delSeqCBState(name []byte) error {
+	return bs.db.DeleteSync(calcSeqCBStateKey(name))
+}
+
+func (bs *BlockStore) getSeqCBState(name []byte) *types.BlockSeqCBState {
+	var state types.BlockSeqCBState
+	value, err := bs.db.Get(calcSeqCBStateKey(name))
+	if err != nil || value == nil {
+		return &state
+	}
+	if err = types.Decode(value, &state); err != nil {
+		storeLog.Error("getSeqCBState", "name", string(name), "err", err)
+	}
+	return &state
+}
+
 //SaveBlockSequence 存储block 序列执行的类型用于blockchain的恢复
 //获取当前的序列号，将此序列号加1存储本block的hash ，当主链使能isRecordBlockSequence
 // 平行链使能isParaChain时，sequence序列号是传入的
@@ -104,6 +104,11 @@ type BestPeerInfo struct {
 
 //SynRoutine 同步事务
 func (chain *BlockChain) SynRoutine() {
+	//新节点先同步状态快照, 然后再开始正常的区块同步
+	if chain.cfg.EnableSnapshotSync {
+		chain.snapshotSync()
+	}
+
 	//获取peerlist的定时器，默认1分钟
 	fetchPeerListTicker := time.NewTicker(time.Duration(fetchPeerListSeconds) * time.Second)
 
@@ -146,11 +151,18 @@ func (chain *BlockChain) SynRoutine() {
 
 		case <-checkHeightNoIncreaseTicker.C:
 			//synlog.Info("CheckHeightNoIncrease")
+			//轻节点在同步区块头的时候处理分叉
+			if chain.cfg.EnableLightClient {
+				break
+			}
 			chain.tickerwg.Add(1)
 			go chain.CheckHeightNoIncrease()
 
 		case <-checkBlockHashTicker.C:
 			//synlog.Info("checkBlockHashTicker")
+			if chain.cfg.EnableLightClient {
+				break
+			}
 			chain.tickerwg.Add(1)
 			go chain.CheckTipBlockHash()
 
@@ -559,6 +571,15 @@ func (chain *BlockChain) SynBlocksFromPeers() {
 	}
 	//synlog.Info("SynBlocksFromPeers", "isbatchsync", chain.isbatchsync)
 
+	//轻节点只同步区块头
+	if chain.cfg.EnableLightClient {
+		if curheight < peerMaxBlkHeight {
+			if pids := chain.GetBestChainPids(); pids != nil {
+				go chain.lightSync(pids, peerMaxBlkHeight)
+			}
+		}
+		return
+	}
 	//如果任务正常，那么不重复启动任务
 	if chain.task.InProgress() {
 		synlog.Info("chain task InProgress")
@@ -568,7 +589,9 @@ func (chain *BlockChain) SynBlocksFromPeers() {
 	if curheight+1 < peerMaxBlkHeight {
 		synlog.Info("SynBlocksFromPeers", "curheight", curheight, "LastCastBlkHeight", RcvLastCastBlkHeight, "peerMaxBlkHeight", peerMaxBlkHeight)
 		pids := chain.GetBestChainPids()
-		if pids != nil {
+		if pids != nil && chain.cfg.EnableHeadersFirstSync {
+			go chain.headersFirstSync(pids, peerMaxBlkHeight)
+		} else if pids != nil {
 			chain.FetchBlock(curheight+1, peerMaxBlkHeight, pids, false)
 		} else {
 			synlog.Info("SynBlocksFromPeers GetBestChainPids is nil")
@@ -696,6 +719,7 @@ func (chain *BlockChain) ProcBlockHeader(headers *types.Headers, peerid string)
 func (chain *BlockChain) ProcBlockHeaders(headers *types.Headers, pid string) error {
 	var ForkHeight int64 = -1
 	var forkhash []byte
+	var forkIndex int
 	count := len(headers.Items)
 	tipheight := chain.bestChain.Height()
 
@@ -705,6 +729,7 @@ func (chain *BlockChain) ProcBlockHeaders(headers *types.Headers, pid string) er
 		if exists {
 			ForkHeight = headers.Items[i].Height
 			forkhash = headers.Items[i].Hash
+			forkIndex = i
 			break
 		}
 	}
@@ -730,6 +755,16 @@ func (chain *BlockChain) ProcBlockHeaders(headers *types.Headers, pid string) er
 	}
 	synlog.Info("ProcBlockHeaders find fork point", "height", ForkHeight, "hash", common.ToHex(forkhash))
 
+	//分叉点在检查点或者最终确认深度之下时不再请求分叉的区块,
+	//发送这个分叉的节点以分叉后的第一个区块记录到故障节点列表中
+	if err := chain.checkForkHeight(ForkHeight); err != nil {
+		synlog.Error("ProcBlockHeaders reject fork", "pid", pid, "forkHeight", ForkHeight)
+		if forkIndex+1 < count {
+			chain.RecordFaultPeer(pid, ForkHeight+1, headers.Items[forkIndex+1].Hash, err)
+		}
+		return err
+	}
+
 	//获取此pid对应的peer信息，
 	peerinfo := chain.GetPeerInfo(pid)
 	if peerinfo == nil {
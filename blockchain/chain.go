@@ -26,6 +26,7 @@ var (
 	//cache 存贮的block个数
 	DefCacheSize        int64 = 128
 	MaxSeqCB            int64 = 20
+	MaxSeqCBBatch       int32 = 100 //每次推送的最大序列号个数
 	cachelock           sync.Mutex
 	zeroHash            [32]byte
 	InitBlockNum        int64 = 10240 //节点刚启动时从db向index和bestchain缓存中添加的blocknode数，和blockNodeCacheLimit保持一致
@@ -37,7 +38,11 @@ var (
 	isParaChain                 = false //是否是平行链。平行链需要记录Sequence信息
 )
 
-const maxFutureBlocks = 256
+const (
+	maxFutureBlocks = 256
+	//rpcEventTimeout 通知rpc模块区块事件时最多等待的时间
+	rpcEventTimeout = 100 * time.Millisecond
+)
 
 //BlockChain 区块链结构体
 type BlockChain struct {
@@ -66,10 +71,12 @@ type BlockChain struct {
 
 	synblock            chan struct{}
 	quit                chan struct{}
+	pruneCh             chan struct{} //通知后台裁剪区块
 	isclosed            int32
 	runcount            int32
 	isbatchsync         int32
 	firstcheckbestchain int32 //节点启动之后首次检测最优链的标志
+	isHeadersSync       int32 //正在先同步区块头的区块同步
 
 	// 孤儿链
 	orphanPool *OrphanPool
@@ -101,12 +108,22 @@ type BlockChain struct {
 	//fork block req
 	forkInfo *ForkInfo
 	forklock sync.Mutex
+
+	//检查点, 按照高度排序
+	checkpoints []*types.ChainCheckpoint
 }
 
 //New new
 func New(cfg *types.BlockChain) *BlockChain {
 	initConfig(cfg)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
+	checkpoints, err := parseCheckpoints(cfg.Checkpoints)
+	if err != nil {
+		panic("blockchain checkpoints config error: " + err.Error())
+	}
+	if cfg.PruneKeepBlocks > 0 && cfg.PruneKeepBlocks < PruneMinKeepBlocks {
+		panic(fmt.Sprintf("blockchain pruneKeepBlocks must not be less than %d", PruneMinKeepBlocks))
+	}
 
 	blockchain := &BlockChain{
 		cache:              NewBlockCache(DefCacheSize),
@@ -121,6 +138,7 @@ func New(cfg *types.BlockChain) *BlockChain {
 		forktask: newTask(300 * time.Second),
 
 		quit:                make(chan struct{}),
+		pruneCh:             make(chan struct{}, 1),
 		synblock:            make(chan struct{}, 1),
 		orphanPool:          NewOrphanPool(),
 		index:               newBlockIndex(),
@@ -132,6 +150,7 @@ func New(cfg *types.BlockChain) *BlockChain {
 		bestChainPeerList:   make(map[string]*BestPeerInfo),
 		futureBlocks:        futureBlocks,
 		forkInfo:            &ForkInfo{},
+		checkpoints:         checkpoints,
 	}
 
 	return blockchain
@@ -195,7 +214,7 @@ func (chain *BlockChain) SetQueueClient(client queue.Client) {
 	chain.blockStore = blockStore
 	stateHash := chain.getStateHash()
 	chain.query = NewQuery(blockStoreDB, chain.client, stateHash)
-	chain.pushseq = newpushseq(chain.blockStore)
+	chain.pushseq = newpushseq(chain.blockStore, chain.cfg.PushFileDir)
 	//startTime
 	chain.startTime = types.Now()
 
@@ -243,6 +262,12 @@ func (chain *BlockChain) InitBlockChain() {
 		// 定时处理futureblock
 		go chain.UpdateRoutine()
 	}
+	if chain.cfg.PruneKeepBlocks > 0 {
+		// 后台裁剪区块, 启动时先裁剪一次
+		chain.tickerwg.Add(1)
+		go chain.pruneRoutine()
+		chain.notifyPrune()
+	}
 	//初始化默认forkinfo
 	chain.DefaultForkInfo()
 }
@@ -259,7 +284,7 @@ func (chain *BlockChain) getStateHash() []byte {
 	return zeroHash[:]
 }
 
-//SendAddBlockEvent blockchain 模块add block到db之后通知mempool 和consense模块做相应的更新
+//SendAddBlockEvent blockchain 模块add block到db之后通知mempool 和consense以及rpc模块做相应的更新
 func (chain *BlockChain) SendAddBlockEvent(block *types.BlockDetail) (err error) {
 	if chain.client == nil {
 		fmt.Println("chain client not bind message queue.")
@@ -284,6 +309,9 @@ func (chain *BlockChain) SendAddBlockEvent(block *types.BlockDetail) (err error)
 	msg = chain.client.NewMessage("wallet", types.EventAddBlock, block)
 	chain.client.Send(msg, false)
 
+	chainlog.Debug("SendAddBlockEvent -->>rpc", "height", block.GetBlock().GetHeight())
+	chain.sendRPCEvent(types.EventAddBlock, block)
+
 	return nil
 }
 
@@ -310,6 +338,9 @@ func (chain *BlockChain) GetBlockHeight() int64 {
 
 //GetBlock 用于获取指定高度的block，首先在缓存中获取，如果不存在就从db中获取
 func (chain *BlockChain) GetBlock(height int64) (block *types.BlockDetail, err error) {
+	if chain.blockStore.isPruned(height) {
+		return nil, types.ErrBlockPruned
+	}
 	blockdetail := chain.cache.CheckcacheBlock(height)
 	if blockdetail != nil {
 		if len(blockdetail.Receipts) == 0 && len(blockdetail.Block.Txs) != 0 {
@@ -329,7 +360,7 @@ func (chain *BlockChain) GetBlock(height int64) (block *types.BlockDetail, err e
 
 }
 
-//SendDelBlockEvent blockchain 模块 del block从db之后通知mempool 和consense以及wallet模块做相应的更新
+//SendDelBlockEvent blockchain 模块 del block从db之后通知mempool 和consense以及wallet,rpc模块做相应的更新
 func (chain *BlockChain) SendDelBlockEvent(block *types.BlockDetail) (err error) {
 	if chain.client == nil {
 		fmt.Println("chain client not bind message queue.")
@@ -341,7 +372,7 @@ func (chain *BlockChain) SendDelBlockEvent(block *types.BlockDetail) (err error)
 		return nil
 	}
 
-	chainlog.Debug("SendDelBlockEvent -->>mempool&consensus&wallet", "height", block.GetBlock().GetHeight())
+	chainlog.Debug("SendDelBlockEvent -->>mempool&consensus&wallet&rpc", "height", block.GetBlock().GetHeight())
 
 	msg := chain.client.NewMessage("consensus", types.EventDelBlock, block)
 	chain.client.Send(msg, false)
@@ -352,9 +383,21 @@ func (chain *BlockChain) SendDelBlockEvent(block *types.BlockDetail) (err error)
 	msg = chain.client.NewMessage("wallet", types.EventDelBlock, block)
 	chain.client.Send(msg, false)
 
+	chain.sendRPCEvent(types.EventDelBlock, block)
+
 	return nil
 }
 
+//sendRPCEvent 通知rpc模块推送区块事件, 队列满了之后最多等待 rpcEventTimeout, 不能长时间阻塞区块的处理.
+//超时丢弃的区块事件由rpc模块根据区块的连接关系发现, 通知订阅者重新同步
+func (chain *BlockChain) sendRPCEvent(ty int64, block *types.BlockDetail) {
+	msg := chain.client.NewMessage("rpc", ty, block)
+	err := chain.client.SendTimeout(msg, false, rpcEventTimeout)
+	if err != nil {
+		chainlog.Error("sendRPCEvent", "height", block.GetBlock().GetHeight(), "err", err)
+	}
+}
+
 //GetDB 获取DB
 func (chain *BlockChain) GetDB() dbm.DB {
 	return chain.blockStore.db
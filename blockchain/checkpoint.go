@@ -0,0 +1,106 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+检查点以及最终确认深度:
+1. 检查点高度的区块hash必须和配置的一致
+2. 分叉点低于 finalizedHeight 的分叉会回滚已经确认的区块, 不会被接受, 发送这个分叉的节点记录到故障节点列表中
+finalizedHeight 为 最新的检查点高度 和 最新高度-finalizedDepth 中较大的一个
+3. 裁剪高度之下的区块没有区块体, 无法回滚, 分叉之后的第一个区块低于裁剪高度时同样不会被接受
+*/
+
+//parseCheckpoints 解析配置中 "height:hash" 格式的检查点, 按照高度排序
+func parseCheckpoints(cfgs []string) ([]*types.ChainCheckpoint, error) {
+	points := make([]*types.ChainCheckpoint, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		items := strings.Split(cfg, ":")
+		if len(items) != 2 {
+			return nil, types.ErrInvalidParam
+		}
+		height, err := strconv.ParseInt(strings.TrimSpace(items[0]), 10, 64)
+		if err != nil || height < 0 {
+			return nil, types.ErrInvalidParam
+		}
+		hash, err := common.FromHex(strings.TrimSpace(items[1]))
+		if err != nil || len(hash) != len(common.Hash{}) {
+			return nil, types.ErrInvalidParam
+		}
+		points = append(points, &types.ChainCheckpoint{Height: height, Hash: hash})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Height < points[j].Height })
+	for i := 1; i < len(points); i++ {
+		if points[i].Height == points[i-1].Height {
+			return nil, types.ErrInvalidParam
+		}
+	}
+	return points, nil
+}
+
+//latestCheckpoint 不高于 height 的最新检查点
+func (chain *BlockChain) latestCheckpoint(height int64) *types.ChainCheckpoint {
+	i := sort.Search(len(chain.checkpoints), func(i int) bool { return chain.checkpoints[i].Height > height })
+	if i == 0 {
+		return nil
+	}
+	return chain.checkpoints[i-1]
+}
+
+//finalizedHeight 最新高度为 tipHeight 时不能被回滚的最高区块, -1 表示没有限制
+func (chain *BlockChain) finalizedHeight(tipHeight int64) int64 {
+	var finalized int64 = -1
+	if point := chain.latestCheckpoint(tipHeight); point != nil {
+		finalized = point.Height
+	}
+	if chain.cfg.FinalizedDepth > 0 && tipHeight-chain.cfg.FinalizedDepth > finalized {
+		finalized = tipHeight - chain.cfg.FinalizedDepth
+	}
+	return finalized
+}
+
+//checkForkHeight 从 forkHeight 分叉会回滚 forkHeight 之上的区块, 不能回滚已经确认的区块以及已经裁剪的区块
+func (chain *BlockChain) checkForkHeight(forkHeight int64) error {
+	finalized := chain.finalizedHeight(chain.bestChain.Height())
+	if forkHeight < finalized {
+		chainlog.Error("checkForkHeight reorg below finalized height", "forkHeight", forkHeight, "finalized", finalized)
+		return types.ErrReorgBelowFinalized
+	}
+	//轻节点只有区块头, 回滚不需要区块体
+	if !chain.cfg.EnableLightClient && chain.blockStore.isPruned(forkHeight+1) {
+		chainlog.Error("checkForkHeight reorg below prune height", "forkHeight", forkHeight, "prune", chain.blockStore.PruneHeight())
+		return types.ErrBlockPruned
+	}
+	return nil
+}
+
+//checkCheckpoint 检查点高度的区块hash必须和检查点一致
+func (chain *BlockChain) checkCheckpoint(height int64, hash []byte) error {
+	point := chain.latestCheckpoint(height)
+	if point != nil && point.Height == height && !bytes.Equal(point.Hash, hash) {
+		chainlog.Error("checkCheckpoint hash not match", "height", height, "hash", common.ToHex(hash), "checkpoint", common.ToHex(point.Hash))
+		return types.ErrCheckpointMismatch
+	}
+	return nil
+}
+
+//GetCheckpoint 获取当前生效的检查点以及最终确认的高度
+func (chain *BlockChain) GetCheckpoint() *types.ReplyCheckpoint {
+	height := chain.bestChain.Height()
+	return &types.ReplyCheckpoint{
+		Checkpoint:      chain.latestCheckpoint(height),
+		FinalizedDepth:  chain.cfg.FinalizedDepth,
+		FinalizedHeight: chain.finalizedHeight(height),
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	assert.Panics(t, func() { blockchain.New(&types.BlockChain{Checkpoints: []string{"1"}}) })
+	assert.Panics(t, func() { blockchain.New(&types.BlockChain{Checkpoints: []string{"1:0x01"}}) })
+
+	//另外一条更长的链
+	mock33 := testnode.New("", nil)
+	var fork []*types.BlockDetail
+	for i := int64(1); i <= 5; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+		detail, err := mock33.GetBlockChain().GetStore().LoadBlockByHeight(i)
+		require.Nil(t, err)
+		fork = append(fork, detail)
+	}
+	mock33.Close()
+
+	cfg, sub := testnode.GetDefaultConfig()
+	cfg.BlockChain.FinalizedDepth = 1
+	cfg.BlockChain.Checkpoints = []string{"5:" + common.ToHex(common.Hash{}.Bytes())}
+	mock33 = testnode.NewWithConfig(cfg, sub, nil)
+	defer mock33.Close()
+	for i := int64(1); i <= 3; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	chain := mock33.GetBlockChain()
+	tip := mock33.GetLastBlock().Hash()
+
+	reply, err := mock33.GetAPI().GetCheckpoint()
+	require.Nil(t, err)
+	assert.Nil(t, reply.Checkpoint)
+	assert.Equal(t, int64(1), reply.FinalizedDepth)
+	assert.Equal(t, int64(2), reply.FinalizedHeight)
+
+	//难度没有超过主链时作为侧链保存
+	for _, detail := range fork[:3] {
+		_, isMainChain, isOrphan, err := chain.ProcessBlock(false, detail, "peer", true, -1)
+		require.Nil(t, err)
+		assert.False(t, isMainChain)
+		assert.False(t, isOrphan)
+	}
+	//从高度0分叉需要回滚已经确认的高度2, 同步时不再请求分叉的区块
+	genesis, err := chain.GetStore().LoadBlockByHeight(0)
+	require.Nil(t, err)
+	headers := &types.Headers{Items: []*types.Header{genesis.Block.GetHeader()}}
+	headers.Items[0].Hash = genesis.Block.Hash()
+	for _, detail := range fork[:4] {
+		header := detail.Block.GetHeader()
+		header.Hash = detail.Block.Hash()
+		headers.Items = append(headers.Items, header)
+	}
+	assert.Equal(t, types.ErrReorgBelowFinalized, chain.ProcBlockHeaders(headers, "peer"))
+	_, _, _, err = chain.ProcessBlock(false, fork[3], "peer", true, -1)
+	assert.Equal(t, types.ErrReorgBelowFinalized, err)
+	//检查点高度的区块hash不一致
+	_, _, _, err = chain.ProcessBlock(false, fork[4], "peer", true, -1)
+	assert.Equal(t, types.ErrCheckpointMismatch, err)
+	assert.Equal(t, int64(3), chain.GetBlockHeight())
+	assert.Equal(t, tip, mock33.GetLastBlock().Hash())
+}
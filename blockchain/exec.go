@@ -14,7 +14,8 @@ import (
 )
 
 //执行区块将变成一个私有的函数
-func execBlock(client queue.Client, prevStateRoot []byte, block *types.Block, errReturn bool, sync bool) (*types.BlockDetail, []*types.Transaction, error) {
+//checkSign 为 false 时不检查区块和交易的签名
+func execBlock(client queue.Client, prevStateRoot []byte, block *types.Block, errReturn bool, sync bool, checkSign bool) (*types.BlockDetail, []*types.Transaction, error) {
 	//发送执行交易给execs模块
 	//通过consensus module 再次检查
 	chainlog.Debug("ExecBlock", "height------->", block.Height, "ntx", len(block.Txs))
@@ -23,7 +24,7 @@ func execBlock(client queue.Client, prevStateRoot []byte, block *types.Block, er
 		chainlog.Info("ExecBlock", "height", block.Height, "ntx", len(block.Txs), "writebatchsync", sync, "cost", types.Since(beg))
 	}()
 
-	if errReturn && block.Height > 0 && !block.CheckSign() {
+	if errReturn && checkSign && block.Height > 0 && !block.CheckSign() {
 		//block的来源不是自己的mempool，而是别人的区块
 		return nil, nil, types.ErrSign
 	}
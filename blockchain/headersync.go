@@ -0,0 +1,354 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+)
+
+/*
+先同步区块头的区块同步:
+1. 从最优链节点中最高的节点获取本节点 tip 之后的 headers, 验证高度, parentHash 的连接关系以及 header 的 hash,
+   每一批 headers 再交给共识模块检查(EventCheckHeaders), 检查通过之后才下载区块.
+   第一个 header 不能连接到本节点的 tip 时, 本节点可能在分叉上, 交给 ProcBlockHeaders 寻找分叉点
+2. 最优链节点中, 最后一个 header 的 hash 和验证过的 headers 一致的节点参与区块下载
+3. 区块按照 headersSyncWindow 个一组分配给空闲的节点并行下载, 优先分配给下载速度快的节点.
+   下载的区块必须和 header 一致, 先到达的区块缓存起来, 按照高度顺序执行
+*/
+
+const (
+	headersSyncRound     = 5000 //一轮同步最多的区块数
+	headersSyncBatch     = 1000 //一次获取的 headers 数量
+	headersSyncWindow    = 16   //一次从一个节点下载的区块数量
+	headersSyncMaxBuffer = 1024 //已经下载还没有执行的区块的最大数量
+	headersSyncMaxFails  = 3    //节点连续下载失败的次数达到之后不再使用
+)
+
+//BlockSource 先同步区块头时的数据来源, 默认通过 p2p 模块从其他节点获取
+type BlockSource interface {
+	GetHeaders(pid string, start, end int64) ([]*types.Header, error)
+	GetBlocks(pid string, start, end int64) ([]*types.Block, error)
+}
+
+//syncPeer 参与区块下载的节点, score 为平均的下载速度(区块数/秒)
+type syncPeer struct {
+	pid     string
+	score   float64
+	busy    bool
+	fails   int
+	dropped bool
+}
+
+type syncWindow struct {
+	peer   *syncPeer
+	start  int64
+	end    int64
+	blocks []*types.Block
+	err    error
+	cost   time.Duration
+}
+
+//headersFirstSync 从最优链的节点同步到 end 高度, 同一时间只有一轮同步
+func (chain *BlockChain) headersFirstSync(pids []string, end int64) {
+	if !atomic.CompareAndSwapInt32(&chain.isHeadersSync, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&chain.isHeadersSync, 0)
+
+	//最高的节点排在前面, 用来获取 headers
+	var peers PeerInfoList
+	for _, pid := range pids {
+		if peer := chain.GetPeerInfo(pid); peer != nil {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) == 0 {
+		return
+	}
+	sort.Sort(sort.Reverse(peers))
+	pids = make([]string, 0, len(peers))
+	for _, peer := range peers {
+		pids = append(pids, peer.Name)
+	}
+	if end > peers[0].Height {
+		end = peers[0].Height
+	}
+	err := chain.HeadersFirstSync(&p2pSource{client: chain.client}, pids, end)
+	if err != nil {
+		synlog.Error("headersFirstSync", "end", end, "err", err)
+	}
+}
+
+//HeadersFirstSync 先从 pids[0] 同步并验证 headers, 然后从 pids 中和这些 headers 一致的节点并行下载区块,
+//每一轮最多同步 headersSyncRound 个区块
+func (chain *BlockChain) HeadersFirstSync(source BlockSource, pids []string, end int64) error {
+	if len(pids) == 0 {
+		return types.ErrNoPeer
+	}
+	tip := chain.bestChain.Tip()
+	if end <= tip.height {
+		return nil
+	}
+	if end > tip.height+headersSyncRound {
+		end = tip.height + headersSyncRound
+	}
+	headers, err := chain.fetchSyncHeaders(source, pids[0], tip.height, tip.hash, end)
+	if err != nil {
+		return err
+	}
+	last := headers[len(headers)-1]
+	var peers []*syncPeer
+	for i, pid := range pids {
+		if i > 0 {
+			items, err := source.GetHeaders(pid, last.Height, last.Height)
+			if err != nil || len(items) != 1 || !bytes.Equal(items[0].Hash, last.Hash) {
+				synlog.Debug("HeadersFirstSync skip peer", "pid", pid, "height", last.Height, "err", err)
+				continue
+			}
+		}
+		peers = append(peers, &syncPeer{pid: pid})
+	}
+	synlog.Info("HeadersFirstSync", "start", headers[0].Height, "end", last.Height, "peers", len(peers))
+	return chain.downloadBlocks(source, peers, headers)
+}
+
+//获取并验证 tip 之后到 end 的 headers, peer 的 headers 不够时只同步已有的部分
+func (chain *BlockChain) fetchSyncHeaders(source BlockSource, pid string, tipheight int64, tiphash []byte, end int64) ([]*types.Header, error) {
+	var headers []*types.Header
+	prev, err := chain.blockStore.GetBlockHeaderByHash(tiphash)
+	if err != nil {
+		return nil, err
+	}
+	prevhash := tiphash
+	for start := tipheight + 1; start <= end; start += headersSyncBatch {
+		batchend := start + headersSyncBatch - 1
+		if batchend > end {
+			batchend = end
+		}
+		items, err := source.GetHeaders(pid, start, batchend)
+		if err != nil {
+			return nil, err
+		}
+		for i, header := range items {
+			height := start + int64(i)
+			//第一个 header 不能连接到 tip, 本节点可能在分叉上, 通过 ProcBlockHeaders 寻找分叉点
+			if height == tipheight+1 && header.Height == height && !bytes.Equal(header.ParentHash, prevhash) {
+				return nil, chain.syncFork(source, pid, tipheight)
+			}
+			if header.Height != height || !bytes.Equal(header.ParentHash, prevhash) || !bytes.Equal(calcHeaderHash(header), header.Hash) {
+				synlog.Error("fetchSyncHeaders invalid header", "pid", pid, "height", height, "hash", common.ToHex(header.Hash))
+				chain.RecordFaultPeer(pid, height, header.Hash, types.ErrBlockHashNoMatch)
+				return nil, types.ErrBlockHashNoMatch
+			}
+			prevhash = header.Hash
+		}
+		if len(items) > 0 {
+			err = util.CheckHeaders(chain.client, &types.Headers{Items: append([]*types.Header{prev}, items...)})
+			if err != nil {
+				synlog.Error("fetchSyncHeaders consensus check", "pid", pid, "start", start, "err", err)
+				chain.RecordFaultPeer(pid, start, items[0].Hash, err)
+				return nil, err
+			}
+			prev = items[len(items)-1]
+		}
+		headers = append(headers, items...)
+		if int64(len(items)) != batchend-start+1 {
+			break
+		}
+	}
+	if len(headers) == 0 {
+		return nil, types.ErrNotFound
+	}
+	return headers, nil
+}
+
+//从 peer 获取 tip 之前的 headers 寻找分叉点, 之后的处理和 CheckHeightNoIncrease 一致
+func (chain *BlockChain) syncFork(source BlockSource, pid string, tipheight int64) error {
+	start := tipheight - BackBlockNum
+	if start < 0 {
+		start = 0
+	}
+	headers, err := source.GetHeaders(pid, start, tipheight)
+	if err != nil {
+		return err
+	}
+	if len(headers) == 0 {
+		return types.ErrNotFound
+	}
+	synlog.Info("HeadersFirstSync tip not match, find fork point", "pid", pid, "start", start, "end", tipheight)
+	err = chain.ProcBlockHeaders(&types.Headers{Items: headers}, pid)
+	if err != nil && err != types.ErrContinueBack {
+		return err
+	}
+	return types.ErrBlockHashNoMatch
+}
+
+//下载的区块必须和 header 一致
+func checkSyncBlocks(headers []*types.Header, blocks []*types.Block) bool {
+	if len(headers) != len(blocks) {
+		return false
+	}
+	for i, block := range blocks {
+		if block == nil || block.Height != headers[i].Height || !bytes.Equal(block.Hash(), headers[i].Hash) ||
+			!bytes.Equal(merkle.CalcMerkleRoot(block.Txs), block.TxHash) {
+			return false
+		}
+	}
+	return true
+}
+
+//空闲节点中下载速度最快的节点
+func bestIdlePeer(peers []*syncPeer) *syncPeer {
+	var best *syncPeer
+	for _, peer := range peers {
+		if peer.busy || peer.dropped {
+			continue
+		}
+		if best == nil || peer.score > best.score {
+			best = peer
+		}
+	}
+	return best
+}
+
+func usablePeers(peers []*syncPeer) int {
+	count := 0
+	for _, peer := range peers {
+		if !peer.dropped {
+			count++
+		}
+	}
+	return count
+}
+
+//并行下载 headers 对应的区块, 按照高度顺序交给 execSyncBlocks 执行
+func (chain *BlockChain) downloadBlocks(source BlockSource, peers []*syncPeer, headers []*types.Header) error {
+	first := headers[0].Height
+	last := headers[len(headers)-1].Height
+	var windows []int64
+	for start := first; start <= last; start += headersSyncWindow {
+		windows = append(windows, start)
+	}
+	results := make(chan *syncWindow, len(peers))
+	execc := make(chan *types.BlockPid, headersSyncMaxBuffer+headersSyncWindow)
+	errc := make(chan error, 1)
+	go chain.execSyncBlocks(execc, errc)
+
+	buffer := make(map[int64]*types.BlockPid)
+	next := first
+	inflight := 0
+	for {
+		//已经下载还没有执行的区块太多时, 等待执行之后再分配新的下载任务
+		for len(windows) > 0 && windows[0] <= chain.GetBlockHeight()+headersSyncMaxBuffer {
+			peer := bestIdlePeer(peers)
+			if peer == nil {
+				break
+			}
+			w := &syncWindow{peer: peer, start: windows[0], end: windows[0] + headersSyncWindow - 1}
+			if w.end > last {
+				w.end = last
+			}
+			windows = windows[1:]
+			peer.busy = true
+			inflight++
+			go func(w *syncWindow) {
+				begin := types.Now()
+				w.blocks, w.err = source.GetBlocks(w.peer.pid, w.start, w.end)
+				w.cost = types.Since(begin)
+				results <- w
+			}(w)
+		}
+		if inflight == 0 {
+			if next > last {
+				close(execc)
+				return <-errc
+			}
+			if len(windows) > 0 && usablePeers(peers) == 0 {
+				close(execc)
+				if err := <-errc; err != nil {
+					return err
+				}
+				return types.ErrNoPeer
+			}
+		}
+		select {
+		case w := <-results:
+			inflight--
+			w.peer.busy = false
+			chain.procSyncWindow(w, headers[w.start-first:w.end-first+1], buffer)
+			if w.blocks == nil {
+				windows = append(windows, w.start)
+				sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+			}
+		case err := <-errc:
+			return err
+		case <-chain.quit:
+			return types.ErrIsClosed
+		case <-time.After(time.Second):
+		}
+		for {
+			blockpid, ok := buffer[next]
+			if !ok {
+				break
+			}
+			delete(buffer, next)
+			execc <- blockpid
+			next++
+		}
+	}
+}
+
+//处理一个下载窗口的结果, 更新节点的评分. 下载失败或者区块和 header 不一致时 w.blocks 为 nil
+func (chain *BlockChain) procSyncWindow(w *syncWindow, headers []*types.Header, buffer map[int64]*types.BlockPid) {
+	peer := w.peer
+	if w.err != nil || !checkSyncBlocks(headers, w.blocks) {
+		peer.fails++
+		peer.score = peer.score / 2
+		if w.err == nil {
+			//区块和验证过的 header 不一致, 不再从这个节点下载
+			synlog.Error("downloadBlocks blocks not match headers", "pid", peer.pid, "start", w.start, "end", w.end)
+			chain.RecordFaultPeer(peer.pid, w.start, headers[0].Hash, types.ErrBlockHashNoMatch)
+			peer.fails = headersSyncMaxFails
+		}
+		if peer.fails >= headersSyncMaxFails {
+			peer.dropped = true
+		}
+		synlog.Debug("downloadBlocks fail", "pid", peer.pid, "start", w.start, "end", w.end, "fails", peer.fails, "err", w.err)
+		w.blocks = nil
+		return
+	}
+	rate := float64(len(w.blocks)) / (w.cost.Seconds() + 0.001)
+	if peer.score == 0 {
+		peer.score = rate
+	} else {
+		peer.score = 0.7*peer.score + 0.3*rate
+	}
+	peer.fails = 0
+	for _, block := range w.blocks {
+		buffer[block.Height] = &types.BlockPid{Pid: peer.pid, Block: block}
+	}
+	synlog.Debug("downloadBlocks", "pid", peer.pid, "start", w.start, "end", w.end, "score", peer.score)
+}
+
+//按照高度顺序执行下载的区块, execc 关闭或者执行出错时返回
+func (chain *BlockChain) execSyncBlocks(execc chan *types.BlockPid, errc chan error) {
+	for blockpid := range execc {
+		_, _, _, err := chain.ProcessBlock(false, &types.BlockDetail{Block: blockpid.Block}, blockpid.Pid, true, -1)
+		if err != nil && err != types.ErrBlockExist {
+			synlog.Error("execSyncBlocks", "height", blockpid.Block.Height, "pid", blockpid.Pid, "err", err)
+			errc <- err
+			return
+		}
+	}
+	errc <- nil
+}
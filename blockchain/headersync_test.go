@@ -0,0 +1,104 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlockSource struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	blocks  []*types.Block
+	fetched map[string]int
+}
+
+func (s *testBlockSource) GetHeaders(pid string, start, end int64) ([]*types.Header, error) {
+	if end >= int64(len(s.headers)) {
+		end = int64(len(s.headers)) - 1
+	}
+	headers := s.headers[start : end+1]
+	//fork 节点在最后的高度上是另外一个区块
+	if pid == "fork" {
+		header := proto.Clone(headers[len(headers)-1]).(*types.Header)
+		header.Hash = make([]byte, 32)
+		headers = []*types.Header{header}
+	}
+	return headers, nil
+}
+
+func (s *testBlockSource) GetBlocks(pid string, start, end int64) ([]*types.Block, error) {
+	s.mu.Lock()
+	s.fetched[pid]++
+	s.mu.Unlock()
+	if pid == "timeout" {
+		return nil, types.ErrTimeout
+	}
+	blocks := s.blocks[start : end+1]
+	//bad 节点返回的区块少了一个交易
+	if pid == "bad" {
+		block := proto.Clone(blocks[0]).(*types.Block)
+		block.Txs = block.Txs[1:]
+		blocks = append([]*types.Block{block}, blocks[1:]...)
+	}
+	return blocks, nil
+}
+
+func TestHeadersFirstSync(t *testing.T) {
+	mock33 := testnode.New("", nil)
+	var height int64 = 40
+	for i := int64(1); i <= height; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	headers, err := mock33.GetAPI().GetHeaders(&types.ReqBlocks{Start: 0, End: height})
+	require.Nil(t, err)
+	source := &testBlockSource{headers: headers.Items, fetched: make(map[string]int)}
+	for i := int64(0); i <= height; i++ {
+		detail, err := mock33.GetBlockChain().GetStore().LoadBlockByHeight(i)
+		require.Nil(t, err)
+		source.blocks = append(source.blocks, detail.Block)
+	}
+	last := mock33.GetLastBlock()
+	mock33.Close()
+
+	//本节点已经有另外一个区块, 需要先寻找分叉点
+	mock33 = testnode.New("", nil)
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(1))
+	err = mock33.GetBlockChain().HeadersFirstSync(source, []string{"good"}, height)
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(1), mock33.GetBlockChain().GetBlockHeight())
+	mock33.Close()
+
+	mock33 = testnode.New("", nil)
+	defer mock33.Close()
+	require.Nil(t, mock33.WaitHeight(0))
+	chain := mock33.GetBlockChain()
+	assert.Equal(t, types.ErrNoPeer, chain.HeadersFirstSync(source, nil, height))
+	//只有 bad 以及 timeout 节点时无法完成同步
+	err = chain.HeadersFirstSync(source, []string{"bad", "timeout"}, height)
+	assert.Equal(t, types.ErrNoPeer, err)
+	assert.Equal(t, 1, source.fetched["bad"])
+	assert.Equal(t, 3, source.fetched["timeout"])
+
+	pids := []string{"good", "bad", "timeout", "fork", "good2"}
+	require.Nil(t, chain.HeadersFirstSync(source, pids, height))
+	assert.Equal(t, height, chain.GetBlockHeight())
+	assert.Equal(t, last.Hash(), mock33.GetLastBlock().Hash())
+	//fork 节点的 header 不一致, 不参与下载
+	assert.Equal(t, 0, source.fetched["fork"])
+	assert.Equal(t, 2, source.fetched["bad"])
+	//已经同步到最新高度
+	require.Nil(t, chain.HeadersFirstSync(source, pids, height))
+}
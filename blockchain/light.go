@@ -0,0 +1,399 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/difficulty"
+	"github.com/33cn/chain33/common/merkle"
+	mavl "github.com/33cn/chain33/system/store/mavl/db"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/golang/protobuf/proto"
+)
+
+/*
+轻节点:
+1. 只执行创世区块, 之后只同步区块头, 区块头按照共识的基本规则(高度, 父hash, 区块时间)以及区块hash, 签名和检查点检查,
+   区块头必须有签名, 再交给共识模块检查(EventCheckHeaders), 由共识模块检查签名的地址是不是允许出块的地址,
+   只支持实现了 HeaderChecker 并且区块有签名的共识.
+   同时从多个节点同步, 和本地不一致的区块头作为分叉链保存, 总难度大于本地主链时才切换主链
+2. 区块头之下的区块都作为已经裁剪的区块, GetBlocks 返回 ErrBlockPruned
+3. QueryTransaction 从全节点获取交易以及默克尔证明, 使用本地区块头的 TxHash 验证, 交易的回执不在区块头中, 不能验证
+4. 状态数据从全节点获取 mavl 证明, 使用本地区块头的 StateHash 验证
+*/
+
+const (
+	lightSyncBatch = 1000 //每次同步的区块头个数
+	lightSyncPeers = 3    //每次同步的节点个数
+)
+
+//LightSource 轻节点获取区块头以及证明的来源
+type LightSource interface {
+	GetHeaders(pid string, start, end int64) ([]*types.Header, error)
+	GetTxProof(pid string, hash []byte) (*types.TransactionDetail, error)
+	GetStateProof(req *types.ReqStateProof) (*types.StateProof, error)
+}
+
+//lightPeers 按照高度从高到低排列的节点
+func (chain *BlockChain) lightPeers() []string {
+	peers := chain.GetPeers()
+	pids := make([]string, 0, len(peers))
+	for i := len(peers) - 1; i >= 0; i-- {
+		pids = append(pids, peers[i].Name)
+	}
+	return pids
+}
+
+func (chain *BlockChain) lightSync(pids []string, end int64) {
+	if !atomic.CompareAndSwapInt32(&chain.isHeadersSync, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&chain.isHeadersSync, 0)
+	err := chain.LightSync(&p2pSource{client: chain.client}, pids, end)
+	if err != nil {
+		synlog.Error("lightSync", "end", end, "err", err)
+	}
+}
+
+//LightSync 轻节点依次从 pids 中的前 lightSyncPeers 个节点同步到 end 高度的区块头,
+//每个节点都检查和本地的区块头是否一致, 不一致时先寻找分叉点, 分叉链的总难度大于本地主链时才切换.
+//有一个节点同步成功就返回成功
+func (chain *BlockChain) LightSync(source LightSource, pids []string, end int64) error {
+	if len(pids) == 0 {
+		return types.ErrNoPeer
+	}
+	if len(pids) > lightSyncPeers {
+		pids = pids[:lightSyncPeers]
+	}
+	var synced bool
+	var lastErr error
+	for _, pid := range pids {
+		err := chain.lightSyncPeer(source, pid, end)
+		if err != nil {
+			synlog.Error("LightSync", "pid", pid, "end", end, "err", err)
+			lastErr = err
+			continue
+		}
+		synced = true
+	}
+	if synced {
+		return nil
+	}
+	return lastErr
+}
+
+//lightSyncPeer 从 pid 同步到 end 高度的区块头, 每一批区块头都从 parent 开始获取, 检查节点的 parent 和本地一致
+func (chain *BlockChain) lightSyncPeer(source LightSource, pid string, end int64) error {
+	parent := chain.blockStore.LastHeader()
+	forked := false
+	for {
+		batchEnd := parent.Height + lightSyncBatch
+		if batchEnd > end {
+			batchEnd = end
+		}
+		if batchEnd < parent.Height {
+			batchEnd = parent.Height
+		}
+		headers, err := source.GetHeaders(pid, parent.Height, batchEnd)
+		if err != nil {
+			return err
+		}
+		if len(headers) == 0 {
+			return types.ErrBlockHashNoMatch
+		}
+		if !bytes.Equal(headers[0].Hash, parent.Hash) {
+			if forked {
+				return types.ErrBlockHashNoMatch
+			}
+			forked = true
+			parent, err = chain.lightFindFork(source, pid, parent.Height)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		headers = headers[1:]
+		if len(headers) == 0 {
+			return nil
+		}
+		valid, checkErr := chain.checkLightHeaders(pid, parent, headers)
+		if len(valid) > 0 {
+			//共识模块检查区块头, 检查失败时这一批区块头都不保存
+			err = util.CheckHeaders(chain.client, &types.Headers{Items: append([]*types.Header{parent}, valid...)})
+			if err != nil {
+				synlog.Error("LightSync consensus check", "pid", pid, "height", valid[0].Height, "err", err)
+				chain.RecordFaultPeer(pid, valid[0].Height, valid[0].Hash, err)
+				return err
+			}
+			parent, err = chain.addLightHeaders(pid, parent, valid)
+			if err != nil {
+				return err
+			}
+		}
+		if checkErr != nil {
+			return checkErr
+		}
+		if parent.Height >= end {
+			return nil
+		}
+	}
+}
+
+//lightFindFork 从 pid 获取 height 之前 BackBlockNum 个区块头, 寻找和本地一致的最高的区块头
+func (chain *BlockChain) lightFindFork(source LightSource, pid string, height int64) (*types.Header, error) {
+	start := height - BackBlockNum
+	if start < 0 {
+		start = 0
+	}
+	headers, err := source.GetHeaders(pid, start, height)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(headers) - 1; i >= 0; i-- {
+		local, err := chain.blockStore.GetBlockHeaderByHeight(headers[i].Height)
+		if err != nil || !bytes.Equal(local.Hash, headers[i].Hash) {
+			continue
+		}
+		if err = chain.checkForkHeight(local.Height); err != nil {
+			if i+1 < len(headers) {
+				chain.RecordFaultPeer(pid, local.Height+1, headers[i+1].Hash, err)
+			}
+			return nil, err
+		}
+		synlog.Info("lightFindFork", "pid", pid, "height", local.Height, "hash", common.ToHex(local.Hash))
+		return local, nil
+	}
+	return nil, types.ErrBlockHashNoMatch
+}
+
+//checkLightHeader 检查区块头, 规则和共识模块 CheckBlock 对父区块的检查一致
+func (chain *BlockChain) checkLightHeader(parent, header *types.Header) error {
+	if header.Height != parent.Height+1 {
+		return types.ErrBlockHeight
+	}
+	if !bytes.Equal(header.ParentHash, parent.Hash) {
+		return types.ErrParentHash
+	}
+	if types.IsFork(header.Height, "ForkCheckBlockTime") && parent.BlockTime > header.BlockTime {
+		return types.ErrBlockTime
+	}
+	if !bytes.Equal(calcHeaderHash(header), header.Hash) {
+		return types.ErrBlockHashNoMatch
+	}
+	if header.Signature == nil || !types.CheckSign(header.Hash, "", header.Signature) {
+		return types.ErrSign
+	}
+	return chain.checkCheckpoint(header.Height, header.Hash)
+}
+
+//checkLightHeaders 依次检查区块头, 返回第一个检查失败的区块头之前的区块头以及检查失败的原因
+func (chain *BlockChain) checkLightHeaders(pid string, parent *types.Header, headers []*types.Header) ([]*types.Header, error) {
+	last := parent
+	for i, header := range headers {
+		if err := chain.checkLightHeader(last, header); err != nil {
+			synlog.Error("checkLightHeaders", "pid", pid, "height", header.Height, "hash", common.ToHex(header.Hash), "err", err)
+			chain.RecordFaultPeer(pid, header.Height, header.Hash, err)
+			return headers[:i], err
+		}
+		last = header
+	}
+	return headers, nil
+}
+
+//addLightHeaders 保存检查通过的区块头, 返回最后保存的区块头.
+//区块头以及总难度都按照hash保存, 区块头直接连接到本地 tip 或者所在链的总难度大于本地主链时才切换主链,
+//从分叉点开始更新高度索引, 否则只作为分叉链保存
+func (chain *BlockChain) addLightHeaders(pid string, parent *types.Header, headers []*types.Header) (*types.Header, error) {
+	chain.chainLock.Lock()
+	defer chain.chainLock.Unlock()
+
+	td, err := chain.blockStore.GetTdByBlockHash(parent.Hash)
+	if err != nil {
+		return parent, err
+	}
+	tip := chain.blockStore.LastHeader()
+	tiptd, err := chain.blockStore.GetTdByBlockHash(tip.Hash)
+	if err != nil {
+		return parent, err
+	}
+	newbatch := chain.blockStore.NewBatch(true)
+	for _, header := range headers {
+		td = new(big.Int).Add(td, difficulty.CalcWork(header.Difficulty))
+		data, err := proto.Marshal(header)
+		if err != nil {
+			return parent, err
+		}
+		newbatch.Set(calcHashToBlockHeaderKey(header.Hash), data)
+		if err = chain.blockStore.SaveTdByBlockHash(newbatch, header.Hash, td); err != nil {
+			return parent, err
+		}
+		if chain.index.LookupNode(header.Hash) == nil {
+			chain.index.AddNode(newBlockNodeByHeader(false, header, pid, -1))
+		}
+	}
+	last := headers[len(headers)-1]
+	if !bytes.Equal(parent.Hash, tip.Hash) && td.Cmp(tiptd) <= 0 {
+		if err = newbatch.Write(); err != nil {
+			return parent, err
+		}
+		synlog.Info("addLightHeaders side chain", "pid", pid, "height", last.Height, "hash", common.ToHex(last.Hash), "td", td, "tiptd", tiptd)
+		return last, nil
+	}
+	branch, err := chain.lightBranch(parent)
+	if err != nil {
+		return parent, err
+	}
+	branch = append(branch, headers...)
+	for _, header := range branch {
+		if err = chain.blockStore.SaveBlockHeader(newbatch, header); err != nil {
+			return parent, err
+		}
+	}
+	//新的主链比原来的主链短时删除多出来的高度索引
+	for height := last.Height + 1; height <= tip.Height; height++ {
+		newbatch.Delete(calcHeightToHashKey(height))
+		newbatch.Delete(calcHeightToBlockHeaderKey(height))
+	}
+	newbatch.Set(blockLastHeight, types.Encode(&types.Int64{Data: last.Height}))
+	chain.blockStore.setPruneHeight(newbatch, last.Height+1)
+	if err = newbatch.Write(); err != nil {
+		return parent, err
+	}
+	chain.blockStore.updatePruneHeight(last.Height + 1)
+	chain.blockStore.UpdateHeight2(last.Height)
+	chain.blockStore.UpdateLastHeader(last)
+	//bestChain 回退到分叉点之后再依次设置新的主链
+	for height := tip.Height; height >= branch[0].Height; height-- {
+		if node := chain.bestChain.NodeByHeight(height); node != nil {
+			chain.bestChain.DelTip(node)
+		}
+	}
+	for _, header := range branch {
+		node := chain.index.LookupNode(header.Hash)
+		if node == nil {
+			node = newBlockNodeByHeader(false, header, pid, -1)
+			chain.index.AddNode(node)
+		}
+		node.parent = chain.bestChain.Tip()
+		chain.bestChain.SetTip(node)
+	}
+	if !bytes.Equal(branch[0].ParentHash, tip.Hash) {
+		synlog.Info("addLightHeaders switch chain", "pid", pid, "fork", branch[0].Height-1, "height", last.Height, "td", td, "tiptd", tiptd)
+	}
+	synlog.Debug("addLightHeaders", "pid", pid, "height", last.Height)
+	return last, nil
+}
+
+//lightBranch 返回 header 所在的分叉链上, 分叉点之后到 header 为止的区块头, header 在主链上时返回空
+func (chain *BlockChain) lightBranch(header *types.Header) ([]*types.Header, error) {
+	var branch []*types.Header
+	for {
+		hash, err := chain.blockStore.GetBlockHashByHeight(header.Height)
+		if err == nil && bytes.Equal(hash, header.Hash) {
+			return branch, nil
+		}
+		branch = append([]*types.Header{header}, branch...)
+		header, err = chain.blockStore.GetBlockHeaderByHash(header.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+//LightQueryTx 依次从 pids 中的节点获取交易的默克尔证明, 返回第一个验证通过的交易
+func (chain *BlockChain) LightQueryTx(source LightSource, pids []string, hash []byte) (*types.TransactionDetail, error) {
+	for _, pid := range pids {
+		detail, err := source.GetTxProof(pid, hash)
+		if err != nil {
+			chainlog.Debug("LightQueryTx", "pid", pid, "hash", common.ToHex(hash), "err", err)
+			continue
+		}
+		if err = chain.verifyTxProof(hash, detail); err != nil {
+			chainlog.Error("LightQueryTx verify", "pid", pid, "hash", common.ToHex(hash), "err", err)
+			continue
+		}
+		return detail, nil
+	}
+	return nil, types.ErrTxNotExist
+}
+
+//verifyTxProof 使用本地区块头的 TxHash 验证交易的默克尔证明
+func (chain *BlockChain) verifyTxProof(hash []byte, detail *types.TransactionDetail) error {
+	if detail.Tx == nil || !bytes.Equal(detail.Tx.Hash(), hash) {
+		return types.ErrInvalidTxProof
+	}
+	header, err := chain.blockStore.GetBlockHeaderByHeight(detail.Height)
+	if err != nil {
+		return err
+	}
+	if detail.Index < 0 || detail.Index >= header.TxCount {
+		return types.ErrInvalidTxProof
+	}
+	root := merkle.GetMerkleRootFromBranch(detail.Proofs, hash, uint32(detail.Index))
+	if !bytes.Equal(root, header.TxHash) {
+		return types.ErrInvalidTxProof
+	}
+	detail.Blocktime = header.BlockTime
+	return nil
+}
+
+//stateProofHeader 获取状态证明对应的区块头, height 小于 0 时为最新的区块头
+func (chain *BlockChain) stateProofHeader(height int64) (*types.Header, error) {
+	if height < 0 {
+		return chain.blockStore.LastHeader(), nil
+	}
+	return chain.blockStore.GetBlockHeaderByHeight(height)
+}
+
+//LightGetStateProof 依次从 pids 中的节点获取状态数据的 mavl 证明, 返回第一个验证通过的证明
+func (chain *BlockChain) LightGetStateProof(source LightSource, pids []string, req *types.ReqStateProof) (*types.StateProof, error) {
+	header, err := chain.stateProofHeader(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	for _, pid := range pids {
+		proof, err := source.GetStateProof(&types.ReqStateProof{StateHash: header.StateHash, Key: req.Key, Height: header.Height, Pid: pid})
+		if err != nil {
+			chainlog.Debug("LightGetStateProof", "pid", pid, "err", err)
+			continue
+		}
+		if !bytes.Equal(proof.StateHash, header.StateHash) || !bytes.Equal(proof.Key, req.Key) || !mavl.VerifyStateProof(proof) {
+			chainlog.Error("LightGetStateProof verify", "pid", pid, "height", header.Height, "err", types.ErrInvalidStateProof)
+			continue
+		}
+		proof.Height = header.Height
+		return proof, nil
+	}
+	return nil, types.ErrNotFound
+}
+
+//GetStateProof 获取状态数据以及 mavl 证明, 轻节点从全节点获取并验证, 全节点从本地的 store 获取
+func (chain *BlockChain) GetStateProof(req *types.ReqStateProof) (*types.StateProof, error) {
+	if chain.cfg.EnableLightClient {
+		return chain.LightGetStateProof(&p2pSource{client: chain.client}, chain.lightPeers(), req)
+	}
+	header, err := chain.stateProofHeader(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	msg := chain.client.NewMessage("store", types.EventStoreGetProof, &types.ReqStateProof{StateHash: header.StateHash, Key: req.Key, Height: header.Height})
+	err = chain.client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := chain.client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.StateProof), nil
+}
@@ -0,0 +1,208 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLightSource struct {
+	headers []*types.Header
+	txs     map[string]*types.TransactionDetail
+	proofs  map[string]*types.StateProof
+}
+
+func (s *testLightSource) GetHeaders(pid string, start, end int64) ([]*types.Header, error) {
+	if end >= int64(len(s.headers)) {
+		end = int64(len(s.headers)) - 1
+	}
+	if start > end {
+		return nil, nil
+	}
+	headers := s.headers[start : end+1]
+	//bad 节点修改了高度3的区块时间
+	if pid == "bad" && start <= 3 && end >= 3 {
+		headers = append([]*types.Header{}, headers...)
+		header := proto.Clone(headers[3-start]).(*types.Header)
+		header.BlockTime++
+		headers[3-start] = header
+	}
+	return headers, nil
+}
+
+func (s *testLightSource) GetTxProof(pid string, hash []byte) (*types.TransactionDetail, error) {
+	detail, ok := s.txs[string(hash)]
+	if !ok {
+		return nil, types.ErrTxNotExist
+	}
+	detail = proto.Clone(detail).(*types.TransactionDetail)
+	//bad 节点返回的交易在另外一个区块中
+	if pid == "bad" {
+		detail.Height++
+	}
+	return detail, nil
+}
+
+func (s *testLightSource) GetStateProof(req *types.ReqStateProof) (*types.StateProof, error) {
+	proof, ok := s.proofs[string(req.StateHash)+string(req.Key)]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	proof = proto.Clone(proof).(*types.StateProof)
+	//bad 节点修改了状态数据
+	if req.Pid == "bad" {
+		proof.Value = append(proof.Value, 1)
+	}
+	return proof, nil
+}
+
+//forkHeader 创建 parent 之后的区块头, 交易以及状态和 template 相同
+func forkHeader(parent, template *types.Header, priv crypto.PrivKey) *types.Header {
+	header := proto.Clone(template).(*types.Header)
+	header.Height = parent.Height + 1
+	header.ParentHash = parent.Hash
+	header.BlockTime = parent.BlockTime + 2
+	block := &types.Block{
+		Version:    header.Version,
+		ParentHash: header.ParentHash,
+		TxHash:     header.TxHash,
+		StateHash:  header.StateHash,
+		Height:     header.Height,
+		BlockTime:  header.BlockTime,
+		Difficulty: header.Difficulty,
+		Txs:        make([]*types.Transaction, header.TxCount),
+	}
+	header.Hash = block.Hash()
+	header.Signature = &types.Signature{Ty: types.SECP256K1, Pubkey: priv.PubKey().Bytes(), Signature: priv.Sign(header.Hash).Bytes()}
+	return header
+}
+
+func TestLightClient(t *testing.T) {
+	mock33 := testnode.New("", nil)
+	var height int64 = 10
+	var hashes [][]byte
+	for i := int64(1); i <= height; i++ {
+		hashes = append(hashes, mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin)))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	api := mock33.GetAPI()
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: 0, End: height})
+	require.Nil(t, err)
+	//solo 的区块没有签名, 轻节点要求区块头有签名
+	unsigned := proto.Clone(headers).(*types.Headers)
+	priv := mock33.GetGenesisKey()
+	for _, header := range headers.Items[1:] {
+		header.Signature = &types.Signature{Ty: types.SECP256K1, Pubkey: priv.PubKey().Bytes(), Signature: priv.Sign(header.Hash).Bytes()}
+	}
+	source := &testLightSource{
+		headers: headers.Items,
+		txs:     make(map[string]*types.TransactionDetail),
+		proofs:  make(map[string]*types.StateProof),
+	}
+	for _, hash := range hashes {
+		detail, err := api.QueryTx(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		source.txs[string(hash)] = detail
+	}
+	key := account.NewCoinsAccount().AccountKey(mock33.GetHotAddress())
+	for _, h := range []int64{5, -1} {
+		proof, err := api.GetStateProof(&types.ReqStateProof{Key: key, Height: h})
+		require.Nil(t, err)
+		source.proofs[string(proof.StateHash)+string(key)] = proof
+	}
+	fullDetail, err := mock33.GetBlockChain().GetStore().LoadBlockByHeight(height)
+	require.Nil(t, err)
+	mock33.Close()
+
+	cfg, sub := testnode.GetDefaultConfig()
+	cfg.BlockChain.EnableLightClient = true
+	//轻节点只接受 solo 配置的 signers 签名的区块头
+	sub.Consensus["solo"] = []byte(`{"genesis":"` + mock33.GetGenesisAddress() + `","genesisBlockTime":1514533394,"waitTxMs":10,"signers":["` + mock33.GetGenesisAddress() + `"]}`)
+	mock33 = testnode.NewWithConfig(cfg, sub, nil)
+	defer mock33.Close()
+	require.Nil(t, mock33.WaitHeight(0))
+	chain := mock33.GetBlockChain()
+	api = mock33.GetAPI()
+
+	//没有签名的区块头不能保存
+	err = chain.LightSync(&testLightSource{headers: unsigned.Items}, []string{"unsigned"}, height)
+	assert.Equal(t, types.ErrSign, err)
+	assert.Equal(t, int64(0), chain.GetBlockHeight())
+	//区块头检查失败的时候只保存之前的区块头
+	err = chain.LightSync(source, []string{"bad"}, height)
+	assert.Equal(t, types.ErrBlockHashNoMatch, err)
+	assert.Equal(t, int64(2), chain.GetBlockHeight())
+	assert.Equal(t, types.ErrNoPeer, chain.LightSync(source, nil, height))
+
+	//从多个节点同步, 一个节点同步成功即可
+	require.Nil(t, chain.LightSync(source, []string{"bad", "good"}, height))
+	assert.Equal(t, height, chain.GetBlockHeight())
+	assert.Equal(t, headers.Items[height].Hash, chain.GetStore().LastHeader().Hash)
+
+	//轻节点不执行区块, 也没有区块体
+	_, _, _, err = chain.ProcessBlock(false, fullDetail, "peer", true, -1)
+	assert.Equal(t, types.ErrNotSupport, err)
+	_, err = api.GetBlocks(&types.ReqBlocks{Start: 5, End: 5})
+	assert.Equal(t, types.ErrBlockPruned, err)
+	lightHeaders, err := api.GetHeaders(&types.ReqBlocks{Start: 0, End: height})
+	require.Nil(t, err)
+	for i, header := range lightHeaders.Items {
+		assert.True(t, proto.Equal(headers.Items[i], header))
+	}
+
+	detail, err := chain.LightQueryTx(source, []string{"bad", "good"}, hashes[4])
+	require.Nil(t, err)
+	assert.Equal(t, int64(5), detail.Height)
+	assert.Equal(t, hashes[4], detail.Tx.Hash())
+	_, err = chain.LightQueryTx(source, []string{"bad"}, hashes[4])
+	assert.Equal(t, types.ErrTxNotExist, err)
+
+	proof, err := chain.LightGetStateProof(source, []string{"bad", "good"}, &types.ReqStateProof{Key: key, Height: -1})
+	require.Nil(t, err)
+	assert.Equal(t, height, proof.Height)
+	var acc types.Account
+	require.Nil(t, types.Decode(proof.Value, &acc))
+	assert.Equal(t, height*types.Coin, acc.Balance)
+	proof, err = chain.LightGetStateProof(source, []string{"good"}, &types.ReqStateProof{Key: key, Height: 5})
+	require.Nil(t, err)
+	require.Nil(t, types.Decode(proof.Value, &acc))
+	assert.Equal(t, 5*types.Coin, acc.Balance)
+	_, err = chain.LightGetStateProof(source, []string{"bad"}, &types.ReqStateProof{Key: key, Height: -1})
+	assert.Equal(t, types.ErrNotFound, err)
+
+	//从高度6开始分叉, 总难度和本地主链相同的分叉链不切换
+	fork := &testLightSource{headers: append([]*types.Header{}, headers.Items[:6]...)}
+	for i := int64(6); i <= height+1; i++ {
+		fork.headers = append(fork.headers, forkHeader(fork.headers[i-1], headers.Items[height], priv))
+	}
+	same := &testLightSource{headers: fork.headers[:height+1]}
+	require.Nil(t, chain.LightSync(same, []string{"fork"}, height))
+	assert.Equal(t, headers.Items[height].Hash, chain.GetStore().LastHeader().Hash)
+	//总难度更大的分叉链切换为主链
+	require.Nil(t, chain.LightSync(fork, []string{"fork"}, height+1))
+	assert.Equal(t, height+1, chain.GetBlockHeight())
+	assert.Equal(t, fork.headers[height+1].Hash, chain.GetStore().LastHeader().Hash)
+	forkHeaders, err := api.GetHeaders(&types.ReqBlocks{Start: 6, End: height + 1})
+	require.Nil(t, err)
+	for i, header := range forkHeaders.Items {
+		assert.True(t, proto.Equal(fork.headers[i+6], header))
+	}
+	//原来的主链更长之后切换回原来的主链
+	more := &testLightSource{headers: append([]*types.Header{}, headers.Items...)}
+	for i := height + 1; i <= height+2; i++ {
+		more.headers = append(more.headers, forkHeader(more.headers[i-1], headers.Items[height], priv))
+	}
+	require.Nil(t, chain.LightSync(more, []string{"fork", "good"}, height+2))
+	assert.Equal(t, more.headers[height+2].Hash, chain.GetStore().LastHeader().Hash)
+}
@@ -183,6 +183,30 @@ func (op *OrphanPool) GetChildOrphanCount(hash string) int {
 	return len(op.prevOrphans[hash])
 }
 
+//Stat 孤儿池的统计信息
+func (op *OrphanPool) Stat() *types.OrphanPoolStat {
+	op.orphanLock.RLock()
+	defer op.orphanLock.RUnlock()
+
+	stat := &types.OrphanPoolStat{
+		Count:     int64(len(op.orphans)),
+		Parents:   int64(len(op.prevOrphans)),
+		MaxCount:  int64(maxOrphanBlocks),
+		MinHeight: -1,
+		MaxHeight: -1,
+	}
+	for _, oBlock := range op.orphans {
+		height := oBlock.block.Height
+		if stat.MinHeight < 0 || height < stat.MinHeight {
+			stat.MinHeight = height
+		}
+		if height > stat.MaxHeight {
+			stat.MaxHeight = height
+		}
+	}
+	return stat
+}
+
 func (op *OrphanPool) getChildOrphan(hash string, index int) *orphanBlock {
 	op.orphanLock.RLock()
 	defer op.orphanLock.RUnlock()
@@ -85,6 +85,20 @@ func (chain *BlockChain) ProcRecvMsg() {
 			go chain.processMsg(msg, reqnum, chain.localPrefixCount)
 		case types.EventAddBlockSeqCB:
 			go chain.processMsg(msg, reqnum, chain.addBlockSeqCB)
+		case types.EventListBlockSeqCB:
+			go chain.processMsg(msg, reqnum, chain.listBlockSeqCB)
+		case types.EventSetBlockSeqCBStatus:
+			go chain.processMsg(msg, reqnum, chain.setBlockSeqCBStatus)
+		case types.EventDelBlockSeqCB:
+			go chain.processMsg(msg, reqnum, chain.delBlockSeqCB)
+		case types.EventGetCheckpoint:
+			go chain.processMsg(msg, reqnum, chain.getCheckpoint)
+		case types.EventGetStateProof:
+			go chain.processMsg(msg, reqnum, chain.getStateProof)
+		case types.EventGetReorgHistory:
+			go chain.processMsg(msg, reqnum, chain.getReorgHistory)
+		case types.EventGetPruneHeight:
+			go chain.processMsg(msg, reqnum, chain.getPruneHeight)
 		default:
 			go chain.processMsg(msg, reqnum, chain.unknowMsg)
 		}
@@ -96,18 +110,117 @@ func (chain *BlockChain) unknowMsg(msg queue.Message) {
 }
 
 func (chain *BlockChain) addBlockSeqCB(msg queue.Message) {
-	if chain.blockStore.seqCBNum() >= MaxSeqCB {
+	cb := (msg.Data).(*types.BlockSeqCB)
+	//兼容之前通过空的 URL 删除订阅
+	if cb.URL == "" {
+		msg.ReplyErr("EventAddBlockSeqCB", chain.delSeqCB(cb.Name))
+		return
+	}
+	if err := chain.blockStore.checkSequence(); err != nil {
+		msg.Reply(chain.client.NewMessage("rpc", types.EventAddBlockSeqCB, err))
+		return
+	}
+	//file 方式只能写到配置的目录下
+	if cb.Transport == types.SeqCBTransportFile {
+		if _, err := pushFilePath(chain.cfg.PushFileDir, cb.URL); err != nil {
+			msg.Reply(chain.client.NewMessage("rpc", types.EventAddBlockSeqCB, err))
+			return
+		}
+	}
+	_, err := chain.blockStore.getBlockSeqCB([]byte(cb.Name))
+	if err != nil && chain.blockStore.seqCBNum() >= MaxSeqCB {
 		msg.Reply(chain.client.NewMessage("rpc", types.EventAddBlockSeqCB, types.ErrTooManySeqCB))
 		return
 	}
-	cb := (msg.Data).(*types.BlockSeqCB)
-	err := chain.blockStore.addBlockSeqCB(cb)
+	err = chain.blockStore.addBlockSeqCB(cb)
 	if err != nil {
 		msg.Reply(chain.client.NewMessage("rpc", types.EventAddBlockSeqCB, err))
 		return
 	}
-	chain.pushseq.addTask(cb)
-	msg.ReplyErr("EventAddBlockSeqCB", nil)
+	msg.ReplyErr("EventAddBlockSeqCB", chain.pushseq.resetTask(cb))
+}
+
+//listBlockSeqCB 列出所有的订阅以及推送的进度和状态, 不返回签名的密钥
+func (chain *BlockChain) listBlockSeqCB(msg queue.Message) {
+	cbs, err := chain.blockStore.listSeqCB()
+	if err != nil && err != types.ErrNotFound {
+		msg.Reply(chain.client.NewMessage("rpc", types.EventListBlockSeqCB, err))
+		return
+	}
+	var reply types.BlockSeqCBs
+	for _, cb := range cbs {
+		name := []byte(cb.Name)
+		cb.Secret = ""
+		reply.Items = append(reply.Items, &types.BlockSeqCBInfo{
+			Cb:      cb,
+			LastNum: chain.blockStore.getSeqCBLastNum(name),
+			State:   chain.blockStore.getSeqCBState(name),
+		})
+	}
+	msg.Reply(chain.client.NewMessage("rpc", types.EventListBlockSeqCB, &reply))
+}
+
+//setBlockSeqCBStatus 暂停或者恢复推送, deadletter 的订阅也通过恢复重新推送
+func (chain *BlockChain) setBlockSeqCBStatus(msg queue.Message) {
+	req := (msg.Data).(*types.ReqSetSeqCBStatus)
+	if req.Status != types.SeqCBActive && req.Status != types.SeqCBPaused {
+		msg.ReplyErr("EventSetBlockSeqCBStatus", types.ErrInvalidParam)
+		return
+	}
+	if req.Status == types.SeqCBActive {
+		if err := chain.blockStore.checkSequence(); err != nil {
+			msg.ReplyErr("EventSetBlockSeqCBStatus", err)
+			return
+		}
+	}
+	name := []byte(req.Name)
+	if _, err := chain.blockStore.getBlockSeqCB(name); err != nil {
+		msg.ReplyErr("EventSetBlockSeqCBStatus", err)
+		return
+	}
+	state := chain.blockStore.getSeqCBState(name)
+	state.Status = req.Status
+	if req.Status == types.SeqCBActive {
+		state.Failures = 0
+		state.LastError = ""
+		state.NextRetry = 0
+	}
+	err := chain.blockStore.setSeqCBState(name, state)
+	if err == nil {
+		chain.pushseq.setStatus(req.Name, req.Status)
+	}
+	msg.ReplyErr("EventSetBlockSeqCBStatus", err)
+}
+
+func (chain *BlockChain) delBlockSeqCB(msg queue.Message) {
+	req := (msg.Data).(*types.ReqString)
+	msg.ReplyErr("EventDelBlockSeqCB", chain.delSeqCB(req.Data))
+}
+
+func (chain *BlockChain) getCheckpoint(msg queue.Message) {
+	msg.Reply(chain.client.NewMessage("rpc", types.EventReplyCheckpoint, chain.GetCheckpoint()))
+}
+
+//getPruneHeight 低于这个高度的区块(创世区块除外)已经裁剪, p2p 不再提供这些区块的下载
+func (chain *BlockChain) getPruneHeight(msg queue.Message) {
+	msg.Reply(chain.client.NewMessage("p2p", types.EventGetPruneHeight, &types.Int64{Data: chain.blockStore.PruneHeight()}))
+}
+
+func (chain *BlockChain) getStateProof(msg queue.Message) {
+	proof, err := chain.GetStateProof(msg.Data.(*types.ReqStateProof))
+	if err != nil {
+		chainlog.Error("getStateProof", "err", err.Error())
+		msg.Reply(chain.client.NewMessage("rpc", types.EventStateProof, err))
+		return
+	}
+	msg.Reply(chain.client.NewMessage("rpc", types.EventStateProof, proof))
+}
+
+func (chain *BlockChain) delSeqCB(name string) error {
+	if _, err := chain.blockStore.getBlockSeqCB([]byte(name)); err != nil {
+		return err
+	}
+	return chain.pushseq.delTask(name)
 }
 
 func (chain *BlockChain) queryTx(msg queue.Message) {
@@ -28,6 +28,10 @@ func (b *BlockChain) ProcessBlock(broadcast bool, block *types.BlockDetail, pid
 	if atomic.LoadInt32(&b.isclosed) == 1 {
 		return nil, false, false, types.ErrIsClosed
 	}
+	//轻节点只执行创世区块, 之后只同步区块头
+	if b.cfg.EnableLightClient && block.Block.Height > 0 {
+		return nil, false, false, types.ErrNotSupport
+	}
 	if block.Block.Height > 0 {
 		var lastBlockHash []byte
 		if addBlock {
@@ -68,7 +72,12 @@ func (b *BlockChain) ProcessBlock(broadcast bool, block *types.BlockDetail, pid
 		return nil, false, false, types.ErrBlockExist
 	}
 
-	//checkpoint 的处理流程，block的时间必须晚于上一次的checkpoint点，以后再增加处理
+	//检查点高度的区块hash必须和检查点一致
+	err := b.checkCheckpoint(block.Block.Height, blockHash)
+	if err != nil {
+		b.RecordFaultPeer(pid, block.Block.Height, blockHash, err)
+		return nil, false, false, err
+	}
 
 	// 判断本block的父block是否存在，如果不存在就将此block添加到孤儿链中
 	var prevHashExists bool
@@ -259,6 +268,14 @@ func (b *BlockChain) connectBestChain(node *blockNode, block *types.BlockDetail)
 	chainlog.Debug("connectBestChain node", "height", node.height, "hash", common.ToHex(node.hash), "parentHash", common.ToHex(parentHash))
 	chainlog.Debug("connectBestChain block", "height", block.Block.Height, "hash", common.ToHex(block.Block.Hash()))
 
+	//不能回滚检查点或者最终确认深度之下的区块
+	fork := b.bestChain.FindFork(node)
+	if err := b.checkForkHeight(fork.height); err != nil {
+		chainlog.Error("connectBestChain reject fork", "height", node.height, "hash", common.ToHex(node.hash), "fork.height", fork.height, "pid", node.pid)
+		b.RecordFaultPeer(node.pid, node.height, node.hash, err)
+		return nil, false, err
+	}
+
 	// 获取需要重组的block node
 	detachNodes, attachNodes := b.getReorganizeNodes(node)
 
@@ -268,7 +285,7 @@ func (b *BlockChain) connectBestChain(node *blockNode, block *types.BlockDetail)
 	if err != nil {
 		return nil, false, err
 	}
-
+	b.saveReorgRecord(fork, detachNodes, attachNodes, node.pid, tiptd, blocktd)
 	return nil, true, nil
 }
 
@@ -298,7 +315,9 @@ func (b *BlockChain) connectBlock(node *blockNode, blockdetail *types.BlockDetai
 	//广播或者同步过来的blcok需要调用执行模块
 	errReturn := (node.pid != "self")
 	//println("--exec before--")
-	blockdetail, _, err = execBlock(b.client, prevStateHash, block, errReturn, sync)
+	//只有从可信的归档文件导入的区块不检查签名
+	checkSign := node.pid != ArchiveTrustedPid
+	blockdetail, _, err = execBlock(b.client, prevStateHash, block, errReturn, sync, checkSign)
 	//println("--exec end--")
 	if err != nil && err != types.ErrFutureBlock {
 		//记录执行出错的block信息,需要过滤掉ErrFutureBlock错误的block，不计入故障中，尝试再次执行
@@ -369,6 +388,9 @@ func (b *BlockChain) connectBlock(node *blockNode, blockdetail *types.BlockDetai
 	b.blockStore.UpdateHeight2(blockdetail.GetBlock().GetHeight())
 	b.blockStore.UpdateLastBlock2(blockdetail.Block)
 
+	//通知后台裁剪旧的区块
+	b.notifyPrune()
+
 	// 更新 best chain的tip节点
 	b.bestChain.SetTip(node)
 
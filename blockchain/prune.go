@@ -0,0 +1,155 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sync/atomic"
+	"time"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+区块裁剪:
+开启 pruneKeepBlocks 之后, 每次添加区块时通知后台裁剪 最新高度-pruneKeepBlocks 以下的区块,
+删除区块体(包括回执), 交易信息只保留高度和索引, 用于交易的重复检查.
+区块头, hash 和高度的对应关系, 区块序列号以及难度都保留, 创世区块不裁剪.
+*/
+
+var (
+	blockPruneHeight = []byte("blockPruneHeight")
+	//PruneMinKeepBlocks 开启裁剪时最少保留的区块数
+	PruneMinKeepBlocks int64 = 256
+	//pruneBlockBatch 每次最多裁剪的区块数
+	pruneBlockBatch int64 = 100
+	//裁剪出错之后重试的最短和最长的等待时间
+	pruneRetryMin = time.Second
+	pruneRetryMax = time.Minute
+)
+
+//PruneHeight 低于这个高度的区块(创世区块除外)已经被裁剪, 只保留区块头
+func (bs *BlockStore) PruneHeight() int64 {
+	return atomic.LoadInt64(&bs.pruneHeight)
+}
+
+//isPruned height 高度的区块体是否已经被裁剪
+func (bs *BlockStore) isPruned(height int64) bool {
+	return height > 0 && height < bs.PruneHeight()
+}
+
+//setPruneHeight 在 batch 中保存裁剪高度, batch 写入之后调用 updatePruneHeight
+func (bs *BlockStore) setPruneHeight(storeBatch dbm.Batch, height int64) {
+	storeBatch.Set(blockPruneHeight, types.Encode(&types.Int64{Data: height}))
+}
+
+func (bs *BlockStore) updatePruneHeight(height int64) {
+	atomic.StoreInt64(&bs.pruneHeight, height)
+}
+
+//pruneBlocks 裁剪 PruneHeight 到 end-1 的区块
+func (bs *BlockStore) pruneBlocks(end int64) error {
+	start := bs.PruneHeight()
+	if start < 1 {
+		start = 1
+	}
+	if start >= end {
+		return nil
+	}
+	newbatch := bs.NewBatch(true)
+	for height := start; height < end; height++ {
+		detail, err := bs.LoadBlockByHeight(height)
+		if err != nil {
+			storeLog.Error("pruneBlocks LoadBlockByHeight", "height", height, "err", err)
+			return err
+		}
+		block := detail.Block
+		newbatch.Delete(calcHashToBlockBodyKey(block.Hash()))
+		for index, tx := range block.Txs {
+			key := types.CalcTxKey(tx.Hash())
+			//没有开启交易索引时不需要处理
+			if _, err := bs.db.Get(key); err != nil {
+				continue
+			}
+			txresult := &types.TxResult{Height: height, Index: int32(index), Blocktime: block.BlockTime}
+			newbatch.Set(key, types.Encode(txresult))
+		}
+	}
+	bs.setPruneHeight(newbatch, end)
+	err := newbatch.Write()
+	if err != nil {
+		storeLog.Error("pruneBlocks", "end", end, "err", err)
+		return err
+	}
+	bs.updatePruneHeight(end)
+	storeLog.Debug("pruneBlocks", "start", start, "end", end)
+	return nil
+}
+
+//notifyPrune 添加区块之后通知后台裁剪, 不阻塞区块的处理
+func (chain *BlockChain) notifyPrune() {
+	if chain.cfg.PruneKeepBlocks <= 0 {
+		return
+	}
+	select {
+	case chain.pruneCh <- struct{}{}:
+	default:
+	}
+}
+
+//pruneRoutine 后台裁剪区块, 每次最多裁剪 pruneBlockBatch 个区块, 直到 最新高度-pruneKeepBlocks 为止.
+//裁剪出错之后等待 pruneRetryMin 再重试, 连续出错时等待的时间翻倍, 最多等待 pruneRetryMax
+func (chain *BlockChain) pruneRoutine() {
+	defer chain.tickerwg.Done()
+	var retry time.Duration
+	for {
+		select {
+		case <-chain.quit:
+			return
+		case <-chain.pruneCh:
+		}
+		for done := false; !done; {
+			var err error
+			done, err = chain.pruneBlocks(chain.GetBlockHeight())
+			if err != nil {
+				retry *= 2
+				if retry < pruneRetryMin {
+					retry = pruneRetryMin
+				}
+				if retry > pruneRetryMax {
+					retry = pruneRetryMax
+				}
+				chainlog.Error("pruneRoutine", "err", err, "retry", retry)
+				done = false
+				select {
+				case <-chain.quit:
+					return
+				case <-time.After(retry):
+				}
+				continue
+			}
+			retry = 0
+			select {
+			case <-chain.quit:
+				return
+			default:
+			}
+		}
+	}
+}
+
+//pruneBlocks 裁剪 pruneKeepBlocks 之前的区块, 一次最多裁剪 pruneBlockBatch 个, 返回是否已经全部裁剪
+func (chain *BlockChain) pruneBlocks(height int64) (bool, error) {
+	end := height - chain.cfg.PruneKeepBlocks + 1
+	done := true
+	if start := chain.blockStore.PruneHeight(); end-start > pruneBlockBatch {
+		end = start + pruneBlockBatch
+		done = false
+	}
+	if err := chain.blockStore.pruneBlocks(end); err != nil {
+		return false, err
+	}
+	return done, nil
+}
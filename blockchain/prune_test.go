@@ -0,0 +1,86 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBlocks(t *testing.T) {
+	assert.Panics(t, func() { blockchain.New(&types.BlockChain{PruneKeepBlocks: 1}) })
+
+	//另外一条链的区块头, 从创世区块分叉
+	mock33 := testnode.New("", nil)
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(1))
+	forkHeaders, err := mock33.GetAPI().GetHeaders(&types.ReqBlocks{Start: 0, End: 1})
+	require.Nil(t, err)
+	mock33.Close()
+
+	minKeep := blockchain.PruneMinKeepBlocks
+	blockchain.PruneMinKeepBlocks = 1
+	defer func() { blockchain.PruneMinKeepBlocks = minKeep }()
+	cfg, sub := testnode.GetDefaultConfig()
+	cfg.BlockChain.PruneKeepBlocks = 5
+	mock33 = testnode.NewWithConfig(cfg, sub, nil)
+	defer mock33.Close()
+	api := mock33.GetAPI()
+
+	var height int64 = 12
+	var hashes [][]byte
+	for i := int64(1); i <= height; i++ {
+		hashes = append(hashes, mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin)))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	//保留最新的 5 个区块 8-12, 在后台裁剪
+	for i := 0; i < 100 && mock33.GetBlockChain().GetStore().PruneHeight() < 8; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, int64(8), mock33.GetBlockChain().GetStore().PruneHeight())
+	client := mock33.GetClient()
+	msg := client.NewMessage("blockchain", types.EventGetPruneHeight, nil)
+	require.Nil(t, client.Send(msg, true))
+	resp, err := client.Wait(msg)
+	require.Nil(t, err)
+	assert.Equal(t, int64(8), resp.GetData().(*types.Int64).Data)
+	//分叉之后的区块已经裁剪, 不能回滚
+	assert.Equal(t, types.ErrBlockPruned, mock33.GetBlockChain().ProcBlockHeaders(forkHeaders, "peer"))
+
+	_, err = api.GetBlocks(&types.ReqBlocks{Start: 3, End: 3})
+	assert.Equal(t, types.ErrBlockPruned, err)
+	_, err = api.GetBlocks(&types.ReqBlocks{Start: 7, End: 9})
+	assert.Equal(t, types.ErrBlockPruned, err)
+	blocks, err := api.GetBlocks(&types.ReqBlocks{Start: 8, End: height, IsDetail: true})
+	require.Nil(t, err)
+	assert.Equal(t, 5, len(blocks.Items))
+	//创世区块不裁剪
+	_, err = api.GetBlocks(&types.ReqBlocks{Start: 0, End: 0})
+	assert.Nil(t, err)
+	//区块头以及序列号保留
+	headers, err := api.GetHeaders(&types.ReqBlocks{Start: 0, End: height})
+	require.Nil(t, err)
+	assert.Equal(t, int(height+1), len(headers.Items))
+	seq, err := api.GetBlockSequences(&types.ReqBlocks{Start: 3, End: 3})
+	require.Nil(t, err)
+	assert.Equal(t, headers.Items[3].Hash, seq.Items[0].Hash)
+
+	_, err = api.QueryTx(&types.ReqHash{Hash: hashes[2]})
+	assert.Equal(t, types.ErrTxPruned, err)
+	detail, err := api.QueryTx(&types.ReqHash{Hash: hashes[9]})
+	require.Nil(t, err)
+	assert.Equal(t, int64(10), detail.Height)
+	//裁剪之后交易仍然不能重复打包
+	has, err := mock33.GetBlockChain().GetStore().HasTx(hashes[2])
+	require.Nil(t, err)
+	assert.True(t, has)
+}
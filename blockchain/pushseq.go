@@ -1,9 +1,6 @@
 package blockchain
 
 import (
-	"bytes"
-	"compress/gzip"
-	"io/ioutil"
 	"net/http"
 	"sync"
 	"time"
@@ -11,22 +8,41 @@ import (
 	"github.com/33cn/chain33/types"
 )
 
+//推送失败之后重试的间隔, 每次失败加倍, 最大为 pushRetryMax
+var (
+	pushRetryBase = time.Second
+	pushRetryMax  = 10 * time.Minute
+)
+
+//pushCB 订阅以及订阅的代数, 每次订阅或者删除订阅代数加一
+type pushCB struct {
+	cb  *types.BlockSeqCB
+	gen int64
+}
+
 type pushNotify struct {
-	cb  chan *types.BlockSeqCB
-	seq chan int64
+	cb     chan pushCB
+	seq    chan int64
+	status chan int32
 }
 
 //push seq data to out
 type pushseq struct {
-	store  *BlockStore
-	cmds   map[string]pushNotify
-	mu     sync.Mutex
-	client *http.Client
+	store   *BlockStore
+	cmds    map[string]pushNotify
+	mu      sync.Mutex
+	client  *http.Client
+	fileDir string
+	//gens 记录每个 name 当前的代数, 推送任务写数据库之前检查代数,
+	//旧的订阅还在推送中的时候重新订阅或者删除订阅, 旧订阅的结果不再写入数据库
+	gens  map[string]int64
+	genmu sync.Mutex
 }
 
-func newpushseq(store *BlockStore) *pushseq {
+func newpushseq(store *BlockStore, fileDir string) *pushseq {
 	cmds := make(map[string]pushNotify)
-	return &pushseq{store: store, cmds: cmds, client: &http.Client{}}
+	gens := make(map[string]int64)
+	return &pushseq{store: store, cmds: cmds, gens: gens, client: &http.Client{Timeout: pushTimeout}, fileDir: fileDir}
 }
 
 //初始化: 从数据库读出seq的数目
@@ -41,37 +57,125 @@ func (p *pushseq) init() {
 	}
 }
 
-func (p *pushseq) updateLastSeq() {
+func (p *pushseq) updateLastSeq(notify pushNotify) {
 	last, err := p.store.LoadBlockLastSequence()
 	if err != nil {
 		chainlog.Error("listSeqCB", "err", err)
 		return
 	}
-	p.updateSeq(last)
+	notify.seq <- last
 }
 
 //每个name 有一个task
 func (p *pushseq) addTask(cb *types.BlockSeqCB) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	gen, _ := p.nextGen(cb.Name, nil)
+	p.addTaskLocked(pushCB{cb: cb, gen: gen})
+}
+
+//resetTask 重新订阅, 清除之前的推送状态
+func (p *pushseq) resetTask(cb *types.BlockSeqCB) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	gen, err := p.nextGen(cb.Name, p.store.delSeqCBState)
+	if err != nil {
+		return err
+	}
+	p.addTaskLocked(pushCB{cb: cb, gen: gen})
+	return nil
+}
+
+//delTask 停止推送任务, 删除订阅以及推送的进度和状态
+func (p *pushseq) delTask(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	gen, err := p.nextGen(name, p.store.delBlockSeqCB)
+	if err != nil {
+		return err
+	}
+	p.addTaskLocked(pushCB{cb: &types.BlockSeqCB{Name: name}, gen: gen})
+	return nil
+}
+
+//nextGen 代数加一之后执行 clean, 之后旧的推送任务不能再写数据库
+func (p *pushseq) nextGen(name string, clean func([]byte) error) (int64, error) {
+	p.genmu.Lock()
+	defer p.genmu.Unlock()
+	p.gens[name]++
+	if clean != nil {
+		if err := clean([]byte(name)); err != nil {
+			//清除失败时原来的推送任务继续运行
+			p.gens[name]--
+			return 0, err
+		}
+	}
+	return p.gens[name], nil
+}
+
+//saveTask 只有当前代数的推送任务才能写数据库
+func (p *pushseq) saveTask(name string, gen int64, save func([]byte) error) {
+	p.genmu.Lock()
+	defer p.genmu.Unlock()
+	if p.gens[name] != gen {
+		chainlog.Debug("pushseq stale task", "name", name, "gen", gen)
+		return
+	}
+	if err := save([]byte(name)); err != nil {
+		chainlog.Error("pushseq save", "name", name, "err", err)
+	}
+}
+
+func (p *pushseq) saveState(name string, gen int64, state *types.BlockSeqCBState) {
+	p.saveTask(name, gen, func(key []byte) error { return p.store.setSeqCBState(key, state) })
+}
+
+func (p *pushseq) saveLastNum(name string, gen int64, num int64) {
+	p.saveTask(name, gen, func(key []byte) error { return p.store.setSeqCBLastNum(key, num) })
+}
+
+func (p *pushseq) addTaskLocked(task pushCB) {
+	cb := task.cb
 	if notify, ok := p.cmds[cb.Name]; ok {
-		notify.cb <- cb
+		notify.cb <- task
 		if cb.URL == "" {
 			delete(p.cmds, cb.Name)
 		}
 		return
 	}
-	p.cmds[cb.Name] = pushNotify{
-		cb:  make(chan *types.BlockSeqCB, 10),
-		seq: make(chan int64, 10),
+	if cb.URL == "" {
+		return
+	}
+	notify := pushNotify{
+		cb:     make(chan pushCB, 10),
+		seq:    make(chan int64, 10),
+		status: make(chan int32, 10),
+	}
+	p.cmds[cb.Name] = notify
+	notify.cb <- task
+	p.runTask(cb.Name, notify)
+	//更新最新的seq, 已经持有锁所以不能调用 updateSeq
+	p.updateLastSeq(notify)
+}
+
+//setStatus 暂停或者恢复推送任务
+func (p *pushseq) setStatus(name string, status int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if notify, ok := p.cmds[name]; ok {
+		notify.status <- status
 	}
-	p.cmds[cb.Name].cb <- cb
-	p.runTask(p.cmds[cb.Name])
-	//更新最新的seq
-	p.updateLastSeq()
 }
 
 func (p *pushseq) updateSeq(seq int64) {
+	//主链的 sequence 在保存区块时生成, 这里传入的是 -1, 需要从数据库中读取
+	if seq < 0 {
+		last, err := p.store.LoadBlockLastSequence()
+		if err != nil {
+			return
+		}
+		seq = last
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	for _, notify := range p.cmds {
@@ -91,18 +195,61 @@ func (p *pushseq) trigeRun(run chan struct{}, sleep time.Duration) {
 	}()
 }
 
-func (p *pushseq) runTask(input pushNotify) {
+//连续失败 failures 次之后的重试间隔
+func pushRetryDelay(failures int32) time.Duration {
+	delay := pushRetryBase
+	for i := int32(1); i < failures && delay < pushRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > pushRetryMax {
+		delay = pushRetryMax
+	}
+	return delay
+}
+
+func (p *pushseq) runTask(name string, input pushNotify) {
 	go func(in pushNotify) {
 		var lastseq int64 = -1
 		var maxseq int64 = -1
 		var cb *types.BlockSeqCB
+		var gen int64
+		var sink pushTransport
+		var retryAt time.Time
 		var run = make(chan struct{}, 10)
+		state := p.store.getSeqCBState([]byte(name))
+		defer func() {
+			if sink != nil {
+				sink.close()
+			}
+		}()
 		for {
 			select {
-			case cb = <-in.cb:
-				if cb.URL == "" {
+			case task := <-in.cb:
+				if sink != nil {
+					sink.close()
+				}
+				newcb := task.cb
+				if newcb.URL == "" {
 					return
 				}
+				//重新订阅时状态已经在数据库中清除
+				if cb != nil {
+					state = &types.BlockSeqCBState{}
+					retryAt = time.Time{}
+				}
+				cb = newcb
+				gen = task.gen
+				sink = newPushTransport(p.client, cb, p.fileDir)
+				p.trigeRun(run, 0)
+			case status := <-in.status:
+				state.Status = status
+				if status == types.SeqCBActive {
+					state.Failures = 0
+					state.LastError = ""
+					state.NextRetry = 0
+					retryAt = time.Time{}
+				}
+				p.saveState(name, gen, state)
 				p.trigeRun(run, 0)
 			case maxseq = <-in.seq:
 				p.trigeRun(run, 0)
@@ -111,6 +258,23 @@ func (p *pushseq) runTask(input pushNotify) {
 					p.trigeRun(run, time.Second)
 					continue
 				}
+				//暂停或者 deadletter 的任务等待恢复
+				if state.Status != types.SeqCBActive {
+					continue
+				}
+				//快照同步之前订阅的任务不能继续推送, 进入 deadletter 状态
+				if err := p.store.checkSequence(); err != nil {
+					chainlog.Error("pushdata", "name", cb.Name, "err", err)
+					state.Status = types.SeqCBDeadLetter
+					state.LastError = err.Error()
+					state.NextRetry = 0
+					p.saveState(cb.Name, gen, state)
+					continue
+				}
+				//等待重试的时间, 期间新的 seq 不触发推送
+				if time.Now().Before(retryAt) {
+					continue
+				}
 				if lastseq == -1 {
 					lastseq = p.store.getSeqCBLastNum([]byte(cb.Name))
 				}
@@ -118,67 +282,68 @@ func (p *pushseq) runTask(input pushNotify) {
 					p.trigeRun(run, 100*time.Millisecond)
 					continue
 				}
-				data, err := p.getDataBySeq(lastseq + 1)
+				end := lastseq + int64(cb.BatchSize)
+				if cb.BatchSize <= 1 {
+					end = lastseq + 1
+				}
+				if end > maxseq {
+					end = maxseq
+				}
+				data, err := p.getDataBySeqs(lastseq+1, end)
 				if err != nil {
 					chainlog.Error("getDataBySeq", "err", err)
 					p.trigeRun(run, 1000*time.Millisecond)
 					continue
 				}
-				err = p.postData(cb, data)
+				err = sink.push(data)
 				if err != nil {
-					chainlog.Error("postdata", "err", err)
-					//sleep 10s
-					p.trigeRun(run, 10000*time.Millisecond)
+					chainlog.Error("pushdata", "name", cb.Name, "err", err)
+					retryAt = p.pushFailed(cb, gen, state, err)
+					if state.Status == types.SeqCBActive {
+						p.trigeRun(run, time.Until(retryAt))
+					}
 					continue
 				}
 				//update seqid
-				lastseq = lastseq + 1
+				p.saveLastNum(cb.Name, gen, end)
+				lastseq = end
+				if state.Failures > 0 {
+					state.Failures = 0
+					state.LastError = ""
+					state.NextRetry = 0
+					p.saveState(cb.Name, gen, state)
+				}
 				p.trigeRun(run, 0)
 			}
 		}
 	}(input)
 }
 
-func (p *pushseq) postData(cb *types.BlockSeqCB, data *types.BlockSeq) (err error) {
-	var postdata []byte
-	if cb.Encode == "json" {
-		postdata, err = types.PBToJSON(data)
-		if err != nil {
-			return err
-		}
-	} else {
-		postdata = types.Encode(data)
-	}
-	//post data in body
-	var buf bytes.Buffer
-	g := gzip.NewWriter(&buf)
-	if _, err = g.Write(postdata); err != nil {
-		return err
-	}
-	if err = g.Close(); err != nil {
-		return err
+//pushFailed 记录失败的次数, 超过 maxRetry 之后进入 deadletter 状态, 返回下次重试的时间
+func (p *pushseq) pushFailed(cb *types.BlockSeqCB, gen int64, state *types.BlockSeqCBState, err error) time.Time {
+	state.Failures++
+	state.LastError = err.Error()
+	retryAt := time.Now().Add(pushRetryDelay(state.Failures))
+	state.NextRetry = retryAt.Unix()
+	if cb.MaxRetry > 0 && state.Failures >= cb.MaxRetry {
+		chainlog.Error("pushdata deadletter", "name", cb.Name, "failures", state.Failures)
+		state.Status = types.SeqCBDeadLetter
+		state.NextRetry = 0
 	}
+	p.saveState(cb.Name, gen, state)
+	return retryAt
+}
 
-	req, err := http.NewRequest("POST", cb.URL, &buf)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "text/plain")
-	req.Header.Set("Content-Encoding", "gzip")
-	resp, err := p.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-	if string(body) != "ok" && string(body) != "OK" {
-		return types.ErrPushSeqPostData
+func (p *pushseq) getDataBySeqs(start, end int64) (*types.BlockSeqs, error) {
+	seqs := &types.BlockSeqs{}
+	for seq := start; seq <= end; seq++ {
+		data, err := p.getDataBySeq(seq)
+		if err != nil {
+			return nil, err
+		}
+		seqs.Seqs = append(seqs.Seqs, data)
 	}
-	p.store.setSeqCBLastNum([]byte(cb.Name), data.Num)
-	return nil
+	return seqs, nil
 }
 
 func (p *pushseq) getDataBySeq(seq int64) (*types.BlockSeq, error) {
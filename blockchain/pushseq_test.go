@@ -0,0 +1,150 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitSeqCB(t *testing.T, api interface {
+	ListSeqCallBack() (*types.BlockSeqCBs, error)
+}, name string, check func(*types.BlockSeqCBInfo) bool) *types.BlockSeqCBInfo {
+	for i := 0; i < 100; i++ {
+		cbs, err := api.ListSeqCallBack()
+		require.Nil(t, err)
+		for _, item := range cbs.Items {
+			if item.Cb.Name == name && check(item) {
+				return item
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("wait seq callback timeout", name)
+	return nil
+}
+
+func TestPushSeqCallBack(t *testing.T) {
+	log.SetLogLevel("crit")
+	dir, err := ioutil.TempDir("", "pushseq")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "seq.ndjson")
+
+	cfg, sub := testnode.GetDefaultConfig()
+	cfg.BlockChain.PushFileDir = dir
+	mock33 := testnode.NewWithConfig(cfg, sub, nil)
+	defer mock33.Close()
+	api := mock33.GetAPI()
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(1))
+
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "file", URL: "seq.ndjson", Transport: "ftp"})
+	assert.NotNil(t, err)
+	//file 方式只能写到 pushFileDir 下
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "file", URL: name, Transport: types.SeqCBTransportFile})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "file", URL: "../seq.ndjson", Transport: types.SeqCBTransportFile})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "file", URL: "seq.ndjson", Transport: types.SeqCBTransportFile, BatchSize: 10, Secret: "secret"})
+	require.Nil(t, err)
+	info := waitSeqCB(t, api, "file", func(item *types.BlockSeqCBInfo) bool { return item.LastNum >= 1 })
+	assert.Equal(t, "", info.Cb.Secret)
+	data, err := ioutil.ReadFile(name)
+	require.Nil(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+
+	//暂停之后不再推送, 恢复之后继续推送
+	_, err = api.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: "file", Status: types.SeqCBPaused})
+	require.Nil(t, err)
+	waitSeqCB(t, api, "file", func(item *types.BlockSeqCBInfo) bool { return item.State.Status == types.SeqCBPaused })
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(2))
+	time.Sleep(200 * time.Millisecond)
+	info = waitSeqCB(t, api, "file", func(item *types.BlockSeqCBInfo) bool { return true })
+	assert.Equal(t, int64(1), info.LastNum)
+	_, err = api.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: "file", Status: types.SeqCBActive})
+	require.Nil(t, err)
+	waitSeqCB(t, api, "file", func(item *types.BlockSeqCBInfo) bool { return item.LastNum == 2 })
+
+	//连续失败达到 maxRetry 之后进入 deadletter
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fail"))
+	}))
+	defer server.Close()
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "http", URL: server.URL, MaxRetry: 2})
+	require.Nil(t, err)
+	info = waitSeqCB(t, api, "http", func(item *types.BlockSeqCBInfo) bool { return item.State.Status == types.SeqCBDeadLetter })
+	assert.Equal(t, int32(2), info.State.Failures)
+	assert.Equal(t, types.ErrPushSeqPostData.Error(), info.State.LastError)
+
+	_, err = api.DelSeqCallBack(&types.ReqString{Data: "http"})
+	require.Nil(t, err)
+	_, err = api.DelSeqCallBack(&types.ReqString{Data: "http"})
+	assert.Equal(t, types.ErrSeqCBNotFound.Error(), err.Error())
+	_, err = api.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: "http", Status: types.SeqCBActive})
+	assert.NotNil(t, err)
+	cbs, err := api.ListSeqCallBack()
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(cbs.Items))
+}
+
+func TestPushSeqResetInFlight(t *testing.T) {
+	log.SetLogLevel("crit")
+	cfg, sub := testnode.GetDefaultConfig()
+	mock33 := testnode.NewWithConfig(cfg, sub, nil)
+	defer mock33.Close()
+	api := mock33.GetAPI()
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(1))
+
+	inflight := make(chan struct{}, 1)
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case inflight <- struct{}{}:
+		default:
+		}
+		<-release
+		w.Write([]byte("fail"))
+	}))
+	defer slow.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer good.Close()
+
+	_, err := api.AddSeqCallBack(&types.BlockSeqCB{Name: "reset", URL: slow.URL, MaxRetry: 1})
+	require.Nil(t, err)
+	select {
+	case <-inflight:
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait push timeout")
+	}
+	//推送还没有返回的时候重新订阅, 旧的推送失败之后不能把 deadletter 状态写回数据库
+	_, err = api.AddSeqCallBack(&types.BlockSeqCB{Name: "reset", URL: good.URL})
+	require.Nil(t, err)
+	close(release)
+	info := waitSeqCB(t, api, "reset", func(item *types.BlockSeqCBInfo) bool { return item.LastNum >= 1 })
+	time.Sleep(200 * time.Millisecond)
+	info = waitSeqCB(t, api, "reset", func(item *types.BlockSeqCBInfo) bool { return true })
+	assert.Equal(t, good.URL, info.Cb.URL)
+	assert.Equal(t, int32(types.SeqCBActive), info.State.Status)
+	assert.Equal(t, int32(0), info.State.Failures)
+	assert.Equal(t, "", info.State.LastError)
+}
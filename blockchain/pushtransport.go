@@ -0,0 +1,245 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc"
+)
+
+//PushSignatureHeader http 推送时 HMAC-SHA256 签名所在的 header, 签名的数据为 gzip 压缩之前的 body
+const PushSignatureHeader = "X-Chain33-Signature"
+
+//单次推送的超时时间
+var pushTimeout = 30 * time.Second
+
+//pushTransport 推送数据的方式, 同一个订阅的数据总是在同一个 goroutine 中推送
+type pushTransport interface {
+	push(data *types.BlockSeqs) error
+	close()
+}
+
+func newPushTransport(client *http.Client, cb *types.BlockSeqCB, fileDir string) pushTransport {
+	switch cb.Transport {
+	case types.SeqCBTransportGRPC:
+		return &grpcTransport{cb: cb}
+	case types.SeqCBTransportFile:
+		return &fileTransport{cb: cb, dir: fileDir}
+	}
+	return &httpTransport{cb: cb, client: client}
+}
+
+//pushFilePath file 方式推送的文件路径, 只能是 dir 下的相对路径, dir 为空时不支持 file 方式
+func pushFilePath(dir, name string) (string, error) {
+	if dir == "" {
+		return "", types.ErrNotAllow
+	}
+	if name == "" || filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\") {
+		return "", types.ErrInvalidParam
+	}
+	for _, elem := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return "", types.ErrInvalidParam
+		}
+	}
+	return filepath.Join(dir, filepath.Clean(name)), nil
+}
+
+//SignPushData 计算推送数据的签名, 接收方可以用来验证数据的来源
+func SignPushData(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func encodePushData(cb *types.BlockSeqCB, data types.Message) ([]byte, error) {
+	if cb.Encode == "json" {
+		return types.PBToJSON(data)
+	}
+	return types.Encode(data), nil
+}
+
+//httpTransport 通过 http post 推送, 返回 ok 表示确认
+type httpTransport struct {
+	cb     *types.BlockSeqCB
+	client *http.Client
+}
+
+func (t *httpTransport) push(data *types.BlockSeqs) (err error) {
+	var postdata []byte
+	//兼容不支持批量推送的订阅, batchSize 不大于1 时推送单个 BlockSeq
+	if t.cb.BatchSize <= 1 && len(data.Seqs) == 1 {
+		postdata, err = encodePushData(t.cb, data.Seqs[0])
+	} else {
+		postdata, err = encodePushData(t.cb, data)
+	}
+	if err != nil {
+		return err
+	}
+	//post data in body
+	var buf bytes.Buffer
+	g := gzip.NewWriter(&buf)
+	if _, err = g.Write(postdata); err != nil {
+		return err
+	}
+	if err = g.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", t.cb.URL, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Content-Encoding", "gzip")
+	if t.cb.Secret != "" {
+		req.Header.Set(PushSignatureHeader, SignPushData(t.cb.Secret, postdata))
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if string(body) != "ok" && string(body) != "OK" {
+		return types.ErrPushSeqPostData
+	}
+	return nil
+}
+
+func (t *httpTransport) close() {}
+
+//fileTransport 以 NDJSON 的格式追加到本地文件, URL 为文件在 pushFileDir 下的相对路径
+//写入失败重试时可能会有重复的数据, 需要按照 num 去重
+type fileTransport struct {
+	cb   *types.BlockSeqCB
+	dir  string
+	file *os.File
+}
+
+func (t *fileTransport) push(data *types.BlockSeqs) error {
+	if t.file == nil {
+		path, err := pushFilePath(t.dir, t.cb.URL)
+		if err != nil {
+			return err
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		t.file = file
+	}
+	var buf bytes.Buffer
+	for _, seq := range data.Seqs {
+		line, err := types.PBToJSON(seq)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	_, err := t.file.Write(buf.Bytes())
+	if err == nil {
+		err = t.file.Sync()
+	}
+	if err != nil {
+		t.close()
+	}
+	return err
+}
+
+func (t *fileTransport) close() {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
+
+//grpcTransport 通过 blockSeqSink 服务的双向流推送, 每个 BlockSeqs 等待一个确认
+type grpcTransport struct {
+	cb     *types.BlockSeqCB
+	conn   *grpc.ClientConn
+	stream types.BlockSeqSink_PushBlockSeqsClient
+	cancel context.CancelFunc
+}
+
+func (t *grpcTransport) connect() error {
+	conn, err := grpc.Dial(t.cb.URL, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := types.NewBlockSeqSinkClient(conn).PushBlockSeqs(ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return err
+	}
+	t.conn, t.stream, t.cancel = conn, stream, cancel
+	return nil
+}
+
+func (t *grpcTransport) push(data *types.BlockSeqs) error {
+	if t.stream == nil {
+		if err := t.connect(); err != nil {
+			return err
+		}
+	}
+	stream := t.stream
+	done := make(chan *types.Reply, 1)
+	fail := make(chan error, 1)
+	go func() {
+		if err := stream.Send(data); err != nil {
+			fail <- err
+			return
+		}
+		reply, err := stream.Recv()
+		if err != nil {
+			fail <- err
+			return
+		}
+		done <- reply
+	}()
+	var err error
+	select {
+	case reply := <-done:
+		//对方拒绝的情况下流还可以继续使用
+		if !reply.IsOk {
+			return errors.New(string(reply.Msg))
+		}
+		return nil
+	case err = <-fail:
+	case <-time.After(pushTimeout):
+		err = types.ErrTimeout
+	}
+	//流出错之后需要重新连接
+	t.close()
+	return err
+}
+
+func (t *grpcTransport) close() {
+	if t.cancel != nil {
+		t.cancel()
+		t.conn.Close()
+		t.conn, t.stream, t.cancel = nil, nil, nil
+	}
+}
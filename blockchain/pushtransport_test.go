@@ -0,0 +1,167 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func testBlockSeqs(start, end int64) *types.BlockSeqs {
+	seqs := &types.BlockSeqs{}
+	for i := start; i <= end; i++ {
+		seqs.Seqs = append(seqs.Seqs, &types.BlockSeq{Num: i, Seq: &types.BlockSequence{Hash: []byte("hash"), Type: AddBlock}})
+	}
+	return seqs
+}
+
+func TestPushRetryDelay(t *testing.T) {
+	assert.Equal(t, pushRetryBase, pushRetryDelay(1))
+	assert.Equal(t, 4*pushRetryBase, pushRetryDelay(3))
+	assert.Equal(t, pushRetryMax, pushRetryDelay(100))
+}
+
+func TestHTTPTransport(t *testing.T) {
+	var body []byte
+	var sign string
+	reply := "ok"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gz, err := gzip.NewReader(r.Body)
+		require.Nil(t, err)
+		body, err = ioutil.ReadAll(gz)
+		require.Nil(t, err)
+		sign = r.Header.Get(PushSignatureHeader)
+		w.Write([]byte(reply))
+	}))
+	defer server.Close()
+
+	cb := &types.BlockSeqCB{Name: "test", URL: server.URL, Secret: "secret"}
+	sink := newPushTransport(http.DefaultClient, cb, "")
+	err := sink.push(testBlockSeqs(1, 1))
+	assert.Nil(t, err)
+	var seq types.BlockSeq
+	assert.Nil(t, types.Decode(body, &seq))
+	assert.Equal(t, int64(1), seq.Num)
+	assert.Equal(t, SignPushData("secret", body), sign)
+
+	cb = &types.BlockSeqCB{Name: "test", URL: server.URL, Encode: "json", BatchSize: 10}
+	sink = newPushTransport(http.DefaultClient, cb, "")
+	err = sink.push(testBlockSeqs(1, 2))
+	assert.Nil(t, err)
+	var seqs types.BlockSeqs
+	assert.Nil(t, types.JSONToPB(body, &seqs))
+	assert.Equal(t, 2, len(seqs.Seqs))
+	assert.Equal(t, "", sign)
+
+	reply = "fail"
+	err = sink.push(testBlockSeqs(3, 3))
+	assert.Equal(t, types.ErrPushSeqPostData, err)
+}
+
+func TestFileTransport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pushseq")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "seq.ndjson")
+
+	sink := newPushTransport(nil, &types.BlockSeqCB{Name: "test", URL: "seq.ndjson", Transport: types.SeqCBTransportFile}, dir)
+	assert.Nil(t, sink.push(testBlockSeqs(1, 2)))
+	assert.Nil(t, sink.push(testBlockSeqs(3, 3)))
+	sink.close()
+
+	f, err := os.Open(name)
+	require.Nil(t, err)
+	defer f.Close()
+	var nums []int64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var seq types.BlockSeq
+		assert.Nil(t, types.JSONToPB(scanner.Bytes(), &seq))
+		nums = append(nums, seq.Num)
+	}
+	assert.Equal(t, []int64{1, 2, 3}, nums)
+
+	sink = newPushTransport(nil, &types.BlockSeqCB{Name: "test", URL: ".", Transport: types.SeqCBTransportFile}, dir)
+	assert.NotNil(t, sink.push(testBlockSeqs(1, 1)))
+	//没有配置目录时不支持 file 方式
+	sink = newPushTransport(nil, &types.BlockSeqCB{Name: "test", URL: "seq.ndjson", Transport: types.SeqCBTransportFile}, "")
+	assert.Equal(t, types.ErrNotAllow, sink.push(testBlockSeqs(1, 1)))
+}
+
+func TestPushFilePath(t *testing.T) {
+	_, err := pushFilePath("", "seq.ndjson")
+	assert.Equal(t, types.ErrNotAllow, err)
+	path, err := pushFilePath("/data/push", "a/seq.ndjson")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join("/data/push", "a", "seq.ndjson"), path)
+	for _, name := range []string{"", "/etc/passwd", "../seq.ndjson", "a/../../seq.ndjson", "a/..", "..\\seq.ndjson"} {
+		_, err = pushFilePath("/data/push", name)
+		assert.Equal(t, types.ErrInvalidParam, err, name)
+	}
+}
+
+type testSink struct {
+	recv chan *types.BlockSeqs
+}
+
+func (s *testSink) PushBlockSeqs(stream types.BlockSeqSink_PushBlockSeqsServer) error {
+	for {
+		seqs, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		s.recv <- seqs
+		reply := &types.Reply{IsOk: seqs.Seqs[0].Num != 0}
+		if !reply.IsOk {
+			reply.Msg = []byte("reject")
+		}
+		if err = stream.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+func TestGRPCTransport(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	server := grpc.NewServer()
+	sink := &testSink{recv: make(chan *types.BlockSeqs, 10)}
+	types.RegisterBlockSeqSinkServer(server, sink)
+	go server.Serve(l)
+
+	cb := &types.BlockSeqCB{Name: "test", URL: l.Addr().String(), Transport: types.SeqCBTransportGRPC}
+	trans := newPushTransport(nil, cb, "")
+	defer trans.close()
+	assert.Nil(t, trans.push(testBlockSeqs(1, 3)))
+	assert.Equal(t, 3, len((<-sink.recv).Seqs))
+
+	//拒绝之后同一个流还可以继续推送
+	err = trans.push(testBlockSeqs(0, 0))
+	assert.Equal(t, "reject", err.Error())
+	<-sink.recv
+	stream := trans.(*grpcTransport).stream
+	assert.Nil(t, trans.push(testBlockSeqs(4, 4)))
+	<-sink.recv
+	assert.Equal(t, stream, trans.(*grpcTransport).stream)
+
+	server.Stop()
+	pushTimeout = time.Second
+	defer func() { pushTimeout = 30 * time.Second }()
+	assert.NotNil(t, trans.push(testBlockSeqs(5, 5)))
+	assert.Nil(t, trans.(*grpcTransport).stream)
+}
@@ -150,6 +150,9 @@ type ReqHash struct {Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"
 type TransactionDetail struct {Hashs [][]byte `protobuf:"bytes,1,rep,name=hashs,proto3" json:"hashs,omitempty"}
 */
 func (chain *BlockChain) ProcQueryTxMsg(txhash []byte) (proof *types.TransactionDetail, err error) {
+	if chain.cfg.EnableLightClient {
+		return chain.LightQueryTx(&p2pSource{client: chain.client}, chain.lightPeers(), txhash)
+	}
 	txresult, err := chain.GetTxResultFromDb(txhash)
 	if err != nil {
 		return nil, err
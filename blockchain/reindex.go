@@ -28,6 +28,10 @@ func (chain *BlockChain) UpgradeChain() {
 		}
 	}
 	if chain.needReIndex(meta) {
+		//裁剪之后没有完整的区块, 不能重建索引
+		if chain.blockStore.PruneHeight() > 0 {
+			panic("blockchain pruned, can not reindex")
+		}
 		//如果没有开始重建index，那么先del all keys
 		if !meta.Indexing {
 			chainlog.Info("begin del all keys")
@@ -0,0 +1,137 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+重组记录:
+每次重组主链之后保存一条记录, 包括共同祖先, 删除和加入主链的区块, 触发重组的节点以及重组前后的总难度,
+记录按照序号保存, 最多保存 MaxReorgRecords 条, 超过之后删除最早的记录.
+*/
+
+var (
+	reorgRecordPerfix = []byte("ReorgRecord:")
+	reorgLastIndex    = []byte("ReorgLastIndex")
+	//MaxReorgRecords 最多保存的重组记录个数
+	MaxReorgRecords int64 = 10000
+)
+
+const (
+	defaultReorgHistoryCount = 10
+	maxReorgHistoryCount     = 1000
+)
+
+func calcReorgRecordKey(index int64) []byte {
+	return append(append([]byte{}, reorgRecordPerfix...), []byte(fmt.Sprintf("%012d", index))...)
+}
+
+//loadReorgLastIndex 最新的重组记录序号, 没有记录时返回 -1
+func (bs *BlockStore) loadReorgLastIndex() int64 {
+	data, err := bs.db.Get(reorgLastIndex)
+	if err != nil || data == nil {
+		return -1
+	}
+	var index types.Int64
+	if err = types.Decode(data, &index); err != nil {
+		storeLog.Error("loadReorgLastIndex", "err", err)
+		return -1
+	}
+	return index.Data
+}
+
+//saveReorgRecord 保存一条重组记录, 超过 MaxReorgRecords 时删除最早的记录
+func (bs *BlockStore) saveReorgRecord(record *types.ReorgRecord) error {
+	record.Index = bs.loadReorgLastIndex() + 1
+	newbatch := bs.NewBatch(true)
+	newbatch.Set(calcReorgRecordKey(record.Index), types.Encode(record))
+	newbatch.Set(reorgLastIndex, types.Encode(&types.Int64{Data: record.Index}))
+	if record.Index >= MaxReorgRecords {
+		newbatch.Delete(calcReorgRecordKey(record.Index - MaxReorgRecords))
+	}
+	return newbatch.Write()
+}
+
+//GetReorgRecords 从新到旧获取最多 count 条重组记录
+func (bs *BlockStore) GetReorgRecords(count int64) ([]*types.ReorgRecord, error) {
+	var records []*types.ReorgRecord
+	last := bs.loadReorgLastIndex()
+	for index := last; index >= 0 && index > last-count; index-- {
+		data, err := bs.db.Get(calcReorgRecordKey(index))
+		if err != nil || data == nil {
+			break
+		}
+		var record types.ReorgRecord
+		if err = types.Decode(data, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+func tdString(td *big.Int) string {
+	if td == nil {
+		return ""
+	}
+	return td.String()
+}
+
+//saveReorgRecord 重组成功之后保存重组记录, 保存失败不影响主链
+func (b *BlockChain) saveReorgRecord(fork *blockNode, detachNodes, attachNodes *list.List, pid string, oldTd, newTd *big.Int) {
+	record := &types.ReorgRecord{
+		ForkHeight: fork.height,
+		ForkHash:   fork.hash,
+		Pid:        pid,
+		Time:       types.Now().Unix(),
+		OldTd:      tdString(oldTd),
+		NewTd:      tdString(newTd),
+	}
+	for e := detachNodes.Front(); e != nil; e = e.Next() {
+		record.Detached = append(record.Detached, e.Value.(*blockNode).hash)
+	}
+	for e := attachNodes.Front(); e != nil; e = e.Next() {
+		record.Attached = append(record.Attached, e.Value.(*blockNode).hash)
+	}
+	chainlog.Info("reorganizeChain", "forkHeight", fork.height, "forkHash", common.ToHex(fork.hash), "detached", len(record.Detached),
+		"attached", len(record.Attached), "pid", pid, "oldTd", record.OldTd, "newTd", record.NewTd)
+	if err := b.blockStore.saveReorgRecord(record); err != nil {
+		chainlog.Error("saveReorgRecord", "forkHeight", fork.height, "err", err)
+	}
+}
+
+//GetReorgHistory 获取最新的重组记录以及孤儿池的统计信息
+func (b *BlockChain) GetReorgHistory(req *types.ReqReorgHistory) (*types.ReorgHistory, error) {
+	count := int64(req.Count)
+	if count <= 0 {
+		count = defaultReorgHistoryCount
+	}
+	if count > maxReorgHistoryCount {
+		count = maxReorgHistoryCount
+	}
+	records, err := b.blockStore.GetReorgRecords(count)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ReorgHistory{Items: records, Orphans: b.orphanPool.Stat()}, nil
+}
+
+func (b *BlockChain) getReorgHistory(msg queue.Message) {
+	reply, err := b.GetReorgHistory(msg.Data.(*types.ReqReorgHistory))
+	if err != nil {
+		chainlog.Error("getReorgHistory", "err", err.Error())
+		msg.Reply(b.client.NewMessage("rpc", types.EventReplyReorgHistory, err))
+		return
+	}
+	msg.Reply(b.client.NewMessage("rpc", types.EventReplyReorgHistory, reply))
+}
@@ -0,0 +1,76 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgHistory(t *testing.T) {
+	//另外一条更长的链
+	mock33 := testnode.New("", nil)
+	var fork []*types.BlockDetail
+	for i := int64(1); i <= 5; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+		detail, err := mock33.GetBlockChain().GetStore().LoadBlockByHeight(i)
+		require.Nil(t, err)
+		fork = append(fork, detail)
+	}
+	mock33.Close()
+
+	mock33 = testnode.New("", nil)
+	defer mock33.Close()
+	var detached [][]byte
+	for i := int64(1); i <= 3; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+		detached = append([][]byte{mock33.GetLastBlock().Hash()}, detached...)
+	}
+	chain := mock33.GetBlockChain()
+	api := mock33.GetAPI()
+	history, err := api.GetReorgHistory(&types.ReqReorgHistory{})
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(history.Items))
+	assert.Equal(t, int64(0), history.Orphans.Count)
+	assert.Equal(t, int64(-1), history.Orphans.MinHeight)
+
+	//父区块未知的区块保存在孤儿池中
+	_, _, isOrphan, err := chain.ProcessBlock(false, fork[4], "peer", true, -1)
+	require.Nil(t, err)
+	assert.True(t, isOrphan)
+	history, err = api.GetReorgHistory(&types.ReqReorgHistory{})
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), history.Orphans.Count)
+	assert.Equal(t, int64(5), history.Orphans.MinHeight)
+	assert.Equal(t, int64(5), history.Orphans.MaxHeight)
+
+	for _, detail := range fork[:4] {
+		_, _, _, err := chain.ProcessBlock(false, detail, "peer", true, -1)
+		require.Nil(t, err)
+	}
+	require.Equal(t, int64(5), chain.GetBlockHeight())
+	history, err = api.GetReorgHistory(&types.ReqReorgHistory{Count: 5})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(history.Items))
+	record := history.Items[0]
+	assert.Equal(t, int64(0), record.Index)
+	assert.Equal(t, int64(0), record.ForkHeight)
+	assert.Equal(t, mock33.GetBlock(0).Hash(), record.ForkHash)
+	assert.Equal(t, detached, record.Detached)
+	require.Equal(t, 4, len(record.Attached))
+	for i, hash := range record.Attached {
+		assert.Equal(t, fork[i].Block.Hash(), hash)
+	}
+	assert.Equal(t, "peer", record.Pid)
+	assert.NotEqual(t, record.OldTd, record.NewTd)
+	assert.Equal(t, int64(0), history.Orphans.Count)
+}
@@ -11,6 +11,9 @@ import (
 
 //GetBlockSequences 通过记录的block序列号获取blockd序列存储的信息
 func (chain *BlockChain) GetBlockSequences(requestblock *types.ReqBlocks) (*types.BlockSequences, error) {
+	if err := chain.blockStore.checkSequence(); err != nil {
+		return nil, err
+	}
 	blockLastSeq, _ := chain.blockStore.LoadBlockLastSequence()
 	if requestblock.Start > blockLastSeq {
 		chainlog.Error("GetBlockSequences StartSeq err", "startSeq", requestblock.Start, "lastSeq", blockLastSeq)
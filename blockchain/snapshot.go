@@ -0,0 +1,362 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/difficulty"
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+快照同步:
+新节点只有创世区块时, 从其他节点获取可信高度 snapshotHeight 的状态快照, 不再从创世区块开始执行所有的区块.
+1. 获取 1 到 snapshotHeight 的 headers, 验证 parentHash 的连接关系, 最后一个 header 的 hash 必须和配置的 snapshotHash 一致
+2. 按照叶子节点的序号分段获取 mavl 状态树, 每个 kv 都用 proof 对 header 中的 stateHash 做验证, 并且序号必须连续
+3. 保存 headers 以及快照高度的区块, 之后从这个高度开始正常的区块同步
+
+快照高度以下的区块只有区块头, 没有交易和回执, 本地数据库中也没有这些区块产生的索引以及区块序列号,
+所以快照节点不支持依赖序列号的功能: 区块推送, GetBlockSequences 以及序列号的一致性检查.
+*/
+
+const (
+	snapshotChunkSize    = 1024
+	snapshotHeaderBatch  = 1000
+	snapshotRetrySeconds = 5
+)
+
+var blockSnapshotHeight = []byte("blockSnapshotHeight")
+
+//SnapshotHeight 通过状态快照同步的节点返回快照高度, 其他节点返回 0
+func (bs *BlockStore) SnapshotHeight() int64 {
+	return atomic.LoadInt64(&bs.snapshotHeight)
+}
+
+//checkSequence 快照节点的区块序列号不是从创世区块开始连续的, 不能通过序列号回放主链
+func (bs *BlockStore) checkSequence() error {
+	if bs.SnapshotHeight() > 0 {
+		return types.ErrSnapshotNoSequence
+	}
+	return nil
+}
+
+//SnapshotSource 快照同步的数据来源, 默认通过 p2p 模块从其他节点获取
+type SnapshotSource interface {
+	GetHeaders(pid string, start, end int64) ([]*types.Header, error)
+	GetStateSnapshot(req *types.ReqStateSnapshot) (*types.StateSnapshotChunk, error)
+}
+
+//p2pSource 通过 p2p 模块从指定的节点同步获取数据
+type p2pSource struct {
+	client queue.Client
+}
+
+func (s *p2pSource) GetHeaders(pid string, start, end int64) ([]*types.Header, error) {
+	msg := s.client.NewMessage("p2p", types.EventFetchPeerHeaders, &types.ReqBlocks{Start: start, End: end, Pid: []string{pid}})
+	err := s.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.Headers).GetItems(), nil
+}
+
+func (s *p2pSource) GetBlocks(pid string, start, end int64) ([]*types.Block, error) {
+	msg := s.client.NewMessage("p2p", types.EventFetchPeerBlocks, &types.ReqBlocks{Start: start, End: end, Pid: []string{pid}})
+	err := s.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.Blocks).GetItems(), nil
+}
+
+func (s *p2pSource) GetStateSnapshot(req *types.ReqStateSnapshot) (*types.StateSnapshotChunk, error) {
+	msg := s.client.NewMessage("p2p", types.EventFetchStateSnapshot, req)
+	err := s.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.StateSnapshotChunk), nil
+}
+
+func (s *p2pSource) GetTxProof(pid string, hash []byte) (*types.TransactionDetail, error) {
+	msg := s.client.NewMessage("p2p", types.EventFetchTxProof, &types.ReqTxProof{Hash: hash, Pid: pid})
+	err := s.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.TransactionDetail), nil
+}
+
+func (s *p2pSource) GetStateProof(req *types.ReqStateProof) (*types.StateProof, error) {
+	msg := s.client.NewMessage("p2p", types.EventFetchStateProof, req)
+	err := s.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*types.StateProof), nil
+}
+
+//calcHeaderHash 通过 header 构造区块, 用 Block.Hash 计算区块的 hash, 交易个数不合法时返回 nil
+func calcHeaderHash(header *types.Header) []byte {
+	if header.TxCount < 0 || header.TxCount > types.MaxTxsPerBlock {
+		return nil
+	}
+	block := &types.Block{
+		Version:    header.Version,
+		ParentHash: header.ParentHash,
+		TxHash:     header.TxHash,
+		StateHash:  header.StateHash,
+		Height:     header.Height,
+		BlockTime:  header.BlockTime,
+		Difficulty: header.Difficulty,
+		//Block.Hash 中的 TxCount 为交易的个数
+		Txs: make([]*types.Transaction, header.TxCount),
+	}
+	return block.Hash()
+}
+
+//snapshotSync 新节点启动时从 peer 同步状态快照, 本节点已经有区块或者同步成功之后返回
+func (chain *BlockChain) snapshotSync() {
+	height := chain.cfg.SnapshotHeight
+	hash, err := common.FromHex(chain.cfg.SnapshotHash)
+	if err != nil || len(hash) != len(common.Hash{}) || height <= 0 {
+		synlog.Error("snapshotSync config error", "height", height, "hash", chain.cfg.SnapshotHash)
+		return
+	}
+	source := &p2pSource{client: chain.client}
+	for {
+		//创世区块还没有生成时需要等待
+		curheight := chain.GetBlockHeight()
+		if curheight > 0 {
+			synlog.Info("snapshotSync skip", "height", curheight)
+			return
+		}
+		if curheight == 0 && chain.fetchPeerList() == nil {
+			for _, peer := range chain.GetPeers() {
+				if peer.Height < height {
+					continue
+				}
+				err = chain.SyncSnapshot(source, peer.Name, height, hash)
+				if err == nil {
+					return
+				}
+				synlog.Error("snapshotSync", "pid", peer.Name, "err", err)
+			}
+		}
+		select {
+		case <-chain.quit:
+			return
+		case <-time.After(snapshotRetrySeconds * time.Second):
+		}
+	}
+}
+
+//SyncSnapshot 从指定的节点同步 height 高度的状态快照, hash 为这个高度的可信区块hash
+func (chain *BlockChain) SyncSnapshot(source SnapshotSource, pid string, height int64, hash []byte) error {
+	//区块hash中没有包含 stateHash 时, 无法验证快照
+	if !types.IsFork(height, "ForkBlockHash") {
+		return types.ErrNotSupport
+	}
+	genesis, err := chain.blockStore.GetBlockHeaderByHeight(0)
+	if err != nil {
+		return err
+	}
+	headers, err := chain.fetchSnapshotHeaders(source, pid, genesis, height, hash)
+	if err != nil {
+		return err
+	}
+	detail, err := chain.fetchSnapshotState(source, pid, headers[len(headers)-1])
+	if err != nil {
+		return err
+	}
+	return chain.saveSnapshot(genesis, headers, detail)
+}
+
+//获取并验证 1 到 height 的 headers
+func (chain *BlockChain) fetchSnapshotHeaders(source SnapshotSource, pid string, genesis *types.Header, height int64, hash []byte) ([]*types.Header, error) {
+	headers := make([]*types.Header, 0, height)
+	prevhash := genesis.Hash
+	for start := int64(1); start <= height; start += snapshotHeaderBatch {
+		end := start + snapshotHeaderBatch - 1
+		if end > height {
+			end = height
+		}
+		items, err := source.GetHeaders(pid, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(items)) != end-start+1 {
+			return nil, types.ErrSnapshotHash
+		}
+		for i, header := range items {
+			if header.Height != start+int64(i) || !bytes.Equal(header.ParentHash, prevhash) || !bytes.Equal(calcHeaderHash(header), header.Hash) {
+				synlog.Error("fetchSnapshotHeaders", "pid", pid, "height", start+int64(i))
+				return nil, types.ErrSnapshotHash
+			}
+			prevhash = header.Hash
+		}
+		headers = append(headers, items...)
+		synlog.Info("fetchSnapshotHeaders", "pid", pid, "height", end)
+	}
+	if !bytes.Equal(prevhash, hash) {
+		return nil, types.ErrSnapshotHash
+	}
+	return headers, nil
+}
+
+//按照序号分段获取状态快照并导入到 store 中, 返回快照高度的区块
+func (chain *BlockChain) fetchSnapshotState(source SnapshotSource, pid string, header *types.Header) (*types.BlockDetail, error) {
+	var detail *types.BlockDetail
+	var start, total int32
+	for {
+		req := &types.ReqStateSnapshot{StateHash: header.StateHash, Height: header.Height, Start: start, Count: snapshotChunkSize, Pid: pid}
+		chunk, err := source.GetStateSnapshot(req)
+		if err != nil {
+			return nil, err
+		}
+		if start == 0 {
+			detail = chunk.Detail
+			if detail == nil || detail.Block == nil || len(detail.Receipts) != len(detail.Block.Txs) ||
+				!bytes.Equal(detail.Block.Hash(), header.Hash) ||
+				!bytes.Equal(merkle.CalcMerkleRoot(detail.Block.Txs), detail.Block.TxHash) {
+				return nil, types.ErrSnapshotHash
+			}
+			total = chunk.Total
+		}
+		if chunk.Start != start || chunk.Total != total || len(chunk.Kvs) == 0 || !bytes.Equal(chunk.StateHash, header.StateHash) {
+			return nil, types.ErrSnapshotProof
+		}
+		chunk.Detail = nil
+		err = chain.importSnapshotChunk(chunk)
+		if err != nil {
+			return nil, err
+		}
+		start += int32(len(chunk.Kvs))
+		synlog.Info("fetchSnapshotState", "pid", pid, "leaves", start, "total", total)
+		if start >= total {
+			return detail, nil
+		}
+	}
+}
+
+func (chain *BlockChain) importSnapshotChunk(chunk *types.StateSnapshotChunk) error {
+	msg := chain.client.NewMessage("store", types.EventStoreImportSnapshot, chunk)
+	err := chain.client.Send(msg, true)
+	if err != nil {
+		return err
+	}
+	resp, err := chain.client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	reply := resp.GetData().(*types.Reply)
+	if !reply.IsOk {
+		return errors.New(string(reply.Msg))
+	}
+	return nil
+}
+
+//保存快照高度以下的 headers 以及快照高度的区块, 更新 bestchain 之后从这个高度开始正常同步
+func (chain *BlockChain) saveSnapshot(genesis *types.Header, headers []*types.Header, detail *types.BlockDetail) error {
+	chain.chainLock.Lock()
+	defer chain.chainLock.Unlock()
+
+	//同步快照的过程中已经有新的区块, 放弃快照
+	if chain.blockStore.Height() != 0 {
+		return types.ErrBlockExist
+	}
+	td, err := chain.blockStore.GetTdByBlockHash(genesis.Hash)
+	if err != nil {
+		return err
+	}
+	newbatch := chain.blockStore.NewBatch(true)
+	for _, header := range headers[:len(headers)-1] {
+		td = new(big.Int).Add(td, difficulty.CalcWork(header.Difficulty))
+		err = chain.blockStore.SaveBlockHeader(newbatch, header)
+		if err != nil {
+			return err
+		}
+		err = chain.blockStore.SaveTdByBlockHash(newbatch, header.Hash, td)
+		if err != nil {
+			return err
+		}
+	}
+	block := detail.Block
+	td = new(big.Int).Add(td, difficulty.CalcWork(block.Difficulty))
+	err = chain.blockStore.AddTxs(newbatch, detail)
+	if err != nil {
+		return err
+	}
+	err = chain.blockStore.SaveBlock(newbatch, detail, -1)
+	if err != nil {
+		return err
+	}
+	err = chain.blockStore.SaveTdByBlockHash(newbatch, block.Hash(), td)
+	if err != nil {
+		return err
+	}
+	//快照高度以下的区块没有区块体, 和裁剪之后的区块一样处理
+	chain.blockStore.setPruneHeight(newbatch, block.Height)
+	//快照高度以下的区块也没有序列号, 不能再通过序列号回放主链
+	newbatch.Set(blockSnapshotHeight, types.Encode(&types.Int64{Data: block.Height}))
+	err = newbatch.Write()
+	if err != nil {
+		return err
+	}
+	chain.blockStore.updatePruneHeight(block.Height)
+	atomic.StoreInt64(&chain.blockStore.snapshotHeight, block.Height)
+	chain.blockStore.UpdateHeight2(block.Height)
+	chain.blockStore.UpdateLastBlock2(block)
+	chain.InitIndexAndBestView()
+	chain.query.updateStateHash(block.StateHash)
+	synlog.Info("snapshotSync done", "height", block.Height, "hash", common.ToHex(block.Hash()))
+
+	chain.SendAddBlockEvent(detail)
+	chain.pushseq.updateSeq(-1)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common/log"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSnapshotSource struct {
+	headers []*types.Header
+	chunks  map[int32]*types.StateSnapshotChunk
+	fake    bool
+}
+
+func (s *testSnapshotSource) GetHeaders(pid string, start, end int64) ([]*types.Header, error) {
+	return s.headers[start-1 : end], nil
+}
+
+func (s *testSnapshotSource) GetStateSnapshot(req *types.ReqStateSnapshot) (*types.StateSnapshotChunk, error) {
+	chunk := proto.Clone(s.chunks[req.Start]).(*types.StateSnapshotChunk)
+	if s.fake && req.Start != 0 {
+		chunk.Kvs[0].Value = []byte("fake")
+	}
+	return chunk, nil
+}
+
+func TestSyncSnapshot(t *testing.T) {
+	log.SetLogLevel("crit")
+	//先生成 3 个区块, 记录 headers 以及状态快照
+	mock33 := testnode.New("", nil)
+	for i := int64(1); i <= 3; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	chain := mock33.GetBlockChain()
+	headers, err := chain.ProcGetHeadersMsg(&types.ReqBlocks{Start: 1, End: 3})
+	require.Nil(t, err)
+	detail, err := chain.GetBlock(3)
+	require.Nil(t, err)
+	stateHash := detail.Block.StateHash
+	balance := mock33.GetAccount(stateHash, mock33.GetHotAddress()).Balance
+
+	source := &testSnapshotSource{headers: headers.Items, chunks: make(map[int32]*types.StateSnapshotChunk)}
+	client := mock33.GetClient()
+	var start, total int32 = 0, 1
+	for start < total {
+		msg := client.NewMessage("store", types.EventStoreGetSnapshot, &types.ReqStateSnapshot{StateHash: stateHash, Start: start, Count: 1})
+		require.Nil(t, client.Send(msg, true))
+		resp, err := client.Wait(msg)
+		require.Nil(t, err)
+		chunk := resp.GetData().(*types.StateSnapshotChunk)
+		if start == 0 {
+			chunk.Detail = detail
+		}
+		source.chunks[start] = chunk
+		start += int32(len(chunk.Kvs))
+		total = chunk.Total
+	}
+	assert.True(t, len(source.chunks) > 1)
+	mock33.Close()
+
+	//新节点从快照同步之后可以继续执行新的区块
+	mock33 = testnode.New("", nil)
+	defer mock33.Close()
+	require.Nil(t, mock33.WaitHeight(0))
+	chain = mock33.GetBlockChain()
+	hash := detail.Block.Hash()
+
+	err = chain.SyncSnapshot(source, "peer", 3, headers.Items[1].Hash)
+	assert.Equal(t, types.ErrSnapshotHash, err)
+	source.fake = true
+	err = chain.SyncSnapshot(source, "peer", 3, hash)
+	assert.Equal(t, types.ErrSnapshotProof.Error(), err.Error())
+	assert.Equal(t, int64(0), chain.GetBlockHeight())
+
+	source.fake = false
+	require.Nil(t, chain.SyncSnapshot(source, "peer", 3, hash))
+	assert.Equal(t, int64(3), chain.GetBlockHeight())
+	assert.Equal(t, hash, mock33.GetLastBlock().Hash())
+	assert.Equal(t, balance, mock33.GetAccount(stateHash, mock33.GetHotAddress()).Balance)
+	header, err := chain.GetStore().GetBlockHeaderByHeight(1)
+	require.Nil(t, err)
+	assert.Equal(t, headers.Items[0].Hash, header.Hash)
+	err = chain.SyncSnapshot(source, "peer", 3, hash)
+	assert.Equal(t, types.ErrBlockExist, err)
+
+	//快照高度以下没有区块序列号, 依赖序列号的功能直接返回错误
+	assert.Equal(t, int64(3), chain.GetStore().SnapshotHeight())
+	_, err = chain.GetBlockSequences(&types.ReqBlocks{Start: 0, End: 1})
+	assert.Equal(t, types.ErrSnapshotNoSequence, err)
+	_, err = chain.GetStore().Verify(&blockchain.VerifyOptions{Start: 0, End: -1, Sequence: true})
+	assert.Equal(t, types.ErrSnapshotNoSequence, err)
+	_, err = mock33.GetAPI().AddSeqCallBack(&types.BlockSeqCB{Name: "snapshot", URL: "http://127.0.0.1:1"})
+	require.NotNil(t, err)
+	assert.Equal(t, types.ErrSnapshotNoSequence.Error(), err.Error())
+
+	mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+	require.Nil(t, mock33.WaitHeight(4))
+	block := mock33.GetBlock(4)
+	assert.Equal(t, balance+types.Coin, mock33.GetAccount(block.StateHash, mock33.GetHotAddress()).Balance)
+}
@@ -0,0 +1,275 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+一致性检查:
+节点异常退出之后, 检查 BlockStore 中区块头, 区块体, 交易索引, 区块序列号以及状态根是否一致.
+高度和 hash 的对应关系, 高度对应的区块头以及交易索引是可以从区块中重建的辅助索引, 可以修复;
+父 hash, TxHash, 区块体, 序列号以及状态根的错误只报告, 需要重新同步区块.
+*/
+
+//一致性检查的问题类型
+const (
+	VerifyHeightHash   = "heighthash"
+	VerifyHeader       = "header"
+	VerifyParentHash   = "parenthash"
+	VerifyHeightHeader = "heightheader"
+	VerifyHashHeight   = "hashheight"
+	VerifyBody         = "body"
+	VerifyTxHash       = "txhash"
+	VerifyTxIndex      = "txindex"
+	VerifyState        = "state"
+	VerifySequence     = "sequence"
+)
+
+//verifyWriteBatch 修复时每检查这么多个区块写一次数据库
+const verifyWriteBatch = 1000
+
+//VerifyOptions 一致性检查的参数
+type VerifyOptions struct {
+	Start int64
+	//End 小于 0 或者大于最新高度时检查到最新高度
+	End    int64
+	Repair bool
+	//Sequence 是否检查区块序列号, 序列号总是从 0 开始回放
+	Sequence bool
+	//HasState 检查状态数据库中是否存在 stateHash, 为 nil 时不检查状态根
+	HasState func(stateHash []byte) bool
+	//StateStart 低于这个高度的状态已经被裁剪, 不检查状态根
+	StateStart int64
+}
+
+//VerifyIssue 检查发现的问题
+type VerifyIssue struct {
+	Height   int64  `json:"height"`
+	Kind     string `json:"kind"`
+	Detail   string `json:"detail"`
+	Repaired bool   `json:"repaired"`
+}
+
+//VerifyReport 一致性检查的结果
+type VerifyReport struct {
+	Start   int64          `json:"start"`
+	End     int64          `json:"end"`
+	Checked int64          `json:"checked"`
+	Issues  []*VerifyIssue `json:"issues"`
+}
+
+//Unrepaired 没有修复的问题个数
+func (report *VerifyReport) Unrepaired() int {
+	count := 0
+	for _, issue := range report.Issues {
+		if !issue.Repaired {
+			count++
+		}
+	}
+	return count
+}
+
+type verifier struct {
+	bs     *BlockStore
+	opts   *VerifyOptions
+	report *VerifyReport
+	batch  dbm.Batch
+}
+
+func (v *verifier) issue(height int64, kind string, repaired bool, format string, args ...interface{}) {
+	detail := fmt.Sprintf(format, args...)
+	storeLog.Error("Verify", "height", height, "kind", kind, "detail", detail, "repaired", repaired)
+	v.report.Issues = append(v.report.Issues, &VerifyIssue{Height: height, Kind: kind, Detail: detail, Repaired: repaired})
+}
+
+//Verify 检查 opts.Start 到 opts.End 高度的区块数据是否一致, 节点需要先停止
+func (bs *BlockStore) Verify(opts *VerifyOptions) (*VerifyReport, error) {
+	end := opts.End
+	if end < 0 || end > bs.Height() {
+		end = bs.Height()
+	}
+	if opts.Start < 0 || opts.Start > end {
+		return nil, types.ErrStartBigThanEnd
+	}
+	if opts.Sequence {
+		if err := bs.checkSequence(); err != nil {
+			return nil, err
+		}
+	}
+	v := &verifier{bs: bs, opts: opts, report: &VerifyReport{Start: opts.Start, End: end}, batch: bs.NewBatch(true)}
+	var prevHash []byte
+	if opts.Start > 0 {
+		prevHash, _ = bs.GetBlockHashByHeight(opts.Start - 1)
+	}
+	for height := opts.Start; height <= end; height++ {
+		prevHash = v.verifyHeight(height, prevHash)
+		v.report.Checked++
+		if height%verifyWriteBatch == 0 {
+			if err := v.flush(); err != nil {
+				return nil, err
+			}
+			storeLog.Info("Verify", "height", height, "end", end, "issues", len(v.report.Issues))
+		}
+	}
+	if opts.Sequence {
+		v.verifySequences()
+	}
+	if err := v.flush(); err != nil {
+		return nil, err
+	}
+	return v.report, nil
+}
+
+func (v *verifier) flush() error {
+	if !v.opts.Repair || v.batch.ValueSize() == 0 {
+		return nil
+	}
+	err := v.batch.Write()
+	v.batch.Reset()
+	return err
+}
+
+//verifyHeight 检查 height 高度的区块, 返回这个高度的区块 hash
+func (v *verifier) verifyHeight(height int64, prevHash []byte) []byte {
+	bs := v.bs
+	hash, err := bs.GetBlockHashByHeight(height)
+	if err != nil {
+		v.issue(height, VerifyHeightHash, false, "height to hash not found: %v", err)
+		return nil
+	}
+	header, err := bs.GetBlockHeaderByHash(hash)
+	if err != nil {
+		v.issue(height, VerifyHeader, false, "header %s not found: %v", common.ToHex(hash), err)
+		return hash
+	}
+	if header.Height != height || !bytes.Equal(calcHeaderHash(header), hash) {
+		v.issue(height, VerifyHeader, false, "header %s height %d mismatch", common.ToHex(hash), header.Height)
+	}
+	if height > 0 && prevHash != nil && !bytes.Equal(header.ParentHash, prevHash) {
+		v.issue(height, VerifyParentHash, false, "parent %s, want %s", common.ToHex(header.ParentHash), common.ToHex(prevHash))
+	}
+
+	byHeight, err := bs.GetBlockHeaderByHeight(height)
+	if err != nil || !bytes.Equal(byHeight.Hash, hash) {
+		if v.opts.Repair {
+			v.batch.Set(calcHeightToBlockHeaderKey(height), types.Encode(header))
+		}
+		v.issue(height, VerifyHeightHeader, v.opts.Repair, "height to header mismatch")
+	}
+	if h, err := bs.GetHeightByBlockHash(hash); err != nil || h != height {
+		if v.opts.Repair {
+			v.batch.Set(calcHashToHeightKey(hash), types.Encode(&types.Int64{Data: height}))
+		}
+		v.issue(height, VerifyHashHeight, v.opts.Repair, "hash %s to height mismatch", common.ToHex(hash))
+	}
+	if v.opts.HasState != nil && height >= v.opts.StateStart && !v.opts.HasState(header.StateHash) {
+		v.issue(height, VerifyState, false, "state root %s not found", common.ToHex(header.StateHash))
+	}
+
+	//已经裁剪的区块没有区块体和交易
+	if bs.isPruned(height) {
+		return hash
+	}
+	detail, err := bs.LoadBlockByHash(hash)
+	if err != nil {
+		v.issue(height, VerifyBody, false, "body %s not found: %v", common.ToHex(hash), err)
+		return hash
+	}
+	block := detail.Block
+	if !bytes.Equal(block.Hash(), hash) {
+		v.issue(height, VerifyBody, false, "body hash %s mismatch", common.ToHex(block.Hash()))
+	}
+	if !bytes.Equal(merkle.CalcMerkleRoot(block.Txs), block.TxHash) {
+		v.issue(height, VerifyTxHash, false, "txhash %s mismatch", common.ToHex(block.TxHash))
+	}
+	for index, tx := range block.Txs {
+		v.verifyTx(detail, index, tx)
+	}
+	return hash
+}
+
+//verifyTx 检查交易索引指向正确的高度和位置, 修复时按照执行模块的格式重建
+func (v *verifier) verifyTx(detail *types.BlockDetail, index int, tx *types.Transaction) {
+	block := detail.Block
+	txhash := tx.Hash()
+	result, err := v.bs.GetTx(txhash)
+	if err == nil && result.Height == block.Height && result.Index == int32(index) {
+		return
+	}
+	if v.opts.Repair {
+		txresult := &types.TxResult{
+			Height:     block.Height,
+			Index:      int32(index),
+			Tx:         tx,
+			Blocktime:  block.BlockTime,
+			ActionName: tx.ActionName(),
+		}
+		if index < len(detail.Receipts) {
+			txresult.Receiptdate = detail.Receipts[index]
+		}
+		v.batch.Set(types.CalcTxKey(txhash), types.Encode(txresult))
+		if types.IsEnable("quickIndex") {
+			v.batch.Set(types.CalcTxShortKey(txhash), []byte("1"))
+		}
+	}
+	if err != nil {
+		v.issue(block.Height, VerifyTxIndex, v.opts.Repair, "tx %s index %d: %v", common.ToHex(txhash), index, err)
+		return
+	}
+	v.issue(block.Height, VerifyTxIndex, v.opts.Repair, "tx %s index %d, found %d:%d", common.ToHex(txhash), index, result.Height, result.Index)
+}
+
+//verifySequences 从 0 开始回放区块序列号, 回放的结果应该和当前的主链一致
+func (v *verifier) verifySequences() {
+	bs := v.bs
+	last, err := bs.LoadBlockLastSequence()
+	if err != nil {
+		v.issue(-1, VerifySequence, false, "last sequence not found: %v", err)
+		return
+	}
+	var chain [][]byte
+	for seq := int64(0); seq <= last; seq++ {
+		blockSeq, err := bs.GetBlockSequence(seq)
+		if err != nil {
+			v.issue(-1, VerifySequence, false, "sequence %d not found: %v", seq, err)
+			return
+		}
+		header, err := bs.GetBlockHeaderByHash(blockSeq.Hash)
+		if err != nil {
+			v.issue(-1, VerifySequence, false, "sequence %d header %s not found: %v", seq, common.ToHex(blockSeq.Hash), err)
+			return
+		}
+		switch blockSeq.Type {
+		case AddBlock:
+			if header.Height != int64(len(chain)) || (len(chain) > 0 && !bytes.Equal(header.ParentHash, chain[len(chain)-1])) {
+				v.issue(header.Height, VerifySequence, false, "sequence %d add block %s not connected", seq, common.ToHex(blockSeq.Hash))
+				return
+			}
+			chain = append(chain, blockSeq.Hash)
+		case DelBlock:
+			if len(chain) == 0 || !bytes.Equal(chain[len(chain)-1], blockSeq.Hash) {
+				v.issue(header.Height, VerifySequence, false, "sequence %d del block %s is not the tip", seq, common.ToHex(blockSeq.Hash))
+				return
+			}
+			chain = chain[:len(chain)-1]
+		default:
+			v.issue(header.Height, VerifySequence, false, "sequence %d type %d unknown", seq, blockSeq.Type)
+			return
+		}
+	}
+	tip := bs.Height()
+	tipHash, _ := bs.GetBlockHashByHeight(tip)
+	if int64(len(chain)) != tip+1 || !bytes.Equal(chain[len(chain)-1], tipHash) {
+		v.issue(tip, VerifySequence, false, "sequence %d replay height %d, want %d", last, len(chain)-1, tip)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blockchain_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/chain33/util/testnode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	mock33 := testnode.New("", nil)
+	defer mock33.Close()
+	var height int64 = 6
+	var hashes [][]byte
+	for i := int64(1); i <= height; i++ {
+		hashes = append(hashes, mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin)))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	bs := mock33.GetBlockChain().GetStore()
+	opts := &blockchain.VerifyOptions{Start: 0, End: -1, Sequence: true}
+	report, err := bs.Verify(opts)
+	require.Nil(t, err)
+	assert.Equal(t, height, report.End)
+	assert.Equal(t, height+1, report.Checked)
+	assert.Equal(t, 0, len(report.Issues))
+
+	//破坏高度 2 的交易索引, 删除高度 4 的交易索引以及 hash 到高度的对应关系
+	header, err := bs.GetBlockHeaderByHeight(4)
+	require.Nil(t, err)
+	batch := bs.NewBatch(true)
+	batch.Set(types.CalcTxKey(hashes[1]), types.Encode(&types.TxResult{Height: 3}))
+	batch.Delete(types.CalcTxKey(hashes[3]))
+	batch.Delete(append([]byte("Hash:"), header.Hash...))
+	require.Nil(t, batch.Write())
+	//状态根丢失只报告, 不修复
+	opts.HasState = func(stateHash []byte) bool {
+		return !bytes.Equal(stateHash, header.StateHash)
+	}
+	report, err = bs.Verify(opts)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(report.Issues))
+	assert.Equal(t, 4, report.Unrepaired())
+	var issues []blockchain.VerifyIssue
+	for _, issue := range report.Issues {
+		issues = append(issues, blockchain.VerifyIssue{Height: issue.Height, Kind: issue.Kind})
+	}
+	assert.Equal(t, []blockchain.VerifyIssue{
+		{Height: 2, Kind: blockchain.VerifyTxIndex},
+		{Height: 4, Kind: blockchain.VerifyHashHeight},
+		{Height: 4, Kind: blockchain.VerifyState},
+		{Height: 4, Kind: blockchain.VerifyTxIndex},
+	}, issues)
+
+	opts.Repair = true
+	report, err = bs.Verify(opts)
+	require.Nil(t, err)
+	assert.Equal(t, 4, len(report.Issues))
+	assert.Equal(t, 1, report.Unrepaired())
+
+	opts.Repair = false
+	report, err = bs.Verify(opts)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(report.Issues))
+	assert.Equal(t, blockchain.VerifyState, report.Issues[0].Kind)
+	detail, err := mock33.GetAPI().QueryTx(&types.ReqHash{Hash: hashes[1]})
+	require.Nil(t, err)
+	assert.Equal(t, int64(2), detail.Height)
+	assert.Equal(t, hashes[1], detail.Tx.Hash())
+	height4, err := bs.GetHeightByBlockHash(header.Hash)
+	require.Nil(t, err)
+	assert.Equal(t, int64(4), height4)
+
+	_, err = bs.Verify(&blockchain.VerifyOptions{Start: height + 1, End: -1})
+	assert.Equal(t, types.ErrStartBigThanEnd, err)
+}
@@ -127,6 +127,20 @@ func (m *mockBlockChain) SetQueueClient(q queue.Queue) {
 				} else {
 					msg.ReplyErr("Do not support", types.ErrInvalidParam)
 				}
+			case types.EventAddBlockSeqCB:
+				msg.ReplyErr("EventAddBlockSeqCB", nil)
+			case types.EventListBlockSeqCB:
+				msg.Reply(client.NewMessage(blockchainKey, types.EventListBlockSeqCB, &types.BlockSeqCBs{}))
+			case types.EventSetBlockSeqCBStatus:
+				msg.ReplyErr("EventSetBlockSeqCBStatus", nil)
+			case types.EventDelBlockSeqCB:
+				msg.ReplyErr("EventDelBlockSeqCB", types.ErrSeqCBNotFound)
+			case types.EventGetCheckpoint:
+				msg.Reply(client.NewMessage(blockchainKey, types.EventReplyCheckpoint, &types.ReplyCheckpoint{FinalizedHeight: -1}))
+			case types.EventGetStateProof:
+				msg.Reply(client.NewMessage(blockchainKey, types.EventStateProof, &types.StateProof{Key: msg.GetData().(*types.ReqStateProof).Key}))
+			case types.EventGetReorgHistory:
+				msg.Reply(client.NewMessage(blockchainKey, types.EventReplyReorgHistory, &types.ReorgHistory{Orphans: &types.OrphanPoolStat{MinHeight: -1, MaxHeight: -1}}))
 			default:
 				msg.ReplyErr("Do not support", types.ErrNotSupport)
 			}
@@ -47,6 +47,14 @@ func (m *mockMempool) SetQueueClient(q queue.Queue) {
 				msg.Reply(client.NewMessage(mempoolKey, types.EventReplyTxList, &types.ReplyTxList{}))
 			case types.EventGetLastMempool:
 				msg.Reply(client.NewMessage(mempoolKey, types.EventReplyTxList, &types.ReplyTxList{}))
+			case types.EventGetMempoolTx:
+				msg.Reply(client.NewMessage(mempoolKey, types.EventReplyMempoolTx, &types.MempoolTx{Size: 100}))
+			case types.EventGetAddrTxs:
+				msg.Reply(client.NewMessage(mempoolKey, types.EventReplyAddrTxs, &types.TransactionDetails{}))
+			case types.EventEvictMempoolTx:
+				msg.Reply(client.NewMessage(mempoolKey, types.EventReply, &types.Reply{IsOk: true}))
+			case types.EventGetMempoolStat:
+				msg.Reply(client.NewMessage(mempoolKey, types.EventReplyMempoolStat, &types.MempoolStat{Size: 1}))
 			default:
 				msg.ReplyErr("Do not support", types.ErrNotSupport)
 			}
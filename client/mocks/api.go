@@ -384,6 +384,98 @@ func (_m *QueueProtocolAPI) GetLastMempool() (*types.ReplyTxList, error) {
 	return r0, r1
 }
 
+// GetMempoolTx provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) GetMempoolTx(param *types.ReqHash) (*types.MempoolTx, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.MempoolTx
+	if rf, ok := ret.Get(0).(func(*types.ReqHash) *types.MempoolTx); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MempoolTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqHash) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMempoolTxsByAddr provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) GetMempoolTxsByAddr(param *types.ReqAddrs) (*types.TransactionDetails, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.TransactionDetails
+	if rf, ok := ret.Get(0).(func(*types.ReqAddrs) *types.TransactionDetails); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransactionDetails)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqAddrs) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvictMempoolTx provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) EvictMempoolTx(param *types.ReqHash) (*types.Reply, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.Reply
+	if rf, ok := ret.Get(0).(func(*types.ReqHash) *types.Reply); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Reply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqHash) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMempoolStat provides a mock function with given fields:
+func (_m *QueueProtocolAPI) GetMempoolStat() (*types.MempoolStat, error) {
+	ret := _m.Called()
+
+	var r0 *types.MempoolStat
+	if rf, ok := ret.Get(0).(func() *types.MempoolStat); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MempoolStat)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMempool provides a mock function with given fields:
 func (_m *QueueProtocolAPI) GetMempool() (*types.ReplyTxList, error) {
 	ret := _m.Called()
@@ -718,6 +810,29 @@ func (_m *QueueProtocolAPI) PeerInfo() (*types.PeerList, error) {
 	return r0, r1
 }
 
+// ExecTxList provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) ExecTxList(param *types.ExecTxList) (*types.Receipts, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.Receipts
+	if rf, ok := ret.Get(0).(func(*types.ExecTxList) *types.Receipts); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ExecTxList) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: driver, funcname, param
 func (_m *QueueProtocolAPI) Query(driver string, funcname string, param types.Message) (types.Message, error) {
 	ret := _m.Called(driver, funcname, param)
@@ -1246,3 +1361,164 @@ func (_m *QueueProtocolAPI) WalletUnLock(param *types.WalletUnLock) (*types.Repl
 
 	return r0, r1
 }
+
+// AddSeqCallBack provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) AddSeqCallBack(param *types.BlockSeqCB) (*types.Reply, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.Reply
+	if rf, ok := ret.Get(0).(func(*types.BlockSeqCB) *types.Reply); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Reply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.BlockSeqCB) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DelSeqCallBack provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) DelSeqCallBack(param *types.ReqString) (*types.Reply, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.Reply
+	if rf, ok := ret.Get(0).(func(*types.ReqString) *types.Reply); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Reply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqString) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCheckpoint provides a mock function with given fields:
+func (_m *QueueProtocolAPI) GetCheckpoint() (*types.ReplyCheckpoint, error) {
+	ret := _m.Called()
+
+	var r0 *types.ReplyCheckpoint
+	if rf, ok := ret.Get(0).(func() *types.ReplyCheckpoint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReplyCheckpoint)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStateProof provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) GetStateProof(param *types.ReqStateProof) (*types.StateProof, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.StateProof
+	if rf, ok := ret.Get(0).(func(*types.ReqStateProof) *types.StateProof); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.StateProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqStateProof) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReorgHistory provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) GetReorgHistory(param *types.ReqReorgHistory) (*types.ReorgHistory, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.ReorgHistory
+	if rf, ok := ret.Get(0).(func(*types.ReqReorgHistory) *types.ReorgHistory); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ReorgHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqReorgHistory) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSeqCallBack provides a mock function with given fields:
+func (_m *QueueProtocolAPI) ListSeqCallBack() (*types.BlockSeqCBs, error) {
+	ret := _m.Called()
+
+	var r0 *types.BlockSeqCBs
+	if rf, ok := ret.Get(0).(func() *types.BlockSeqCBs); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BlockSeqCBs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSeqCallBackStatus provides a mock function with given fields: param
+func (_m *QueueProtocolAPI) SetSeqCallBackStatus(param *types.ReqSetSeqCBStatus) (*types.Reply, error) {
+	ret := _m.Called(param)
+
+	var r0 *types.Reply
+	if rf, ok := ret.Get(0).(func(*types.ReqSetSeqCBStatus) *types.Reply); ok {
+		r0 = rf(param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Reply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ReqSetSeqCBStatus) error); ok {
+		r1 = rf(param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -6,6 +6,7 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -468,6 +469,81 @@ func (q *QueueProtocol) GetLastMempool() (*types.ReplyTxList, error) {
 	return nil, types.ErrTypeAsset
 }
 
+// GetMempoolTx get the pending transaction in mempool by hash
+func (q *QueueProtocol) GetMempoolTx(param *types.ReqHash) (*types.MempoolTx, error) {
+	if param == nil {
+		err := types.ErrInvalidParam
+		log.Error("GetMempoolTx", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(mempoolKey, types.EventGetMempoolTx, param)
+	if err != nil {
+		log.Error("GetMempoolTx", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.MempoolTx); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetMempoolTx", "Error", err)
+	return nil, err
+}
+
+// GetMempoolTxsByAddr get the pending transactions in mempool of the addresses
+func (q *QueueProtocol) GetMempoolTxsByAddr(param *types.ReqAddrs) (*types.TransactionDetails, error) {
+	if param == nil {
+		err := types.ErrInvalidParam
+		log.Error("GetMempoolTxsByAddr", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(mempoolKey, types.EventGetAddrTxs, param)
+	if err != nil {
+		log.Error("GetMempoolTxsByAddr", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.TransactionDetails); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetMempoolTxsByAddr", "Error", err)
+	return nil, err
+}
+
+// EvictMempoolTx remove the pending transaction from mempool
+func (q *QueueProtocol) EvictMempoolTx(param *types.ReqHash) (*types.Reply, error) {
+	if param == nil {
+		err := types.ErrInvalidParam
+		log.Error("EvictMempoolTx", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(mempoolKey, types.EventEvictMempoolTx, param)
+	if err != nil {
+		log.Error("EvictMempoolTx", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.Reply); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("EvictMempoolTx", "Error", err)
+	return nil, err
+}
+
+// GetMempoolStat get mempool statistics
+func (q *QueueProtocol) GetMempoolStat() (*types.MempoolStat, error) {
+	msg, err := q.query(mempoolKey, types.EventGetMempoolStat, &types.ReqNil{})
+	if err != nil {
+		log.Error("GetMempoolStat", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.MempoolStat); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetMempoolStat", "Error", err)
+	return nil, err
+}
+
 // GetBlockOverview get block head detil by hash
 func (q *QueueProtocol) GetBlockOverview(param *types.ReqHash) (*types.BlockOverview, error) {
 	if param == nil {
@@ -951,6 +1027,124 @@ func (q *QueueProtocol) GetBlockSequences(param *types.ReqBlocks) (*types.BlockS
 	return nil, err
 }
 
+// AddSeqCallBack add or update the subscription of block sequence push
+func (q *QueueProtocol) AddSeqCallBack(param *types.BlockSeqCB) (*types.Reply, error) {
+	if param == nil || param.Name == "" {
+		err := types.ErrInvalidParam
+		log.Error("AddSeqCallBack", "Error", err)
+		return nil, err
+	}
+	return q.seqCallBackReply("AddSeqCallBack", types.EventAddBlockSeqCB, param)
+}
+
+// ListSeqCallBack list the subscriptions with the push progress and state
+func (q *QueueProtocol) ListSeqCallBack() (*types.BlockSeqCBs, error) {
+	msg, err := q.query(blockchainKey, types.EventListBlockSeqCB, &types.ReqNil{})
+	if err != nil {
+		log.Error("ListSeqCallBack", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.BlockSeqCBs); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("ListSeqCallBack", "Error", err)
+	return nil, err
+}
+
+// SetSeqCallBackStatus pause or resume the subscription
+func (q *QueueProtocol) SetSeqCallBackStatus(param *types.ReqSetSeqCBStatus) (*types.Reply, error) {
+	if param == nil || param.Name == "" {
+		err := types.ErrInvalidParam
+		log.Error("SetSeqCallBackStatus", "Error", err)
+		return nil, err
+	}
+	return q.seqCallBackReply("SetSeqCallBackStatus", types.EventSetBlockSeqCBStatus, param)
+}
+
+// DelSeqCallBack delete the subscription
+func (q *QueueProtocol) DelSeqCallBack(param *types.ReqString) (*types.Reply, error) {
+	if param == nil || param.Data == "" {
+		err := types.ErrInvalidParam
+		log.Error("DelSeqCallBack", "Error", err)
+		return nil, err
+	}
+	return q.seqCallBackReply("DelSeqCallBack", types.EventDelBlockSeqCB, param)
+}
+
+// GetCheckpoint get the current checkpoint and finalized height
+func (q *QueueProtocol) GetCheckpoint() (*types.ReplyCheckpoint, error) {
+	msg, err := q.query(blockchainKey, types.EventGetCheckpoint, &types.ReqNil{})
+	if err != nil {
+		log.Error("GetCheckpoint", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.ReplyCheckpoint); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetCheckpoint", "Error", err)
+	return nil, err
+}
+
+// GetStateProof get state value and mavl proof, light client verifies it with the local header
+func (q *QueueProtocol) GetStateProof(param *types.ReqStateProof) (*types.StateProof, error) {
+	if param == nil || len(param.Key) == 0 {
+		err := types.ErrInvalidParam
+		log.Error("GetStateProof", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(blockchainKey, types.EventGetStateProof, param)
+	if err != nil {
+		log.Error("GetStateProof", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.StateProof); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetStateProof", "Error", err)
+	return nil, err
+}
+
+// GetReorgHistory get the latest reorg records and orphan pool statistics
+func (q *QueueProtocol) GetReorgHistory(param *types.ReqReorgHistory) (*types.ReorgHistory, error) {
+	if param == nil {
+		err := types.ErrInvalidParam
+		log.Error("GetReorgHistory", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(blockchainKey, types.EventGetReorgHistory, param)
+	if err != nil {
+		log.Error("GetReorgHistory", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.ReorgHistory); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("GetReorgHistory", "Error", err)
+	return nil, err
+}
+
+func (q *QueueProtocol) seqCallBackReply(title string, ty int64, param types.Message) (*types.Reply, error) {
+	msg, err := q.query(blockchainKey, ty, param)
+	if err != nil {
+		log.Error(title, "Error", err.Error())
+		return nil, err
+	}
+	reply, ok := msg.GetData().(*types.Reply)
+	if !ok {
+		err = types.ErrTypeAsset
+		log.Error(title, "Error", err)
+		return nil, err
+	}
+	if !reply.GetIsOk() {
+		return nil, errors.New(string(reply.GetMsg()))
+	}
+	return reply, nil
+}
+
 // QueryChain query chain
 func (q *QueueProtocol) QueryChain(param *types.ChainExecutor) (types.Message, error) {
 	if param == nil {
@@ -971,6 +1165,26 @@ func (q *QueueProtocol) QueryChain(param *types.ChainExecutor) (types.Message, e
 	return nil, err
 }
 
+// ExecTxList exec txs on the state hash, the result is not committed
+func (q *QueueProtocol) ExecTxList(param *types.ExecTxList) (*types.Receipts, error) {
+	if param == nil {
+		err := types.ErrInvalidParam
+		log.Error("ExecTxList", "Error", err)
+		return nil, err
+	}
+	msg, err := q.query(executorKey, types.EventExecTxList, param)
+	if err != nil {
+		log.Error("ExecTxList", "Error", err.Error())
+		return nil, err
+	}
+	if reply, ok := msg.GetData().(*types.Receipts); ok {
+		return reply, nil
+	}
+	err = types.ErrTypeAsset
+	log.Error("ExecTxList", "Error", err)
+	return nil, err
+}
+
 // GetTicketCount get ticket count from consensus
 func (q *QueueProtocol) GetTicketCount() (*types.Int64, error) {
 	msg, err := q.query(consensusKey, types.EventGetTicketCount, &types.ReqNil{})
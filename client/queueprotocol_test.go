@@ -96,6 +96,87 @@ func TestQueueProtocol(t *testing.T) {
 	testStoreGetTotalCoins(t, api)
 	testStoreList(t, api)
 	testBlockChainQuery(t, api)
+	testExecTxList(t, api)
+	testSeqCallBack(t, api)
+	testGetCheckpoint(t, api)
+	testGetStateProof(t, api)
+	testGetReorgHistory(t, api)
+	testMempoolQuery(t, api)
+}
+
+func testMempoolQuery(t *testing.T, api client.QueueProtocolAPI) {
+	_, err := api.GetMempoolTx(nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	tx, err := api.GetMempoolTx(&types.ReqHash{Hash: []byte("hash")})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), tx.Size)
+	_, err = api.GetMempoolTxsByAddr(nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = api.GetMempoolTxsByAddr(&types.ReqAddrs{Addrs: []string{"addr"}})
+	assert.Nil(t, err)
+	_, err = api.EvictMempoolTx(nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	reply, err := api.EvictMempoolTx(&types.ReqHash{Hash: []byte("hash")})
+	assert.Nil(t, err)
+	assert.True(t, reply.IsOk)
+	stat, err := api.GetMempoolStat()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), stat.Size)
+}
+
+func testGetCheckpoint(t *testing.T, api client.QueueProtocolAPI) {
+	reply, err := api.GetCheckpoint()
+	assert.Nil(t, err)
+	assert.Nil(t, reply.Checkpoint)
+	assert.Equal(t, int64(-1), reply.FinalizedHeight)
+}
+
+func testGetStateProof(t *testing.T, api client.QueueProtocolAPI) {
+	_, err := api.GetStateProof(&types.ReqStateProof{})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	proof, err := api.GetStateProof(&types.ReqStateProof{Key: []byte("key"), Height: -1})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("key"), proof.Key)
+}
+
+func testGetReorgHistory(t *testing.T, api client.QueueProtocolAPI) {
+	_, err := api.GetReorgHistory(nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	reply, err := api.GetReorgHistory(&types.ReqReorgHistory{Count: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(reply.Items))
+	assert.Equal(t, int64(-1), reply.Orphans.MinHeight)
+}
+
+func testSeqCallBack(t *testing.T, api client.QueueProtocolAPI) {
+	_, err := api.AddSeqCallBack(nil)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	reply, err := api.AddSeqCallBack(&types.BlockSeqCB{Name: "test", URL: "http://127.0.0.1"})
+	assert.Nil(t, err)
+	assert.True(t, reply.IsOk)
+
+	cbs, err := api.ListSeqCallBack()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(cbs.Items))
+
+	_, err = api.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{})
+	assert.Equal(t, types.ErrInvalidParam, err)
+	_, err = api.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: "test", Status: types.SeqCBPaused})
+	assert.Nil(t, err)
+
+	_, err = api.DelSeqCallBack(&types.ReqString{Data: "test"})
+	assert.Equal(t, types.ErrSeqCBNotFound.Error(), err.Error())
+}
+
+func testExecTxList(t *testing.T, api client.QueueProtocolAPI) {
+	_, err := api.ExecTxList(nil)
+	if nil == err {
+		t.Error("ExecTxList(nil) need return error.")
+	}
+	_, err = api.ExecTxList(&types.ExecTxList{})
+	if err != nil {
+		t.Error("Call ExecTxList Failed.", err)
+	}
 }
 
 func testBlockChainQuery(t *testing.T, api client.QueueProtocolAPI) {
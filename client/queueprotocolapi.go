@@ -24,12 +24,22 @@ type QueueProtocolAPI interface {
 	GetMempool() (*types.ReplyTxList, error)
 	// types.EventGetLastMempool
 	GetLastMempool() (*types.ReplyTxList, error)
+	// types.EventGetMempoolTx 根据hash获取mempool中的交易
+	GetMempoolTx(param *types.ReqHash) (*types.MempoolTx, error)
+	// types.EventGetAddrTxs 获取mempool中账户(列表)的交易
+	GetMempoolTxsByAddr(param *types.ReqAddrs) (*types.TransactionDetails, error)
+	// types.EventEvictMempoolTx 从mempool中删除交易
+	EvictMempoolTx(param *types.ReqHash) (*types.Reply, error)
+	// types.EventGetMempoolStat 获取mempool的统计信息
+	GetMempoolStat() (*types.MempoolStat, error)
 	// +++++++++++++++ execs interfaces begin
 	// types.EventBlockChainQuery
 	Query(driver, funcname string, param types.Message) (types.Message, error)
 	QueryConsensus(param *types.ChainExecutor) (types.Message, error)
 	QueryConsensusFunc(driver string, funcname string, param types.Message) (types.Message, error)
 	QueryChain(param *types.ChainExecutor) (types.Message, error)
+	// types.EventExecTxList 在指定状态上执行交易, 执行结果不会写入数据库
+	ExecTxList(param *types.ExecTxList) (*types.Receipts, error)
 	ExecWalletFunc(driver string, funcname string, param types.Message) (types.Message, error)
 	ExecWallet(param *types.ChainExecutor) (types.Message, error)
 	// --------------- execs interfaces end
@@ -111,6 +121,20 @@ type QueueProtocolAPI interface {
 	GetBlockSequences(param *types.ReqBlocks) (*types.BlockSequences, error)
 	//types.EventGetBlockByHashes:
 	GetBlockByHashes(param *types.ReqHashes) (*types.BlockDetails, error)
+	// types.EventAddBlockSeqCB 添加或者更新推送区块序列号的订阅
+	AddSeqCallBack(param *types.BlockSeqCB) (*types.Reply, error)
+	// types.EventListBlockSeqCB
+	ListSeqCallBack() (*types.BlockSeqCBs, error)
+	// types.EventSetBlockSeqCBStatus 暂停或者恢复推送
+	SetSeqCallBackStatus(param *types.ReqSetSeqCBStatus) (*types.Reply, error)
+	// types.EventDelBlockSeqCB
+	DelSeqCallBack(param *types.ReqString) (*types.Reply, error)
+	// types.EventGetCheckpoint 获取当前生效的检查点
+	GetCheckpoint() (*types.ReplyCheckpoint, error)
+	// types.EventGetStateProof 获取状态数据以及 mavl 证明
+	GetStateProof(param *types.ReqStateProof) (*types.StateProof, error)
+	// types.EventGetReorgHistory 获取最新的重组记录以及孤儿池统计
+	GetReorgHistory(param *types.ReqReorgHistory) (*types.ReorgHistory, error)
 
 	// --------------- blockchain interfaces end
 
@@ -0,0 +1,45 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/33cn/chain33/rpc"
+	// register system executors
+	_ "github.com/33cn/chain33/system"
+	"github.com/spf13/cobra"
+)
+
+//OpenRPCCmd 生成 jsonrpc 接口的 OpenRPC 文档
+func OpenRPCCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openrpc",
+		Short: "Generate OpenRPC document of chain33 json-rpc methods and executor queries",
+		Run:   genOpenRPC,
+	}
+	cmd.Flags().StringP("out", "o", "", "output file, print to stdout if not set")
+	return cmd
+}
+
+func genOpenRPC(cmd *cobra.Command, args []string) {
+	out, _ := cmd.Flags().GetString("out")
+	data, err := json.MarshalIndent(rpc.OpenRPCDocument(), "", "  ")
+	if err != nil {
+		fmt.Println("marshal openrpc document error:", err)
+		return
+	}
+	if out == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := ioutil.WriteFile(out, data, 0644); err != nil {
+		fmt.Println("write openrpc document error:", err)
+		return
+	}
+	fmt.Println("OpenRPC document is written to", out)
+}
@@ -8,6 +8,7 @@ Package main chain33开发者工具，主要提供以下功能：
 2. 通过本地创建各种执行器工程，相关命令为 simple, advance
 3. 扫描本地插件信息，更新引用关系
 4. 通过本地创建完整的插件项目,可以选择simple模式和advance模式.
+5. 生成 jsonrpc 接口和执行器查询接口的 OpenRPC 文档, 相关命令为 openrpc
 
 目录介绍
 	1. config目录为tools工具使用的配置目录
@@ -7,10 +7,13 @@ package executor
 
 //store package store the world - state data
 import (
+	"bytes"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/33cn/chain33/account"
+	dbm "github.com/33cn/chain33/common/db"
 	clog "github.com/33cn/chain33/common/log"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/pluginmgr"
@@ -71,6 +74,7 @@ func New(cfg *types.Exec, sub map[string][]byte) *Executor {
 	exec.pluginEnable["addrindex"] = !cfg.DisableAddrIndex
 	exec.pluginEnable["txindex"] = true
 	exec.pluginEnable["fee"] = true
+	exec.pluginEnable["logindex"] = cfg.EnableLogIndex
 
 	exec.alias = make(map[string]string)
 	for _, v := range cfg.Alias {
@@ -124,7 +128,16 @@ func (exec *Executor) procExecQuery(msg queue.Message) {
 		return
 	}
 	data := msg.GetData().(*types.ChainExecutor)
-	driver, err := drivers.LoadDriver(data.Driver, header.GetHeight())
+	localdb := NewLocalDB(exec.client)
+	height := header.GetHeight()
+	if data.HasHeight || (data.StateHash != nil && !bytes.Equal(data.StateHash, header.StateHash)) {
+		height, err = exec.historyState(data, header, localdb)
+		if err != nil {
+			msg.Reply(exec.client.NewMessage("", types.EventBlockChainQuery, err))
+			return
+		}
+	}
+	driver, err := drivers.LoadDriver(data.Driver, height)
 	if err != nil {
 		msg.Reply(exec.client.NewMessage("", types.EventBlockChainQuery, err))
 		return
@@ -132,9 +145,8 @@ func (exec *Executor) procExecQuery(msg queue.Message) {
 	if data.StateHash == nil {
 		data.StateHash = header.StateHash
 	}
-	localdb := NewLocalDB(exec.client)
 	driver.SetLocalDB(localdb)
-	opt := &StateDBOption{EnableMVCC: exec.pluginEnable["mvcc"], Height: header.GetHeight()}
+	opt := &StateDBOption{EnableMVCC: exec.pluginEnable["mvcc"], Height: height}
 
 	db := NewStateDB(exec.client, data.StateHash, localdb, opt)
 	db.(*StateDB).enableMVCC()
@@ -151,6 +163,84 @@ func (exec *Executor) procExecQuery(msg queue.Message) {
 	msg.Reply(exec.client.NewMessage("", types.EventBlockChainQuery, ret))
 }
 
+// historyState 在历史状态上查询, 根据高度找到状态hash, 返回状态对应的高度, 执行器按照这个高度的分叉规则加载.
+// 只有状态hash 并且没有 mvcc 时返回最新的高度
+func (exec *Executor) historyState(data *types.ChainExecutor, last *types.Header, localdb dbm.KVDB) (int64, error) {
+	if data.HasHeight && (data.Height < 0 || data.Height > last.Height) {
+		return 0, types.ErrHeightNotExist
+	}
+	height := data.Height
+	if data.HasHeight {
+		headers, err := exec.qclient.GetHeaders(&types.ReqBlocks{Start: height, End: height})
+		if err != nil {
+			return 0, err
+		}
+		if len(headers.Items) != 1 {
+			return 0, types.ErrHeightNotExist
+		}
+		if data.StateHash != nil && !bytes.Equal(data.StateHash, headers.Items[0].StateHash) {
+			return 0, types.ErrCheckStateHash
+		}
+		data.StateHash = headers.Items[0].StateHash
+	} else if exec.pluginEnable["mvcc"] {
+		//mvcc 中记录了状态hash 对应的高度
+		v, err := dbm.NewSimpleMVCC(localdb).GetVersion(data.StateHash)
+		if err != nil {
+			return 0, types.ErrHashNotFound
+		}
+		height = v
+	} else if mavlPruneHeight() > 0 {
+		//开启了裁剪, 只有状态hash 无法判断是否已经被裁剪, 需要指定高度
+		elog.Error("historyState: height is required when mavl prune is enabled")
+		return 0, types.ErrInvalidParam
+	} else {
+		//没有 mvcc 无法根据状态hash 找到高度, 在给定的状态上按照最新高度的分叉规则查询,
+		//需要按照历史高度的分叉规则查询时指定高度
+		if err := exec.hasStateRoot(data.StateHash); err != nil {
+			return 0, err
+		}
+		height = last.Height
+	}
+	if prune := mavlPruneHeight(); prune > 0 && last.Height-height >= prune {
+		return 0, types.ErrStateHashPruned
+	}
+	return height, nil
+}
+
+// hasStateRoot 检查状态hash 在 store 中是否存在, 不存在时返回 ErrHashNotFound
+func (exec *Executor) hasStateRoot(stateHash []byte) error {
+	msg := exec.client.NewMessage("store", types.EventStoreHasRoot, &types.ReqHash{Hash: stateHash})
+	err := exec.client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return err
+	}
+	resp, err := exec.client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return err
+	}
+	//只有 store 明确返回不存在时才是不存在, store 不支持检查时当作存在
+	reply, ok := resp.GetData().(*types.Reply)
+	if ok && !reply.GetIsOk() && string(reply.GetMsg()) == types.ErrHashNotFound.Error() {
+		return types.ErrHashNotFound
+	}
+	return nil
+}
+
+// mavlPruneHeight 开启了 mavl 裁剪时返回保留的高度, 没有开启返回0
+func mavlPruneHeight() int64 {
+	if types.Conf("config.store").GStr("name") != "mavl" {
+		return 0
+	}
+	sub := types.Conf("config.store.sub.mavl")
+	if !sub.IsEnable("enableMavlPrune") {
+		return 0
+	}
+	if h := sub.GInt("pruneHeight"); h > 0 {
+		return h
+	}
+	return 1
+}
+
 func (exec *Executor) procExecCheckTx(msg queue.Message) {
 	datas := msg.GetData().(*types.ExecTxList)
 	execute := newExecutor(datas.StateHash, exec, datas.Height, datas.BlockTime, datas.Difficulty, datas.Txs, nil)
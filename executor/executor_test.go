@@ -10,6 +10,8 @@ import (
 
 	"encoding/hex"
 
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/queue"
 	_ "github.com/33cn/chain33/system"
 	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
@@ -187,3 +189,51 @@ func TestKeyLocalAllow(t *testing.T) {
 	err = isAllowLocalKey([]byte("paracross"), []byte("LODB-user.p.para.paracross-xxxx"))
 	assert.Equal(t, err, types.ErrLocalPrefix)
 }
+
+func TestHistoryState(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	q := queue.New("channel")
+	defer q.Close()
+	exec := &Executor{qclient: api, client: q.Client(), pluginEnable: make(map[string]bool)}
+	last := &types.Header{Height: 100, StateHash: []byte("last")}
+	api.On("GetHeaders", &types.ReqBlocks{Start: 10, End: 10}).Return(&types.Headers{Items: []*types.Header{{Height: 10, StateHash: []byte("h10")}}}, nil)
+	api.On("GetHeaders", &types.ReqBlocks{Start: 0, End: 0}).Return(&types.Headers{Items: []*types.Header{{Height: 0, StateHash: []byte("h0")}}}, nil)
+
+	data := &types.ChainExecutor{Height: 10, HasHeight: true}
+	height, err := exec.historyState(data, last, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), height)
+	assert.Equal(t, []byte("h10"), data.StateHash)
+
+	//创世区块的状态
+	data = &types.ChainExecutor{Height: 0, HasHeight: true}
+	height, err = exec.historyState(data, last, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), height)
+	assert.Equal(t, []byte("h0"), data.StateHash)
+
+	_, err = exec.historyState(&types.ChainExecutor{Height: 10, HasHeight: true, StateHash: []byte("other")}, last, nil)
+	assert.Equal(t, types.ErrCheckStateHash, err)
+	_, err = exec.historyState(&types.ChainExecutor{Height: 101, HasHeight: true}, last, nil)
+	assert.Equal(t, types.ErrHeightNotExist, err)
+	_, err = exec.historyState(&types.ChainExecutor{Height: -1, HasHeight: true}, last, nil)
+	assert.Equal(t, types.ErrHeightNotExist, err)
+
+	//只有状态hash 时检查状态是否存在, 没有 mvcc 时按照最新高度的分叉规则查询
+	go func() {
+		client := q.Client()
+		client.Sub("store")
+		for msg := range client.Recv() {
+			if string(msg.GetData().(*types.ReqHash).Hash) == "h10" {
+				msg.ReplyErr("StoreHasRoot", nil)
+			} else {
+				msg.ReplyErr("StoreHasRoot", types.ErrHashNotFound)
+			}
+		}
+	}()
+	_, err = exec.historyState(&types.ChainExecutor{StateHash: []byte("garbage")}, last, nil)
+	assert.Equal(t, types.ErrHashNotFound, err)
+	height, err = exec.historyState(&types.ChainExecutor{StateHash: []byte("h10")}, last, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, last.Height, height)
+}
@@ -50,6 +50,7 @@ func (p *addrindexPlugin) ExecLocal(executor *executor, data *types.BlockDetail)
 				set.KV = append(set.KV, kv)
 			}
 		}
+		set.KV = append(set.KV, getAddrFilterKV(executor, tx, receipt, txindex, true)...)
 	}
 	return set.KV, nil
 }
@@ -82,10 +83,92 @@ func (p *addrindexPlugin) ExecDelLocal(executor *executor, data *types.BlockDeta
 				set.KV = append(set.KV, kv)
 			}
 		}
+		set.KV = append(set.KV, getAddrFilterKV(executor, tx, receipt, txindex, false)...)
 	}
 	return set.KV, nil
 }
 
+//addrDirection 交易相关的地址以及地址的方向, from 和 to 相同时只有一个
+type addrDirection struct {
+	addr      string
+	direction int32
+}
+
+func getAddrDirections(txindex *txIndex) []addrDirection {
+	var dirs []addrDirection
+	if len(txindex.from) != 0 {
+		dirs = append(dirs, addrDirection{txindex.from, drivers.TxIndexFrom})
+	}
+	if len(txindex.to) != 0 {
+		if txindex.to == txindex.from {
+			dirs[0].direction |= drivers.TxIndexTo
+		} else {
+			dirs = append(dirs, addrDirection{txindex.to, drivers.TxIndexTo})
+		}
+	}
+	return dirs
+}
+
+//getAddrFilterKV 按照 执行器/action 的地址交易索引以及统计, 删除时生成的 key 和添加时一致
+func getAddrFilterKV(executor *executor, tx *types.Transaction, receipt *types.ReceiptData, txindex *txIndex, isadd bool) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	execer := string(tx.Execer)
+	action := tx.ActionName()
+	for _, dir := range getAddrDirections(txindex) {
+		var value []byte
+		if isadd {
+			value = types.Encode(&types.AddrTxIndex{
+				Hash:       txindex.index.Hash,
+				Height:     txindex.index.Height,
+				Index:      txindex.index.Index,
+				Execer:     execer,
+				ActionName: action,
+				Ty:         receipt.Ty,
+				Blocktime:  executor.blocktime,
+				Direction:  dir.direction,
+			})
+		}
+		kvs = append(kvs,
+			&types.KeyValue{Key: types.CalcTxAddrIndexKey(dir.addr, txindex.heightstr), Value: value},
+			&types.KeyValue{Key: types.CalcTxAddrExecKey(dir.addr, execer, txindex.heightstr), Value: value},
+			&types.KeyValue{Key: types.CalcTxAddrActionKey(dir.addr, execer, action, txindex.heightstr), Value: value})
+		count := &types.AddrTxCount{Execer: execer, ActionName: action, Direction: dir.direction, Ty: receipt.Ty}
+		kv, err := updateAddrTxCount(executor.localDB, dir.addr, count, isadd)
+		if err == nil && kv != nil {
+			kvs = append(kvs, kv)
+		}
+	}
+	return kvs
+}
+
+//updateAddrTxCount 更新地址按照 执行器/action/方向/回执类型 统计的交易数, 减到 0 时删除
+func updateAddrTxCount(cachedb dbm.KVDB, addr string, count *types.AddrTxCount, isadd bool) (*types.KeyValue, error) {
+	key := types.CalcTxAddrCountKey(addr, count.Execer, count.ActionName, count.Direction, count.Ty)
+	data, err := cachedb.Get(key)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	var old types.AddrTxCount
+	if len(data) != 0 {
+		if err = types.Decode(data, &old); err != nil {
+			return nil, err
+		}
+	}
+	if isadd {
+		count.Count = old.Count + 1
+	} else {
+		count.Count = old.Count - 1
+	}
+	var value []byte
+	if count.Count > 0 {
+		value = types.Encode(count)
+	}
+	if err = cachedb.Set(key, value); err != nil {
+		return nil, err
+	}
+	return &types.KeyValue{Key: key, Value: value}, nil
+}
+
 func getAddrTxsCountKV(addr string, count int64) *types.KeyValue {
 	counts := &types.Int64{Data: count}
 	countbytes := types.Encode(counts)
@@ -0,0 +1,139 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common/address"
+	dbm "github.com/33cn/chain33/common/db"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func saveAddrIndex(t *testing.T, db dbm.DB, exec *executor, height int64, txs []*types.Transaction, tys []int32, isadd bool) {
+	exec.height = height
+	exec.blocktime = height * 10
+	detail := &types.BlockDetail{Block: &types.Block{Height: height, Txs: txs}}
+	for _, ty := range tys {
+		detail.Receipts = append(detail.Receipts, &types.ReceiptData{Ty: ty})
+	}
+	p := &addrindexPlugin{}
+	var kvs []*types.KeyValue
+	var err error
+	if isadd {
+		kvs, err = p.ExecLocal(exec, detail)
+	} else {
+		kvs, err = p.ExecDelLocal(exec, detail)
+	}
+	require.Nil(t, err)
+	for _, kv := range kvs {
+		if kv.Value == nil {
+			require.Nil(t, db.Delete(kv.Key))
+		} else {
+			require.Nil(t, db.Set(kv.Key, kv.Value))
+		}
+	}
+}
+
+func TestGetTxsByAddrFilter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrindex")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db := dbm.NewDB("addrindex", "leveldb", dir, 16)
+	defer db.Close()
+	priv := util.TestPrivkeyList[0]
+	from := address.PubKeyToAddress(priv.PubKey().Bytes()).String()
+	to, _ := util.Genaddress()
+	exec := &executor{localDB: NewLocalDB(nil)}
+	for height := int64(1); height <= 3; height++ {
+		txs := []*types.Transaction{util.CreateCoinsTx(priv, to, height), util.CreateNoneTx(priv)}
+		saveAddrIndex(t, db, exec, height, txs, []int32{types.ExecOk, types.ExecOk}, true)
+	}
+	//高度 4: 一个执行失败的交易以及一个转给自己的交易
+	block4 := []*types.Transaction{util.CreateCoinsTx(priv, to, 4), util.CreateCoinsTx(priv, from, 4)}
+	saveAddrIndex(t, db, exec, 4, block4, []int32{types.ExecPack, types.ExecOk}, true)
+
+	d := &drivers.DriverBase{}
+	d.SetLocalDB(dbm.NewKVDB(db))
+	query := func(req *types.ReqTxsByAddrFilter) *types.ReplyTxsByAddrFilter {
+		msg, err := d.GetTxsByAddrFilter(req)
+		require.Nil(t, err)
+		return msg.(*types.ReplyTxsByAddrFilter)
+	}
+
+	//分页查询所有的交易, 从新到旧
+	req := &types.ReqTxsByAddrFilter{Addr: from, Count: 3}
+	var txs []*types.AddrTxIndex
+	for {
+		reply := query(req)
+		assert.Equal(t, int64(8), reply.Total)
+		txs = append(txs, reply.Txs...)
+		if reply.Cursor == "" {
+			break
+		}
+		req.Cursor = reply.Cursor
+	}
+	require.Equal(t, 8, len(txs))
+	assert.Equal(t, block4[1].Hash(), txs[0].Hash)
+	assert.Equal(t, int32(drivers.TxIndexFrom|drivers.TxIndexTo), txs[0].Direction)
+	assert.Equal(t, int64(40), txs[0].Blocktime)
+	assert.Equal(t, int64(1), txs[7].Height)
+
+	//按照执行器, action, 方向以及执行结果过滤
+	reply := query(&types.ReqTxsByAddrFilter{Addr: from, Execer: "coins", ActionName: "transfer", Direction: dbm.ListASC})
+	assert.Equal(t, int64(5), reply.Total)
+	require.Equal(t, 5, len(reply.Txs))
+	assert.Equal(t, int64(1), reply.Txs[0].Height)
+	assert.Equal(t, "transfer", reply.Txs[0].ActionName)
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from, Execer: "none"})
+	assert.Equal(t, int64(3), reply.Total)
+	assert.Equal(t, 3, len(reply.Txs))
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from, Flag: drivers.TxIndexTo})
+	assert.Equal(t, int64(1), reply.Total)
+	assert.Equal(t, block4[1].Hash(), reply.Txs[0].Hash)
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from, Status: 2})
+	assert.Equal(t, int64(1), reply.Total)
+	assert.Equal(t, block4[0].Hash(), reply.Txs[0].Hash)
+	reply = query(&types.ReqTxsByAddrFilter{Addr: to, Flag: drivers.TxIndexTo, Status: 1})
+	assert.Equal(t, int64(3), reply.Total)
+	assert.Equal(t, 3, len(reply.Txs))
+
+	//按照区块时间过滤, 总数通过扫描索引得到
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from, StartTime: 20, EndTime: 30})
+	assert.Equal(t, int64(4), reply.Total)
+	require.Equal(t, 4, len(reply.Txs))
+	assert.Equal(t, int64(3), reply.Txs[0].Height)
+	assert.Equal(t, int64(2), reply.Txs[3].Height)
+	assert.Equal(t, "", reply.Cursor)
+
+	//回滚区块删除索引以及统计
+	saveAddrIndex(t, db, exec, 4, block4, []int32{types.ExecPack, types.ExecOk}, false)
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from})
+	assert.Equal(t, int64(6), reply.Total)
+	assert.Equal(t, 6, len(reply.Txs))
+	reply = query(&types.ReqTxsByAddrFilter{Addr: from, Status: 2})
+	assert.Equal(t, int64(0), reply.Total)
+	assert.Equal(t, 0, len(reply.Txs))
+
+	for _, req := range []*types.ReqTxsByAddrFilter{
+		{},
+		{Addr: from, ActionName: "transfer"},
+		{Addr: from, Flag: 3},
+		{Addr: from, Status: 3},
+		{Addr: from, Count: drivers.MaxTxsPerAddrQuery + 1},
+		{Addr: from, Direction: dbm.ListSeek},
+	} {
+		_, err = d.GetTxsByAddrFilter(req)
+		assert.Equal(t, types.ErrInvalidParam, err)
+	}
+	_, err = d.GetTxsByAddrFilter(&types.ReqTxsByAddrFilter{Addr: from, StartTime: 30, EndTime: 20})
+	assert.Equal(t, types.ErrStartBigThanEnd, err)
+}
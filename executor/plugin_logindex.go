@@ -0,0 +1,80 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/types"
+)
+
+func init() {
+	RegisterPlugin("logindex", &logindexPlugin{})
+}
+
+//logindexPlugin 按照 执行器/日志类型/高度 索引回执日志, 用于 GetLogs 查询.
+//日志只保存在执行器索引中, 类型索引的值是执行器索引的 key
+type logindexPlugin struct {
+	*pluginBase
+}
+
+func (p *logindexPlugin) CheckEnable(executor *executor, enable bool) (kvs []*types.KeyValue, ok bool, err error) {
+	kvs, ok, err = p.checkFlag(executor, types.FlagLogIndex, enable)
+	if err == types.ErrDBFlag {
+		panic("logindex config is enable, it must be synchronized from 0 height ")
+	}
+	return kvs, ok, err
+}
+
+func (p *logindexPlugin) ExecLocal(executor *executor, data *types.BlockDetail) (kvs []*types.KeyValue, err error) {
+	return getLogIndex(executor, data, false), nil
+}
+
+func (p *logindexPlugin) ExecDelLocal(executor *executor, data *types.BlockDetail) (kvs []*types.KeyValue, err error) {
+	return getLogIndex(executor, data, true), nil
+}
+
+func getLogIndex(executor *executor, data *types.BlockDetail, isDel bool) []*types.KeyValue {
+	var kvs []*types.KeyValue
+	var blockHash []byte
+	for i, tx := range data.Block.Txs {
+		if i >= len(data.Receipts) {
+			break
+		}
+		receipt := data.Receipts[i]
+		if len(receipt.Logs) == 0 {
+			continue
+		}
+		if blockHash == nil {
+			blockHash = data.Block.Hash()
+		}
+		txHash := tx.Hash()
+		execer := string(tx.Execer)
+		from := address.PubKeyToAddress(tx.GetSignature().GetPubkey()).String()
+		to := tx.GetRealToAddr()
+		for j, l := range receipt.Logs {
+			position := types.LogIndexPosition(executor.height, int32(i), int32(j))
+			key := types.CalcLogIndexKey(execer, position)
+			var value, ref []byte
+			if !isDel {
+				ref = key
+				value = types.Encode(&types.ReceiptLogIndex{
+					TxHash:    txHash,
+					BlockHash: blockHash,
+					Height:    executor.height,
+					TxIndex:   int32(i),
+					LogIndex:  int32(j),
+					Execer:    execer,
+					Ty:        l.Ty,
+					Log:       l.Log,
+					From:      from,
+					To:        to,
+				})
+			}
+			kvs = append(kvs, &types.KeyValue{Key: key, Value: value})
+			kvs = append(kvs, &types.KeyValue{Key: types.CalcLogIndexTyKey(execer, l.Ty, position), Value: ref})
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,99 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	dbm "github.com/33cn/chain33/common/db"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func saveLogIndex(t *testing.T, db dbm.DB, height int64, txs []*types.Transaction, tys ...int32) {
+	detail := &types.BlockDetail{Block: &types.Block{Height: height, Txs: txs}}
+	for range txs {
+		receipt := &types.ReceiptData{Ty: types.ExecOk}
+		for _, ty := range tys {
+			receipt.Logs = append(receipt.Logs, &types.ReceiptLog{Ty: ty, Log: []byte("log")})
+		}
+		detail.Receipts = append(detail.Receipts, receipt)
+	}
+	for _, kv := range getLogIndex(&executor{height: height}, detail, false) {
+		assert.Nil(t, db.Set(kv.Key, kv.Value))
+	}
+}
+
+func TestGetLogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logindex")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	db := dbm.NewDB("logindex", "leveldb", dir, 16)
+	defer db.Close()
+	priv := util.TestPrivkeyList[0]
+	to, _ := util.Genaddress()
+	for height := int64(1); height <= 3; height++ {
+		txs := []*types.Transaction{util.CreateCoinsTx(priv, to, 1), util.CreateCoinsTx(priv, to, 2)}
+		saveLogIndex(t, db, height, txs, types.TyLogFee, types.TyLogTransfer)
+	}
+	d := &drivers.DriverBase{}
+	d.SetLocalDB(dbm.NewKVDB(db))
+
+	//分页查询所有的日志
+	req := &types.ReqGetLogs{Execer: "coins", FromHeight: 2, ToHeight: -1, Count: 3}
+	var logs []*types.ReceiptLogIndex
+	for {
+		msg, err := d.GetLogs(req)
+		assert.Nil(t, err)
+		reply := msg.(*types.ReplyGetLogs)
+		logs = append(logs, reply.Logs...)
+		if reply.Cursor == "" {
+			break
+		}
+		req.Cursor = reply.Cursor
+	}
+	assert.Equal(t, 8, len(logs))
+	assert.Equal(t, int64(2), logs[0].Height)
+	assert.Equal(t, int32(1), logs[1].LogIndex)
+	assert.Equal(t, int32(1), logs[2].TxIndex)
+	assert.Equal(t, to, logs[0].To)
+
+	//按照类型, 高度和地址过滤
+	msg, err := d.GetLogs(&types.ReqGetLogs{Execer: "coins", LogTypes: []int32{types.TyLogTransfer}, FromHeight: 1, ToHeight: 2, Addrs: []string{to}})
+	assert.Nil(t, err)
+	reply := msg.(*types.ReplyGetLogs)
+	assert.Equal(t, 4, len(reply.Logs))
+	assert.Equal(t, "", reply.Cursor)
+	for _, l := range reply.Logs {
+		assert.Equal(t, int32(types.TyLogTransfer), l.Ty)
+	}
+	msg, err = d.GetLogs(&types.ReqGetLogs{Execer: "coins", FromHeight: 1, ToHeight: -1, Addrs: []string{"notexist"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(msg.(*types.ReplyGetLogs).Logs))
+
+	//类型索引只保存执行器索引的 key
+	detail := &types.BlockDetail{Block: &types.Block{Height: 3, Txs: []*types.Transaction{util.CreateCoinsTx(priv, to, 1)}},
+		Receipts: []*types.ReceiptData{{Logs: []*types.ReceiptLog{{Ty: types.TyLogFee}}}}}
+	kvs := getLogIndex(&executor{height: 3}, detail, false)
+	assert.Equal(t, 2, len(kvs))
+	position := types.LogIndexPosition(3, 0, 0)
+	assert.Equal(t, types.CalcLogIndexKey("coins", position), kvs[0].Key)
+	assert.Equal(t, types.CalcLogIndexTyKey("coins", types.TyLogFee, position), kvs[1].Key)
+	assert.Equal(t, kvs[0].Key, kvs[1].Value)
+
+	//回滚区块删除索引
+	for _, kv := range getLogIndex(&executor{height: 3}, detail, true) {
+		assert.Nil(t, kv.Value)
+	}
+
+	_, err = d.GetLogs(&types.ReqGetLogs{Execer: "coins", FromHeight: 3, ToHeight: 1})
+	assert.Equal(t, types.ErrStartBigThanEnd, err)
+	_, err = d.GetLogs(&types.ReqGetLogs{FromHeight: 1})
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
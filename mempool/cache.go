@@ -5,40 +5,122 @@
 package mempool
 
 import (
-	"container/list"
+	"container/heap"
+	"fmt"
+	"sort"
 
+	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/types"
 )
 
+/*
+交易排序:
+交易按照每千字节的手续费(priority)排序, 打包时优先选择手续费率高的交易,
+同一个账户的交易保持加入 mempool 的先后顺序, 手续费率相同的交易先加入的优先.
+mempool 满了之后, 新的交易手续费率高于 mempool 中最低的交易时, 替换掉手续费率最低的交易.
+同一个账户 nonce 相同的交易, 手续费以及手续费率都更高的替换掉 mempool 中原有的交易, nonce 为 0 的交易不参与替换.
+*/
+
 //--------------------------------------------------------------------------------
 // Module txCache
 
 type txCache struct {
 	size       int
-	txMap      map[string]*list.Element
-	txList     *list.List
+	bytes      int64
+	seq        int64
+	txMap      map[string]*Item
+	txHeap     txHeap
+	nonceMap   map[string]*Item
 	txFrontTen []*types.Transaction
-	accMap     map[string][]*types.Transaction
+	accMap     map[string][]*Item
+	//交易从 cache 中删除之后的回调, 用于记录交易日志
+	onRemove func(hash []byte)
 }
 
 // Item 为Mempool中包装交易的数据结构
 type Item struct {
 	value     *types.Transaction
 	priority  int64
+	size      int64
 	enterTime int64
+	seq       int64
+	index     int
+}
+
+//txPriority 每千字节的手续费, 避免 Fee*1000 溢出
+func txPriority(tx *types.Transaction, size int64) int64 {
+	if size == 0 {
+		return tx.Fee
+	}
+	return tx.Fee/size*1000 + tx.Fee%size*1000/size
+}
+
+//before 打包时 a 是否排在 b 的前面
+func (a *Item) before(b *Item) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.seq < b.seq
+}
+
+//txHeap 按照手续费率排序的最小堆, 堆顶是最先被替换掉的交易
+type txHeap []*Item
+
+func (h txHeap) Len() int           { return len(h) }
+func (h txHeap) Less(i, j int) bool { return h[j].before(h[i]) }
+func (h txHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *txHeap) Push(x interface{}) {
+	item := x.(*Item)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *txHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+//accountHeap 每个账户的交易队列按照队首交易排序
+type accountHeap [][]*Item
+
+func (h accountHeap) Len() int            { return len(h) }
+func (h accountHeap) Less(i, j int) bool  { return h[i][0].before(h[j][0]) }
+func (h accountHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *accountHeap) Push(x interface{}) { *h = append(*h, x.([]*Item)) }
+func (h *accountHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	items := old[n-1]
+	*h = old[:n-1]
+	return items
 }
 
 // NewTxCache初始化txCache
 func newTxCache(cacheSize int64) *txCache {
 	return &txCache{
 		size:       int(cacheSize),
-		txMap:      make(map[string]*list.Element, cacheSize),
-		txList:     list.New(),
+		txMap:      make(map[string]*Item, cacheSize),
+		txHeap:     make(txHeap, 0, cacheSize),
+		nonceMap:   make(map[string]*Item, cacheSize),
 		txFrontTen: make([]*types.Transaction, 0),
-		accMap:     make(map[string][]*types.Transaction),
+		accMap:     make(map[string][]*Item),
 	}
 }
 
+func nonceKey(tx *types.Transaction) string {
+	return fmt.Sprintf("%s:%d", tx.From(), tx.Nonce)
+}
+
 // txCache.TxNumOfAccount返回账户在Mempool中交易数量
 func (cache *txCache) TxNumOfAccount(addr string) int64 {
 	return int64(len(cache.accMap[addr]))
@@ -50,41 +132,53 @@ func (cache *txCache) Exists(hash []byte) bool {
 	return exists
 }
 
-// txCache.Push把给定tx添加到txCache；如果tx已经存在txCache中或Mempool已满则返回对应error
+// txCache.Push把给定tx添加到txCache；如果tx已经存在txCache中, 替换交易的手续费不够或Mempool已满则返回对应error
 func (cache *txCache) Push(tx *types.Transaction) error {
 	hash := tx.Hash()
-	if cache.Exists(hash) {
-		addedItem, ok := cache.txMap[string(hash)].Value.(*Item)
-		if !ok {
-			mlog.Error("mempoolItemError", "item", cache.txMap[string(hash)].Value)
-			return types.ErrTxExist
-		}
-		addedTime := addedItem.enterTime
-		if types.Now().Unix()-addedTime < mempoolDupResendInterval {
+	if addedItem, ok := cache.txMap[string(hash)]; ok {
+		if types.Now().Unix()-addedItem.enterTime < mempoolDupResendInterval {
 			return types.ErrTxExist
 		}
 		// 超过2分钟之后的重发交易返回nil，再次发送给P2P，但是不再次加入mempool
 		// 并修改其enterTime，以避免该交易一直在节点间被重发
-		newEnterTime := types.Now().Unix()
-		resendItem := &Item{value: tx, priority: tx.Fee, enterTime: newEnterTime}
-		newItem := cache.txList.InsertAfter(resendItem, cache.txMap[string(hash)])
-		cache.txList.Remove(cache.txMap[string(hash)])
-		cache.txMap[string(hash)] = newItem
-		// ------------------
+		addedItem.value = tx
+		addedItem.enterTime = types.Now().Unix()
 		return nil
 	}
 
-	if cache.txList.Len() >= cache.size {
-		return types.ErrMemFull
+	size := int64(tx.Size())
+	it := &Item{value: tx, priority: txPriority(tx, size), size: size, enterTime: types.Now().Unix(), seq: cache.seq}
+	// 同一个账户 nonce 相同的交易, 手续费和手续费率都更高的才可以替换, 避免用更大的交易抬高手续费
+	key := nonceKey(tx)
+	if pending, ok := cache.nonceMap[key]; ok {
+		if tx.Fee <= pending.value.Fee || it.priority <= pending.priority {
+			return types.ErrReplaceFeeTooLow
+		}
+		mlog.Info("mempool replace tx", "old", common.ToHex(pending.value.Hash()), "new", common.ToHex(hash),
+			"oldFee", pending.value.Fee, "newFee", tx.Fee, "oldPriority", pending.priority, "newPriority", it.priority)
+		cache.Remove(pending.value.Hash())
+	}
+	if len(cache.txMap) >= cache.size {
+		// 手续费率不高于 mempool 中最低的交易时拒绝
+		lowest := cache.txHeap[0]
+		if !it.before(lowest) {
+			return types.ErrMemFull
+		}
+		mlog.Debug("mempool evict tx", "hash", common.ToHex(lowest.value.Hash()), "priority", lowest.priority, "new", it.priority)
+		cache.Remove(lowest.value.Hash())
 	}
 
-	it := &Item{value: tx, priority: tx.Fee, enterTime: types.Now().Unix()}
-	txElement := cache.txList.PushBack(it)
-	cache.txMap[string(hash)] = txElement
+	cache.seq++
+	cache.bytes += it.size
+	cache.txMap[string(hash)] = it
+	if tx.Nonce != 0 {
+		cache.nonceMap[key] = it
+	}
+	heap.Push(&cache.txHeap, it)
 
 	// 账户交易数量
 	accountAddr := tx.From()
-	cache.accMap[accountAddr] = append(cache.accMap[accountAddr], tx)
+	cache.accMap[accountAddr] = append(cache.accMap[accountAddr], it)
 
 	if len(cache.txFrontTen) >= 10 {
 		cache.txFrontTen = cache.txFrontTen[len(cache.txFrontTen)-9:]
@@ -94,6 +188,67 @@ func (cache *txCache) Push(tx *types.Transaction) error {
 	return nil
 }
 
+// txCache.Walk按照打包的顺序遍历交易, 手续费率高的优先, 同一个账户的交易保持加入的顺序, cb 返回 false 时停止遍历
+func (cache *txCache) Walk(cb func(item *Item) bool) {
+	accounts := make(accountHeap, 0, len(cache.accMap))
+	for _, items := range cache.accMap {
+		accounts = append(accounts, items)
+	}
+	heap.Init(&accounts)
+	for accounts.Len() > 0 {
+		items := accounts[0]
+		if !cb(items[0]) {
+			return
+		}
+		if len(items) == 1 {
+			heap.Pop(&accounts)
+			continue
+		}
+		accounts[0] = items[1:]
+		heap.Fix(&accounts, 0)
+	}
+}
+
+// txCache.GetTx返回给定hash的交易信息, 不存在时返回nil
+func (cache *txCache) GetTx(hash []byte) *types.MempoolTx {
+	item, ok := cache.txMap[string(hash)]
+	if !ok {
+		return nil
+	}
+	return &types.MempoolTx{Tx: item.value, EnterTime: item.enterTime, FeeRate: item.priority, Size: item.size}
+}
+
+// txCache.Stat统计交易的个数, 字节数, 手续费率的分布, 每个执行器的交易个数以及最早加入的交易
+func (cache *txCache) Stat(now int64) *types.MempoolStat {
+	stat := &types.MempoolStat{Size: int64(len(cache.txMap)), Bytes: cache.bytes}
+	for _, min := range mempoolFeeBuckets {
+		stat.FeeHistogram = append(stat.FeeHistogram, &types.MempoolFeeBucket{MinFeeRate: min})
+	}
+	execers := make(map[string]int64)
+	var oldest *Item
+	for _, item := range cache.txMap {
+		i := sort.Search(len(mempoolFeeBuckets), func(i int) bool { return mempoolFeeBuckets[i] > item.priority }) - 1
+		if i < 0 {
+			i = 0
+		}
+		stat.FeeHistogram[i].Count++
+		execers[string(item.value.Execer)]++
+		if oldest == nil || item.enterTime < oldest.enterTime || (item.enterTime == oldest.enterTime && item.seq < oldest.seq) {
+			oldest = item
+		}
+	}
+	for execer, count := range execers {
+		stat.Execers = append(stat.Execers, &types.MempoolExecerCount{Execer: execer, Count: count})
+	}
+	sort.Slice(stat.Execers, func(i, j int) bool { return stat.Execers[i].Execer < stat.Execers[j].Execer })
+	if oldest != nil {
+		stat.OldestHash = oldest.value.Hash()
+		stat.OldestEnterTime = oldest.enterTime
+		stat.OldestAge = now - oldest.enterTime
+	}
+	return stat
+}
+
 // txCache.GetLatestTx返回最新十条加入到txCache的交易
 func (cache *txCache) GetLatestTx() []*types.Transaction {
 	return cache.txFrontTen
@@ -101,14 +256,22 @@ func (cache *txCache) GetLatestTx() []*types.Transaction {
 
 // txCache.Remove移除txCache中给定tx
 func (cache *txCache) Remove(hash []byte) {
-	value := cache.txList.Remove(cache.txMap[string(hash)])
-	delete(cache.txMap, string(hash))
-	// 账户交易数量减1
-	if value == nil {
+	item, ok := cache.txMap[string(hash)]
+	if !ok {
 		return
 	}
-	tx := value.(*Item).value
-	addr := tx.From()
+	delete(cache.txMap, string(hash))
+	cache.bytes -= item.size
+	heap.Remove(&cache.txHeap, item.index)
+	key := nonceKey(item.value)
+	if cache.nonceMap[key] == item {
+		delete(cache.nonceMap, key)
+	}
+	if cache.onRemove != nil {
+		cache.onRemove(hash)
+	}
+	// 账户交易数量减1
+	addr := item.value.From()
 	if cache.TxNumOfAccount(addr) > 0 {
 		cache.AccountTxNumDecrease(addr, hash)
 	}
@@ -116,12 +279,12 @@ func (cache *txCache) Remove(hash []byte) {
 
 // txCache.Size返回txCache中已存tx数目
 func (cache *txCache) Size() int {
-	return cache.txList.Len()
+	return len(cache.txMap)
 }
 
 // txCache.SetSize用来设置Mempool容量
 func (cache *txCache) SetSize(newSize int) {
-	if cache.txList.Len() > 0 {
+	if len(cache.txMap) > 0 {
 		panic("only can set a empty size")
 	}
 	cache.size = newSize
@@ -132,7 +295,8 @@ func (cache *txCache) GetAccTxs(addrs *types.ReqAddrs) *types.TransactionDetails
 	res := &types.TransactionDetails{}
 	for _, addr := range addrs.Addrs {
 		if value, ok := cache.accMap[addr]; ok {
-			for _, v := range value {
+			for _, item := range value {
+				v := item.value
 				txAmount, err := v.Amount()
 				if err != nil {
 					// continue
@@ -155,7 +319,7 @@ func (cache *txCache) GetAccTxs(addrs *types.ReqAddrs) *types.TransactionDetails
 func (cache *txCache) AccountTxNumDecrease(addr string, hash []byte) {
 	if value, ok := cache.accMap[addr]; ok {
 		for i, t := range value {
-			if string(t.Hash()) == string(hash) {
+			if string(t.value.Hash()) == string(hash) {
 				cache.accMap[addr] = append(cache.accMap[addr][:i], cache.accMap[addr][i+1:]...)
 				if len(cache.accMap[addr]) == 0 {
 					delete(cache.accMap, addr)
@@ -99,6 +99,13 @@ func (mem *Mempool) checkTxRemote(msg queue.Message) queue.Message {
 	}
 	errstr := result.Errs[0]
 	if errstr == "" {
+		//重复交易和手续费都检查通过之后再检查准入策略, mempool 中已有的交易由 PushTx 处理, 不占用策略的额度
+		if !mem.exists(txlist.Txs[0].Hash()) {
+			msg = mem.checkPolicy(msg)
+			if msg.Err() != nil {
+				return msg
+			}
+		}
 		err1 := mem.PushTx(txlist.Txs[0])
 		if err1 != nil {
 			mlog.Error("wrong tx", "err", err1)
@@ -18,7 +18,11 @@ var (
 	mempoolDupResendInterval int64 = 120    // mempool重复交易可再次发送间隔，120秒
 	mempoolAddedTxSize             = 102400 // 已添加过的交易缓存大小
 	maxTxNumPerAccount       int64 = 100    // TODO 每个账户在mempool中最大交易数量，10
+	journalMaxSize           int64 = 64     // 交易日志文件的最大值，64M
+	journalCompactInterval   int64 = 3600   // 交易日志压缩的间隔，1小时
 	processNum               int
+	// 统计手续费率分布的区间, 每千字节的手续费
+	mempoolFeeBuckets = []int64{0, 1e5, 2e5, 5e5, 1e6, 2e6, 5e6, 1e7, 1e8}
 )
 
 // TODO
@@ -0,0 +1,469 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+交易日志文件的格式:
+magic | 记录1 | 记录2 | ...
+每条记录为 1 字节类型 + 4 字节长度 + 数据, 加入交易的数据为交易的编码, 删除交易的数据为交易 hash.
+节点重启之后读取日志, 把没有删除的交易重新走一遍检查交易的流程加入 mempool.
+日志只增加不修改, 定期用 mempool 中现有的交易重写日志文件.
+交易从 mempool 中删除(打包, 替换, 满了之后被挤出, 过期等)时都记录删除.
+日志文件超过最大值时通知后台重写, 没有删除的交易超过最大值时拒绝新的交易(ErrJournalFull).
+
+重写在后台进行: 加锁复制 mempool 中的交易之后不加锁写入临时文件, 重写期间追加的记录同时保存在内存中,
+临时文件写完之后再加锁追加这些记录, fsync 之后替换日志文件, 所以加入交易时不会等待重写.
+追加的记录不立即 fsync, 后台每 journalSyncInterval fsync 一次, 节点崩溃时最多丢失这段时间内的记录,
+这些交易重启之后不会重新加入 mempool, 需要重新发送.
+*/
+
+var journalMagic = []byte("C33MPJR1")
+
+//journalSyncInterval 追加记录之后 fsync 的间隔
+var journalSyncInterval = time.Second
+
+const (
+	journalAdd byte = 1
+	journalDel byte = 2
+)
+
+//journalRecord 重写期间追加的记录
+type journalRecord struct {
+	ty   byte
+	hash string
+	data []byte
+}
+
+//txJournal mempool 交易日志
+type txJournal struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	size     int64
+	liveSize int64
+	file     *os.File
+	closed   bool
+	//最近一次 fsync 之后是否有新的记录
+	dirty bool
+	//没有删除的交易以及记录的大小
+	hashes map[string]int64
+	//正在重写日志, 期间追加的记录保存在 pending 中
+	compacting bool
+	pending    []journalRecord
+}
+
+//openTxJournal 打开交易日志, 返回日志中没有删除的交易, 并用这些交易重写日志文件
+func openTxJournal(path string, maxSize int64) (*txJournal, []*types.Transaction, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, err
+	}
+	j := &txJournal{path: path, maxSize: maxSize, hashes: make(map[string]int64)}
+	txs, err := loadTxJournal(path)
+	if err != nil {
+		//日志损坏不影响启动, 丢弃日志中的交易
+		mlog.Error("openTxJournal load", "path", path, "err", err)
+		txs = nil
+	}
+	j.beginCompact()
+	if err = j.compact(txs); err != nil {
+		return nil, nil, err
+	}
+	return j, txs, nil
+}
+
+//loadTxJournal 按照加入的顺序读取日志中没有删除的交易, 最后一条不完整的记录(写入时崩溃)忽略
+func loadTxJournal(path string) ([]*types.Transaction, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	r := bufio.NewReader(file)
+	magic := make([]byte, len(journalMagic))
+	if _, err = io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, journalMagic) {
+		return nil, types.ErrJournalFormat
+	}
+	var txs []*types.Transaction
+	index := make(map[string]int)
+	for {
+		var head [5]byte
+		if _, err = io.ReadFull(r, head[:]); err != nil {
+			break
+		}
+		data := make([]byte, binary.BigEndian.Uint32(head[1:]))
+		if _, err = io.ReadFull(r, data); err != nil {
+			break
+		}
+		switch head[0] {
+		case journalAdd:
+			var tx types.Transaction
+			if err = types.Decode(data, &tx); err != nil {
+				return nil, err
+			}
+			hash := string(tx.Hash())
+			if _, ok := index[hash]; !ok {
+				index[hash] = len(txs)
+				txs = append(txs, &tx)
+			}
+		case journalDel:
+			if i, ok := index[string(data)]; ok {
+				txs[i] = nil
+				delete(index, string(data))
+			}
+		default:
+			return nil, types.ErrJournalFormat
+		}
+	}
+	var result []*types.Transaction
+	for _, tx := range txs {
+		if tx != nil {
+			result = append(result, tx)
+		}
+	}
+	return result, nil
+}
+
+func writeJournalRecord(w io.Writer, ty byte, data []byte) (int64, error) {
+	var head [5]byte
+	head[0] = ty
+	binary.BigEndian.PutUint32(head[1:], uint32(len(data)))
+	if _, err := w.Write(append(head[:], data...)); err != nil {
+		return 0, err
+	}
+	return int64(len(head) + len(data)), nil
+}
+
+func (j *txJournal) write(ty byte, hash string, data []byte) error {
+	n, err := writeJournalRecord(j.file, ty, data)
+	if err != nil {
+		return err
+	}
+	j.size += n
+	j.dirty = true
+	if j.compacting {
+		j.pending = append(j.pending, journalRecord{ty: ty, hash: hash, data: data})
+	}
+	return nil
+}
+
+//insert 记录加入 mempool 的交易, 没有删除的交易超过最大值时返回 ErrJournalFull
+func (j *txJournal) insert(tx *types.Transaction) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	hash := string(tx.Hash())
+	if _, ok := j.hashes[hash]; j.file == nil || ok {
+		return nil
+	}
+	data := types.Encode(tx)
+	record := int64(5 + len(data))
+	if int64(len(journalMagic))+j.liveSize+record > j.maxSize {
+		mlog.Debug("txJournal full", "size", j.liveSize, "hash", common.ToHex(tx.Hash()))
+		return types.ErrJournalFull
+	}
+	if err := j.write(journalAdd, hash, data); err != nil {
+		mlog.Error("txJournal insert", "err", err)
+		return nil
+	}
+	j.hashes[hash] = record
+	j.liveSize += record
+	return nil
+}
+
+//needCompact 日志文件超过最大值并且没有在重写
+func (j *txJournal) needCompact() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.size > j.maxSize && !j.compacting
+}
+
+//has 交易是否在日志中并且没有删除
+func (j *txJournal) has(hash []byte) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, ok := j.hashes[string(hash)]
+	return ok
+}
+
+//remove 记录从 mempool 删除的交易
+func (j *txJournal) remove(hash []byte) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	record, ok := j.hashes[string(hash)]
+	if j.file == nil || !ok {
+		return
+	}
+	if err := j.write(journalDel, string(hash), hash); err != nil {
+		mlog.Error("txJournal remove", "err", err)
+		return
+	}
+	delete(j.hashes, string(hash))
+	j.liveSize -= record
+}
+
+//beginCompact 开始重写日志, 之后追加的记录同时保存在 pending 中, 已经在重写或者已经关闭时返回 false
+func (j *txJournal) beginCompact() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.compacting || j.closed {
+		return false
+	}
+	j.compacting = true
+	j.pending = nil
+	return true
+}
+
+func (j *txJournal) endCompact() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.compacting = false
+	j.pending = nil
+}
+
+//compact 用 beginCompact 时的交易 txs 重写日志文件, 超过最大值的交易不再写入.
+//先不加锁写入临时文件并且 fsync, 然后加锁追加重写期间的记录, 再次 fsync 之后替换日志文件
+func (j *txJournal) compact(txs []*types.Transaction) error {
+	defer j.endCompact()
+	tmp := j.path + ".new"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+	size := int64(len(journalMagic))
+	hashes := make(map[string]int64)
+	w.Write(journalMagic)
+	for _, tx := range txs {
+		data := types.Encode(tx)
+		if size+int64(5+len(data)) > j.maxSize {
+			break
+		}
+		n, err := writeJournalRecord(w, journalAdd, data)
+		if err != nil {
+			return err
+		}
+		size += n
+		hashes[string(tx.Hash())] = n
+	}
+	//先 fsync 大部分的数据, 持有锁的时候只需要 fsync 重写期间追加的记录
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closed {
+		os.Remove(tmp)
+		return nil
+	}
+	for _, record := range j.pending {
+		_, ok := hashes[record.hash]
+		if record.ty == journalDel && !ok {
+			continue
+		}
+		n, err := writeJournalRecord(w, record.ty, record.data)
+		if err != nil {
+			return err
+		}
+		size += n
+		if record.ty == journalDel {
+			delete(hashes, record.hash)
+		} else if !ok {
+			hashes[record.hash] = n
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+	if j.file != nil {
+		j.file.Close()
+	}
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		j.file = nil
+		return err
+	}
+	j.size = size
+	j.liveSize = 0
+	for _, n := range hashes {
+		j.liveSize += n
+	}
+	j.hashes = hashes
+	j.dirty = false
+	mlog.Info("txJournal compact", "path", j.path, "txs", len(hashes), "pending", len(j.pending), "size", size)
+	return nil
+}
+
+//sync fsync 追加的记录, 不持有锁, 这期间重写完成关闭了旧的文件时忽略错误
+func (j *txJournal) sync() error {
+	j.mu.Lock()
+	file := j.file
+	dirty := j.dirty
+	j.dirty = false
+	j.mu.Unlock()
+	if file == nil || !dirty {
+		return nil
+	}
+	if err := file.Sync(); err != nil && !isClosedFileErr(err) {
+		return err
+	}
+	return nil
+}
+
+func isClosedFileErr(err error) bool {
+	if e, ok := err.(*os.PathError); ok {
+		err = e.Err
+	}
+	return err == os.ErrClosed
+}
+
+func (j *txJournal) close() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.closed = true
+	if j.file != nil {
+		j.file.Sync()
+		j.file.Close()
+		j.file = nil
+	}
+}
+
+//replayJournal 同步完成之后把日志中的交易重新走一遍检查交易的流程加入 mempool, 过期以及检查失败的交易从日志中删除
+func (mem *Mempool) replayJournal(txs []*types.Transaction) {
+	defer mem.wg.Done()
+	for !mem.isSync() || mem.GetHeader() == nil {
+		if mem.isClose() {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	var added, dropped int
+	for _, tx := range txs {
+		if mem.isClose() {
+			return
+		}
+		mem.proxyMtx.Lock()
+		valid := mem.checkExpireValid(tx)
+		mem.proxyMtx.Unlock()
+		if valid {
+			msg := mem.client.NewMessage("mempool", types.EventTx, tx)
+			if err := mem.client.Send(msg, true); err != nil {
+				return
+			}
+			resp, err := mem.client.Wait(msg)
+			if err != nil {
+				return
+			}
+			if reply := resp.GetData().(*types.Reply); reply.GetIsOk() {
+				added++
+				continue
+			}
+		}
+		dropped++
+		mem.journal.remove(tx.Hash())
+	}
+	mem.proxyMtx.Lock()
+	mem.journalTxs = nil
+	mem.proxyMtx.Unlock()
+	mlog.Info("replayJournal", "added", added, "dropped", dropped)
+}
+
+//compactJournal 用 mempool 中现有的交易按照打包的顺序重写日志, 还没有重新加入 mempool 的日志中的交易保留在最后,
+//只在复制交易的时候持有 proxyMtx, 写文件的时候不影响交易的加入
+func (mem *Mempool) compactJournal() error {
+	mem.proxyMtx.Lock()
+	if !mem.journal.beginCompact() {
+		mem.proxyMtx.Unlock()
+		return nil
+	}
+	var txs []*types.Transaction
+	mem.cache.Walk(func(item *Item) bool {
+		txs = append(txs, item.value)
+		return true
+	})
+	for _, tx := range mem.journalTxs {
+		hash := tx.Hash()
+		if !mem.cache.Exists(hash) && mem.journal.has(hash) {
+			txs = append(txs, tx)
+		}
+	}
+	mem.proxyMtx.Unlock()
+	return mem.journal.compact(txs)
+}
+
+//journalInsert 记录加入 mempool 的交易, 日志文件满了之后通知后台重写日志, 日志记录不下时从 mempool 中删除这个交易
+func (mem *Mempool) journalInsert(tx *types.Transaction) error {
+	err := mem.journal.insert(tx)
+	if err != nil {
+		mem.cache.Remove(tx.Hash())
+		return err
+	}
+	if mem.journal.needCompact() {
+		select {
+		case mem.journalCompact <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (mem *Mempool) compactJournalLoop() {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(time.Duration(journalCompactInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-mem.journalCompact:
+		case <-mem.done:
+			return
+		}
+		if err := mem.compactJournal(); err != nil {
+			mlog.Error("compactJournal", "err", err)
+		}
+	}
+}
+
+func (mem *Mempool) syncJournalLoop() {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(journalSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := mem.journal.sync(); err != nil {
+				mlog.Error("syncJournal", "err", err)
+			}
+		case <-mem.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initJournalEnv(path string) (queue.Queue, *Mempool) {
+	var q = queue.New("channel")
+	cfg, _ := types.InitCfg("../cmd/chain33/chain33.test.toml")
+	cfg.MemPool.JournalFile = path
+	blockchainProcess(q)
+	execProcess(q)
+	mem := New(cfg.MemPool)
+	mem.SetQueueClient(q.Client())
+	mem.setSync(true)
+	mem.SetMinFee(types.GInt("MinFee"))
+	mem.WaitPollLastHeader()
+	return q, mem
+}
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	q, mem := initJournalEnv(path)
+	require.Nil(t, add4Tx(mem.client))
+	require.Nil(t, mem.RemoveTxs(&types.TxHashList{Hashes: [][]byte{tx2.Hash()}}))
+	mem.Close()
+	q.Close()
+
+	txs, err := loadTxJournal(path)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(txs))
+	assert.Equal(t, tx1.Hash(), txs[0].Hash())
+	assert.Equal(t, tx4.Hash(), txs[2].Hash())
+
+	//过期的交易重启之后丢弃
+	_, priv := genaddress()
+	expired := createTx(priv, toAddr, amount)
+	expired.Expire = types.Now().Unix() - 10
+	expired.Sign(types.SECP256K1, priv)
+	journal, _, err := openTxJournal(path, journalMaxSize*1024*1024)
+	require.Nil(t, err)
+	journal.insert(expired)
+	journal.close()
+
+	q, mem = initJournalEnv(path)
+	defer q.Close()
+	defer mem.Close()
+	for i := 0; i < 50 && mem.Size() < 3; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	assert.Equal(t, 3, mem.Size())
+	assert.True(t, mem.cache.Exists(tx3.Hash()))
+	assert.False(t, mem.cache.Exists(tx2.Hash()))
+	assert.False(t, mem.cache.Exists(expired.Hash()))
+
+	require.Nil(t, mem.compactJournal())
+	txs, err = loadTxJournal(path)
+	require.Nil(t, err)
+	assert.Equal(t, 3, len(txs))
+}
+
+func TestJournalMaxSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	//只能保存一个交易
+	maxSize := int64(len(journalMagic)+len(types.Encode(tx2))) + 10
+	journal, txs, err := openTxJournal(path, maxSize)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(txs))
+	assert.Nil(t, journal.insert(tx2))
+	assert.Equal(t, types.ErrJournalFull, journal.insert(tx3))
+	journal.remove(tx3.Hash())
+	journal.close()
+	txs, err = loadTxJournal(path)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(txs))
+	assert.Equal(t, tx2.Hash(), txs[0].Hash())
+
+	//删除之后日志文件超过最大值, 仍然记录并且需要重写
+	journal, txs, err = openTxJournal(path, maxSize)
+	require.Nil(t, err)
+	journal.remove(tx2.Hash())
+	assert.Nil(t, journal.insert(tx3))
+	assert.True(t, journal.needCompact())
+	require.True(t, journal.beginCompact())
+	assert.False(t, journal.needCompact())
+	require.Nil(t, journal.compact([]*types.Transaction{tx3}))
+	assert.False(t, journal.needCompact())
+	journal.close()
+	txs, err = loadTxJournal(path)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(txs))
+	assert.Equal(t, tx3.Hash(), txs[0].Hash())
+
+	//日志格式错误的时候丢弃日志中的交易
+	require.Nil(t, ioutil.WriteFile(path, []byte("bad journal"), 0644))
+	_, err = loadTxJournal(path)
+	assert.Equal(t, types.ErrJournalFormat, err)
+	journal, txs, err = openTxJournal(path, maxSize)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(txs))
+	journal.close()
+}
+
+func TestJournalRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	q, mem := initJournalEnv(path)
+	_, priv := genaddress()
+	pending := createTx(priv, toAddr, amount)
+	replace := createTx(priv, toAddr, amount)
+	replace.Nonce = pending.Nonce
+	replace.Fee = pending.Fee * 2
+	replace.Sign(types.SECP256K1, priv)
+	stale := createTx(priv, toAddr, amount+1)
+	require.Nil(t, sendTx(mem.client, pending))
+	require.Nil(t, sendTx(mem.client, replace))
+	require.Nil(t, sendTx(mem.client, stale))
+
+	//滞留时间过长的交易清理之后从日志中删除
+	mem.proxyMtx.Lock()
+	mem.cache.txMap[string(stale.Hash())].enterTime = 0
+	mem.proxyMtx.Unlock()
+	mem.RemoveExpiredAndDuplicateMempoolTxs()
+	mem.Close()
+	q.Close()
+
+	//被替换的交易也从日志中删除
+	txs, err := loadTxJournal(path)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(txs))
+	assert.Equal(t, replace.Hash(), txs[0].Hash())
+}
+
+func TestJournalCompactPending(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mempool")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.journal")
+
+	journal, _, err := openTxJournal(path, journalMaxSize*1024*1024)
+	require.Nil(t, err)
+	require.Nil(t, journal.insert(tx1))
+	require.Nil(t, journal.insert(tx2))
+
+	//重写期间追加的记录在重写完成之后也在新的日志中
+	require.True(t, journal.beginCompact())
+	assert.False(t, journal.beginCompact())
+	require.Nil(t, journal.insert(tx3))
+	journal.remove(tx1.Hash())
+	require.Nil(t, journal.compact([]*types.Transaction{tx1, tx2}))
+	assert.False(t, journal.has(tx1.Hash()))
+	assert.True(t, journal.has(tx3.Hash()))
+	require.Nil(t, journal.insert(tx4))
+	require.Nil(t, journal.sync())
+	journal.close()
+
+	txs, err := loadTxJournal(path)
+	require.Nil(t, err)
+	require.Equal(t, 3, len(txs))
+	assert.Equal(t, tx2.Hash(), txs[0].Hash())
+	assert.Equal(t, tx3.Hash(), txs[1].Hash())
+	assert.Equal(t, tx4.Hash(), txs[2].Hash())
+
+	//关闭之后不再重写
+	assert.False(t, journal.beginCompact())
+}
@@ -45,6 +45,10 @@ type Mempool struct {
 	wg                sync.WaitGroup
 	done              chan struct{}
 	removeBlockTicket *time.Ticker
+	journal           *txJournal
+	journalTxs        []*types.Transaction
+	journalCompact    chan struct{}
+	policies          []*namedPolicy
 }
 
 // New new mempool
@@ -59,7 +63,20 @@ func New(cfg *types.MemPool) *Mempool {
 	pool.addedTxs, _ = lru.New(mempoolAddedTxSize)
 	pool.cfg = cfg
 	pool.poolHeader = make(chan struct{}, 2)
+	pool.journalCompact = make(chan struct{}, 1)
 	pool.removeBlockTicket = time.NewTicker(time.Minute)
+	pool.policies = newPolicies(cfg)
+	if cfg.JournalFile != "" {
+		journal, txs, err := openTxJournal(cfg.JournalFile, journalMaxSize*1024*1024)
+		if err != nil {
+			mlog.Error("open mempool journal", "path", cfg.JournalFile, "err", err)
+		} else {
+			pool.journal = journal
+			pool.journalTxs = txs
+			//所有从 cache 中删除的交易都记录到日志中
+			pool.cache.onRemove = journal.remove
+		}
+	}
 	return pool
 }
 
@@ -70,6 +87,12 @@ func initConfig(cfg *types.MemPool) {
 	if cfg.MaxTxNumPerAccount > 0 {
 		maxTxNumPerAccount = cfg.MaxTxNumPerAccount
 	}
+	if cfg.JournalMaxSize > 0 {
+		journalMaxSize = cfg.JournalMaxSize
+	}
+	if cfg.JournalCompactInterval > 0 {
+		journalCompactInterval = cfg.JournalCompactInterval
+	}
 }
 
 // Resize 设置Mempool容量
@@ -131,7 +154,7 @@ func (mem *Mempool) TxNumOfAccount(addr string) int64 {
 	return mem.cache.TxNumOfAccount(addr)
 }
 
-// GetTxList 从txCache中返回给定数目的tx
+// GetTxList 从txCache中按照手续费率从高到低返回给定数目的tx
 func (mem *Mempool) GetTxList(hashList *types.TxHashList) []*types.Transaction {
 	mem.proxyMtx.Lock()
 	defer mem.proxyMtx.Unlock()
@@ -142,22 +165,18 @@ func (mem *Mempool) GetTxList(hashList *types.TxHashList) []*types.Transaction {
 	}
 	var result []*types.Transaction
 	i := 0
-	txlist := mem.cache.txList
-	for v := txlist.Front(); v != nil; v = v.Next() {
-		if v.Value.(*Item).value.IsExpire(mem.header.GetHeight(), mem.header.GetBlockTime()) {
-			continue
-		} else {
-			tx := v.Value.(*Item).value
-			if _, ok := dupMap[string(tx.Hash())]; ok {
-				continue
-			}
-			result = append(result, tx)
-			i++
-			if i == int(minSize) {
-				break
-			}
+	mem.cache.Walk(func(item *Item) bool {
+		if item.value.IsExpire(mem.header.GetHeight(), mem.header.GetBlockTime()) {
+			return true
 		}
-	}
+		tx := item.value
+		if _, ok := dupMap[string(tx.Hash())]; ok {
+			return true
+		}
+		result = append(result, tx)
+		i++
+		return i != int(minSize)
+	})
 	return result
 }
 
@@ -167,8 +186,7 @@ func (mem *Mempool) RemoveExpiredAndDuplicateMempoolTxs() []*types.Transaction {
 	defer mem.proxyMtx.Unlock()
 
 	var result []*types.Transaction
-	for _, v := range mem.cache.txMap {
-		item := v.Value.(*Item)
+	for _, item := range mem.cache.txMap {
 		hash := item.value.Hash()
 		if types.Now().Unix()-item.enterTime >= mempoolExpiredInterval {
 			// 清理滞留Mempool中超过10分钟的交易
@@ -193,6 +211,9 @@ func (mem *Mempool) RemoveTxsOfBlock(block *types.Block) bool {
 		exist := mem.cache.Exists(hash)
 		if exist {
 			mem.cache.Remove(hash)
+		} else if mem.journal != nil {
+			//还没有重新加入 mempool 的日志中的交易
+			mem.journal.remove(hash)
 		}
 	}
 	return true
@@ -206,6 +227,8 @@ func (mem *Mempool) RemoveTxs(hashList *types.TxHashList) error {
 		exist := mem.cache.Exists(hash)
 		if exist {
 			mem.cache.Remove(hash)
+		} else if mem.journal != nil {
+			mem.journal.remove(hash)
 		}
 	}
 	return nil
@@ -243,11 +266,21 @@ func (mem *Mempool) DelBlock(block *types.Block) {
 	}
 }
 
+// exists 交易是否已经在 mempool 中
+func (mem *Mempool) exists(hash []byte) bool {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	return mem.cache.Exists(hash)
+}
+
 // PushTx 将交易推入Mempool，并返回结果（error）
 func (mem *Mempool) PushTx(tx *types.Transaction) error {
 	mem.proxyMtx.Lock()
 	defer mem.proxyMtx.Unlock()
 	err := mem.cache.Push(tx)
+	if err == nil && mem.journal != nil {
+		err = mem.journalInsert(tx)
+	}
 	return err
 }
 
@@ -271,8 +304,8 @@ func (mem *Mempool) ReTry() {
 	var result []*types.Transaction
 	mem.proxyMtx.Lock()
 	for _, v := range mem.cache.txMap {
-		if types.Now().Unix()-v.Value.(*Item).enterTime >= mempoolReSendInterval {
-			result = append(result, v.Value.(*Item).value)
+		if types.Now().Unix()-v.enterTime >= mempoolReSendInterval {
+			result = append(result, v.value)
 		}
 	}
 	mem.proxyMtx.Unlock()
@@ -331,10 +364,9 @@ func (mem *Mempool) RemoveBlockedTxs() {
 
 			mem.proxyMtx.Lock()
 			for _, t := range dupTxs {
-				txValue, exists := mem.cache.txMap[string(t)]
-				if exists {
+				if mem.cache.Exists(t) {
 					mem.addedTxs.Add(string(t), nil)
-					mem.cache.Remove(txValue.Value.(*Item).value.Hash())
+					mem.cache.Remove(t)
 				}
 			}
 			mem.proxyMtx.Unlock()
@@ -373,6 +405,49 @@ func (mem *Mempool) GetAccTxs(addrs *types.ReqAddrs) *types.TransactionDetails {
 	return mem.cache.GetAccTxs(addrs)
 }
 
+// GetMempoolTx 根据交易hash获取Mempool中的交易以及加入的时间, 手续费率
+func (mem *Mempool) GetMempoolTx(hash []byte) (*types.MempoolTx, error) {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	tx := mem.cache.GetTx(hash)
+	if tx == nil {
+		return nil, types.ErrTxNotExist
+	}
+	return tx, nil
+}
+
+// GetMempoolTxs 根据hash批量获取Mempool中的交易, 不在Mempool中的交易忽略
+func (mem *Mempool) GetMempoolTxs(hashes [][]byte) []*types.Transaction {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	var txs []*types.Transaction
+	for _, hash := range hashes {
+		if item, ok := mem.cache.txMap[string(hash)]; ok {
+			txs = append(txs, item.value)
+		}
+	}
+	return txs
+}
+
+// EvictTx 从Mempool中删除给定的交易, 用于处理长时间不能打包的交易
+func (mem *Mempool) EvictTx(hash []byte) error {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	if !mem.cache.Exists(hash) {
+		return types.ErrTxNotExist
+	}
+	mem.cache.Remove(hash)
+	mlog.Info("mempool evict tx", "hash", common.ToHex(hash))
+	return nil
+}
+
+// GetMempoolStat 获取Mempool的统计信息
+func (mem *Mempool) GetMempoolStat() *types.MempoolStat {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+	return mem.cache.Stat(types.Now().Unix())
+}
+
 // SendTxToP2P 向"p2p"发送消息
 func (mem *Mempool) SendTxToP2P(tx *types.Transaction) {
 	if mem.client == nil {
@@ -419,6 +494,9 @@ func (mem *Mempool) Close() {
 	mem.removeBlockTicket.Stop()
 	mlog.Info("mempool module closing")
 	mem.wg.Wait()
+	if mem.journal != nil {
+		mem.journal.close()
+	}
 	mlog.Info("mempool module closed")
 }
 
@@ -598,6 +676,12 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 	}()
 	mem.wg.Add(1)
 	go mem.RemoveBlockedTxs()
+	if mem.journal != nil {
+		mem.wg.Add(3)
+		go mem.replayJournal(mem.journalTxs)
+		go mem.compactJournalLoop()
+		go mem.syncJournalLoop()
+	}
 	mem.wg.Add(1)
 	go func() {
 		defer mlog.Info("mempool message recv quit")
@@ -681,6 +765,25 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 				addrs := msg.GetData().(*types.ReqAddrs)
 				txlist := mem.GetAccTxs(addrs)
 				msg.Reply(mem.client.NewMessage("", types.EventReplyAddrTxs, txlist))
+			case types.EventGetMempoolTx:
+				// 根据hash获取Mempool中的交易
+				tx, err := mem.GetMempoolTx(msg.GetData().(*types.ReqHash).Hash)
+				if err != nil {
+					msg.Reply(mem.client.NewMessage("", types.EventReplyMempoolTx, err))
+				} else {
+					msg.Reply(mem.client.NewMessage("", types.EventReplyMempoolTx, tx))
+				}
+			case types.EventGetMempoolTxs:
+				// 根据hash批量获取Mempool中的交易
+				txs := mem.GetMempoolTxs(msg.GetData().(*types.ReqHashes).Hashes)
+				msg.Reply(mem.client.NewMessage("", types.EventReplyTxList, &types.ReplyTxList{Txs: txs}))
+			case types.EventEvictMempoolTx:
+				// 从Mempool中删除交易
+				err := mem.EvictTx(msg.GetData().(*types.ReqHash).Hash)
+				msg.ReplyErr("EventEvictMempoolTx", err)
+			case types.EventGetMempoolStat:
+				// 获取Mempool的统计信息
+				msg.Reply(mem.client.NewMessage("", types.EventReplyMempoolStat, mem.GetMempoolStat()))
 			default:
 			}
 			mlog.Debug("mempool", "cost", types.Since(beg), "msg", types.GetEventName(int(msg.Ty)))
@@ -467,13 +467,206 @@ func TestAddMoreTxThanPoolSize(t *testing.T) {
 		return
 	}
 
+	//手续费率更高的交易替换掉手续费率最低的交易
 	msg5 := mem.client.NewMessage("mempool", types.EventTx, tx5)
 	mem.client.Send(msg5, true)
 	mem.client.Wait(msg5)
 
-	if mem.Size() != 4 || mem.cache.Exists(tx5.Hash()) {
+	if mem.Size() != 4 || !mem.cache.Exists(tx5.Hash()) || mem.cache.Exists(tx1.Hash()) {
 		t.Error("TestAddMoreTxThanPoolSize failed", mem.Size(), mem.cache.Exists(tx5.Hash()))
 	}
+
+	_, priv := genaddress()
+	msg6 := mem.client.NewMessage("mempool", types.EventTx, createTx(priv, toAddr, amount))
+	mem.client.Send(msg6, true)
+	resp, _ := mem.client.Wait(msg6)
+	if string(resp.GetData().(*types.Reply).GetMsg()) != types.ErrMemFull.Error() || mem.Size() != 4 {
+		t.Error("TestAddMoreTxThanPoolSize failed", string(resp.GetData().(*types.Reply).GetMsg()), mem.Size())
+	}
+}
+
+func sendTx(client queue.Client, tx *types.Transaction) error {
+	msg := client.NewMessage("mempool", types.EventTx, tx)
+	client.Send(msg, true)
+	resp, err := client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	return checkReply(resp.GetData().(*types.Reply))
+}
+
+func TestReplaceByFee(t *testing.T) {
+	q, mem := initEnv(0)
+	defer q.Close()
+	defer mem.Close()
+
+	_, priv := genaddress()
+	pending := createTx(priv, toAddr, amount)
+	replace := createTx(priv, toAddr, amount)
+	replace.Nonce = pending.Nonce
+	replace.Fee = pending.Fee * 2
+	replace.Sign(types.SECP256K1, priv)
+	lowFee := createTx(priv, toAddr, amount+1)
+	lowFee.Nonce = pending.Nonce
+	lowFee.Fee = replace.Fee
+	lowFee.Sign(types.SECP256K1, priv)
+	//手续费更高, 但是交易更大, 手续费率更低
+	v := &cty.CoinsAction_Transfer{Transfer: &types.AssetsTransfer{Amount: amount, Note: make([]byte, 4096)}}
+	lowRate := &types.Transaction{Execer: []byte("coins"), Payload: types.Encode(&cty.CoinsAction{Value: v, Ty: cty.CoinsActionTransfer}),
+		Fee: replace.Fee + 1, Nonce: pending.Nonce, To: toAddr}
+	lowRate.Sign(types.SECP256K1, priv)
+
+	if err := sendTx(mem.client, pending); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := sendTx(mem.client, replace); err != nil {
+		t.Error(err)
+		return
+	}
+	if mem.Size() != 1 || !mem.cache.Exists(replace.Hash()) || mem.cache.Exists(pending.Hash()) {
+		t.Error("TestReplaceByFee failed", mem.Size())
+	}
+	//手续费没有提高的交易不能替换
+	err := sendTx(mem.client, lowFee)
+	if err == nil || err.Error() != types.ErrReplaceFeeTooLow.Error() || mem.Size() != 1 {
+		t.Error("TestReplaceByFee failed", err, mem.Size())
+	}
+	//手续费率没有提高的交易不能替换
+	err = sendTx(mem.client, lowRate)
+	if err == nil || err.Error() != types.ErrReplaceFeeTooLow.Error() || !mem.cache.Exists(replace.Hash()) {
+		t.Error("TestReplaceByFee failed", err, mem.Size())
+	}
+	//nonce 为 0 的交易不参与替换
+	noNonce := createTx(priv, toAddr, amount)
+	noNonce.Nonce = 0
+	noNonce.Sign(types.SECP256K1, priv)
+	noNonce2 := createTx(priv, toAddr, amount+1)
+	noNonce2.Nonce = 0
+	noNonce2.Fee = noNonce.Fee * 2
+	noNonce2.Sign(types.SECP256K1, priv)
+	if err = sendTx(mem.client, noNonce); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = sendTx(mem.client, noNonce2); err != nil {
+		t.Error(err)
+		return
+	}
+	if mem.Size() != 3 {
+		t.Error("TestReplaceByFee failed", mem.Size())
+	}
+}
+
+func TestMempoolQuery(t *testing.T) {
+	q, mem := initEnv(0)
+	defer q.Close()
+	defer mem.Close()
+
+	err := add4Tx(mem.client)
+	if err != nil {
+		t.Error("add tx error", err.Error())
+		return
+	}
+	msg := mem.client.NewMessage("mempool", types.EventGetMempoolTx, &types.ReqHash{Hash: tx2.Hash()})
+	mem.client.Send(msg, true)
+	resp, err := mem.client.Wait(msg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	mtx := resp.GetData().(*types.MempoolTx)
+	if string(mtx.Tx.Hash()) != string(tx2.Hash()) || mtx.Size != int64(tx2.Size()) || mtx.FeeRate != tx2.Fee*1000/int64(tx2.Size()) {
+		t.Error("TestMempoolQuery failed", mtx.Size, mtx.FeeRate)
+	}
+
+	msg = mem.client.NewMessage("mempool", types.EventGetMempoolTxs, &types.ReqHashes{Hashes: [][]byte{tx2.Hash(), tx5.Hash(), tx3.Hash()}})
+	mem.client.Send(msg, true)
+	resp, err = mem.client.Wait(msg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	txs := resp.GetData().(*types.ReplyTxList).Txs
+	if len(txs) != 2 || string(txs[0].Hash()) != string(tx2.Hash()) || string(txs[1].Hash()) != string(tx3.Hash()) {
+		t.Error("TestMempoolQuery batch failed", len(txs))
+	}
+
+	msg = mem.client.NewMessage("mempool", types.EventGetMempoolStat, nil)
+	mem.client.Send(msg, true)
+	resp, err = mem.client.Wait(msg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	stat := resp.GetData().(*types.MempoolStat)
+	var count int64
+	for _, bucket := range stat.FeeHistogram {
+		count += bucket.Count
+	}
+	if stat.Size != 4 || count != 4 || len(stat.Execers) != 1 || stat.Execers[0].Count != 4 || string(stat.OldestHash) != string(tx1.Hash()) {
+		t.Error("TestMempoolQuery failed", stat)
+	}
+	var bytes int64
+	for _, tx := range []*types.Transaction{tx1, tx2, tx3, tx4} {
+		bytes += int64(tx.Size())
+	}
+	if stat.Bytes != bytes {
+		t.Error("TestMempoolQuery failed", stat.Bytes, bytes)
+	}
+
+	msg = mem.client.NewMessage("mempool", types.EventEvictMempoolTx, &types.ReqHash{Hash: tx2.Hash()})
+	mem.client.Send(msg, true)
+	resp, err = mem.client.Wait(msg)
+	if err != nil || !resp.GetData().(*types.Reply).IsOk || mem.Size() != 3 || mem.cache.Exists(tx2.Hash()) {
+		t.Error("TestMempoolQuery evict failed", err, mem.Size())
+	}
+	if err = mem.EvictTx(tx2.Hash()); err != types.ErrTxNotExist {
+		t.Error("TestMempoolQuery evict failed", err)
+	}
+	if _, err = mem.GetMempoolTx(tx2.Hash()); err != types.ErrTxNotExist {
+		t.Error("TestMempoolQuery failed", err)
+	}
+}
+
+func TestGetTxListByFee(t *testing.T) {
+	q, mem := initEnv(0)
+	defer q.Close()
+	defer mem.Close()
+
+	var txs []*types.Transaction
+	for _, fee := range []int64{1e6, 3e6, 2e6, 4e6} {
+		_, priv := genaddress()
+		tx := createTx(priv, toAddr, amount)
+		tx.Fee = fee
+		tx.Sign(types.SECP256K1, priv)
+		txs = append(txs, tx)
+	}
+	//同一个账户的交易保持加入的顺序
+	_, priv := genaddress()
+	for _, fee := range []int64{5e5, 5e6} {
+		tx := createTx(priv, toAddr, amount)
+		tx.Fee = fee
+		tx.Sign(types.SECP256K1, priv)
+		txs = append(txs, tx)
+	}
+	for _, tx := range txs {
+		if err := sendTx(mem.client, tx); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	expect := []*types.Transaction{txs[3], txs[1], txs[2], txs[0], txs[4], txs[5]}
+	list := mem.GetTxList(&types.TxHashList{Count: 10})
+	if len(list) != len(expect) {
+		t.Error("TestGetTxListByFee failed", len(list))
+		return
+	}
+	for i := range list {
+		if string(list[i].Hash()) != string(expect[i].Hash()) {
+			t.Error("TestGetTxListByFee failed", "index", i, "fee", list[i].Fee)
+		}
+	}
 }
 
 func TestRemoveTxOfBlock(t *testing.T) {
@@ -0,0 +1,327 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+/*
+交易准入策略:
+交易通过签名, 重复交易以及执行器(手续费, 余额等)的检查之后, 加入 mempool 之前依次经过所有启用的准入策略, 任何一个策略拒绝都不会加入 mempool,
+策略返回的错误通过 SendTransaction 返回给调用者, 错误需要注册错误码(types.RegisterErrCode).
+执行器在 init 中调用 RegisterPolicy 注册自己的策略, mempool 创建时根据配置决定是否启用.
+交易组中的每笔交易都要检查, 同一个策略会被多个 goroutine 同时调用.
+*/
+
+// AdmissionPolicy 交易进入 mempool 的准入策略
+type AdmissionPolicy interface {
+	// Admit 返回错误表示拒绝交易
+	Admit(ctx *PolicyContext, tx *types.Transaction) error
+}
+
+// PolicyCreate 根据 mempool 的配置创建准入策略, 返回 nil 表示不启用
+type PolicyCreate func(cfg *types.MemPool) AdmissionPolicy
+
+// PolicyContext 准入检查时 mempool 的状态以及交易(组)的手续费和大小
+type PolicyContext struct {
+	Header *types.Header
+	Client queue.Client
+	Fee    int64
+	Size   int
+}
+
+var policyCreates = make(map[string]PolicyCreate)
+
+// RegisterPolicy 注册准入策略, 名字不能重复
+func RegisterPolicy(name string, create PolicyCreate) {
+	if create == nil {
+		panic("mempool: register policy create is nil " + name)
+	}
+	if _, ok := policyCreates[name]; ok {
+		panic("mempool: register policy duplicate " + name)
+	}
+	policyCreates[name] = create
+}
+
+type namedPolicy struct {
+	name   string
+	policy AdmissionPolicy
+}
+
+// newPolicies 按照名字的顺序创建启用的准入策略
+func newPolicies(cfg *types.MemPool) []*namedPolicy {
+	var names []string
+	for name := range policyCreates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var policies []*namedPolicy
+	for _, name := range names {
+		if policy := policyCreates[name](cfg); policy != nil {
+			mlog.Info("mempool admission policy enabled", "name", name)
+			policies = append(policies, &namedPolicy{name: name, policy: policy})
+		}
+	}
+	return policies
+}
+
+// Mempool.checkPolicy 检查交易(组)中的每笔交易是否满足准入策略
+func (mem *Mempool) checkPolicy(msg queue.Message) queue.Message {
+	if len(mem.policies) == 0 {
+		return msg
+	}
+	group := msg.GetData().(types.TxGroup)
+	tx := group.Tx()
+	txs, err := group.GetTxGroup()
+	if err != nil {
+		msg.Data = err
+		return msg
+	}
+	ctx := &PolicyContext{Header: mem.GetHeader(), Client: mem.client, Fee: tx.Fee, Size: tx.Size()}
+	list := []*types.Transaction{tx}
+	if txs != nil {
+		list = txs.Txs
+	}
+	for _, item := range list {
+		for _, p := range mem.policies {
+			if err := p.policy.Admit(ctx, item); err != nil {
+				mlog.Debug("mempool policy reject tx", "policy", p.name, "hash", common.ToHex(item.Hash()), "err", err)
+				msg.Data = err
+				return msg
+			}
+		}
+	}
+	return msg
+}
+
+func init() {
+	RegisterPolicy("execRate", newExecRatePolicy)
+	RegisterPolicy("execMinFee", newExecMinFeePolicy)
+	RegisterPolicy("execPayloadSize", newExecPayloadPolicy)
+	RegisterPolicy("denySenders", newDenySendersPolicy)
+}
+
+// execPolicies 返回配置了给定限制的执行器
+func execPolicies(cfg *types.MemPool, enabled func(p *types.MempoolExecPolicy) bool) map[string]*types.MempoolExecPolicy {
+	policies := make(map[string]*types.MempoolExecPolicy)
+	for _, p := range cfg.ExecPolicies {
+		if p != nil && p.Execer != "" && enabled(p) {
+			policies[p.Execer] = p
+		}
+	}
+	return policies
+}
+
+// execRatePolicy 用令牌桶限制每个执行器每秒进入 mempool 的交易数
+type execRatePolicy struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	buckets map[string]*tokenBucket
+}
+
+func newExecRatePolicy(cfg *types.MemPool) AdmissionPolicy {
+	policies := execPolicies(cfg, func(p *types.MempoolExecPolicy) bool { return p.Rate > 0 })
+	if len(policies) == 0 {
+		return nil
+	}
+	p := &execRatePolicy{now: time.Now, buckets: make(map[string]*tokenBucket)}
+	now := p.now()
+	for execer, policy := range policies {
+		p.buckets[execer] = newTokenBucket(policy.Rate, policy.Burst, now)
+	}
+	return p
+}
+
+func (p *execRatePolicy) Admit(ctx *PolicyContext, tx *types.Transaction) error {
+	bucket, ok := p.buckets[string(tx.Execer)]
+	if !ok {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !bucket.allow(p.now()) {
+		return types.ErrTxRateLimited
+	}
+	return nil
+}
+
+// execMinFeePolicy 执行器每千字节的最低手续费, 低于 minTxFee 时不起作用, 交易组按照整个交易组的大小和手续费计算
+type execMinFeePolicy struct {
+	minFees map[string]int64
+}
+
+func newExecMinFeePolicy(cfg *types.MemPool) AdmissionPolicy {
+	policies := execPolicies(cfg, func(p *types.MempoolExecPolicy) bool { return p.MinFee > 0 })
+	if len(policies) == 0 {
+		return nil
+	}
+	p := &execMinFeePolicy{minFees: make(map[string]int64)}
+	for execer, policy := range policies {
+		p.minFees[execer] = policy.MinFee
+	}
+	return p
+}
+
+func (p *execMinFeePolicy) Admit(ctx *PolicyContext, tx *types.Transaction) error {
+	minFee, ok := p.minFees[string(tx.Execer)]
+	if !ok {
+		return nil
+	}
+	if ctx.Fee < int64(ctx.Size/1000+1)*minFee {
+		return types.ErrExecFeeTooLow
+	}
+	return nil
+}
+
+// execPayloadPolicy 限制执行器交易 payload 的字节数
+type execPayloadPolicy struct {
+	maxSizes map[string]int64
+}
+
+func newExecPayloadPolicy(cfg *types.MemPool) AdmissionPolicy {
+	policies := execPolicies(cfg, func(p *types.MempoolExecPolicy) bool { return p.MaxPayloadSize > 0 })
+	if len(policies) == 0 {
+		return nil
+	}
+	p := &execPayloadPolicy{maxSizes: make(map[string]int64)}
+	for execer, policy := range policies {
+		p.maxSizes[execer] = policy.MaxPayloadSize
+	}
+	return p
+}
+
+func (p *execPayloadPolicy) Admit(ctx *PolicyContext, tx *types.Transaction) error {
+	maxSize, ok := p.maxSizes[string(tx.Execer)]
+	if ok && int64(len(tx.Payload)) > maxSize {
+		return types.ErrTxPayloadTooBig
+	}
+	return nil
+}
+
+// denySendersRetry 读取配置项失败之后重试的间隔
+var denySendersRetry = 5 * time.Second
+
+// denySendersPolicy 拒绝 manage 执行器配置项中的地址发送的交易, 每个新区块之后重新读取配置项,
+// 同一时间只有一个 goroutine 读取, 读取完成之前其他交易用之前的配置检查.
+// 第一次读取成功之前没有可用的配置, 所有交易都返回 ErrPolicyNotReady, 发送者可以稍后重试
+type denySendersPolicy struct {
+	key     string
+	now     func() time.Time
+	mu      sync.Mutex
+	height  int64
+	loading bool
+	retry   time.Time
+	senders map[string]bool
+}
+
+func newDenySendersPolicy(cfg *types.MemPool) AdmissionPolicy {
+	if cfg.DenySendersKey == "" {
+		return nil
+	}
+	return &denySendersPolicy{key: cfg.DenySendersKey, now: time.Now, height: -1}
+}
+
+func (p *denySendersPolicy) Admit(ctx *PolicyContext, tx *types.Transaction) error {
+	p.mu.Lock()
+	now := p.now()
+	reload := ctx.Header != nil && ctx.Header.Height != p.height && !p.loading && !now.Before(p.retry)
+	if reload {
+		p.loading = true
+	}
+	senders := p.senders
+	p.mu.Unlock()
+
+	if reload {
+		loaded, err := p.load(ctx)
+		p.mu.Lock()
+		p.loading = false
+		if err != nil {
+			mlog.Error("denySendersPolicy load", "key", p.key, "err", err)
+			p.retry = now.Add(denySendersRetry)
+		} else {
+			p.senders = loaded
+			p.height = ctx.Header.Height
+			senders = loaded
+		}
+		p.mu.Unlock()
+	}
+	//读取成功时 senders 不为 nil
+	if senders == nil {
+		return types.ErrPolicyNotReady
+	}
+	if senders[tx.From()] {
+		return types.ErrSenderDenied
+	}
+	return nil
+}
+
+// load 从最新的状态中读取配置项, 和 manage 执行器的 GetConfigItem 一样先读取 ManageKey 再读取 ConfigKey
+func (p *denySendersPolicy) load(ctx *PolicyContext) (map[string]bool, error) {
+	keys := [][]byte{[]byte(types.ManageKey(p.key)), []byte(types.ConfigKey(p.key))}
+	msg := ctx.Client.NewMessage("store", types.EventStoreGet, &types.StoreGet{StateHash: ctx.Header.StateHash, Keys: keys})
+	err := ctx.Client.Send(msg, true)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ctx.Client.Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	reply, ok := resp.GetData().(*types.StoreReplyValue)
+	if !ok {
+		return nil, types.ErrTypeAsset
+	}
+	senders := make(map[string]bool)
+	for _, value := range reply.Values {
+		if value == nil {
+			continue
+		}
+		var item types.ConfigItem
+		err = types.Decode(value, &item)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range item.GetArr().GetValue() {
+			senders[addr] = true
+		}
+		break
+	}
+	return senders, nil
+}
+
+// tokenBucket 令牌桶, rate 为每秒生成的令牌数
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int64, now time.Time) *tokenBucket {
+	if burst < rate {
+		burst = rate
+	}
+	return &tokenBucket{rate: float64(rate), burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initPolicyEnv(cfg *types.MemPool) (queue.Queue, *Mempool) {
+	var q = queue.New("channel")
+	blockchainProcess(q)
+	execProcess(q)
+	mem := New(cfg)
+	mem.SetQueueClient(q.Client())
+	mem.setSync(true)
+	mem.SetMinFee(types.GInt("MinFee"))
+	mem.WaitPollLastHeader()
+	return q, mem
+}
+
+func storeProcess(q queue.Queue, key string, addrs []string) {
+	go func() {
+		client := q.Client()
+		client.Sub("store")
+		item := &types.ConfigItem{Key: key, Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: addrs}}}
+		for msg := range client.Recv() {
+			if msg.Ty == types.EventStoreGet {
+				keys := msg.GetData().(*types.StoreGet).Keys
+				values := make([][]byte, len(keys))
+				values[0] = types.Encode(item)
+				msg.Reply(client.NewMessage("", types.EventStoreGetReply, &types.StoreReplyValue{Values: values}))
+			}
+		}
+	}()
+}
+
+func TestExecPolicy(t *testing.T) {
+	cfg, _ := types.InitCfg("../cmd/chain33/chain33.test.toml")
+	cfg.MemPool.ExecPolicies = []*types.MempoolExecPolicy{
+		{Execer: "coins", Rate: 1, Burst: 1},
+		{Execer: "user.write", MinFee: 1e10},
+	}
+	q, mem := initPolicyEnv(cfg.MemPool)
+	defer q.Close()
+	defer mem.Close()
+	require.Equal(t, 2, len(mem.policies))
+	rate := mem.policies[1].policy.(*execRatePolicy)
+	now := time.Now()
+	rate.mu.Lock()
+	rate.now = func() time.Time { return now }
+	rate.buckets["coins"].last = now
+	rate.mu.Unlock()
+
+	_, priv := genaddress()
+	first := createTx(priv, toAddr, amount)
+	require.Nil(t, sendTx(mem.client, first))
+	//重复的交易不占用额度
+	err := sendTx(mem.client, first)
+	assert.NotEqual(t, types.ErrTxRateLimited.Error(), err.Error())
+	err = sendTx(mem.client, createTx(priv, toAddr, amount))
+	assert.Equal(t, types.ErrTxRateLimited.Error(), err.Error())
+	err = sendTx(mem.client, tx15)
+	assert.Equal(t, types.ErrExecFeeTooLow.Error(), err.Error())
+	assert.Equal(t, 1, mem.Size())
+	//一秒之后生成新的令牌
+	rate.mu.Lock()
+	now = now.Add(time.Second)
+	rate.mu.Unlock()
+	require.Nil(t, sendTx(mem.client, createTx(priv, toAddr, amount)))
+	assert.Equal(t, 2, mem.Size())
+
+	payload := newExecPayloadPolicy(&types.MemPool{ExecPolicies: []*types.MempoolExecPolicy{{Execer: "coins", MaxPayloadSize: 4}}})
+	assert.Equal(t, types.ErrTxPayloadTooBig, payload.Admit(&PolicyContext{}, tx1))
+	assert.Nil(t, payload.Admit(&PolicyContext{}, tx15))
+	assert.Nil(t, newExecPayloadPolicy(&types.MemPool{}))
+}
+
+func TestDenySendersPolicy(t *testing.T) {
+	cfg, _ := types.InitCfg("../cmd/chain33/chain33.test.toml")
+	cfg.MemPool.DenySendersKey = "mempool-denySenders"
+	_, denied := genaddress()
+	deniedAddr := address.PubKeyToAddress(denied.PubKey().Bytes()).String()
+	q, mem := initPolicyEnv(cfg.MemPool)
+	defer q.Close()
+	defer mem.Close()
+	storeProcess(q, cfg.MemPool.DenySendersKey, []string{deniedAddr})
+
+	err := sendTx(mem.client, createTx(denied, toAddr, amount))
+	assert.Equal(t, types.ErrSenderDenied.Error(), err.Error())
+	_, priv := genaddress()
+	assert.Nil(t, sendTx(mem.client, createTx(priv, toAddr, amount)))
+	assert.Equal(t, 1, mem.Size())
+	assert.NotEqual(t, types.ErrCodeUnknown, types.GetErrCode(types.ErrSenderDenied.Error()))
+}
+
+func TestDenySendersLoad(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	var loads int
+	go func() {
+		client := q.Client()
+		client.Sub("store")
+		for msg := range client.Recv() {
+			loads++
+			//错误的回复类型
+			msg.Reply(client.NewMessage("", types.EventStoreGetReply, &types.Reply{}))
+		}
+	}()
+	p := newDenySendersPolicy(&types.MemPool{DenySendersKey: "mempool-denySenders"}).(*denySendersPolicy)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	ctx := &PolicyContext{Header: &types.Header{Height: 1}, Client: q.Client()}
+	//没有读取成功之前拒绝所有交易
+	assert.Equal(t, types.ErrPolicyNotReady, p.Admit(ctx, tx1))
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, int64(-1), p.height)
+	//失败之后等待一段时间再重新读取
+	assert.Equal(t, types.ErrPolicyNotReady, p.Admit(ctx, tx1))
+	assert.Equal(t, 1, loads)
+	now = now.Add(denySendersRetry)
+	assert.Equal(t, types.ErrPolicyNotReady, p.Admit(ctx, tx1))
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, types.ErrPolicyNotReady, p.Admit(&PolicyContext{}, tx1))
+}
+
+func TestDenySendersInitialLoad(t *testing.T) {
+	q := queue.New("channel")
+	defer q.Close()
+	loading := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		client := q.Client()
+		client.Sub("store")
+		item := &types.ConfigItem{Value: &types.ConfigItem_Arr{Arr: &types.ArrayConfig{Value: []string{tx1.From()}}}}
+		for msg := range client.Recv() {
+			close(loading)
+			<-release
+			values := make([][]byte, len(msg.GetData().(*types.StoreGet).Keys))
+			values[0] = types.Encode(item)
+			msg.Reply(client.NewMessage("", types.EventStoreGetReply, &types.StoreReplyValue{Values: values}))
+		}
+	}()
+	p := newDenySendersPolicy(&types.MemPool{DenySendersKey: "mempool-denySenders"}).(*denySendersPolicy)
+	ctx := &PolicyContext{Header: &types.Header{Height: 1}, Client: q.Client()}
+	_, priv := genaddress()
+	allowed := createTx(priv, toAddr, amount)
+	done := make(chan error, 1)
+	go func() { done <- p.Admit(ctx, allowed) }()
+	<-loading
+	//第一次读取还没有完成的时候, 禁止的地址不能发送交易
+	assert.Equal(t, types.ErrPolicyNotReady, p.Admit(ctx, tx1))
+	close(release)
+	assert.Nil(t, <-done)
+	assert.Equal(t, types.ErrSenderDenied, p.Admit(ctx, tx1))
+	assert.Nil(t, p.Admit(ctx, allowed))
+}
@@ -24,12 +24,18 @@ var (
 	GetAddrFromGitHubInterval   = 5 * time.Minute
 	CheckActivePeersInterVal    = 5 * time.Second
 	CheckBlackListInterVal      = 30 * time.Second
+	TxInvInterval               = 200 * time.Millisecond
+	txRequestTimeout            = 30 * time.Second
 )
 
 const (
 	msgTx           = 1
 	msgBlock        = 2
 	tryMapPortTimes = 20
+	//GetPeerBlocks 一次最多获取的区块数
+	maxPeerBlocks = 128
+	//一次最多公告的交易hash数
+	maxTxInvBatch = 1000
 )
 
 var (
@@ -51,6 +57,8 @@ const (
 	nodeNetwork = 1
 	nodeGetUTXO = 2
 	nodeBloom   = 4
+	//支持交易的公告/请求, 只在握手时发送, 不影响节点的 ServiceType
+	nodeTxInv = 8
 )
 
 const (
@@ -148,6 +148,7 @@ func (nf *NodeInfo) latestPeerInfo(n *Node) map[string]*types.Peer {
 		pr.Name = peerinfo.GetName()
 		pr.MempoolSize = peerinfo.GetMempoolSize()
 		pr.Header = peerinfo.GetHeader()
+		pr.PruneHeight = peerinfo.GetPruneHeight()
 		peerlist[fmt.Sprintf("%v:%v", peerinfo.Addr, peerinfo.Port)] = &pr
 	}
 	return peerlist
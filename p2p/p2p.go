@@ -223,6 +223,16 @@ func (network *P2p) subP2pMsg() {
 				go network.p2pCli.GetHeaders(msg, taskIndex)
 			case types.EventGetNetInfo:
 				go network.p2pCli.GetNetInfo(msg, taskIndex)
+			case types.EventFetchStateSnapshot:
+				go network.p2pCli.GetStateSnapshot(msg, taskIndex)
+			case types.EventFetchPeerHeaders:
+				go network.p2pCli.GetPeerHeaders(msg, taskIndex)
+			case types.EventFetchPeerBlocks:
+				go network.p2pCli.GetPeerBlocks(msg, taskIndex)
+			case types.EventFetchTxProof:
+				go network.p2pCli.GetTxProof(msg, taskIndex)
+			case types.EventFetchStateProof:
+				go network.p2pCli.GetStateProof(msg, taskIndex)
 			default:
 				log.Warn("unknown msgtype", "msg", msg)
 				msg.Reply(network.client.NewMessage("", msg.Ty, types.Reply{Msg: []byte("unknown msgtype")}))
@@ -30,6 +30,11 @@ type EventInterface interface {
 	GetBlocks(msg queue.Message, taskindex int64)
 	BlockBroadcast(msg queue.Message, taskindex int64)
 	GetNetInfo(msg queue.Message, taskindex int64)
+	GetStateSnapshot(msg queue.Message, taskindex int64)
+	GetPeerHeaders(msg queue.Message, taskindex int64)
+	GetPeerBlocks(msg queue.Message, taskindex int64)
+	GetTxProof(msg queue.Message, taskindex int64)
+	GetStateProof(msg queue.Message, taskindex int64)
 }
 
 // NormalInterface subscribe to the event hander interface
@@ -243,7 +248,7 @@ func (m *Cli) SendVersion(peer *Peer, nodeinfo *NodeInfo) (string, error) {
 	}
 	addrfrom := nodeinfo.GetExternalAddr().String()
 
-	resp, err := peer.mconn.gcli.Version2(context.Background(), &pb.P2PVersion{Version: nodeinfo.cfg.Version, Service: int64(nodeinfo.ServiceTy()), Timestamp: pb.Now().Unix(),
+	resp, err := peer.mconn.gcli.Version2(context.Background(), &pb.P2PVersion{Version: nodeinfo.cfg.Version, Service: int64(nodeinfo.ServiceTy()) | nodeTxInv, Timestamp: pb.Now().Unix(),
 		AddrRecv: peer.Addr(), AddrFrom: addrfrom, Nonce: int64(rand.Int31n(102040)),
 		UserAgent: hex.EncodeToString(in.Sign.GetPubkey()), StartHeight: blockheight}, grpc.FailFast(true))
 	log.Debug("SendVersion", "resp", resp, "addrfrom", addrfrom, "sendto", peer.Addr())
@@ -259,6 +264,7 @@ func (m *Cli) SendVersion(peer *Peer, nodeinfo *NodeInfo) (string, error) {
 	P2pComm.CollectPeerStat(err, peer)
 	log.Debug("SHOW VERSION BACK", "VersionBack", resp, "peer", peer.Addr())
 	peer.version.SetVersion(resp.GetVersion())
+	peer.version.SetTxInv(isTxInvService(resp.GetService()))
 
 	ip, _, err := net.SplitHostPort(resp.GetAddrRecv())
 	if err == nil {
@@ -338,6 +344,7 @@ func (m *Cli) GetPeerInfo(msg queue.Message, taskindex int64) {
 	peer.MempoolSize = peerinfo.GetMempoolSize()
 	peer.Self = true
 	peer.Header = peerinfo.GetHeader()
+	peer.PruneHeight = peerinfo.GetPruneHeight()
 	peers = append(peers, &peer)
 	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventPeerList, &pb.PeerList{Peers: peers}))
 }
@@ -387,6 +394,165 @@ func (m *Cli) GetHeaders(msg queue.Message, taskindex int64) {
 	}
 }
 
+//查找指定 pid 的节点
+func (m *Cli) findPeer(pid string) *Peer {
+	peers, infos := m.network.node.GetActivePeers()
+	for paddr, info := range infos {
+		if info.GetName() == pid {
+			if peer, ok := peers[paddr]; ok && peer != nil {
+				return peer
+			}
+		}
+	}
+	return nil
+}
+
+// GetStateSnapshot 从指定的节点同步获取状态快照的一段
+func (m *Cli) GetStateSnapshot(msg queue.Message, taskindex int64) {
+	defer func() {
+		<-m.network.otherFactory
+		log.Debug("GetStateSnapshot", "task complete:", taskindex)
+	}()
+	req := msg.GetData().(*pb.ReqStateSnapshot)
+	peer := m.findPeer(req.GetPid())
+	if peer == nil {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateSnapshotChunk, pb.ErrNoPeer))
+		return
+	}
+	req = &pb.ReqStateSnapshot{StateHash: req.GetStateHash(), Height: req.GetHeight(), Start: req.GetStart(), Count: req.GetCount(),
+		Version: m.network.node.nodeInfo.cfg.Version}
+	chunk, err := peer.mconn.gcli.GetStateSnapshot(context.Background(), req, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, peer)
+	if err != nil {
+		log.Error("GetStateSnapshot", "Err", err.Error())
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateSnapshotChunk, err))
+		return
+	}
+	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateSnapshotChunk, chunk))
+}
+
+// GetTxProof 从指定的节点获取交易以及交易的默克尔证明
+func (m *Cli) GetTxProof(msg queue.Message, taskindex int64) {
+	defer func() {
+		<-m.network.otherFactory
+		log.Debug("GetTxProof", "task complete:", taskindex)
+	}()
+	req := msg.GetData().(*pb.ReqTxProof)
+	peer := m.findPeer(req.GetPid())
+	if peer == nil {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventTransactionDetail, pb.ErrNoPeer))
+		return
+	}
+	detail, err := peer.mconn.gcli.GetTxProof(context.Background(), req, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, peer)
+	if err != nil {
+		log.Error("GetTxProof", "Err", err.Error())
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventTransactionDetail, err))
+		return
+	}
+	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventTransactionDetail, detail))
+}
+
+// GetStateProof 从指定的节点获取状态数据的 mavl 证明
+func (m *Cli) GetStateProof(msg queue.Message, taskindex int64) {
+	defer func() {
+		<-m.network.otherFactory
+		log.Debug("GetStateProof", "task complete:", taskindex)
+	}()
+	req := msg.GetData().(*pb.ReqStateProof)
+	peer := m.findPeer(req.GetPid())
+	if peer == nil {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateProof, pb.ErrNoPeer))
+		return
+	}
+	proof, err := peer.mconn.gcli.GetStateProof(context.Background(), req, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, peer)
+	if err != nil {
+		log.Error("GetStateProof", "Err", err.Error())
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateProof, err))
+		return
+	}
+	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventStateProof, proof))
+}
+
+// GetPeerHeaders 从指定的节点同步获取 headers, 和 GetHeaders 不同, headers 直接作为回复返回
+func (m *Cli) GetPeerHeaders(msg queue.Message, taskindex int64) {
+	defer func() {
+		<-m.network.otherFactory
+		log.Debug("GetPeerHeaders", "task complete:", taskindex)
+	}()
+	req := msg.GetData().(*pb.ReqBlocks)
+	var peer *Peer
+	if len(req.GetPid()) > 0 {
+		peer = m.findPeer(req.GetPid()[0])
+	}
+	if peer == nil {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventHeaders, pb.ErrNoPeer))
+		return
+	}
+	headers, err := peer.mconn.gcli.GetHeaders(context.Background(), &pb.P2PGetHeaders{StartHeight: req.GetStart(), EndHeight: req.GetEnd(),
+		Version: m.network.node.nodeInfo.cfg.Version}, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, peer)
+	if err != nil {
+		log.Error("GetPeerHeaders", "Err", err.Error())
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventHeaders, err))
+		return
+	}
+	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventHeaders, &pb.Headers{Items: headers.GetHeaders()}))
+}
+
+// GetPeerBlocks 从指定的节点同步获取 start 到 end 的区块, 区块直接作为回复返回
+func (m *Cli) GetPeerBlocks(msg queue.Message, taskindex int64) {
+	defer func() {
+		<-m.network.otherFactory
+		log.Debug("GetPeerBlocks", "task complete:", taskindex)
+	}()
+	req := msg.GetData().(*pb.ReqBlocks)
+	var peer *Peer
+	if len(req.GetPid()) > 0 {
+		peer = m.findPeer(req.GetPid()[0])
+	}
+	if peer == nil {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventBlocks, pb.ErrNoPeer))
+		return
+	}
+	if req.GetEnd() < req.GetStart() || req.GetEnd()-req.GetStart() >= maxPeerBlocks {
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventBlocks, pb.ErrInvalidParam))
+		return
+	}
+	var p2pdata pb.P2PGetData
+	p2pdata.Version = m.network.node.nodeInfo.cfg.Version
+	for height := req.GetStart(); height <= req.GetEnd(); height++ {
+		p2pdata.Invs = append(p2pdata.Invs, &pb.Inventory{Ty: msgBlock, Height: height})
+	}
+	resp, err := peer.mconn.gcli.GetData(context.Background(), &p2pdata, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, peer)
+	if err != nil {
+		log.Error("GetPeerBlocks", "GetData err", err.Error())
+		msg.Reply(m.network.client.NewMessage("blockchain", pb.EventBlocks, err))
+		return
+	}
+	defer resp.CloseSend()
+	var blocks pb.Blocks
+	for {
+		invdatas, err := resp.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Error("GetPeerBlocks", "Recv err", err.Error(), "pid", req.GetPid()[0])
+			msg.Reply(m.network.client.NewMessage("blockchain", pb.EventBlocks, err))
+			return
+		}
+		for _, item := range invdatas.Items {
+			if item.GetBlock() != nil {
+				blocks.Items = append(blocks.Items, item.GetBlock())
+			}
+		}
+	}
+	msg.Reply(m.network.client.NewMessage("blockchain", pb.EventBlocks, &blocks))
+}
+
 // GetBlocks get blocks information
 func (m *Cli) GetBlocks(msg queue.Message, taskindex int64) {
 	defer func() {
@@ -415,6 +581,9 @@ func (m *Cli) GetBlocks(msg queue.Message, taskindex int64) {
 		}
 		for paddr, info := range infos {
 			if _, ok := pidmap[info.GetName()]; ok { //匹配成功
+				if info.GetPruneHeight() > req.GetStart() { //区块已经裁剪
+					continue
+				}
 
 				peer, ok := peers[paddr]
 				if ok && peer != nil {
@@ -454,8 +623,10 @@ func (m *Cli) GetBlocks(msg queue.Message, taskindex int64) {
 			pr.Name = peerinfo.GetName()
 			pr.MempoolSize = peerinfo.GetMempoolSize()
 			pr.Header = peerinfo.GetHeader()
+			pr.PruneHeight = peerinfo.GetPruneHeight()
 
-			if peerinfo.GetHeader().GetHeight() < req.GetEnd() {
+			//没有这个高度的区块或者区块已经裁剪
+			if peerinfo.GetHeader().GetHeight() < req.GetEnd() || peerinfo.GetPruneHeight() > req.GetStart() {
 				continue
 			}
 
@@ -485,7 +656,7 @@ func (m *Cli) GetBlocks(msg queue.Message, taskindex int64) {
 			if !ok {
 				continue
 			}
-			if peerinfo.GetHeader().GetHeight() < req.GetStart() { //高度不符合要求
+			if peerinfo.GetHeader().GetHeight() < req.GetStart() || peerinfo.GetPruneHeight() > req.GetStart() { //高度不符合要求
 				continue
 			}
 
@@ -648,7 +819,13 @@ func (m *Cli) getLocalPeerInfo() (*pb.P2PPeerInfo, error) {
 	log.Debug("getLocalPeerInfo", "EventGetLastHeader", "after")
 	header := resp.GetData().(*pb.Header)
 
+	prune, err := getPruneHeight(client)
+	if err != nil {
+		log.Error("getLocalPeerInfo prune height", "Error", err.Error())
+	}
+
 	localpeerinfo.Header = header
+	localpeerinfo.PruneHeight = prune
 	localpeerinfo.Name = pub
 	localpeerinfo.MempoolSize = int32(meminfo.GetSize())
 	if m.network.node.nodeInfo.GetExternalAddr().IP == nil {
@@ -5,6 +5,7 @@
 package p2p
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/33cn/chain33/common/version"
+	"github.com/33cn/chain33/queue"
 	pb "github.com/33cn/chain33/types"
 	"golang.org/x/net/context"
 	pr "google.golang.org/grpc/peer"
@@ -26,7 +28,9 @@ type P2pserver struct {
 	smtx         sync.Mutex
 	node         *Node
 	streams      map[pb.P2Pgservice_ServerStreamSendServer]chan interface{}
+	peerStreams  map[string]chan interface{} //peername 对应的 stream 数据
 	inboundpeers map[string]*innerpeer
+	txInvPeers   map[string]bool //支持交易公告的连接节点
 	deleteSChan  chan pb.P2Pgservice_ServerStreamSendServer
 	closed       int32
 }
@@ -57,8 +61,10 @@ func (s *P2pserver) IsClose() bool {
 func NewP2pServer() *P2pserver {
 	return &P2pserver{
 		streams:      make(map[pb.P2Pgservice_ServerStreamSendServer]chan interface{}),
+		peerStreams:  make(map[string]chan interface{}),
 		deleteSChan:  make(chan pb.P2Pgservice_ServerStreamSendServer, 1024),
 		inboundpeers: make(map[string]*innerpeer),
+		txInvPeers:   make(map[string]bool),
 	}
 
 }
@@ -114,7 +120,7 @@ func (s *P2pserver) GetAddrList(ctx context.Context, in *pb.P2PGetAddr) (*pb.P2P
 	for _, info := range infos {
 
 		peerinfos = append(peerinfos, &pb.P2PPeerInfo{Addr: info.GetAddr(), Port: info.GetPort(), Name: info.GetName(), Header: info.GetHeader(),
-			MempoolSize: info.GetMempoolSize()})
+			MempoolSize: info.GetMempoolSize(), PruneHeight: info.GetPruneHeight()})
 	}
 
 	return &pb.P2PAddrList{Nonce: in.Nonce, Peerinfo: peerinfos}, nil
@@ -157,7 +163,8 @@ func (s *P2pserver) Version2(ctx context.Context, in *pb.P2PVersion) (*pb.P2PVer
 		}
 	}
 
-	return &pb.P2PVersion{Version: s.node.nodeInfo.cfg.Version, Service: int64(s.node.nodeInfo.ServiceTy()), Nonce: in.Nonce,
+	s.setTxInvPeer(in.GetUserAgent(), isTxInvService(in.GetService()))
+	return &pb.P2PVersion{Version: s.node.nodeInfo.cfg.Version, Service: int64(s.node.nodeInfo.ServiceTy()) | nodeTxInv, Nonce: in.Nonce,
 		AddrFrom: in.AddrRecv, AddrRecv: fmt.Sprintf("%v:%v", peerip, port), UserAgent: pub}, nil
 
 }
@@ -204,8 +211,16 @@ func (s *P2pserver) GetBlocks(ctx context.Context, in *pb.P2PGetBlocks) (*pb.P2P
 		return nil, err
 	}
 	headers := resp.Data.(*pb.Headers)
+	//已经裁剪的区块不再公告
+	prune, err := getPruneHeight(client)
+	if err != nil {
+		return nil, err
+	}
 	var invs = make([]*pb.Inventory, 0)
 	for _, item := range headers.Items {
+		if item.GetHeight() > 0 && item.GetHeight() < prune {
+			continue
+		}
 		var inv pb.Inventory
 		inv.Ty = msgBlock
 		inv.Height = item.GetHeight()
@@ -237,34 +252,30 @@ func (s *P2pserver) GetMemPool(ctx context.Context, in *pb.P2PGetMempool) (*pb.P
 func (s *P2pserver) GetData(in *pb.P2PGetData, stream pb.P2Pgservice_GetDataServer) error {
 	log.Debug("p2pServer Recv GetDataTx", "p2p version", in.GetVersion())
 	var p2pInvData = make([]*pb.InvData, 0)
-	var count = 0
 	if !s.checkVersion(in.GetVersion()) {
 		return pb.ErrVersion
 	}
 	invs := in.GetInvs()
 	client := s.node.nodeInfo.client
+	//交易按照hash从mempool中获取
+	for _, tx := range getMempoolTxs(client, invs) {
+		p2pInvData = append(p2pInvData, &pb.InvData{Value: &pb.InvData_Tx{Tx: tx}, Ty: msgTx})
+	}
+	var prune int64 = -1
 	for _, inv := range invs { //过滤掉不需要的数据
 		var invdata pb.InvData
-		var memtx = make(map[string]*pb.Transaction)
-		if inv.GetTy() == msgTx {
-			//loadMempool
-			if count == 0 {
+		if inv.GetTy() == msgBlock {
+			height := inv.GetHeight()
+			//已经裁剪的区块不能提供
+			if prune == -1 {
 				var err error
-				memtx, err = s.loadMempool()
-				if err != nil {
-					continue
+				if prune, err = getPruneHeight(client); err != nil {
+					return err
 				}
 			}
-			count++
-			txhash := hex.EncodeToString(inv.GetHash())
-			if tx, ok := memtx[txhash]; ok {
-				invdata.Value = &pb.InvData_Tx{Tx: tx}
-				invdata.Ty = msgTx
-				p2pInvData = append(p2pInvData, &invdata)
+			if height > 0 && height < prune {
+				continue
 			}
-
-		} else if inv.GetTy() == msgBlock {
-			height := inv.GetHeight()
 			reqblock := &pb.ReqBlocks{Start: height, End: height}
 			msg := client.NewMessage("blockchain", pb.EventGetBlocks, reqblock)
 			err := client.Send(msg, true)
@@ -278,7 +289,11 @@ func (s *P2pserver) GetData(in *pb.P2PGetData, stream pb.P2Pgservice_GetDataServ
 				continue
 			}
 
-			blocks := resp.Data.(*pb.BlockDetails)
+			blocks, ok := resp.Data.(*pb.BlockDetails)
+			if !ok {
+				log.Error("GetBlocks", "height", height, "err", resp.Err())
+				continue
+			}
 			for _, item := range blocks.Items {
 				invdata.Ty = msgBlock
 				invdata.Value = &pb.InvData_Block{Block: item.Block}
@@ -332,6 +347,94 @@ func (s *P2pserver) GetHeaders(ctx context.Context, in *pb.P2PGetHeaders) (*pb.P
 	return &pb.P2PHeaders{Headers: headers.GetItems()}, nil
 }
 
+// GetStateSnapshot 获取状态快照的一段, start 为 0 时同时返回快照高度的区块
+func (s *P2pserver) GetStateSnapshot(ctx context.Context, in *pb.ReqStateSnapshot) (*pb.StateSnapshotChunk, error) {
+	log.Debug("p2pServer GetStateSnapshot", "height", in.GetHeight(), "start", in.GetStart())
+	if !s.checkVersion(in.GetVersion()) {
+		return nil, pb.ErrVersion
+	}
+	client := s.node.nodeInfo.client
+	req := &pb.ReqStateSnapshot{StateHash: in.GetStateHash(), Height: in.GetHeight(), Start: in.GetStart(), Count: in.GetCount()}
+	msg := client.NewMessage("store", pb.EventStoreGetSnapshot, req)
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		log.Error("GetStateSnapshot", "Error", err.Error())
+		return nil, err
+	}
+	resp, err := client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	chunk := resp.GetData().(*pb.StateSnapshotChunk)
+	if in.GetStart() != 0 {
+		return chunk, nil
+	}
+
+	msg = client.NewMessage("blockchain", pb.EventGetBlocks, &pb.ReqBlocks{Start: in.GetHeight(), End: in.GetHeight(), IsDetail: true})
+	err = client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		log.Error("GetStateSnapshot", "Error", err.Error())
+		return nil, err
+	}
+	resp, err = client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	details := resp.GetData().(*pb.BlockDetails)
+	if len(details.Items) != 1 || !bytes.Equal(details.Items[0].Block.StateHash, in.GetStateHash()) {
+		return nil, pb.ErrSnapshotHash
+	}
+	chunk.Detail = details.Items[0]
+	return chunk, nil
+}
+
+// GetTxProof 获取交易以及交易在区块中的默克尔证明
+func (s *P2pserver) GetTxProof(ctx context.Context, in *pb.ReqTxProof) (*pb.TransactionDetail, error) {
+	log.Debug("p2pServer GetTxProof", "hash", hex.EncodeToString(in.GetHash()))
+	client := s.node.nodeInfo.client
+	msg := client.NewMessage("blockchain", pb.EventQueryTx, &pb.ReqHash{Hash: in.GetHash()})
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		log.Error("GetTxProof", "Error", err.Error())
+		return nil, err
+	}
+	resp, err := client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*pb.TransactionDetail), nil
+}
+
+// GetStateProof 获取状态数据的 mavl 证明
+func (s *P2pserver) GetStateProof(ctx context.Context, in *pb.ReqStateProof) (*pb.StateProof, error) {
+	log.Debug("p2pServer GetStateProof", "height", in.GetHeight())
+	client := s.node.nodeInfo.client
+	req := &pb.ReqStateProof{StateHash: in.GetStateHash(), Key: in.GetKey(), Height: in.GetHeight()}
+	msg := client.NewMessage("store", pb.EventStoreGetProof, req)
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		log.Error("GetStateProof", "Error", err.Error())
+		return nil, err
+	}
+	resp, err := client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if err = resp.Err(); err != nil {
+		return nil, err
+	}
+	return resp.GetData().(*pb.StateProof), nil
+}
+
 // GetPeerInfo get peer information of p2pServer
 func (s *P2pserver) GetPeerInfo(ctx context.Context, in *pb.P2PGetPeerInfo) (*pb.P2PPeerInfo, error) {
 	log.Debug("p2pServer GetPeerInfo", "p2p version", in.GetVersion())
@@ -372,7 +475,13 @@ func (s *P2pserver) GetPeerInfo(ctx context.Context, in *pb.P2PGetPeerInfo) (*pb
 	log.Debug("GetPeerInfo", "EventGetLastHeader", "after")
 	header := resp.GetData().(*pb.Header)
 
+	prune, err := getPruneHeight(client)
+	if err != nil {
+		log.Error("GetPeerInfo prune height", "Error", err.Error())
+	}
+
 	peerinfo.Header = header
+	peerinfo.PruneHeight = prune
 	peerinfo.Name = pub
 	peerinfo.MempoolSize = int32(meminfo.GetSize())
 	peerinfo.Addr = s.node.nodeInfo.GetExternalAddr().IP.String()
@@ -380,6 +489,24 @@ func (s *P2pserver) GetPeerInfo(ctx context.Context, in *pb.P2PGetPeerInfo) (*pb
 	return &peerinfo, nil
 }
 
+//getPruneHeight 本节点低于这个高度的区块(创世区块除外)已经裁剪
+func getPruneHeight(client queue.Client) (int64, error) {
+	msg := client.NewMessage("blockchain", pb.EventGetPruneHeight, nil)
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.WaitTimeout(msg, time.Second*10)
+	if err != nil {
+		return 0, err
+	}
+	prune, ok := resp.GetData().(*pb.Int64)
+	if !ok {
+		return 0, pb.ErrTypeAsset
+	}
+	return prune.GetData(), nil
+}
+
 // BroadCastBlock broadcast block of p2pserver
 func (s *P2pserver) BroadCastBlock(ctx context.Context, in *pb.P2PBlock) (*pb.Reply, error) {
 	log.Debug("BroadCastBlock")
@@ -400,27 +527,50 @@ func (s *P2pserver) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_Serve
 	}
 	log.Debug("ServerStreamSend")
 	peername := hex.EncodeToString(in.GetSign().GetPubkey())
-	dataChain := s.addStreamHandler(stream)
-	for data := range dataChain {
-		if s.IsClose() {
-			return fmt.Errorf("node close")
-		}
+	dataChain := s.addStreamHandler(peername, stream)
+	invTicker := time.NewTicker(TxInvInterval)
+	defer invTicker.Stop()
+	invBatch := newTxInvBatch()
+	for {
 		p2pdata := new(pb.BroadCastData)
-		if block, ok := data.(*pb.P2PBlock); ok {
-			if block.GetBlock() != nil {
-				log.Debug("ServerStreamSend", "blockhash", hex.EncodeToString(block.GetBlock().GetTxHash()))
+		select {
+		case data, ok := <-dataChain:
+			if !ok {
+				return nil
 			}
+			if s.IsClose() {
+				return fmt.Errorf("node close")
+			}
+			if block, ok := data.(*pb.P2PBlock); ok {
+				if block.GetBlock() != nil {
+					log.Debug("ServerStreamSend", "blockhash", hex.EncodeToString(block.GetBlock().GetTxHash()))
+				}
 
-			p2pdata.Value = &pb.BroadCastData_Block{Block: block}
-		} else if tx, ok := data.(*pb.P2PTx); ok {
-			log.Debug("ServerStreamSend", "txhash", hex.EncodeToString(tx.GetTx().Hash()))
-			p2pdata.Value = &pb.BroadCastData_Tx{Tx: tx}
-		} else {
-			log.Error("RoutChate", "Convert error", data)
-			continue
+				p2pdata.Value = &pb.BroadCastData_Block{Block: block}
+			} else if tx, ok := data.(*pb.P2PTx); ok {
+				log.Debug("ServerStreamSend", "txhash", hex.EncodeToString(tx.GetTx().Hash()))
+				if s.isTxInvPeer(peername) {
+					if !invBatch.add(tx.GetTx()) {
+						continue
+					}
+					p2pdata = invBatch.flush()
+				} else {
+					p2pdata.Value = &pb.BroadCastData_Tx{Tx: tx}
+				}
+			} else if req, ok := data.(*pb.P2PGetData); ok {
+				//向对方请求公告的交易, 不需要过滤
+				p2pdata.Value = &pb.BroadCastData_GetData{GetData: req}
+			} else {
+				log.Error("RoutChate", "Convert error", data)
+				continue
+			}
+		case <-invTicker.C:
+			if p2pdata = invBatch.flush(); p2pdata == nil {
+				continue
+			}
 		}
 		//增加过滤，如果自己连接了远程节点，则不需要通过stream send 重复发送数据给这个节点
-		if peerinfo := s.getInBoundPeerInfo(peername); peerinfo != nil {
+		if peerinfo := s.getInBoundPeerInfo(peername); peerinfo != nil && p2pdata.GetGetData() == nil {
 			if s.node.Has(peerinfo.addr) {
 				continue
 			}
@@ -433,8 +583,6 @@ func (s *P2pserver) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_Serve
 			return err
 		}
 	}
-
-	return nil
 }
 
 // ServerStreamRead server stream read of p2pserver
@@ -499,6 +647,11 @@ func (s *P2pserver) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServe
 			}
 			//Filter.RegRecvData(txhash)
 
+		} else if invs := in.GetInvs(); invs != nil {
+			//只请求没有收到过的交易, 通过对方的 ServerStreamSend 发送请求
+			if unknown := txRequests.filter(invs.GetInvs()); len(unknown) > 0 {
+				s.addPeerStreamData(peername, &pb.P2PGetData{Invs: unknown, Version: s.node.nodeInfo.cfg.Version})
+			}
 		} else if ping := in.GetPing(); ping != nil { ///被远程节点初次连接后，会收到ping 数据包，收到后注册到inboundpeers.
 			//Ping package
 
@@ -645,14 +798,30 @@ func (s *P2pserver) manageStream() {
 	}()
 }
 
-func (s *P2pserver) addStreamHandler(stream pb.P2Pgservice_ServerStreamSendServer) chan interface{} {
+func (s *P2pserver) addStreamHandler(peername string, stream pb.P2Pgservice_ServerStreamSendServer) chan interface{} {
 	s.smtx.Lock()
 	defer s.smtx.Unlock()
 	s.streams[stream] = make(chan interface{}, 1024)
+	s.peerStreams[peername] = s.streams[stream]
 	return s.streams[stream]
 
 }
 
+// addPeerStreamData 发送数据给指定的连接节点, stream 不存在或者已满时丢弃
+func (s *P2pserver) addPeerStreamData(peername string, data interface{}) {
+	s.smtx.Lock()
+	defer s.smtx.Unlock()
+	dataChan, ok := s.peerStreams[peername]
+	if !ok {
+		return
+	}
+	select {
+	case dataChan <- data:
+	default:
+		log.Debug("addPeerStreamData", "stream full", peername)
+	}
+}
+
 func (s *P2pserver) addStreamData(data interface{}) {
 	s.smtx.Lock()
 	defer s.smtx.Unlock()
@@ -682,6 +851,11 @@ func (s *P2pserver) deleteDisableStream() {
 func (s *P2pserver) deleteStream(stream pb.P2Pgservice_ServerStreamSendServer) {
 	s.smtx.Lock()
 	defer s.smtx.Unlock()
+	for peername, dataChan := range s.peerStreams {
+		if dataChan == s.streams[stream] {
+			delete(s.peerStreams, peername)
+		}
+	}
 	close(s.streams[stream])
 	delete(s.streams, stream)
 }
@@ -696,7 +870,20 @@ func (s *P2pserver) deleteInBoundPeerInfo(peername string) {
 	s.imtx.Lock()
 	defer s.imtx.Unlock()
 	delete(s.inboundpeers, peername)
+	delete(s.txInvPeers, peername)
+
+}
 
+func (s *P2pserver) setTxInvPeer(peername string, ok bool) {
+	s.imtx.Lock()
+	defer s.imtx.Unlock()
+	s.txInvPeers[peername] = ok
+}
+
+func (s *P2pserver) isTxInvPeer(peername string) bool {
+	s.imtx.Lock()
+	defer s.imtx.Unlock()
+	return s.txInvPeers[peername]
 }
 
 func (s *P2pserver) getInBoundPeerInfo(peername string) *innerpeer {
@@ -6,6 +6,7 @@ package p2p
 
 import (
 	"encoding/hex"
+	"io"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -45,8 +46,9 @@ type Peer struct {
 	mconn        *MConnection
 	peerAddr     *NetAddress
 	peerStat     *Stat
-	taskChan     chan interface{} //tx block
-	inBounds     int32            //连接此节点的客户端节点数量
+	taskChan     chan interface{}    //tx block
+	getDataChan  chan *pb.P2PGetData //对方请求的交易
+	inBounds     int32               //连接此节点的客户端节点数量
 	IsMaxInbouds bool
 }
 
@@ -57,6 +59,7 @@ func NewPeer(conn *grpc.ClientConn, node *Node, remote *NetAddress) *Peer {
 		node: node,
 	}
 	p.peerStat = new(Stat)
+	p.getDataChan = make(chan *pb.P2PGetData, 128)
 	p.version = new(Version)
 	p.version.SetSupport(true)
 	p.mconn = NewMConnection(conn, remote, p)
@@ -68,6 +71,7 @@ type Version struct {
 	mtx            sync.Mutex
 	version        int32
 	versionSupport bool
+	txInv          bool
 }
 
 // Stat object information
@@ -125,6 +129,20 @@ func (v *Version) GetVersion() int32 {
 	return v.version
 }
 
+// SetTxInv set support of tx inventory
+func (v *Version) SetTxInv(ok bool) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	v.txInv = ok
+}
+
+// IsTxInv is support tx inventory
+func (v *Version) IsTxInv() bool {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return v.txInv
+}
+
 func (p *Peer) heartBeat() {
 
 	pcli := NewNormalP2PCli()
@@ -177,6 +195,8 @@ func (p *Peer) GetPeerInfo(version int32) (*pb.P2PPeerInfo, error) {
 }
 
 func (p *Peer) sendStream() {
+	//交易公告的缓存在重新获取 stream 之后继续使用
+	invBatch := newTxInvBatch()
 	//Stream Send data
 	for {
 		if !p.GetRunning() {
@@ -222,73 +242,116 @@ func (p *Peer) sendStream() {
 			time.Sleep(time.Second)
 			continue
 		}
-		timeout := time.NewTimer(time.Second * 2)
-		defer timeout.Stop()
-		var hash [64]byte
-	SEND_LOOP:
-		for {
+		if !p.sendLoop(resp, invBatch) {
+			cancel()
+			return
+		}
+		cancel()
+	}
+}
 
-			select {
-			case task := <-p.taskChan:
-				if !p.GetRunning() {
-					resp.CloseSend()
-					cancel()
-					log.Error("sendStream peer is not running")
-					return
-				}
-				p2pdata := new(pb.BroadCastData)
-				if block, ok := task.(*pb.P2PBlock); ok {
-					height := block.GetBlock().GetHeight()
-					hex.Encode(hash[:], block.GetBlock().Hash())
-					blockhash := string(hash[:])
-					log.Debug("sendStream", "will send block", blockhash)
-					pinfo, err := p.GetPeerInfo(p.node.nodeInfo.cfg.Version)
-					P2pComm.CollectPeerStat(err, p)
-					if err == nil {
-						if pinfo.GetHeader().GetHeight() >= height {
-							log.Debug("sendStream", "find peer height>this broadblock ,send process", "break")
-							continue
-						}
+// sendLoop 通过一个 stream 发送数据, 返回 false 表示 peer 已经停止, 返回 true 表示需要重新获取 stream,
+// 定时器在每个 stream 结束时释放, 还没有发送的交易公告留在 invBatch 中由下一个 stream 发送
+func (p *Peer) sendLoop(resp pb.P2Pgservice_ServerStreamReadClient, invBatch *txInvBatch) bool {
+	timeout := time.NewTimer(time.Second * 2)
+	defer timeout.Stop()
+	invTicker := time.NewTicker(TxInvInterval)
+	defer invTicker.Stop()
+	var hash [64]byte
+	for {
+		var p2pdatas []*pb.BroadCastData
+		select {
+		case task := <-p.taskChan:
+			if !p.GetRunning() {
+				p.flushTxInv(resp, invBatch)
+				resp.CloseSend()
+				log.Error("sendStream peer is not running")
+				return false
+			}
+			p2pdata := new(pb.BroadCastData)
+			if block, ok := task.(*pb.P2PBlock); ok {
+				height := block.GetBlock().GetHeight()
+				hex.Encode(hash[:], block.GetBlock().Hash())
+				blockhash := string(hash[:])
+				log.Debug("sendStream", "will send block", blockhash)
+				pinfo, err := p.GetPeerInfo(p.node.nodeInfo.cfg.Version)
+				P2pComm.CollectPeerStat(err, p)
+				if err == nil {
+					if pinfo.GetHeader().GetHeight() >= height {
+						log.Debug("sendStream", "find peer height>this broadblock ,send process", "break")
+						continue
 					}
+				}
 
-					p2pdata.Value = &pb.BroadCastData_Block{Block: block}
-					Filter.RegRecvData(blockhash)
+				p2pdata.Value = &pb.BroadCastData_Block{Block: block}
+				Filter.RegRecvData(blockhash)
 
-				} else if tx, ok := task.(*pb.P2PTx); ok {
+			} else if tx, ok := task.(*pb.P2PTx); ok {
+				if p.version.IsTxInv() {
+					//对方支持交易公告, 只发送交易hash, 缓存满了之后立即发送
+					if !invBatch.add(tx.GetTx()) {
+						continue
+					}
+					p2pdata = invBatch.flush()
+				} else {
 					hex.Encode(hash[:], tx.GetTx().Hash())
 					txhash := string(hash[:])
 					log.Debug("sendStream", "will send tx", txhash)
 					p2pdata.Value = &pb.BroadCastData_Tx{Tx: tx}
 					Filter.RegRecvData(txhash)
 				}
+			}
+			p2pdatas = append(p2pdatas, p2pdata)
 
-				err := resp.Send(p2pdata)
-				P2pComm.CollectPeerStat(err, p)
-				if err != nil {
-					log.Error("sendStream", "send", err)
-					if grpc.Code(err) == codes.Unimplemented { //maybe order peers delete peer to BlackList
-						p.node.nodeInfo.blacklist.Add(p.Addr(), 3600)
-					}
-					time.Sleep(time.Second) //have a rest
-					resp.CloseSend()
-					cancel()
+		case <-invTicker.C:
+			if p2pdata := invBatch.flush(); p2pdata != nil {
+				p2pdatas = append(p2pdatas, p2pdata)
+			}
 
-					break SEND_LOOP //下一次外循环重新获取stream
-				}
-				log.Debug("sendStream", "send data", "ok")
-
-			case <-timeout.C:
-				if !p.GetRunning() {
-					log.Error("sendStream timeout")
-					resp.CloseSend()
-					cancel()
-					return
-				}
-				timeout.Reset(time.Second * 2)
+		case req := <-p.getDataChan:
+			//对方请求的交易从本地 mempool 中一次获取后发送
+			for _, tx := range getMempoolTxs(p.node.nodeInfo.client, req.GetInvs()) {
+				p2pdatas = append(p2pdatas, &pb.BroadCastData{Value: &pb.BroadCastData_Tx{Tx: &pb.P2PTx{Tx: tx}}})
+			}
+
+		case <-timeout.C:
+			if !p.GetRunning() {
+				log.Error("sendStream timeout")
+				p.flushTxInv(resp, invBatch)
+				resp.CloseSend()
+				return false
+			}
+			timeout.Reset(time.Second * 2)
+
+		}
 
+		for _, p2pdata := range p2pdatas {
+			err := resp.Send(p2pdata)
+			P2pComm.CollectPeerStat(err, p)
+			if err != nil {
+				log.Error("sendStream", "send", err)
+				if grpc.Code(err) == codes.Unimplemented { //maybe order peers delete peer to BlackList
+					p.node.nodeInfo.blacklist.Add(p.Addr(), 3600)
+				}
+				//没有发送成功的交易公告由下一个 stream 发送
+				invBatch.requeue(p2pdata)
+				time.Sleep(time.Second) //have a rest
+				resp.CloseSend()
+				return true //下一次外循环重新获取stream
 			}
+			log.Debug("sendStream", "send data", "ok")
 		}
+	}
+}
 
+// flushTxInv 关闭 stream 之前发送缓存的交易公告
+func (p *Peer) flushTxInv(resp pb.P2Pgservice_ServerStreamReadClient, invBatch *txInvBatch) {
+	if p2pdata := invBatch.flush(); p2pdata != nil {
+		err := resp.Send(p2pdata)
+		P2pComm.CollectPeerStat(err, p)
+		if err != nil {
+			log.Error("sendStream", "flush tx inv", err)
+		}
 	}
 }
 
@@ -389,6 +452,43 @@ func (p *Peer) readStream() {
 					p.node.nodeInfo.client.Send(msg, false)
 					//Filter.RegRecvData(txhash) //登记
 				}
+			} else if invs := data.GetInvs(); invs != nil {
+				//只请求没有收到过的交易
+				if unknown := txRequests.filter(invs.GetInvs()); len(unknown) > 0 {
+					go p.getTxs(unknown)
+				}
+			} else if req := data.GetGetData(); req != nil {
+				select {
+				case p.getDataChan <- req:
+				default:
+					log.Debug("readStream", "getData chan full", p.Addr())
+				}
+			}
+		}
+	}
+}
+
+// getTxs 通过 GetData 向对方请求公告的交易
+func (p *Peer) getTxs(invs []*pb.Inventory) {
+	resp, err := p.mconn.gcli.GetData(context.Background(), &pb.P2PGetData{Invs: invs, Version: p.node.nodeInfo.cfg.Version}, grpc.FailFast(true))
+	P2pComm.CollectPeerStat(err, p)
+	if err != nil {
+		log.Error("getTxs", "GetData", err, "peer", p.Addr())
+		return
+	}
+	defer resp.CloseSend()
+	for {
+		invdatas, err := resp.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			log.Error("getTxs", "Recv", err, "peer", p.Addr())
+			return
+		}
+		for _, item := range invdatas.GetItems() {
+			if tx := item.GetTx(); tx != nil {
+				recvTx(p.node.nodeInfo.client, tx)
 			}
 		}
 	}
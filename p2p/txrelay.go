@@ -0,0 +1,148 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/queue"
+	pb "github.com/33cn/chain33/types"
+)
+
+/*
+交易的公告/请求转发:
+握手(Version2)时双方在 Service 中带上 nodeTxInv 标志, 只有对方支持时才只发送交易hash,
+否则仍然发送完整的交易, 兼容旧版本的节点.
+发送方把交易hash缓存起来, 每隔 TxInvInterval 或者缓存满 maxTxInvBatch 个之后一次性发送 P2PInv,
+接收方过滤掉已经收到以及已经向其他节点请求过的交易, 只请求未知的交易:
+主动连接的一方直接调用对方的 GetData 获取交易,
+被连接的一方通过 ServerStreamSend 把 P2PGetData 发送给对方, 对方再通过 ServerStreamRead 发送完整的交易.
+*/
+
+// txRequests 已经请求过的交易, 避免同一笔交易向多个节点重复请求
+var txRequests = newTxRequested()
+
+// txInvBatch 等待公告的交易hash
+type txInvBatch struct {
+	invs []*pb.Inventory
+}
+
+func newTxInvBatch() *txInvBatch {
+	return &txInvBatch{}
+}
+
+// add 缓存交易hash, 并登记到 Filter, 对方再公告回来时不会重复请求, 缓存满时返回 true
+func (b *txInvBatch) add(tx *pb.Transaction) bool {
+	hash := tx.Hash()
+	Filter.RegRecvData(hex.EncodeToString(hash))
+	b.invs = append(b.invs, &pb.Inventory{Ty: msgTx, Hash: hash})
+	return len(b.invs) >= maxTxInvBatch
+}
+
+// flush 取出缓存的交易hash, 没有缓存时返回 nil
+func (b *txInvBatch) flush() *pb.BroadCastData {
+	if len(b.invs) == 0 {
+		return nil
+	}
+	invs := b.invs
+	b.invs = nil
+	return &pb.BroadCastData{Value: &pb.BroadCastData_Invs{Invs: &pb.P2PInv{Invs: invs}}}
+}
+
+// requeue 把没有发送成功的交易公告放回缓存, 其他数据忽略
+func (b *txInvBatch) requeue(data *pb.BroadCastData) {
+	if invs := data.GetInvs().GetInvs(); len(invs) > 0 {
+		b.invs = append(invs, b.invs...)
+	}
+}
+
+type txRequested struct {
+	mtx   sync.Mutex
+	items map[string]int64
+}
+
+func newTxRequested() *txRequested {
+	return &txRequested{items: make(map[string]int64)}
+}
+
+// filter 返回需要请求的交易, 已经收到的交易以及 txRequestTimeout 内请求过的交易不再请求
+func (r *txRequested) filter(invs []*pb.Inventory) []*pb.Inventory {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	now := pb.Now().Unix()
+	timeout := int64(txRequestTimeout / time.Second)
+	if len(r.items) >= P2pCacheTxSize {
+		for key, reqtime := range r.items {
+			if now-reqtime >= timeout {
+				delete(r.items, key)
+			}
+		}
+	}
+	var unknown []*pb.Inventory
+	for _, inv := range invs {
+		if inv.GetTy() != msgTx {
+			continue
+		}
+		txhash := hex.EncodeToString(inv.GetHash())
+		if Filter.QueryRecvData(txhash) {
+			continue
+		}
+		if reqtime, ok := r.items[txhash]; ok && now-reqtime < timeout {
+			continue
+		}
+		r.items[txhash] = now
+		unknown = append(unknown, inv)
+	}
+	return unknown
+}
+
+// recvTx 处理请求到的交易, 和 stream 中收到的交易一样先通过 Filter 过滤再发送给 mempool
+func recvTx(client queue.Client, tx *pb.Transaction) {
+	txhash := hex.EncodeToString(tx.Hash())
+	Filter.GetLock()
+	if Filter.QueryRecvData(txhash) {
+		Filter.ReleaseLock()
+		return
+	}
+	Filter.RegRecvData(txhash)
+	Filter.ReleaseLock()
+	msg := client.NewMessage("mempool", pb.EventTx, tx)
+	client.Send(msg, false)
+}
+
+// getMempoolTxs 从本地 mempool 中一次获取请求的所有交易, 已经不在 mempool 中的交易忽略
+func getMempoolTxs(client queue.Client, invs []*pb.Inventory) []*pb.Transaction {
+	var hashes [][]byte
+	for _, inv := range invs {
+		if inv.GetTy() == msgTx {
+			hashes = append(hashes, inv.GetHash())
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	msg := client.NewMessage("mempool", pb.EventGetMempoolTxs, &pb.ReqHashes{Hashes: hashes})
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		log.Error("getMempoolTxs", "Error", err.Error())
+		return nil
+	}
+	resp, err := client.WaitTimeout(msg, time.Second*10)
+	if err != nil {
+		log.Error("getMempoolTxs", "Error", err.Error())
+		return nil
+	}
+	if txs, ok := resp.GetData().(*pb.ReplyTxList); ok {
+		return txs.GetTxs()
+	}
+	return nil
+}
+
+// isTxInvService 对方是否支持交易的公告/请求
+func isTxInvService(service int64) bool {
+	return service&nodeTxInv != 0
+}
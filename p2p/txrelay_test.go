@@ -0,0 +1,247 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/queue"
+	pb "github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func testTx(note string) *pb.Transaction {
+	return &pb.Transaction{Execer: []byte("coins"), Payload: []byte(note), Fee: 100000, Nonce: 1}
+}
+
+func TestTxInvBatch(t *testing.T) {
+	batch := newTxInvBatch()
+	assert.Nil(t, batch.flush())
+	tx1, tx2 := testTx("txinv1"), testTx("txinv2")
+	assert.False(t, batch.add(tx1))
+	assert.True(t, Filter.QueryRecvData(hex.EncodeToString(tx1.Hash())))
+	data := batch.flush()
+	require.NotNil(t, data)
+	require.Equal(t, 1, len(data.GetInvs().GetInvs()))
+	assert.Equal(t, tx1.Hash(), data.GetInvs().GetInvs()[0].GetHash())
+	assert.Equal(t, int32(msgTx), data.GetInvs().GetInvs()[0].GetTy())
+	assert.Nil(t, batch.flush())
+
+	//没有发送成功的公告放回缓存, 排在新的公告之前
+	batch.add(tx2)
+	batch.requeue(data)
+	batch.requeue(&pb.BroadCastData{Value: &pb.BroadCastData_Tx{Tx: &pb.P2PTx{Tx: tx1}}})
+	data = batch.flush()
+	require.Equal(t, 2, len(data.GetInvs().GetInvs()))
+	assert.Equal(t, tx1.Hash(), data.GetInvs().GetInvs()[0].GetHash())
+	assert.Equal(t, tx2.Hash(), data.GetInvs().GetInvs()[1].GetHash())
+
+	//缓存满了之后立即发送
+	for i := 0; i < maxTxInvBatch-1; i++ {
+		assert.False(t, batch.add(tx1))
+	}
+	assert.True(t, batch.add(tx1))
+	assert.Equal(t, maxTxInvBatch, len(batch.flush().GetInvs().GetInvs()))
+}
+
+func TestTxRequested(t *testing.T) {
+	r := newTxRequested()
+	tx1, tx2 := testTx("txreq1"), testTx("txreq2")
+	inv1 := &pb.Inventory{Ty: msgTx, Hash: tx1.Hash()}
+	inv2 := &pb.Inventory{Ty: msgTx, Hash: tx2.Hash()}
+	block := &pb.Inventory{Ty: msgBlock, Height: 1}
+	assert.Equal(t, []*pb.Inventory{inv1}, r.filter([]*pb.Inventory{inv1, block}))
+	//已经请求过的交易不再请求
+	assert.Equal(t, []*pb.Inventory{inv2}, r.filter([]*pb.Inventory{inv1, inv2}))
+	assert.Nil(t, r.filter([]*pb.Inventory{inv1, inv2}))
+	//已经收到的交易不再请求
+	tx3 := testTx("txreq3")
+	Filter.RegRecvData(hex.EncodeToString(tx3.Hash()))
+	assert.Nil(t, r.filter([]*pb.Inventory{{Ty: msgTx, Hash: tx3.Hash()}}))
+
+	//超时之后可以向其他节点重新请求
+	pb.SetTimeDelta(int64(txRequestTimeout))
+	defer pb.SetTimeDelta(0)
+	assert.Equal(t, []*pb.Inventory{inv1, inv2}, r.filter([]*pb.Inventory{inv1, inv2}))
+}
+
+// testRemote 远程节点, 记录收到的数据, 并通过 ServerStreamSend 发送请求
+type testRemote struct {
+	pb.P2PgserviceServer
+	service int64
+	recv    chan *pb.BroadCastData
+	send    chan *pb.BroadCastData
+}
+
+func (r *testRemote) Version2(ctx context.Context, in *pb.P2PVersion) (*pb.P2PVersion, error) {
+	return &pb.P2PVersion{Version: in.Version, Service: r.service, AddrRecv: in.AddrFrom}, nil
+}
+
+func (r *testRemote) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServer) error {
+	for {
+		in, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		r.recv <- in
+	}
+}
+
+func (r *testRemote) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_ServerStreamSendServer) error {
+	for {
+		select {
+		case data := <-r.send:
+			if err := stream.Send(data); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// next 返回下一个满足条件的数据, 忽略 ping 和版本信息
+func (r *testRemote) next(t *testing.T) *pb.BroadCastData {
+	for {
+		select {
+		case data := <-r.recv:
+			if data.GetPing() != nil || data.GetVersion() != nil {
+				continue
+			}
+			return data
+		case <-time.After(5 * time.Second):
+			t.Fatal("wait remote data timeout")
+			return nil
+		}
+	}
+}
+
+func startTestRemote(t *testing.T, service int64) (*testRemote, string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	remote := &testRemote{service: service, recv: make(chan *pb.BroadCastData, 16), send: make(chan *pb.BroadCastData, 16)}
+	server := grpc.NewServer()
+	pb.RegisterP2PgserviceServer(server, remote)
+	go server.Serve(l)
+	return remote, l.Addr().String(), server.Stop
+}
+
+// newTestPeer 创建连接到 addr 的 peer, 本地的 mempool 中有给定的交易
+func newTestPeer(t *testing.T, addr string, txs []*pb.Transaction) (*Peer, *int32, func()) {
+	dir, err := ioutil.TempDir("", "p2p")
+	require.Nil(t, err)
+	node, err := NewNode(&pb.P2P{Driver: "leveldb", DbPath: dir, DbCache: 4, Version: 119, VerMin: 118, VerMax: 128})
+	require.Nil(t, err)
+	q := queue.New("channel")
+	node.SetQueueClient(q.Client())
+	var lookups int32
+	go func() {
+		client := q.Client()
+		client.Sub("mempool")
+		for msg := range client.Recv() {
+			if msg.Ty == pb.EventGetMempoolTxs {
+				atomic.AddInt32(&lookups, 1)
+				var reply []*pb.Transaction
+				for _, hash := range msg.GetData().(*pb.ReqHashes).Hashes {
+					for _, tx := range txs {
+						if string(tx.Hash()) == string(hash) {
+							reply = append(reply, tx)
+						}
+					}
+				}
+				msg.Reply(client.NewMessage("", pb.EventReplyTxList, &pb.ReplyTxList{Txs: reply}))
+			}
+		}
+	}()
+	go func() {
+		client := q.Client()
+		client.Sub("blockchain")
+		for msg := range client.Recv() {
+			if msg.Ty == pb.EventGetBlockHeight {
+				msg.Reply(client.NewMessage("", pb.EventReplyBlockHeight, &pb.ReplyBlockHeight{Height: 1}))
+			}
+		}
+	}()
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	require.Nil(t, err)
+	remote, err := NewNetAddressString(addr)
+	require.Nil(t, err)
+	peer := NewPeer(conn, node, remote)
+	peer.SetAddr(remote)
+	peer.taskChan = make(chan interface{}, 16)
+	return peer, &lookups, func() {
+		atomic.StoreInt32(&peer.isclose, 1)
+		peer.mconn.Close()
+		node.nodeInfo.addrBook.Close()
+		q.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestTxInvService(t *testing.T) {
+	assert.True(t, isTxInvService(int64(Service)|nodeTxInv))
+	assert.False(t, isTxInvService(int64(Service)))
+
+	//握手时根据对方的 Service 决定是否发送交易公告
+	_, addr, stop := startTestRemote(t, int64(Service))
+	defer stop()
+	peer, _, closePeer := newTestPeer(t, addr, nil)
+	defer closePeer()
+	_, err := NewNormalP2PCli().SendVersion(peer, peer.node.nodeInfo)
+	require.Nil(t, err)
+	assert.False(t, peer.version.IsTxInv())
+
+	_, addr2, stop2 := startTestRemote(t, int64(Service)|nodeTxInv)
+	defer stop2()
+	peer2, _, closePeer2 := newTestPeer(t, addr2, nil)
+	defer closePeer2()
+	_, err = NewNormalP2PCli().SendVersion(peer2, peer2.node.nodeInfo)
+	require.Nil(t, err)
+	assert.True(t, peer2.version.IsTxInv())
+}
+
+func TestTxRelay(t *testing.T) {
+	tx1, tx2, tx3 := testTx("txrelay1"), testTx("txrelay2"), testTx("txrelay3")
+	remote, addr, stop := startTestRemote(t, int64(Service))
+	defer stop()
+	peer, lookups, closePeer := newTestPeer(t, addr, []*pb.Transaction{tx2, tx3})
+	defer closePeer()
+	go peer.sendStream()
+	go peer.readStream()
+
+	//对方不支持交易公告时发送完整的交易
+	peer.taskChan <- &pb.P2PTx{Tx: tx1}
+	data := remote.next(t)
+	require.NotNil(t, data.GetTx())
+	assert.Equal(t, tx1.Hash(), data.GetTx().GetTx().Hash())
+
+	//支持交易公告时一次发送缓存的交易hash
+	peer.version.SetTxInv(true)
+	peer.taskChan <- &pb.P2PTx{Tx: tx2}
+	peer.taskChan <- &pb.P2PTx{Tx: tx3}
+	var invs []*pb.Inventory
+	for len(invs) < 2 {
+		data = remote.next(t)
+		require.NotNil(t, data.GetInvs())
+		invs = append(invs, data.GetInvs().GetInvs()...)
+	}
+	require.Equal(t, 2, len(invs))
+
+	//对方请求之后从 mempool 中一次获取所有交易
+	remote.send <- &pb.BroadCastData{Value: &pb.BroadCastData_GetData{GetData: &pb.P2PGetData{Invs: invs}}}
+	assert.Equal(t, tx2.Hash(), remote.next(t).GetTx().GetTx().Hash())
+	assert.Equal(t, tx3.Hash(), remote.next(t).GetTx().GetTx().Hash())
+	assert.Equal(t, int32(1), atomic.LoadInt32(lookups))
+}
@@ -233,6 +233,32 @@ func (c *channelClient) DecodeRawTransaction(param *types.ReqDecodeRawTransactio
 	return &tx, nil
 }
 
+// SimulateTransaction 在最新的状态上执行交易, 执行结果不会提交, 返回交易回执和最新区块头
+func (c *channelClient) SimulateTransaction(tx *types.Transaction) (*types.Receipt, *types.Header, error) {
+	if tx.GroupCount != 0 {
+		return nil, nil, types.ErrTxGroupNotSupport
+	}
+	header, err := c.GetLastHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	list := &types.ExecTxList{
+		StateHash:  header.StateHash,
+		Txs:        []*types.Transaction{tx},
+		BlockTime:  types.Now().Unix(),
+		Height:     header.Height + 1,
+		Difficulty: uint64(header.Difficulty),
+	}
+	receipts, err := c.ExecTxList(list)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(receipts.Receipts) != 1 {
+		return nil, nil, types.ErrTypeAsset
+	}
+	return receipts.Receipts[0], header, nil
+}
+
 // GetTimeStatus get status of time
 func (c *channelClient) GetTimeStatus() (*types.TimeStatus, error) {
 	ntpTime := common.GetRealTimeRetry(types.NtpHosts, 10)
@@ -0,0 +1,47 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/33cn/chain33/common/version"
+	"github.com/33cn/chain33/rpc/openrpc"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+)
+
+// discoverMethod OpenRPC 规定的服务发现方法
+const discoverMethod = "rpc.discover"
+
+var (
+	openrpcOnce sync.Once
+	openrpcDoc  *openrpc.Document
+)
+
+// OpenRPCDocument 反射所有注册的 jsonrpc 方法和执行器的查询接口, 生成 OpenRPC 文档
+func OpenRPCDocument() *openrpc.Document {
+	openrpcOnce.Do(func() {
+		g := openrpc.New("Chain33 JSON-RPC", version.GetVersion())
+		g.AddService("Chain33", &Chain33{})
+		services := rpctypes.JRPCServices()
+		names := make([]string, 0, len(services))
+		for name := range services {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			g.AddService(name, services[name])
+		}
+		g.AddMethod(discoverMethod, "Returns the OpenRPC document of this node", nil, reflect.TypeOf((*interface{})(nil)).Elem())
+		for _, name := range types.ListExecutorType() {
+			g.AddExecutor(name, types.LoadExecutorType(name))
+		}
+		openrpcDoc = g.Document()
+	})
+	return openrpcDoc
+}
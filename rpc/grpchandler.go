@@ -339,6 +339,69 @@ func (g *Grpc) GetBlockByHashes(ctx context.Context, in *pb.ReqHashes) (*pb.Bloc
 	return g.cli.GetBlockByHashes(in)
 }
 
+// SubscribeBlockSequence 从 in.Start 开始推送区块序列, 追上最新序列后等待新区块
+// 客户端断开重连时把最后确认处理的序列号+1 作为 Start 即可续传
+func (g *Grpc) SubscribeBlockSequence(in *pb.ReqBlockSeqStream, stream pb.Chain33_SubscribeBlockSequenceServer) error {
+	if in.GetStart() < 0 {
+		return pb.ErrInvalidParam
+	}
+	next := in.GetStart()
+	for {
+		//先取得通知再查询最新序列, 避免漏掉两者之间到达的区块
+		wait := g.notifier.wait()
+		last, err := g.cli.GetLastBlockSequence()
+		if err != nil {
+			return err
+		}
+		for next <= last.GetData() {
+			if next, err = g.sendBlockSequences(stream, next, last.GetData()); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-wait:
+		case <-time.After(seqStreamWaitTimeout):
+		}
+	}
+}
+
+// sendBlockSequences 推送 [start, last] 中的一批序列, 返回下一个需要推送的序列号
+func (g *Grpc) sendBlockSequences(stream pb.Chain33_SubscribeBlockSequenceServer, start, last int64) (int64, error) {
+	end := start + seqStreamBatch - 1
+	if end > last {
+		end = last
+	}
+	seqs, err := g.cli.GetBlockSequences(&pb.ReqBlocks{Start: start, End: end})
+	if err != nil {
+		return start, err
+	}
+	hashes := make([][]byte, len(seqs.GetItems()))
+	for i, item := range seqs.GetItems() {
+		hashes[i] = item.GetHash()
+	}
+	details, err := g.cli.GetBlockByHashes(&pb.ReqHashes{Hashes: hashes})
+	if err != nil {
+		return start, err
+	}
+	if len(details.GetItems()) != len(hashes) {
+		return start, pb.ErrBlockNotFound
+	}
+	for i, item := range seqs.GetItems() {
+		detail := details.GetItems()[i]
+		if detail == nil {
+			log.Error("SubscribeBlockSequence", "seq", start, "hash", hex.EncodeToString(item.GetHash()))
+			return start, pb.ErrBlockNotFound
+		}
+		if err := stream.Send(&pb.BlockSeq{Num: start, Seq: item, Detail: detail}); err != nil {
+			return start, err
+		}
+		start++
+	}
+	return start, nil
+}
+
 // SignRawTx signature rawtransaction
 func (g *Grpc) SignRawTx(ctx context.Context, in *pb.ReqSignRawTx) (*pb.ReplySignRawTx, error) {
 	return g.cli.SignRawTx(in)
@@ -352,3 +415,8 @@ func (g *Grpc) QueryRandNum(ctx context.Context, in *pb.ReqRandHash) (*pb.ReplyH
 	}
 	return reply.(*pb.ReplyHash), nil
 }
+
+// EvictMempoolTx remove the pending transaction from mempool, only allowed for the admin role
+func (g *Grpc) EvictMempoolTx(ctx context.Context, in *pb.ReqHash) (*pb.Reply, error) {
+	return g.cli.EvictMempoolTx(in)
+}
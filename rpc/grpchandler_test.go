@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/33cn/chain33/client/mocks"
 	"github.com/33cn/chain33/types"
@@ -903,6 +904,14 @@ func TestIsSync(t *testing.T) {
 	testIsSyncOK(t)
 }
 
+func TestGrpcEvictMempoolTx(t *testing.T) {
+	in := &pb.ReqHash{Hash: []byte{1}}
+	qapi.On("EvictMempoolTx", in).Return(&pb.Reply{IsOk: true}, nil)
+	data, err := g.EvictMempoolTx(getOkCtx(), in)
+	assert.Nil(t, err)
+	assert.True(t, data.IsOk)
+}
+
 //func testIsNtpClockSyncReject(t *testing.T) {
 //	var in *pb.ReqNil
 //
@@ -1046,3 +1055,66 @@ func TestQueryTransaction(t *testing.T) {
 //func Test_CreateTxGroup(t *testing.T) {
 //	testCreateTxGroupOk(t)
 //}
+
+type mockSeqStream struct {
+	pb.Chain33_SubscribeBlockSequenceServer
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   chan *pb.BlockSeq
+}
+
+func (s *mockSeqStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockSeqStream) Send(seq *pb.BlockSeq) error {
+	s.sent <- seq
+	if len(s.sent) == cap(s.sent) {
+		s.cancel()
+	}
+	return nil
+}
+
+func TestSubscribeBlockSequence(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	grpc := &Grpc{notifier: newSeqNotifier()}
+	grpc.cli.QueueProtocolAPI = api
+
+	seqs := make([]*pb.BlockSequence, 4)
+	details := make([]*pb.BlockDetail, 4)
+	for i := range seqs {
+		details[i] = &pb.BlockDetail{Block: &pb.Block{Height: int64(i)}}
+		seqs[i] = &pb.BlockSequence{Hash: details[i].Block.Hash(), Type: 1}
+	}
+	api.On("GetLastBlockSequence").Return(&pb.Int64{Data: 2}, nil).Once()
+	api.On("GetLastBlockSequence").Return(&pb.Int64{Data: 3}, nil)
+	api.On("GetBlockSequences", &pb.ReqBlocks{Start: 1, End: 2}).Return(&pb.BlockSequences{Items: seqs[1:3]}, nil)
+	api.On("GetBlockSequences", &pb.ReqBlocks{Start: 3, End: 3}).Return(&pb.BlockSequences{Items: seqs[3:]}, nil)
+	api.On("GetBlockByHashes", &pb.ReqHashes{Hashes: [][]byte{seqs[1].Hash, seqs[2].Hash}}).Return(&pb.BlockDetails{Items: details[1:3]}, nil)
+	api.On("GetBlockByHashes", &pb.ReqHashes{Hashes: [][]byte{seqs[3].Hash}}).Return(&pb.BlockDetails{Items: details[3:]}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &mockSeqStream{ctx: ctx, cancel: cancel, sent: make(chan *pb.BlockSeq, 3)}
+	done := make(chan error)
+	go func() {
+		done <- grpc.SubscribeBlockSequence(&pb.ReqBlockSeqStream{Start: 1}, stream)
+	}()
+	//第一批推送完成后通知新区块
+	for len(stream.sent) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	grpc.notifier.notify()
+	err := <-done
+	assert.Equal(t, context.Canceled, err)
+	close(stream.sent)
+	var num int64 = 1
+	for seq := range stream.sent {
+		assert.Equal(t, num, seq.Num)
+		assert.Equal(t, num, seq.Detail.Block.Height)
+		num++
+	}
+	assert.Equal(t, int64(4), num)
+
+	err = grpc.SubscribeBlockSequence(&pb.ReqBlockSeqStream{Start: -1}, stream)
+	assert.Equal(t, pb.ErrInvalidParam, err)
+}
@@ -5,7 +5,6 @@
 package rpc
 
 import (
-	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"fmt"
@@ -13,12 +12,10 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
-	"net/rpc/jsonrpc"
 	"strings"
 
 	"github.com/rs/cors"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 	pr "google.golang.org/grpc/peer"
 )
 
@@ -53,6 +50,10 @@ func (j *JSONRPCServer) Listen() (int, error) {
 		return 0, err
 	}
 	j.l = listener
+	if listener, err = newTLSListener(listener, rpcCfg, "http/1.1"); err != nil {
+		j.l.Close()
+		return 0, err
+	}
 	co := cors.New(cors.Options{})
 
 	// Insert the middleware
@@ -68,42 +69,31 @@ func (j *JSONRPCServer) Listen() (int, error) {
 			writeError(w, r, 0, fmt.Sprintf(`The %s Address is not authorized!`, ip))
 			return
 		}
+		if rpcCfg.EnableWebsocket && r.URL.Path == "/" && isWebsocketRequest(r) {
+			j.serveWebsocket(w, r, ip)
+			return
+		}
 		if r.URL.Path == "/" {
 			data, err := ioutil.ReadAll(r.Body)
 			if err != nil {
 				writeError(w, r, 0, "Can't get request body!")
 				return
 			}
-			//格式做一个检查
-			client, err := parseJSONRpcParams(data)
-			errstr := "nil"
-			if err != nil {
-				errstr = err.Error()
-			}
-			log.Debug("JSONRPCServer", "request", string(data), "err", errstr)
-			if err != nil {
-				writeError(w, r, 0, fmt.Sprintf(`parse request err %s`, err.Error()))
+			resp := j.serveJSONRPC(data, newRPCCaller(ip, httpBearerToken(r)))
+			log.Debug("JSONRPCServer", "request", string(data))
+			w.Header().Set("Content-type", "application/json")
+			//全部是通知请求, 不需要返回内容
+			if resp == nil {
+				w.WriteHeader(http.StatusNoContent)
 				return
 			}
-			//Release local request
-			ipaddr := net.ParseIP(ip)
-			if !ipaddr.IsLoopback() {
-				funcName := strings.Split(client.Method, ".")[len(strings.Split(client.Method, "."))-1]
-				if checkJrpcFuncBlacklist(funcName) || !checkJrpcFuncWhitelist(funcName) {
-					writeError(w, r, client.ID, fmt.Sprintf(`The %s method is not authorized!`, funcName))
-					return
-				}
-			}
-			serverCodec := jsonrpc.NewServerCodec(&HTTPConn{in: ioutil.NopCloser(bytes.NewReader(data)), out: w, r: r})
-			w.Header().Set("Content-type", "application/json")
 			if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
 				w.Header().Set("Content-Encoding", "gzip")
 			}
 			w.WriteHeader(200)
-			err = j.s.ServeRequest(serverCodec)
-			if err != nil {
-				log.Debug("Error while serving JSON request: %v", err)
-				return
+			conn := &HTTPConn{out: w, r: r}
+			if _, err = conn.Write(resp); err != nil {
+				log.Debug("Error while writing JSON response", "err", err)
 			}
 		}
 	})
@@ -138,6 +128,10 @@ func (g *Grpcserver) Listen() (int, error) {
 		return 0, err
 	}
 	g.l = listener
+	if listener, err = newTLSListener(listener, rpcCfg, "h2"); err != nil {
+		g.l.Close()
+		return 0, err
+	}
 	go g.s.Serve(listener)
 	return listener.Addr().(*net.TCPAddr).Port, nil
 }
@@ -149,9 +143,14 @@ func isLoopBackAddr(addr net.Addr) bool {
 	return false
 }
 
-func auth(ctx context.Context, info *grpc.UnaryServerInfo) error {
+func auth(ctx context.Context, fullMethod string) error {
 	getctx, ok := pr.FromContext(ctx)
 	if ok {
+		funcName := grpcFuncName(fullMethod)
+		caller := newRPCCaller(grpcRemoteIP(ctx), grpcBearerToken(ctx))
+		if err := caller.checkAdmin(funcName); err != nil {
+			return err
+		}
 		if isLoopBackAddr(getctx.Addr) {
 			return nil
 		}
@@ -165,15 +164,30 @@ func auth(ctx context.Context, info *grpc.UnaryServerInfo) error {
 			return fmt.Errorf("the %s Address is not authorized", ip)
 		}
 
-		funcName := strings.Split(info.FullMethod, "/")[len(strings.Split(info.FullMethod, "/"))-1]
 		if checkGrpcFuncBlacklist(funcName) || !checkGrpcFuncWhitelist(funcName) {
 			return fmt.Errorf("the %s method is not authorized", funcName)
 		}
-		return nil
+		return caller.checkToken(funcName)
 	}
 	return fmt.Errorf("can't get remote ip")
 }
 
+func grpcFuncName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func grpcRemoteIP(ctx context.Context) string {
+	getctx, ok := pr.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	ip, _, err := net.SplitHostPort(getctx.Addr.String())
+	if err != nil {
+		return getctx.Addr.String()
+	}
+	return ip
+}
+
 type clientRequest struct {
 	Method string         `json:"method"`
 	Params [1]interface{} `json:"params"`
@@ -7,6 +7,7 @@ package rpc
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -116,6 +117,73 @@ func (c *Chain33) SendTransaction(in rpctypes.RawParm, result *interface{}) erro
 	return err
 }
 
+// queryHistory 在指定高度或者状态hash 的历史状态上查询
+func (c *Chain33) queryHistory(in rpctypes.Query4Jrpc, param types.Message) (types.Message, error) {
+	query := &types.ChainExecutor{
+		Driver:   types.ExecName(in.Execer),
+		FuncName: in.FuncName,
+		Param:    types.Encode(param),
+	}
+	if in.Height != nil {
+		if *in.Height < 0 {
+			return nil, types.ErrInvalidParam
+		}
+		query.Height = *in.Height
+		query.HasHeight = true
+	}
+	if in.StateHash != "" {
+		stateHash, err := common.FromHex(in.StateHash)
+		if err != nil {
+			return nil, err
+		}
+		query.StateHash = stateHash
+	}
+	return c.cli.QueryChain(query)
+}
+
+// SimulateTransaction exec the transaction on the latest state without commit, return the receipt and fee
+func (c *Chain33) SimulateTransaction(in rpctypes.RawParm, result *interface{}) error {
+	data, err := common.FromHex(in.Data)
+	if err != nil {
+		return err
+	}
+	var tx types.Transaction
+	if err = types.Decode(data, &tx); err != nil {
+		return err
+	}
+	receipt, header, err := c.cli.SimulateTransaction(&tx)
+	if err != nil {
+		return err
+	}
+	var rd rpctypes.ReceiptData
+	rd.Ty = receipt.Ty
+	for _, l := range receipt.Logs {
+		rd.Logs = append(rd.Logs, &rpctypes.ReceiptLog{Ty: l.Ty, Log: common.ToHex(l.Log)})
+	}
+	decoded, err := rpctypes.DecodeLog(tx.Execer, &rd)
+	if err != nil {
+		return err
+	}
+	res := &rpctypes.SimulateTxResult{
+		Height:    header.Height + 1,
+		StateHash: common.ToHex(header.StateHash),
+		Ty:        decoded.Ty,
+		TyName:    decoded.TyName,
+		Logs:      decoded.Logs,
+	}
+	//收取了手续费才会有 fee 日志
+	for _, l := range receipt.Logs {
+		if l.Ty == types.TyLogFee {
+			res.Fee = tx.Fee
+		}
+	}
+	for _, kv := range receipt.KV {
+		res.KV = append(res.KV, &rpctypes.KeyValueResult{Key: common.ToHex(kv.Key), Value: common.ToHex(kv.Value)})
+	}
+	*result = res
+	return nil
+}
+
 // GetHexTxByHash get hex transaction by hash
 func (c *Chain33) GetHexTxByHash(in rpctypes.QueryParm, result *interface{}) error {
 	var data types.ReqHash
@@ -166,42 +234,11 @@ func (c *Chain33) GetBlocks(in rpctypes.BlockParam, result *interface{}) error {
 		var blockDetails rpctypes.BlockDetails
 		items := reply.GetItems()
 		for _, item := range items {
-			var bdtl rpctypes.BlockDetail
-			var block rpctypes.Block
-			block.BlockTime = item.Block.GetBlockTime()
-			block.Height = item.Block.GetHeight()
-			block.Version = item.Block.GetVersion()
-			block.ParentHash = common.ToHex(item.Block.GetParentHash())
-			block.StateHash = common.ToHex(item.Block.GetStateHash())
-			block.TxHash = common.ToHex(item.Block.GetTxHash())
-			txs := item.Block.GetTxs()
-			if in.Isdetail && len(txs) != len(item.Receipts) { //只有获取详情时才需要校验txs和Receipts的数量是否相等CHAIN33-540
-				return types.ErrDecode
-			}
-			for _, tx := range txs {
-				tran, err := rpctypes.DecodeTx(tx)
-				if err != nil {
-					continue
-				}
-				block.Txs = append(block.Txs, tran)
-			}
-			bdtl.Block = &block
-
-			for i, rp := range item.Receipts {
-				var recp rpctypes.ReceiptData
-				recp.Ty = rp.GetTy()
-				for _, log := range rp.Logs {
-					recp.Logs = append(recp.Logs,
-						&rpctypes.ReceiptLog{Ty: log.Ty, Log: common.ToHex(log.GetLog())})
-				}
-				rd, err := rpctypes.DecodeLog(txs[i].Execer, &recp)
-				if err != nil {
-					continue
-				}
-				bdtl.Receipts = append(bdtl.Receipts, rd)
+			bdtl, err := fmtBlockDetail(item, in.Isdetail)
+			if err != nil {
+				return err
 			}
-
-			blockDetails.Items = append(blockDetails.Items, &bdtl)
+			blockDetails.Items = append(blockDetails.Items, bdtl)
 		}
 		*result = &blockDetails
 	}
@@ -218,27 +255,7 @@ func (c *Chain33) GetLastHeader(in *types.ReqNil, result *interface{}) error {
 		return err
 	}
 
-	{
-		var header rpctypes.Header
-		header.BlockTime = reply.GetBlockTime()
-		header.Height = reply.GetHeight()
-		header.ParentHash = common.ToHex(reply.GetParentHash())
-		header.StateHash = common.ToHex(reply.GetStateHash())
-		header.TxHash = common.ToHex(reply.GetTxHash())
-		header.Version = reply.GetVersion()
-		header.Hash = common.ToHex(reply.GetHash())
-		header.TxCount = reply.TxCount
-		header.Difficulty = reply.GetDifficulty()
-		/* 空值，斩不显示
-		Signature: &Signature{
-			Ty:        reply.GetSignature().GetTy(),
-			Pubkey:    common.ToHex(reply.GetSignature().GetPubkey()),
-			Signature: common.ToHex(reply.GetSignature().GetSignature()),
-		}
-		*/
-		*result = &header
-	}
-
+	*result = fmtHeader(reply)
 	return nil
 }
 
@@ -348,6 +365,68 @@ func fmtTxDetail(tx *types.TransactionDetail, disableDetail bool) (*rpctypes.Tra
 	}, nil
 }
 
+func fmtHeader(item *types.Header) *rpctypes.Header {
+	return &rpctypes.Header{
+		BlockTime:  item.GetBlockTime(),
+		TxCount:    item.GetTxCount(),
+		Hash:       common.ToHex(item.GetHash()),
+		Height:     item.GetHeight(),
+		ParentHash: common.ToHex(item.GetParentHash()),
+		StateHash:  common.ToHex(item.GetStateHash()),
+		TxHash:     common.ToHex(item.GetTxHash()),
+		Difficulty: item.GetDifficulty(),
+		/* 空值，斩不显示
+		Signature: &Signature{
+			Ty:        item.GetSignature().GetTy(),
+			Pubkey:    common.ToHex(item.GetSignature().GetPubkey()),
+			Signature: common.ToHex(item.GetSignature().GetSignature()),
+		},
+		*/
+		Version: item.GetVersion(),
+	}
+}
+
+func fmtBlockDetail(item *types.BlockDetail, isDetail bool) (*rpctypes.BlockDetail, error) {
+	var bdtl rpctypes.BlockDetail
+	var block rpctypes.Block
+	block.BlockTime = item.Block.GetBlockTime()
+	block.Height = item.Block.GetHeight()
+	block.Version = item.Block.GetVersion()
+	block.ParentHash = common.ToHex(item.Block.GetParentHash())
+	block.StateHash = common.ToHex(item.Block.GetStateHash())
+	block.TxHash = common.ToHex(item.Block.GetTxHash())
+	txs := item.Block.GetTxs()
+	if isDetail && len(txs) != len(item.Receipts) { //只有获取详情时才需要校验txs和Receipts的数量是否相等CHAIN33-540
+		return nil, types.ErrDecode
+	}
+	for _, tx := range txs {
+		tran, err := rpctypes.DecodeTx(tx)
+		if err != nil {
+			continue
+		}
+		block.Txs = append(block.Txs, tran)
+	}
+	bdtl.Block = &block
+
+	for i, rp := range item.Receipts {
+		rd, err := decodeReceipt(txs[i].Execer, rp)
+		if err != nil {
+			continue
+		}
+		bdtl.Receipts = append(bdtl.Receipts, rd)
+	}
+	return &bdtl, nil
+}
+
+func decodeReceipt(execer []byte, rp *types.ReceiptData) (*rpctypes.ReceiptDataResult, error) {
+	var recp rpctypes.ReceiptData
+	recp.Ty = rp.GetTy()
+	for _, l := range rp.Logs {
+		recp.Logs = append(recp.Logs, &rpctypes.ReceiptLog{Ty: l.Ty, Log: common.ToHex(l.GetLog())})
+	}
+	return rpctypes.DecodeLog(execer, &recp)
+}
+
 // GetMempool get mempool information
 func (c *Chain33) GetMempool(in *types.ReqNil, result *interface{}) error {
 
@@ -627,28 +706,10 @@ func (c *Chain33) GetHeaders(in types.ReqBlocks, result *interface{}) error {
 		return err
 	}
 	var headers rpctypes.Headers
-	{
-		for _, item := range reply.Items {
-			headers.Items = append(headers.Items, &rpctypes.Header{
-				BlockTime:  item.GetBlockTime(),
-				TxCount:    item.GetTxCount(),
-				Hash:       common.ToHex(item.GetHash()),
-				Height:     item.GetHeight(),
-				ParentHash: common.ToHex(item.GetParentHash()),
-				StateHash:  common.ToHex(item.GetStateHash()),
-				TxHash:     common.ToHex(item.GetTxHash()),
-				Difficulty: item.GetDifficulty(),
-				/* 空值，斩不显示
-				Signature: &Signature{
-					Ty:        item.GetSignature().GetTy(),
-					Pubkey:    common.ToHex(item.GetSignature().GetPubkey()),
-					Signature: common.ToHex(item.GetSignature().GetSignature()),
-				},
-				*/
-				Version: item.GetVersion()})
-		}
-		*result = &headers
+	for _, item := range reply.Items {
+		headers.Items = append(headers.Items, fmtHeader(item))
 	}
+	*result = &headers
 	return nil
 }
 
@@ -674,6 +735,94 @@ func (c *Chain33) GetLastMemPool(in types.ReqNil, result *interface{}) error {
 	return nil
 }
 
+// GetMempoolTx get the pending transaction in mempool by hash
+func (c *Chain33) GetMempoolTx(in rpctypes.QueryParm, result *interface{}) error {
+	hash, err := common.FromHex(in.Hash)
+	if err != nil {
+		return err
+	}
+	reply, err := c.cli.GetMempoolTx(&types.ReqHash{Hash: hash})
+	if err != nil {
+		return err
+	}
+	tran, err := rpctypes.DecodeTx(reply.Tx)
+	if err != nil {
+		return err
+	}
+	tran.Amount, _ = reply.Tx.Amount()
+	*result = &rpctypes.MempoolTx{
+		Tx:        tran,
+		Hash:      common.ToHex(hash),
+		EnterTime: reply.EnterTime,
+		FeeRate:   reply.FeeRate,
+		Size:      reply.Size,
+	}
+	return nil
+}
+
+// GetMempoolTxsByAddr get the pending transactions in mempool of the addresses
+func (c *Chain33) GetMempoolTxsByAddr(in types.ReqAddrs, result *interface{}) error {
+	if len(in.Addrs) == 0 {
+		return types.ErrInvalidParam
+	}
+	reply, err := c.cli.GetMempoolTxsByAddr(&in)
+	if err != nil {
+		return err
+	}
+	txdetails := &rpctypes.TransactionDetails{Txs: make([]*rpctypes.TransactionDetail, 0, len(reply.Txs))}
+	for _, tx := range reply.Txs {
+		txDetail, err := fmtTxDetail(tx, true)
+		if err != nil {
+			continue
+		}
+		txdetails.Txs = append(txdetails.Txs, txDetail)
+	}
+	*result = txdetails
+	return nil
+}
+
+// EvictMempoolTx remove the pending transaction from mempool, only allowed for the admin role
+func (c *Chain33) EvictMempoolTx(in rpctypes.QueryParm, result *interface{}) error {
+	hash, err := common.FromHex(in.Hash)
+	if err != nil {
+		return err
+	}
+	reply, err := c.cli.EvictMempoolTx(&types.ReqHash{Hash: hash})
+	if err != nil {
+		return err
+	}
+	if !reply.IsOk {
+		return errors.New(string(reply.Msg))
+	}
+	*result = &rpctypes.Reply{IsOk: true}
+	return nil
+}
+
+// GetMempoolStat get mempool statistics
+func (c *Chain33) GetMempoolStat(in types.ReqNil, result *interface{}) error {
+	reply, err := c.cli.GetMempoolStat()
+	if err != nil {
+		return err
+	}
+	stat := &rpctypes.MempoolStat{
+		Size:            reply.Size,
+		Bytes:           reply.Bytes,
+		OldestEnterTime: reply.OldestEnterTime,
+		OldestAge:       reply.OldestAge,
+	}
+	if len(reply.OldestHash) > 0 {
+		stat.OldestHash = common.ToHex(reply.OldestHash)
+	}
+	for _, bucket := range reply.FeeHistogram {
+		stat.FeeHistogram = append(stat.FeeHistogram, &rpctypes.MempoolFeeBucket{MinFeeRate: bucket.MinFeeRate, Count: bucket.Count})
+	}
+	for _, execer := range reply.Execers {
+		stat.Execers = append(stat.Execers, &rpctypes.MempoolExecerCount{Execer: execer.Execer, Count: execer.Count})
+	}
+	*result = stat
+	return nil
+}
+
 // GetBlockOverview get overview of block
 // GetBlockOverview(parm *types.ReqHash) (*types.BlockOverview, error)
 func (c *Chain33) GetBlockOverview(in rpctypes.QueryParm, result *interface{}) error {
@@ -868,7 +1017,12 @@ func (c *Chain33) Query(in rpctypes.Query4Jrpc, result *interface{}) error {
 		log.Error("EventQuery1", "err", err.Error())
 		return err
 	}
-	resp, err := c.cli.Query(types.ExecName(in.Execer), in.FuncName, decodePayload)
+	var resp types.Message
+	if in.Height != nil || in.StateHash != "" {
+		resp, err = c.queryHistory(in, decodePayload)
+	} else {
+		resp, err = c.cli.Query(types.ExecName(in.Execer), in.FuncName, decodePayload)
+	}
 	if err != nil {
 		log.Error("EventQuery2", "err", err.Error())
 		return err
@@ -936,6 +1090,90 @@ func (c *Chain33) IsNtpClockSync(in *types.ReqNil, result *interface{}) error {
 	return nil
 }
 
+// GetRateLimitStats get rate limit counters of jsonrpc and grpc
+func (c *Chain33) GetRateLimitStats(in *types.ReqNil, result *interface{}) error {
+	*result = &rpctypes.RPCLimitStats{Jrpc: jrpcLimiter.stats(), Grpc: grpcLimiter.stats()}
+	return nil
+}
+
+// GetLogs get receipt logs by execer, log types, height range and address, need exec.enableLogIndex
+func (c *Chain33) GetLogs(in rpctypes.ReqGetLogs, result *interface{}) error {
+	req := &types.ReqGetLogs{
+		Execer:     in.Execer,
+		LogTypes:   in.LogTypes,
+		FromHeight: in.FromHeight,
+		ToHeight:   in.ToHeight,
+		Addrs:      in.Addrs,
+		Count:      in.Count,
+		Cursor:     in.Cursor,
+	}
+	resp, err := c.cli.Query(types.ExecName("coins"), "GetLogs", req)
+	if err != nil {
+		return err
+	}
+	reply := resp.(*types.ReplyGetLogs)
+	logs := &rpctypes.ReplyGetLogs{Cursor: reply.Cursor, Logs: make([]*rpctypes.ReceiptLogNotify, 0, len(reply.Logs))}
+	for _, l := range reply.Logs {
+		notify := &rpctypes.ReceiptLogNotify{
+			Execer:    l.Execer,
+			Height:    l.Height,
+			BlockHash: common.ToHex(l.BlockHash),
+			TxHash:    common.ToHex(l.TxHash),
+			TxIndex:   int64(l.TxIndex),
+			LogIndex:  int64(l.LogIndex),
+			Ty:        l.Ty,
+			TyName:    "unkownType",
+			RawLog:    common.ToHex(l.Log),
+			From:      l.From,
+			To:        l.To,
+		}
+		logType := types.LoadLog([]byte(l.Execer), int64(l.Ty))
+		if logType != nil {
+			notify.TyName = logType.Name()
+			notify.Log, _ = logType.JSON(l.Log)
+		}
+		logs.Logs = append(logs.Logs, notify)
+	}
+	*result = logs
+	return nil
+}
+
+// GetTxsByAddrFilter get txs of the address filtered by execer, action, direction, status and block time
+func (c *Chain33) GetTxsByAddrFilter(in rpctypes.ReqTxsByAddrFilter, result *interface{}) error {
+	req := &types.ReqTxsByAddrFilter{
+		Addr:       in.Addr,
+		Execer:     in.Execer,
+		ActionName: in.ActionName,
+		Flag:       in.Flag,
+		Status:     in.Status,
+		StartTime:  in.StartTime,
+		EndTime:    in.EndTime,
+		Count:      in.Count,
+		Direction:  in.Direction,
+		Cursor:     in.Cursor,
+	}
+	resp, err := c.cli.Query(types.ExecName("coins"), "GetTxsByAddrFilter", req)
+	if err != nil {
+		return err
+	}
+	reply := resp.(*types.ReplyTxsByAddrFilter)
+	txs := &rpctypes.ReplyTxsByAddrFilter{Total: reply.Total, Cursor: reply.Cursor, Txs: make([]*rpctypes.AddrTxIndex, 0, len(reply.Txs))}
+	for _, tx := range reply.Txs {
+		txs.Txs = append(txs.Txs, &rpctypes.AddrTxIndex{
+			Hash:       common.ToHex(tx.Hash),
+			Height:     tx.Height,
+			Index:      tx.Index,
+			Execer:     tx.Execer,
+			ActionName: tx.ActionName,
+			Ty:         tx.Ty,
+			Blocktime:  tx.Blocktime,
+			Direction:  tx.Direction,
+		})
+	}
+	*result = txs
+	return nil
+}
+
 // QueryTotalFee query total fee
 func (c *Chain33) QueryTotalFee(in *types.LocalDBGet, result *interface{}) error {
 	reply, err := c.cli.LocalGet(in)
@@ -1121,6 +1359,129 @@ func (c *Chain33) GetBlockSequences(in rpctypes.BlockParam, result *interface{})
 	return nil
 }
 
+// AddSeqCallBack add or update the subscription of block sequence push
+func (c *Chain33) AddSeqCallBack(in types.BlockSeqCB, result *interface{}) error {
+	reply, err := c.cli.AddSeqCallBack(&in)
+	if err != nil {
+		return err
+	}
+	*result = reply
+	return nil
+}
+
+// ListSeqCallBack list the subscriptions with the push progress and state
+func (c *Chain33) ListSeqCallBack(in *types.ReqNil, result *interface{}) error {
+	reply, err := c.cli.ListSeqCallBack()
+	if err != nil {
+		return err
+	}
+	*result = reply
+	return nil
+}
+
+// PauseSeqCallBack pause the subscription
+func (c *Chain33) PauseSeqCallBack(in types.ReqString, result *interface{}) error {
+	reply, err := c.cli.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: in.Data, Status: types.SeqCBPaused})
+	if err != nil {
+		return err
+	}
+	*result = reply
+	return nil
+}
+
+// ResumeSeqCallBack resume the paused or dead letter subscription
+func (c *Chain33) ResumeSeqCallBack(in types.ReqString, result *interface{}) error {
+	reply, err := c.cli.SetSeqCallBackStatus(&types.ReqSetSeqCBStatus{Name: in.Data, Status: types.SeqCBActive})
+	if err != nil {
+		return err
+	}
+	*result = reply
+	return nil
+}
+
+// DelSeqCallBack delete the subscription
+func (c *Chain33) DelSeqCallBack(in types.ReqString, result *interface{}) error {
+	reply, err := c.cli.DelSeqCallBack(&in)
+	if err != nil {
+		return err
+	}
+	*result = reply
+	return nil
+}
+
+// GetCheckpoint get the current checkpoint, blocks not higher than finalizedHeight can not be reorganized
+func (c *Chain33) GetCheckpoint(in *types.ReqNil, result *interface{}) error {
+	reply, err := c.cli.GetCheckpoint()
+	if err != nil {
+		return err
+	}
+	checkpoint := &rpctypes.Checkpoint{
+		Height:          -1,
+		FinalizedDepth:  reply.FinalizedDepth,
+		FinalizedHeight: reply.FinalizedHeight,
+	}
+	if reply.Checkpoint != nil {
+		checkpoint.Height = reply.Checkpoint.Height
+		checkpoint.Hash = common.ToHex(reply.Checkpoint.Hash)
+	}
+	*result = checkpoint
+	return nil
+}
+
+// GetStateProof get state value with mavl proof, light client verifies the proof with the local header
+func (c *Chain33) GetStateProof(in rpctypes.ReqStateProof, result *interface{}) error {
+	reply, err := c.cli.GetStateProof(&types.ReqStateProof{Key: []byte(in.Key), Height: in.Height})
+	if err != nil {
+		return err
+	}
+	*result = &rpctypes.StateProof{
+		Height:    reply.Height,
+		StateHash: common.ToHex(reply.StateHash),
+		Key:       string(reply.Key),
+		Value:     common.ToHex(reply.Value),
+		Proof:     common.ToHex(reply.Proof),
+	}
+	return nil
+}
+
+// GetReorgHistory get the latest reorg records and orphan pool statistics
+func (c *Chain33) GetReorgHistory(in rpctypes.ReqReorgHistory, result *interface{}) error {
+	reply, err := c.cli.GetReorgHistory(&types.ReqReorgHistory{Count: in.Count})
+	if err != nil {
+		return err
+	}
+	history := &rpctypes.ReorgHistory{Items: make([]*rpctypes.ReorgRecord, 0, len(reply.Items))}
+	for _, item := range reply.Items {
+		record := &rpctypes.ReorgRecord{
+			Index:      item.Index,
+			ForkHeight: item.ForkHeight,
+			ForkHash:   common.ToHex(item.ForkHash),
+			Pid:        item.Pid,
+			Time:       item.Time,
+			OldTd:      item.OldTd,
+			NewTd:      item.NewTd,
+		}
+		for _, hash := range item.Detached {
+			record.Detached = append(record.Detached, common.ToHex(hash))
+		}
+		for _, hash := range item.Attached {
+			record.Attached = append(record.Attached, common.ToHex(hash))
+		}
+		history.Items = append(history.Items, record)
+	}
+	if reply.Orphans != nil {
+		history.Orphans = &rpctypes.OrphanPoolStat{
+			Count:     reply.Orphans.Count,
+			Parents:   reply.Orphans.Parents,
+			MaxCount:  reply.Orphans.MaxCount,
+			MinHeight: reply.Orphans.MinHeight,
+			MaxHeight: reply.Orphans.MaxHeight,
+		}
+	}
+	*result = history
+	return nil
+}
+
 // GetBlockByHashes get block information by hashes
 func (c *Chain33) GetBlockByHashes(in rpctypes.ReqHashes, result *interface{}) error {
 	log.Warn("GetBlockByHashes", "hashes", in)
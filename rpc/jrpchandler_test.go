@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"encoding/hex"
+	"encoding/json"
 
 	"github.com/33cn/chain33/client/mocks"
 	"github.com/33cn/chain33/common"
@@ -1182,6 +1183,195 @@ func TestChain33_GetLastBlockSequence(t *testing.T) {
 	assert.Equal(t, int64(1), result2)
 }
 
+func TestChain33_SeqCallBack(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	cb := types.BlockSeqCB{Name: "test", URL: "127.0.0.1:8805", Transport: types.SeqCBTransportGRPC, BatchSize: 10}
+	api.On("AddSeqCallBack", &cb).Return(&types.Reply{IsOk: true}, nil)
+	err := client.AddSeqCallBack(cb, &result)
+	assert.Nil(t, err)
+	assert.True(t, result.(*types.Reply).IsOk)
+
+	cbs := &types.BlockSeqCBs{Items: []*types.BlockSeqCBInfo{{Cb: &cb, LastNum: 10, State: &types.BlockSeqCBState{}}}}
+	api.On("ListSeqCallBack").Return(cbs, nil)
+	err = client.ListSeqCallBack(&types.ReqNil{}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, cbs, result)
+
+	api.On("SetSeqCallBackStatus", &types.ReqSetSeqCBStatus{Name: "test", Status: types.SeqCBPaused}).Return(&types.Reply{IsOk: true}, nil)
+	err = client.PauseSeqCallBack(types.ReqString{Data: "test"}, &result)
+	assert.Nil(t, err)
+	api.On("SetSeqCallBackStatus", &types.ReqSetSeqCBStatus{Name: "test", Status: types.SeqCBActive}).Return(nil, types.ErrSeqCBNotFound)
+	err = client.ResumeSeqCallBack(types.ReqString{Data: "test"}, &result)
+	assert.Equal(t, types.ErrSeqCBNotFound, err)
+
+	api.On("DelSeqCallBack", &types.ReqString{Data: "test"}).Return(&types.Reply{IsOk: true}, nil)
+	err = client.DelSeqCallBack(types.ReqString{Data: "test"}, &result)
+	assert.Nil(t, err)
+}
+
+func TestChain33_GetCheckpoint(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	api.On("GetCheckpoint").Return(&types.ReplyCheckpoint{FinalizedDepth: 10, FinalizedHeight: 90}, nil).Once()
+	err := client.GetCheckpoint(&types.ReqNil{}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, &rpctypes.Checkpoint{Height: -1, FinalizedDepth: 10, FinalizedHeight: 90}, result)
+
+	point := &types.ChainCheckpoint{Height: 100, Hash: []byte{1, 2}}
+	api.On("GetCheckpoint").Return(&types.ReplyCheckpoint{Checkpoint: point, FinalizedDepth: 10, FinalizedHeight: 100}, nil).Once()
+	err = client.GetCheckpoint(&types.ReqNil{}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, &rpctypes.Checkpoint{Height: 100, Hash: "0x0102", FinalizedDepth: 10, FinalizedHeight: 100}, result)
+
+	api.On("GetCheckpoint").Return(nil, types.ErrTypeAsset)
+	err = client.GetCheckpoint(&types.ReqNil{}, &result)
+	assert.Equal(t, types.ErrTypeAsset, err)
+}
+
+func TestChain33_GetStateProof(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	req := &types.ReqStateProof{Key: []byte("mavl-coins-bty-addr"), Height: -1}
+	proof := &types.StateProof{Height: 10, StateHash: []byte{1}, Key: req.Key, Value: []byte{2}, Proof: []byte{3}}
+	api.On("GetStateProof", req).Return(proof, nil).Once()
+	err := client.GetStateProof(rpctypes.ReqStateProof{Key: "mavl-coins-bty-addr", Height: -1}, &result)
+	assert.Nil(t, err)
+	expect := &rpctypes.StateProof{Height: 10, StateHash: "0x01", Key: "mavl-coins-bty-addr", Value: "0x02", Proof: "0x03"}
+	assert.Equal(t, expect, result)
+
+	api.On("GetStateProof", mock.Anything).Return(nil, types.ErrNotFound)
+	err = client.GetStateProof(rpctypes.ReqStateProof{Key: "key"}, &result)
+	assert.Equal(t, types.ErrNotFound, err)
+}
+
+func TestChain33_GetReorgHistory(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	reply := &types.ReorgHistory{
+		Items: []*types.ReorgRecord{{
+			Index:      1,
+			ForkHeight: 10,
+			ForkHash:   []byte{1},
+			Detached:   [][]byte{{2}},
+			Attached:   [][]byte{{3}, {4}},
+			Pid:        "peer",
+			Time:       100,
+			OldTd:      "11",
+			NewTd:      "12",
+		}},
+		Orphans: &types.OrphanPoolStat{Count: 1, Parents: 1, MaxCount: 10240, MinHeight: 20, MaxHeight: 20},
+	}
+	api.On("GetReorgHistory", &types.ReqReorgHistory{Count: 5}).Return(reply, nil).Once()
+	err := client.GetReorgHistory(rpctypes.ReqReorgHistory{Count: 5}, &result)
+	assert.Nil(t, err)
+	expect := &rpctypes.ReorgHistory{
+		Items: []*rpctypes.ReorgRecord{{
+			Index:      1,
+			ForkHeight: 10,
+			ForkHash:   "0x01",
+			Detached:   []string{"0x02"},
+			Attached:   []string{"0x03", "0x04"},
+			Pid:        "peer",
+			Time:       100,
+			OldTd:      "11",
+			NewTd:      "12",
+		}},
+		Orphans: &rpctypes.OrphanPoolStat{Count: 1, Parents: 1, MaxCount: 10240, MinHeight: 20, MaxHeight: 20},
+	}
+	assert.Equal(t, expect, result)
+
+	api.On("GetReorgHistory", mock.Anything).Return(nil, types.ErrInvalidParam)
+	err = client.GetReorgHistory(rpctypes.ReqReorgHistory{}, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+func TestChain33_GetTxsByAddrFilter(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	req := &types.ReqTxsByAddrFilter{Addr: "addr", Execer: "coins", ActionName: "transfer", Flag: 1, Status: 1, Count: 10}
+	reply := &types.ReplyTxsByAddrFilter{
+		Txs:    []*types.AddrTxIndex{{Hash: []byte{1}, Height: 2, Index: 3, Execer: "coins", ActionName: "transfer", Ty: types.ExecOk, Blocktime: 100, Direction: 1}},
+		Total:  5,
+		Cursor: "000000000000200003",
+	}
+	api.On("Query", types.ExecName("coins"), "GetTxsByAddrFilter", req).Return(reply, nil).Once()
+	err := client.GetTxsByAddrFilter(rpctypes.ReqTxsByAddrFilter{Addr: "addr", Execer: "coins", ActionName: "transfer", Flag: 1, Status: 1, Count: 10}, &result)
+	assert.Nil(t, err)
+	expect := &rpctypes.ReplyTxsByAddrFilter{
+		Txs:    []*rpctypes.AddrTxIndex{{Hash: "0x01", Height: 2, Index: 3, Execer: "coins", ActionName: "transfer", Ty: types.ExecOk, Blocktime: 100, Direction: 1}},
+		Total:  5,
+		Cursor: "000000000000200003",
+	}
+	assert.Equal(t, expect, result)
+
+	api.On("Query", types.ExecName("coins"), "GetTxsByAddrFilter", mock.Anything).Return(nil, types.ErrInvalidParam)
+	err = client.GetTxsByAddrFilter(rpctypes.ReqTxsByAddrFilter{}, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
+
+func TestChain33_MempoolQuery(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	client := newTestChain33(api)
+	var result interface{}
+	tx := &types.Transaction{Execer: []byte("coins"), Fee: 100000, To: "to"}
+	api.On("GetMempoolTx", &types.ReqHash{Hash: []byte{1}}).Return(&types.MempoolTx{Tx: tx, EnterTime: 10, FeeRate: 200000, Size: 500}, nil)
+	err := client.GetMempoolTx(rpctypes.QueryParm{Hash: "0x01"}, &result)
+	assert.Nil(t, err)
+	mtx := result.(*rpctypes.MempoolTx)
+	assert.Equal(t, "0x01", mtx.Hash)
+	assert.Equal(t, int64(200000), mtx.FeeRate)
+	assert.Equal(t, int64(500), mtx.Size)
+	assert.Equal(t, "coins", mtx.Tx.Execer)
+	api.On("GetMempoolTx", &types.ReqHash{Hash: []byte{2}}).Return(nil, types.ErrTxNotExist)
+	err = client.GetMempoolTx(rpctypes.QueryParm{Hash: "0x02"}, &result)
+	assert.Equal(t, types.ErrTxNotExist, err)
+
+	err = client.GetMempoolTxsByAddr(types.ReqAddrs{}, &result)
+	assert.Equal(t, types.ErrInvalidParam, err)
+	api.On("GetMempoolTxsByAddr", &types.ReqAddrs{Addrs: []string{"addr"}}).Return(&types.TransactionDetails{
+		Txs: []*types.TransactionDetail{{Tx: tx, Fromaddr: "addr", ActionName: "transfer"}}}, nil)
+	err = client.GetMempoolTxsByAddr(types.ReqAddrs{Addrs: []string{"addr"}}, &result)
+	assert.Nil(t, err)
+	details := result.(*rpctypes.TransactionDetails)
+	assert.Equal(t, 1, len(details.Txs))
+	assert.Equal(t, "addr", details.Txs[0].Fromaddr)
+
+	api.On("EvictMempoolTx", &types.ReqHash{Hash: []byte{1}}).Return(&types.Reply{IsOk: true}, nil)
+	err = client.EvictMempoolTx(rpctypes.QueryParm{Hash: "0x01"}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, &rpctypes.Reply{IsOk: true}, result)
+	api.On("EvictMempoolTx", &types.ReqHash{Hash: []byte{2}}).Return(&types.Reply{Msg: []byte(types.ErrTxNotExist.Error())}, nil)
+	err = client.EvictMempoolTx(rpctypes.QueryParm{Hash: "0x02"}, &result)
+	assert.Equal(t, types.ErrTxNotExist.Error(), err.Error())
+
+	api.On("GetMempoolStat").Return(&types.MempoolStat{
+		Size:            2,
+		Bytes:           600,
+		FeeHistogram:    []*types.MempoolFeeBucket{{MinFeeRate: 0, Count: 0}, {MinFeeRate: 100000, Count: 2}},
+		Execers:         []*types.MempoolExecerCount{{Execer: "coins", Count: 2}},
+		OldestHash:      []byte{1},
+		OldestEnterTime: 10,
+		OldestAge:       5,
+	}, nil)
+	err = client.GetMempoolStat(types.ReqNil{}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, &rpctypes.MempoolStat{
+		Size:            2,
+		Bytes:           600,
+		FeeHistogram:    []*rpctypes.MempoolFeeBucket{{MinFeeRate: 0, Count: 0}, {MinFeeRate: 100000, Count: 2}},
+		Execers:         []*rpctypes.MempoolExecerCount{{Execer: "coins", Count: 2}},
+		OldestHash:      "0x01",
+		OldestEnterTime: 10,
+		OldestAge:       5,
+	}, result)
+}
+
 func TestChain33_GetBlockSequences(t *testing.T) {
 	api := new(mocks.QueueProtocolAPI)
 	client := newTestChain33(api)
@@ -1260,3 +1450,70 @@ func TestChain33_GetExecBalance(t *testing.T) {
 	err = client.GetExecBalance(in, &testResult2)
 	assert.NotNil(t, err)
 }
+
+func TestChain33_SimulateTransaction(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	testChain33 := newTestChain33(api)
+
+	tx := &types.Transaction{Execer: []byte("coins"), Fee: 100000}
+	header := &types.Header{Height: 10, StateHash: []byte("statehash")}
+	api.On("GetLastHeader").Return(header, nil)
+	receipt := &types.Receipt{
+		Ty:   types.ExecOk,
+		KV:   []*types.KeyValue{{Key: []byte("k"), Value: []byte("v")}},
+		Logs: []*types.ReceiptLog{{Ty: types.TyLogFee, Log: types.Encode(&types.ReceiptAccountTransfer{})}},
+	}
+	api.On("ExecTxList", mock.MatchedBy(func(list *types.ExecTxList) bool {
+		return list.Height == 11 && string(list.StateHash) == "statehash" && len(list.Txs) == 1
+	})).Return(&types.Receipts{Receipts: []*types.Receipt{receipt}}, nil)
+
+	var testResult interface{}
+	err := testChain33.SimulateTransaction(rpctypes.RawParm{Data: common.ToHex(types.Encode(tx))}, &testResult)
+	assert.Nil(t, err)
+	res := testResult.(*rpctypes.SimulateTxResult)
+	assert.Equal(t, int64(11), res.Height)
+	assert.Equal(t, "ExecOk", res.TyName)
+	assert.Equal(t, int64(100000), res.Fee)
+	assert.Equal(t, common.ToHex([]byte("k")), res.KV[0].Key)
+	assert.Equal(t, "LogFee", res.Logs[0].TyName)
+
+	tx.GroupCount = 2
+	err = testChain33.SimulateTransaction(rpctypes.RawParm{Data: common.ToHex(types.Encode(tx))}, &testResult)
+	assert.Equal(t, types.ErrTxGroupNotSupport, err)
+}
+
+func TestChain33_QueryHistory(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	testChain33 := newTestChain33(api)
+
+	api.On("QueryChain", mock.MatchedBy(func(query *types.ChainExecutor) bool {
+		return query.Height == 10 && query.HasHeight && query.Driver == "coins" && query.FuncName == "GetAddrReciver"
+	})).Return(&types.Int64{Data: 100}, nil)
+	var testResult interface{}
+	height := int64(10)
+	in := rpctypes.Query4Jrpc{Execer: "coins", FuncName: "GetAddrReciver", Payload: []byte(`{"addr":"1addr"}`), Height: &height}
+	err := testChain33.Query(in, &testResult)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"data":"100"}`, string(testResult.(json.RawMessage)))
+
+	api.On("QueryChain", mock.MatchedBy(func(query *types.ChainExecutor) bool {
+		return string(query.StateHash) == "hash"
+	})).Return(nil, types.ErrStateHashPruned)
+	in.Height = nil
+	in.StateHash = common.ToHex([]byte("hash"))
+	err = testChain33.Query(in, &testResult)
+	assert.Equal(t, types.ErrStateHashPruned, err)
+
+	//高度0 查询创世区块的状态
+	api.On("QueryChain", mock.MatchedBy(func(query *types.ChainExecutor) bool {
+		return query.Height == 0 && query.HasHeight
+	})).Return(&types.Int64{Data: 0}, nil)
+	height = 0
+	in = rpctypes.Query4Jrpc{Execer: "coins", FuncName: "GetAddrReciver", Payload: []byte(`{"addr":"1addr"}`), Height: &height}
+	err = testChain33.Query(in, &testResult)
+	assert.Nil(t, err)
+
+	height = -1
+	err = testChain33.Query(in, &testResult)
+	assert.Equal(t, types.ErrInvalidParam, err)
+}
@@ -7,7 +7,10 @@ package jsonclient
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -21,6 +24,49 @@ import (
 type JSONClient struct {
 	url    string
 	prefix string
+	token  string
+	client *http.Client
+}
+
+var defaultClient = http.DefaultClient
+var defaultToken string
+
+// SetToken 设置默认的认证 token, 之后创建的 JSONClient 都通过 Authorization header 发送这个 token
+func SetToken(token string) {
+	defaultToken = token
+}
+
+// NewTLSConfig 生成连接 https 节点的 tls 配置, caFile 为空时使用系统的根证书, certFile 和 keyFile 用于双向认证
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		data, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("ErrNoCertificateInCAFile")
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// SetTLSConfig 设置默认的 tls 配置, 之后创建的 JSONClient 都使用这个配置
+func SetTLSConfig(cfg *tls.Config) {
+	if cfg == nil {
+		defaultClient = http.DefaultClient
+		return
+	}
+	defaultClient = &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
 }
 
 func addPrefix(prefix, name string) string {
@@ -32,12 +78,18 @@ func addPrefix(prefix, name string) string {
 
 // NewJSONClient produce a json object
 func NewJSONClient(url string) (*JSONClient, error) {
-	return &JSONClient{url: url, prefix: "Chain33"}, nil
+	return &JSONClient{url: url, prefix: "Chain33", token: defaultToken, client: defaultClient}, nil
 }
 
 // New produce a jsonclient by perfix and url
 func New(prefix, url string) (*JSONClient, error) {
-	return &JSONClient{url: url, prefix: prefix}, nil
+	return &JSONClient{url: url, prefix: prefix, token: defaultToken, client: defaultClient}, nil
+}
+
+// NewWithTLS produce a jsonclient which connect https url with tls config
+func NewWithTLS(prefix, url string, cfg *tls.Config) (*JSONClient, error) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	return &JSONClient{url: url, prefix: prefix, token: defaultToken, client: client}, nil
 }
 
 type clientRequest struct {
@@ -63,7 +115,15 @@ func (client *JSONClient) Call(method string, params, resp interface{}) error {
 		return err
 	}
 	//println("request JsonStr", string(data), "")
-	postresp, err := http.Post(client.url, "application/json", bytes.NewBuffer(data))
+	httpreq, err := http.NewRequest("POST", client.url, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	httpreq.Header.Set("Content-Type", "application/json")
+	if client.token != "" {
+		httpreq.Header.Set("Authorization", "Bearer "+client.token)
+	}
+	postresp, err := client.client.Do(httpreq)
 	if err != nil {
 		return err
 	}
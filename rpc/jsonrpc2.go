@@ -0,0 +1,224 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"strings"
+
+	"github.com/33cn/chain33/types"
+)
+
+// jsonrpc 2.0 规范中定义的错误码
+const (
+	JSONRPCParseError     = -32700
+	JSONRPCInvalidRequest = -32600
+	JSONRPCMethodNotFound = -32601
+	JSONRPCInvalidParams  = -32602
+	JSONRPCInternalError  = -32603
+	// JSONRPCNotAuthorized ip 或者方法没有授权
+	JSONRPCNotAuthorized = -32001
+)
+
+const jsonrpcVersion = "2.0"
+
+type jsonrpc2Request struct {
+	Version string           `json:"jsonrpc"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params"`
+	ID      *json.RawMessage `json:"id"`
+}
+
+// JSONRPCError jsonrpc 2.0 的错误对象
+type JSONRPCError struct {
+	Code    int32       `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *JSONRPCError) Error() string {
+	return e.Message
+}
+
+type jsonrpc2Response struct {
+	Version string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *JSONRPCError    `json:"error,omitempty"`
+}
+
+func newJSONRPCError(code int32, msg string) *JSONRPCError {
+	return &JSONRPCError{Code: code, Message: msg}
+}
+
+// jsonrpc2Codec 处理一个 jsonrpc 2.0 请求的 rpc.ServerCodec
+type jsonrpc2Codec struct {
+	req      *jsonrpc2Request
+	resp     *jsonrpc2Response
+	paramErr bool
+}
+
+func (c *jsonrpc2Codec) ReadRequestHeader(r *rpc.Request) error {
+	r.ServiceMethod = c.req.Method
+	r.Seq = 0
+	return nil
+}
+
+// ReadRequestBody 参数支持 chain33 原有的 [param] 数组格式, 也支持直接传对象
+func (c *jsonrpc2Codec) ReadRequestBody(x interface{}) error {
+	if x == nil {
+		return nil
+	}
+	params := bytes.TrimSpace(c.req.Params)
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	var err error
+	if params[0] == '[' {
+		var args [1]interface{}
+		args[0] = x
+		err = json.Unmarshal(params, &args)
+	} else {
+		err = json.Unmarshal(params, x)
+	}
+	if err != nil {
+		c.paramErr = true
+	}
+	return err
+}
+
+func (c *jsonrpc2Codec) WriteResponse(r *rpc.Response, x interface{}) error {
+	if r.Error == "" {
+		c.resp.Result = x
+		return nil
+	}
+	switch {
+	case c.paramErr:
+		c.resp.Error = newJSONRPCError(JSONRPCInvalidParams, r.Error)
+	case strings.HasPrefix(r.Error, "rpc: can't find"):
+		c.resp.Error = newJSONRPCError(JSONRPCMethodNotFound, r.Error)
+	default:
+		c.resp.Error = newJSONRPCError(types.GetErrCode(r.Error), r.Error)
+	}
+	return nil
+}
+
+func (c *jsonrpc2Codec) Close() error {
+	return nil
+}
+
+func isJSONRPCBatch(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
+}
+
+// checkJrpcFuncAuth 检查管理接口, 方法的白名单/黑名单以及调用者 token 对应的角色
+func checkJrpcFuncAuth(caller *rpcCaller, method string) error {
+	funcName := method[strings.LastIndex(method, ".")+1:]
+	if err := caller.checkAdmin(funcName); err != nil {
+		return err
+	}
+	if !net.ParseIP(caller.ip).IsLoopback() {
+		if checkJrpcFuncBlacklist(funcName) || !checkJrpcFuncWhitelist(funcName) {
+			return fmt.Errorf(`The %s method is not authorized!`, funcName)
+		}
+	}
+	return caller.checkToken(funcName)
+}
+
+// serveJSONRPC 处理一个 http body, 可以是 jsonrpc 2.0 的单个请求或者批量请求,
+// 没有 "jsonrpc":"2.0" 的单个请求按照原来的格式处理. 返回 nil 表示全部是通知, 不需要回复
+func (j *JSONRPCServer) serveJSONRPC(data []byte, caller *rpcCaller) []byte {
+	if isJSONRPCBatch(data) {
+		var reqs []json.RawMessage
+		if err := json.Unmarshal(data, &reqs); err != nil {
+			return mustMarshal(&jsonrpc2Response{Version: jsonrpcVersion, Error: newJSONRPCError(JSONRPCParseError, err.Error())})
+		}
+		if len(reqs) == 0 {
+			return mustMarshal(&jsonrpc2Response{Version: jsonrpcVersion, Error: newJSONRPCError(JSONRPCInvalidRequest, "empty batch")})
+		}
+		var resps []*jsonrpc2Response
+		for _, raw := range reqs {
+			if resp := j.serveJSONRPC2(raw, caller); resp != nil {
+				resps = append(resps, resp)
+			}
+		}
+		if len(resps) == 0 {
+			return nil
+		}
+		return mustMarshal(resps)
+	}
+	var req jsonrpc2Request
+	if json.Unmarshal(data, &req) == nil && req.Version == jsonrpcVersion {
+		if resp := j.serveJSONRPC2(data, caller); resp != nil {
+			return mustMarshal(resp)
+		}
+		return nil
+	}
+	return j.serveJSONRPC1(data, caller)
+}
+
+// serveJSONRPC1 兼容原来的 jsonrpc 请求格式
+func (j *JSONRPCServer) serveJSONRPC1(data []byte, caller *rpcCaller) []byte {
+	client, err := parseJSONRpcParams(data)
+	if err != nil {
+		return mustMarshal(&serverResponse{0, nil, fmt.Sprintf(`parse request err %s`, err.Error())})
+	}
+	if err = checkJrpcFuncAuth(caller, client.Method); err != nil {
+		return mustMarshal(&serverResponse{client.ID, nil, err.Error()})
+	}
+	if client.Method == discoverMethod {
+		return mustMarshal(&serverResponse{client.ID, OpenRPCDocument(), nil})
+	}
+	release, err := jrpcLimiter.acquire(caller.ip, client.Method[strings.LastIndex(client.Method, ".")+1:])
+	if err != nil {
+		return mustMarshal(&serverResponse{client.ID, nil, err.Error()})
+	}
+	defer release()
+	out := &bytes.Buffer{}
+	serverCodec := jsonrpc.NewServerCodec(&wsRPCConn{in: bytes.NewReader(data), out: out})
+	if err := j.s.ServeRequest(serverCodec); err != nil {
+		log.Debug("Error while serving JSON request", "err", err)
+	}
+	return out.Bytes()
+}
+
+// serveJSONRPC2 处理单个 jsonrpc 2.0 请求, 通知请求返回 nil
+func (j *JSONRPCServer) serveJSONRPC2(data []byte, caller *rpcCaller) *jsonrpc2Response {
+	var req jsonrpc2Request
+	resp := &jsonrpc2Response{Version: jsonrpcVersion}
+	if err := json.Unmarshal(data, &req); err != nil {
+		resp.Error = newJSONRPCError(JSONRPCInvalidRequest, err.Error())
+		return resp
+	}
+	resp.ID = req.ID
+	if req.Version != jsonrpcVersion || req.Method == "" {
+		resp.Error = newJSONRPCError(JSONRPCInvalidRequest, "invalid request")
+		return resp
+	}
+	if err := checkJrpcFuncAuth(caller, req.Method); err != nil {
+		resp.Error = newJSONRPCError(JSONRPCNotAuthorized, err.Error())
+	} else if req.Method == discoverMethod {
+		resp.Result = OpenRPCDocument()
+	} else if release, err := jrpcLimiter.acquire(caller.ip, req.Method[strings.LastIndex(req.Method, ".")+1:]); err != nil {
+		resp.Error = newJSONRPCError(types.GetErrCode(err.Error()), err.Error())
+	} else {
+		codec := &jsonrpc2Codec{req: &req, resp: resp}
+		if err := j.s.ServeRequest(codec); err != nil {
+			log.Debug("Error while serving JSON request", "method", req.Method, "err", err)
+		}
+		release()
+	}
+	//没有 id 的是通知请求, 不需要回复
+	if req.ID == nil {
+		return nil
+	}
+	return resp
+}
@@ -0,0 +1,116 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	qmocks "github.com/33cn/chain33/queue/mocks"
+	"github.com/33cn/chain33/rpc/openrpc"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testJSONRPC2Response struct {
+	Version string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  *json.RawMessage `json:"result"`
+	Error   *JSONRPCError    `json:"error"`
+}
+
+func TestServeJSONRPC(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	api.On("Version").Return(&types.VersionInfo{Chain33: "6.0.2"}, nil)
+	api.On("GetBlockHash", &types.ReqInt{Height: 100}).Return(nil, types.ErrInvalidParam)
+	server := NewJSONRPCServer(&qmocks.Client{}, api)
+
+	//单个 2.0 请求
+	data := server.serveJSONRPC([]byte(`{"jsonrpc":"2.0","id":"a","method":"Chain33.Version","params":[]}`), &rpcCaller{ip: "127.0.0.1"})
+	var resp testJSONRPC2Response
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, "2.0", resp.Version)
+	assert.Equal(t, `"a"`, string(*resp.ID))
+	assert.Nil(t, resp.Error)
+	assert.Contains(t, string(*resp.Result), "6.0.2")
+
+	//批量请求, 通知没有返回
+	batch := `[
+		{"jsonrpc":"2.0","id":1,"method":"Chain33.Version"},
+		{"jsonrpc":"2.0","method":"Chain33.Version"},
+		{"jsonrpc":"2.0","id":2,"method":"Chain33.NotExist"},
+		{"jsonrpc":"2.0","id":3,"method":"Chain33.GetBlockHash","params":[{"height":"x"}]},
+		{"jsonrpc":"2.0","id":4,"method":"Chain33.GetBlockHash","params":{"height":100}},
+		{"id":5,"method":"Chain33.Version"},
+		1
+	]`
+	data = server.serveJSONRPC([]byte(batch), &rpcCaller{ip: "127.0.0.1"})
+	var resps []*testJSONRPC2Response
+	assert.Nil(t, json.Unmarshal(data, &resps))
+	assert.Equal(t, 6, len(resps))
+	assert.Equal(t, "1", string(*resps[0].ID))
+	assert.Nil(t, resps[0].Error)
+	assert.Equal(t, int32(JSONRPCMethodNotFound), resps[1].Error.Code)
+	assert.Equal(t, int32(JSONRPCInvalidParams), resps[2].Error.Code)
+	assert.Equal(t, types.GetErrCode(types.ErrInvalidParam.Error()), resps[3].Error.Code)
+	assert.Equal(t, types.ErrInvalidParam.Error(), resps[3].Error.Message)
+	assert.Equal(t, int32(JSONRPCInvalidRequest), resps[4].Error.Code)
+	assert.Equal(t, int32(JSONRPCInvalidRequest), resps[5].Error.Code)
+	assert.Nil(t, resps[5].ID)
+
+	//全部是通知
+	assert.Nil(t, server.serveJSONRPC([]byte(`[{"jsonrpc":"2.0","method":"Chain33.Version"}]`), &rpcCaller{ip: "127.0.0.1"}))
+
+	data = server.serveJSONRPC([]byte(`[]`), &rpcCaller{ip: "127.0.0.1"})
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, int32(JSONRPCInvalidRequest), resp.Error.Code)
+
+	data = server.serveJSONRPC([]byte(`[{"jsonrpc":"2.0",`), &rpcCaller{ip: "127.0.0.1"})
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, int32(JSONRPCParseError), resp.Error.Code)
+
+	//没有授权的方法
+	whitelist := jrpcFuncWhitelist
+	jrpcFuncWhitelist = make(map[string]bool)
+	defer func() { jrpcFuncWhitelist = whitelist }()
+	data = server.serveJSONRPC([]byte(`{"jsonrpc":"2.0","id":1,"method":"Chain33.Version"}`), &rpcCaller{ip: "192.168.1.1"})
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, int32(JSONRPCNotAuthorized), resp.Error.Code)
+
+	//兼容原来的格式
+	data = server.serveJSONRPC([]byte(`{"id":1,"method":"Chain33.Version","params":[{}]}`), &rpcCaller{ip: "127.0.0.1"})
+	var legacy struct {
+		ID     uint64      `json:"id"`
+		Result interface{} `json:"result"`
+		Error  interface{} `json:"error"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &legacy))
+	assert.Equal(t, uint64(1), legacy.ID)
+	assert.Nil(t, legacy.Error)
+	assert.NotNil(t, legacy.Result)
+}
+
+func TestDiscover(t *testing.T) {
+	server := NewJSONRPCServer(&qmocks.Client{}, new(mocks.QueueProtocolAPI))
+	data := server.serveJSONRPC([]byte(`{"jsonrpc":"2.0","id":1,"method":"rpc.discover"}`), &rpcCaller{ip: "127.0.0.1"})
+	var resp struct {
+		Result *openrpc.Document `json:"result"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, openrpc.Version, resp.Result.OpenRPC)
+	var names []string
+	for _, m := range resp.Result.Methods {
+		names = append(names, m.Name)
+	}
+	assert.Contains(t, names, "Chain33.GetBlocks")
+	assert.Contains(t, names, discoverMethod)
+
+	//原来的请求格式
+	data = server.serveJSONRPC([]byte(`{"id":1,"method":"rpc.discover","params":[]}`), &rpcCaller{ip: "127.0.0.1"})
+	resp.Result = nil
+	assert.Nil(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, openrpc.Version, resp.Result.OpenRPC)
+}
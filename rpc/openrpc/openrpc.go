@@ -0,0 +1,243 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package openrpc 通过反射生成 jsonrpc 接口的 OpenRPC 文档, 用于自动生成客户端 SDK
+package openrpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/33cn/chain33/types"
+)
+
+// Version OpenRPC 规范的版本
+const Version = "1.2.6"
+
+const refPrefix = "#/components/schemas/"
+
+// Document OpenRPC 文档
+type Document struct {
+	OpenRPC    string                      `json:"openrpc"`
+	Info       *Info                       `json:"info"`
+	Methods    []*Method                   `json:"methods"`
+	Components *Components                 `json:"components"`
+	Executors  map[string]*ExecutorMethods `json:"x-executors,omitempty"`
+}
+
+// Info 文档的描述信息
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Method 一个 jsonrpc 方法
+type Method struct {
+	Name           string         `json:"name"`
+	Summary        string         `json:"summary,omitempty"`
+	ParamStructure string         `json:"paramStructure,omitempty"`
+	Params         []*ContentDesc `json:"params"`
+	Result         *ContentDesc   `json:"result"`
+}
+
+// ContentDesc 参数或者返回值的描述
+type ContentDesc struct {
+	Name     string  `json:"name"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// Components 公共的类型定义
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// ExecutorMethods 执行器的查询接口和构造交易的 action, 通过 Chain33.Query 和 Chain33.CreateTransaction 调用
+type ExecutorMethods struct {
+	Queries map[string]*ExecutorQuery `json:"queries,omitempty"`
+	Actions map[string]*Schema        `json:"actions,omitempty"`
+}
+
+// ExecutorQuery 执行器 Query_ 接口的参数和返回值
+type ExecutorQuery struct {
+	Params *Schema `json:"params"`
+	Result *Schema `json:"result"`
+}
+
+// Schema JSON Schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Generator 根据注册的 rpc 服务和执行器生成文档
+type Generator struct {
+	doc *Document
+}
+
+// New new generator
+func New(title, version string) *Generator {
+	return &Generator{doc: &Document{
+		OpenRPC:    Version,
+		Info:       &Info{Title: title, Version: version},
+		Components: &Components{Schemas: make(map[string]*Schema)},
+	}}
+}
+
+// AddMethod 添加一个方法, params 为 nil 表示没有参数
+func (g *Generator) AddMethod(name, summary string, params, result reflect.Type) {
+	m := &Method{Name: name, Summary: summary, ParamStructure: "by-position", Params: []*ContentDesc{}}
+	if params != nil {
+		m.Params = append(m.Params, &ContentDesc{Name: "params", Required: true, Schema: g.Schema(params)})
+	}
+	m.Result = &ContentDesc{Name: "result", Schema: g.Schema(result)}
+	g.doc.Methods = append(g.doc.Methods, m)
+}
+
+// AddService 添加一个 net/rpc 服务的所有方法, 方法的格式为 func (t *T) Method(in T1, result *T2) error
+func (g *Generator) AddService(service string, rcvr interface{}) {
+	ty := reflect.TypeOf(rcvr)
+	for i := 0; i < ty.NumMethod(); i++ {
+		method := ty.Method(i)
+		mtype := method.Type
+		if method.PkgPath != "" || mtype.NumIn() != 3 || mtype.NumOut() != 1 || mtype.Out(0) != errorType {
+			continue
+		}
+		if mtype.In(2).Kind() != reflect.Ptr {
+			continue
+		}
+		g.AddMethod(service+"."+method.Name, "", mtype.In(1), mtype.In(2).Elem())
+	}
+}
+
+// AddExecutor 添加执行器的 Query_ 接口和可以构造交易的 action
+func (g *Generator) AddExecutor(name string, exec types.ExecutorType) {
+	methods := &ExecutorMethods{}
+	queries := exec.GetQueryMap()
+	if len(queries) > 0 {
+		methods.Queries = make(map[string]*ExecutorQuery)
+		for funcName, ty := range queries {
+			methods.Queries[funcName] = &ExecutorQuery{Params: g.Schema(ty.In(1)), Result: g.Schema(ty.Out(0))}
+		}
+	}
+	for action := range exec.GetTypeMap() {
+		msg, err := exec.GetAction(action)
+		if err != nil {
+			continue
+		}
+		if methods.Actions == nil {
+			methods.Actions = make(map[string]*Schema)
+		}
+		methods.Actions[action] = g.Schema(reflect.TypeOf(msg))
+	}
+	if methods.Queries == nil && methods.Actions == nil {
+		return
+	}
+	if g.doc.Executors == nil {
+		g.doc.Executors = make(map[string]*ExecutorMethods)
+	}
+	g.doc.Executors[name] = methods
+}
+
+// Document 返回生成的文档, 方法按照名称排序
+func (g *Generator) Document() *Document {
+	sort.Slice(g.doc.Methods, func(i, j int) bool {
+		return g.doc.Methods[i].Name < g.doc.Methods[j].Name
+	})
+	return g.doc
+}
+
+// Schema 返回类型对应的 JSON Schema, 结构体放到 components 中通过 $ref 引用
+func (g *Generator) Schema(ty reflect.Type) *Schema {
+	if ty == rawMessageType {
+		return &Schema{}
+	}
+	switch ty.Kind() {
+	case reflect.Ptr:
+		return g.Schema(ty.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if ty.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.Schema(ty.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Schema(ty.Elem())}
+	case reflect.Struct:
+		if ty.Name() == "" {
+			s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			g.addFields(s, ty)
+			return s
+		}
+		name := schemaName(ty)
+		if _, ok := g.doc.Components.Schemas[name]; !ok {
+			//先占位, 防止递归的类型死循环
+			s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			g.doc.Components.Schemas[name] = s
+			g.addFields(s, ty)
+		}
+		return &Schema{Ref: refPrefix + name}
+	}
+	//interface 等无法确定类型
+	return &Schema{}
+}
+
+func (g *Generator) addFields(s *Schema, ty reflect.Type) {
+	for i := 0; i < ty.NumField(); i++ {
+		field := ty.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		//和 encoding/json 一样展开匿名结构体的字段
+		if field.Anonymous && name == "" {
+			fty := field.Type
+			if fty.Kind() == reflect.Ptr {
+				fty = fty.Elem()
+			}
+			if fty.Kind() == reflect.Struct {
+				g.addFields(s, fty)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = g.Schema(field.Type)
+	}
+}
+
+// schemaName 使用 包路径.类型名 作为类型的名称, 区分 types 和 rpc/types 中同名的类型
+func schemaName(ty reflect.Type) string {
+	pkg := strings.TrimPrefix(ty.PkgPath(), "github.com/33cn/")
+	if pkg == "" {
+		return ty.Name()
+	}
+	return strings.Replace(pkg, "/", ".", -1) + "." + ty.Name()
+}
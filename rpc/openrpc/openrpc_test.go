@@ -0,0 +1,75 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package openrpc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	_ "github.com/33cn/chain33/system"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNode struct {
+	Name     string          `json:"name"`
+	Data     []byte          `json:"data,omitempty"`
+	Children []*testNode     `json:"children"`
+	Extra    json.RawMessage `json:"extra"`
+	Ignore   int64           `json:"-"`
+	private  int64
+}
+
+type testReq struct {
+	testNode
+	Height int64            `json:"height"`
+	Tags   map[string]int32 `json:"tags"`
+}
+
+type testService struct{}
+
+func (s *testService) Get(in testReq, result *interface{}) error    { return nil }
+func (s *testService) notRPC(in testReq, result *interface{}) error { return nil }
+func (s *testService) Other(in testReq) error                       { return nil }
+
+func TestGenerator(t *testing.T) {
+	g := New("test", "1.0")
+	g.AddService("Test", &testService{})
+	doc := g.Document()
+	assert.Equal(t, Version, doc.OpenRPC)
+	assert.Equal(t, 1, len(doc.Methods))
+	assert.Equal(t, "Test.Get", doc.Methods[0].Name)
+	assert.Equal(t, refPrefix+"chain33.rpc.openrpc.testReq", doc.Methods[0].Params[0].Schema.Ref)
+
+	req := doc.Components.Schemas["chain33.rpc.openrpc.testReq"]
+	assert.Equal(t, "int64", req.Properties["height"].Format)
+	assert.Equal(t, "integer", req.Properties["tags"].AdditionalProperties.Type)
+	//匿名字段展开
+	assert.Equal(t, "string", req.Properties["name"].Type)
+	assert.Equal(t, "byte", req.Properties["data"].Format)
+	assert.Equal(t, refPrefix+"chain33.rpc.openrpc.testNode", req.Properties["children"].Items.Ref)
+	assert.Equal(t, &Schema{}, req.Properties["extra"])
+	_, ok := req.Properties["Ignore"]
+	assert.False(t, ok)
+	//递归类型
+	node := doc.Components.Schemas["chain33.rpc.openrpc.testNode"]
+	assert.Equal(t, refPrefix+"chain33.rpc.openrpc.testNode", node.Properties["children"].Items.Ref)
+
+	assert.Equal(t, &Schema{Type: "string"}, g.Schema(reflect.TypeOf("")))
+	_, err := json.Marshal(doc)
+	assert.Nil(t, err)
+}
+
+func TestAddExecutor(t *testing.T) {
+	g := New("test", "1.0")
+	for _, name := range types.ListExecutorType() {
+		g.AddExecutor(name, types.LoadExecutorType(name))
+	}
+	doc := g.Document()
+	assert.NotNil(t, doc.Executors["coins"])
+	assert.Equal(t, refPrefix+"chain33.types.ReqAddr", doc.Executors["coins"].Queries["GetTxsByAddr"].Params.Ref)
+	assert.Equal(t, refPrefix+"chain33.types.AssetsTransfer", doc.Executors["coins"].Actions["Transfer"].Ref)
+}
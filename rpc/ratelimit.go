@@ -0,0 +1,191 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+)
+
+var (
+	jrpcLimiter *rateLimiter
+	grpcLimiter *rateLimiter
+)
+
+const bucketIdleTimeout = time.Minute
+
+// tokenBucket 令牌桶, rate 为每秒生成的令牌数
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst int64, now time.Time) *tokenBucket {
+	if burst < rate {
+		burst = rate
+	}
+	return &tokenBucket{rate: float64(rate), burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// rateLimiter 按照ip 和 ip+方法 限流, 同时限制同时处理的请求数
+type rateLimiter struct {
+	mu        sync.Mutex
+	ipRate    int64
+	ipBurst   int64
+	methods   map[string]*types.RPCMethodLimit
+	buckets   map[string]*tokenBucket
+	lastClean time.Time
+	sem       chan struct{}
+
+	allowed            int64
+	rateLimited        int64
+	concurrencyLimited int64
+	methodLimited      map[string]int64
+}
+
+func newRateLimiter(cfg *types.RPCRateLimit, maxConcurrent int64) *rateLimiter {
+	l := &rateLimiter{
+		ipRate:        cfg.IPRate,
+		ipBurst:       cfg.IPBurst,
+		methods:       make(map[string]*types.RPCMethodLimit),
+		buckets:       make(map[string]*tokenBucket),
+		lastClean:     time.Now(),
+		methodLimited: make(map[string]int64),
+	}
+	for _, m := range cfg.Methods {
+		if m != nil && m.Name != "" && m.Rate > 0 {
+			l.methods[m.Name] = m
+		}
+	}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+// InitRateLimit init rpc rate limit
+func InitRateLimit(cfg *types.RPC) {
+	jrpcLimiter = nil
+	grpcLimiter = nil
+	if cfg.RateLimit == nil {
+		return
+	}
+	jrpcLimiter = newRateLimiter(cfg.RateLimit, cfg.RateLimit.JrpcMaxConcurrent)
+	grpcLimiter = newRateLimiter(cfg.RateLimit, cfg.RateLimit.GrpcMaxConcurrent)
+}
+
+func (l *rateLimiter) allowRate(ip, funcName string) bool {
+	if net.ParseIP(ip).IsLoopback() {
+		return true
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastClean) > bucketIdleTimeout {
+		for key, b := range l.buckets {
+			if now.Sub(b.last) > bucketIdleTimeout {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastClean = now
+	}
+	if l.ipRate > 0 && !l.bucket(ip, l.ipRate, l.ipBurst, now).allow(now) {
+		return false
+	}
+	if m, ok := l.methods[funcName]; ok {
+		if !l.bucket(ip+"/"+funcName, m.Rate, m.Burst, now).allow(now) {
+			l.methodLimited[funcName]++
+			return false
+		}
+	}
+	return true
+}
+
+func (l *rateLimiter) bucket(key string, rate, burst int64, now time.Time) *tokenBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(rate, burst, now)
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// acquire 检查限流, 成功后需要调用返回的 release 释放并发数
+func (l *rateLimiter) acquire(ip, funcName string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if !l.allowRate(ip, funcName) {
+		atomic.AddInt64(&l.rateLimited, 1)
+		return nil, types.ErrRateLimited
+	}
+	if l.sem == nil {
+		atomic.AddInt64(&l.allowed, 1)
+		return func() {}, nil
+	}
+	select {
+	case l.sem <- struct{}{}:
+		atomic.AddInt64(&l.allowed, 1)
+		return func() { <-l.sem }, nil
+	default:
+		atomic.AddInt64(&l.concurrencyLimited, 1)
+		return nil, types.ErrConcurrencyLimited
+	}
+}
+
+// allow 只检查速率, 用于 grpc 的长连接推送流, 不占用并发数
+func (l *rateLimiter) allow(ip, funcName string) error {
+	if l == nil {
+		return nil
+	}
+	if !l.allowRate(ip, funcName) {
+		atomic.AddInt64(&l.rateLimited, 1)
+		return types.ErrRateLimited
+	}
+	atomic.AddInt64(&l.allowed, 1)
+	return nil
+}
+
+func (l *rateLimiter) stats() *rpctypes.RateLimitStats {
+	if l == nil {
+		return nil
+	}
+	stats := &rpctypes.RateLimitStats{
+		Allowed:            atomic.LoadInt64(&l.allowed),
+		RateLimited:        atomic.LoadInt64(&l.rateLimited),
+		ConcurrencyLimited: atomic.LoadInt64(&l.concurrencyLimited),
+		MethodLimited:      make(map[string]int64),
+	}
+	if l.sem != nil {
+		stats.InFlight = int64(len(l.sem))
+		stats.MaxConcurrent = int64(cap(l.sem))
+	}
+	l.mu.Lock()
+	for name, count := range l.methodLimited {
+		stats.MethodLimited[name] = count
+	}
+	l.mu.Unlock()
+	return stats
+}
@@ -0,0 +1,63 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(2, 3, now)
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.allow(now))
+	}
+	assert.False(t, b.allow(now))
+	assert.True(t, b.allow(now.Add(500*time.Millisecond)))
+	assert.False(t, b.allow(now.Add(500*time.Millisecond)))
+}
+
+func TestRateLimiter(t *testing.T) {
+	var nilLimiter *rateLimiter
+	release, err := nilLimiter.acquire("192.168.1.1", "Version")
+	assert.Nil(t, err)
+	release()
+
+	cfg := &types.RPCRateLimit{
+		IPRate:  100,
+		IPBurst: 100,
+		Methods: []*types.RPCMethodLimit{{Name: "GetTxByAddr", Rate: 1, Burst: 1}},
+	}
+	l := newRateLimiter(cfg, 1)
+	release, err = l.acquire("192.168.1.1", "GetTxByAddr")
+	assert.Nil(t, err)
+	_, err = l.acquire("192.168.1.2", "Version")
+	assert.Equal(t, types.ErrConcurrencyLimited, err)
+	release()
+
+	_, err = l.acquire("192.168.1.1", "GetTxByAddr")
+	assert.Equal(t, types.ErrRateLimited, err)
+	//其他ip 和本地地址不受影响
+	release, err = l.acquire("192.168.1.2", "GetTxByAddr")
+	assert.Nil(t, err)
+	release()
+	for i := 0; i < 5; i++ {
+		release, err = l.acquire("127.0.0.1", "GetTxByAddr")
+		assert.Nil(t, err)
+		release()
+	}
+
+	stats := l.stats()
+	assert.Equal(t, int64(7), stats.Allowed)
+	assert.Equal(t, int64(1), stats.RateLimited)
+	assert.Equal(t, int64(1), stats.ConcurrencyLimited)
+	assert.Equal(t, int64(1), stats.MethodLimited["GetTxByAddr"])
+	assert.Equal(t, int64(1), stats.MaxConcurrent)
+	assert.NotEqual(t, types.ErrCodeUnknown, types.GetErrCode(types.ErrRateLimited.Error()))
+}
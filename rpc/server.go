@@ -7,6 +7,7 @@ package rpc
 import (
 	"net"
 	"net/rpc"
+	"sync"
 	"time"
 
 	"github.com/33cn/chain33/client"
@@ -16,7 +17,9 @@ import (
 	"golang.org/x/net/context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	_ "google.golang.org/grpc/encoding/gzip" // register gzip
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -35,7 +38,36 @@ type Chain33 struct {
 
 // Grpc a channelClient
 type Grpc struct {
-	cli channelClient
+	cli      channelClient
+	notifier *seqNotifier
+}
+
+const (
+	seqStreamBatch       = 32
+	seqStreamWaitTimeout = 10 * time.Second
+)
+
+// seqNotifier 新区块到达时唤醒所有等待中的序列推送流
+type seqNotifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newSeqNotifier() *seqNotifier {
+	return &seqNotifier{ch: make(chan struct{})}
+}
+
+func (n *seqNotifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+func (n *seqNotifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
 }
 
 // Grpcserver a object
@@ -47,7 +79,7 @@ type Grpcserver struct {
 
 // NewGrpcServer new  GrpcServer object
 func NewGrpcServer() *Grpcserver {
-	return &Grpcserver{grpc: &Grpc{}}
+	return &Grpcserver{grpc: &Grpc{notifier: newSeqNotifier()}}
 }
 
 // JSONRPCServer  a json rpcserver object
@@ -55,6 +87,7 @@ type JSONRPCServer struct {
 	jrpc *Chain33
 	s    *rpc.Server
 	l    net.Listener
+	hub  *wsHub
 }
 
 // Close json rpcserver close
@@ -136,19 +169,34 @@ func (j *Grpcserver) Close() {
 
 // NewGRpcServer new grpcserver object
 func NewGRpcServer(c queue.Client, api client.QueueProtocolAPI) *Grpcserver {
-	s := &Grpcserver{grpc: &Grpc{}}
+	s := &Grpcserver{grpc: &Grpc{notifier: newSeqNotifier()}}
 	s.grpc.cli.Init(c, api)
 	var opts []grpc.ServerOption
 	//register interceptor
 	//var interceptor grpc.UnaryServerInterceptor
 	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		if err := auth(ctx, info); err != nil {
+		if err := auth(ctx, info.FullMethod); err != nil {
 			return nil, err
 		}
+		release, err := grpcLimiter.acquire(grpcRemoteIP(ctx), grpcFuncName(info.FullMethod))
+		if err != nil {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		defer release()
 		// Continue processing the request
 		return handler(ctx, req)
 	}
 	opts = append(opts, grpc.UnaryInterceptor(interceptor))
+	streamInterceptor := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := auth(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		if err := grpcLimiter.allow(grpcRemoteIP(ss.Context()), grpcFuncName(info.FullMethod)); err != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return handler(srv, ss)
+	}
+	opts = append(opts, grpc.StreamInterceptor(streamInterceptor))
 	server := grpc.NewServer(opts...)
 	s.s = server
 	types.RegisterChain33Server(server, s.grpc)
@@ -157,7 +205,7 @@ func NewGRpcServer(c queue.Client, api client.QueueProtocolAPI) *Grpcserver {
 
 // NewJSONRPCServer new json rpcserver object
 func NewJSONRPCServer(c queue.Client, api client.QueueProtocolAPI) *JSONRPCServer {
-	j := &JSONRPCServer{jrpc: &Chain33{}}
+	j := &JSONRPCServer{jrpc: &Chain33{}, hub: newWsHub()}
 	j.jrpc.cli.Init(c, api)
 	server := rpc.NewServer()
 	j.s = server
@@ -182,6 +230,8 @@ func InitCfg(cfg *types.RPC) {
 	InitGrpcFuncWhitelist(cfg)
 	InitJrpcFuncBlacklist(cfg)
 	InitGrpcFuncBlacklist(cfg)
+	InitRateLimit(cfg)
+	InitAuth(cfg)
 }
 
 // New produce a rpc by cfg
@@ -202,6 +252,7 @@ func (r *RPC) SetQueueClient(c queue.Client) {
 	r.gapi = gapi
 	r.japi = japi
 	r.c = c
+	r.subBlockEvent()
 	//注册系统rpc
 	pluginmgr.AddRPC(r)
 	r.Listen()
@@ -214,6 +265,24 @@ func (r *RPC) SetQueueClientNoListen(c queue.Client) {
 	r.gapi = gapi
 	r.japi = japi
 	r.c = c
+	r.subBlockEvent()
+}
+
+// subBlockEvent 接收blockchain 模块发送的 add/del block 事件, 推送给websocket 订阅者并唤醒grpc 序列推送流
+func (r *RPC) subBlockEvent() {
+	r.c.Sub("rpc")
+	go func() {
+		for msg := range r.c.Recv() {
+			switch msg.Ty {
+			case types.EventAddBlock:
+				r.japi.hub.notifyBlock(msg.GetData().(*types.BlockDetail), false)
+				r.gapi.grpc.notifier.notify()
+			case types.EventDelBlock:
+				r.japi.hub.notifyBlock(msg.GetData().(*types.BlockDetail), true)
+				r.gapi.grpc.notifier.notify()
+			}
+		}
+	}()
 }
 
 // Listen rpc listen
@@ -222,6 +291,7 @@ func (r *RPC) Listen() (port1 int, port2 int) {
 	for i := 0; i < 10; i++ {
 		port1, err = r.gapi.Listen()
 		if err != nil {
+			log.Error("grpc listen", "err", err)
 			time.Sleep(time.Second)
 			continue
 		}
@@ -230,6 +300,7 @@ func (r *RPC) Listen() (port1 int, port2 int) {
 	for i := 0; i < 10; i++ {
 		port2, err = r.japi.Listen()
 		if err != nil {
+			log.Error("jsonrpc listen", "err", err)
 			time.Sleep(time.Second)
 			continue
 		}
@@ -0,0 +1,147 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/types"
+)
+
+const certCheckInterval = time.Second
+
+var errNoCertificate = errors.New("ErrNoCertificateInCAFile")
+
+// certReloader 握手时检查证书文件的修改时间, 文件变化后重新加载, 不需要重启节点
+type certReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newCertReloader(certFile, keyFile, caFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// latestModTime 返回所有证书文件中最新的修改时间
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		if pool, err = loadCertPool(r.caFile); err != nil {
+			return err
+		}
+	}
+	r.cert = &cert
+	r.clientCAs = pool
+	r.modTime = modTime
+	return nil
+}
+
+func (r *certReloader) maybeReload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.lastCheck) < certCheckInterval {
+		return
+	}
+	r.lastCheck = now
+	modTime, err := r.latestModTime()
+	if err != nil || !modTime.After(r.modTime) {
+		return
+	}
+	//重新加载失败继续使用原来的证书
+	if err := r.load(); err != nil {
+		log.Error("reload tls certificate", "err", err)
+		return
+	}
+	log.Info("reload tls certificate", "cert", r.certFile)
+}
+
+func (r *certReloader) config(nextProtos []string) *tls.Config {
+	r.maybeReload()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{*r.cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   nextProtos,
+	}
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errNoCertificate
+	}
+	return pool, nil
+}
+
+// newTLSListener 配置了 tls 时把监听包装为 tls 监听, jsonrpc 使用 http/1.1, grpc 使用 h2
+func newTLSListener(l net.Listener, cfg *types.RPC, nextProtos ...string) (net.Listener, error) {
+	if cfg == nil || !cfg.EnableTLS {
+		return l, nil
+	}
+	r, err := newCertReloader(cfg.CertFile, cfg.KeyFile, cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.config(nextProtos), nil
+		},
+	}
+	return tls.NewListener(l, tlsCfg), nil
+}
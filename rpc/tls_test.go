@@ -0,0 +1,109 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/rpc/jsonclient"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCert 生成自签名证书, 同时作为服务端证书, 客户端证书和CA
+func writeTestCert(t *testing.T, dir string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "cert.pem"), certPem, 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "ca.pem"), certPem, 0600))
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), keyPem, 0600))
+	//保证修改时间变化
+	future := time.Now().Add(time.Duration(serial) * time.Second)
+	for _, name := range []string{"cert.pem", "ca.pem", "key.pem"} {
+		assert.Nil(t, os.Chtimes(filepath.Join(dir, name), future, future))
+	}
+}
+
+func TestTLSListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpctls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	writeTestCert(t, dir, 1)
+
+	cfg := &types.RPC{
+		EnableTLS:    true,
+		CertFile:     filepath.Join(dir, "cert.pem"),
+		KeyFile:      filepath.Join(dir, "key.pem"),
+		ClientCAFile: filepath.Join(dir, "ca.pem"),
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	l, err = newTLSListener(l, cfg, "http/1.1")
+	assert.Nil(t, err)
+	defer l.Close()
+	go http.Serve(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":1,"result":"ok","error":null}`))
+	}))
+	url := "https://" + l.Addr().String()
+
+	tlsCfg, err := jsonclient.NewTLSConfig(cfg.ClientCAFile, cfg.CertFile, cfg.KeyFile)
+	assert.Nil(t, err)
+	client, err := jsonclient.NewWithTLS("Chain33", url, tlsCfg)
+	assert.Nil(t, err)
+	var res string
+	assert.Nil(t, client.Call("Version", nil, &res))
+	assert.Equal(t, "ok", res)
+
+	//没有客户端证书
+	noCert, err := jsonclient.NewTLSConfig(cfg.ClientCAFile, "", "")
+	assert.Nil(t, err)
+	client, err = jsonclient.NewWithTLS("Chain33", url, noCert)
+	assert.Nil(t, err)
+	assert.NotNil(t, client.Call("Version", nil, &res))
+
+	//证书更新后新的连接使用新证书
+	writeTestCert(t, dir, 2)
+	time.Sleep(certCheckInterval + 100*time.Millisecond)
+	tlsCfg, err = jsonclient.NewTLSConfig(cfg.ClientCAFile, cfg.CertFile, cfg.KeyFile)
+	assert.Nil(t, err)
+	conn, err := tls.Dial("tcp", l.Addr().String(), tlsCfg)
+	assert.Nil(t, err)
+	defer conn.Close()
+	assert.Equal(t, int64(2), conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64())
+
+	_, err = newTLSListener(l, &types.RPC{EnableTLS: true, CertFile: "notexist.pem", KeyFile: "notexist.pem"})
+	assert.NotNil(t, err)
+}
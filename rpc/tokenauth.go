@@ -0,0 +1,235 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"hash"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/33cn/chain33/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+var rpcAuth *tokenAuth
+
+const bearerPrefix = "Bearer "
+
+// adminRole 管理接口需要的角色
+const adminRole = "admin"
+
+// adminMethods 只有 admin 角色可以调用的管理接口, 其他角色配置的 "*" 不包括这些接口
+var adminMethods = map[string]bool{
+	"AddSeqCallBack":    true,
+	"ListSeqCallBack":   true,
+	"PauseSeqCallBack":  true,
+	"ResumeSeqCallBack": true,
+	"DelSeqCallBack":    true,
+	"EvictMempoolTx":    true,
+}
+
+// authOnlyMethods 必须开启认证才能调用的管理接口, 没有开启认证时本地回环地址也不能调用
+var authOnlyMethods = map[string]bool{
+	"EvictMempoolTx": true,
+}
+
+// tokenAuth 根据 api key 或者 jwt 得到调用者的角色, 再根据角色检查可以调用的方法
+type tokenAuth struct {
+	apiKeys   map[string][]string
+	jwtSecret []byte
+	roles     map[string]map[string]bool
+	anonymous []string
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Roles []string `json:"roles"`
+	Exp   int64    `json:"exp"`
+	Nbf   int64    `json:"nbf"`
+}
+
+// InitAuth init rpc token auth
+func InitAuth(cfg *types.RPC) {
+	rpcAuth = nil
+	if cfg.Auth == nil {
+		return
+	}
+	a := &tokenAuth{
+		apiKeys:   make(map[string][]string),
+		jwtSecret: []byte(cfg.Auth.JwtSecret),
+		roles:     make(map[string]map[string]bool),
+		anonymous: cfg.Auth.AnonymousRoles,
+	}
+	for _, key := range cfg.Auth.APIKeys {
+		if key != nil && key.Key != "" {
+			a.apiKeys[key.Key] = key.Roles
+		}
+	}
+	for _, role := range cfg.Auth.Roles {
+		if role == nil {
+			continue
+		}
+		methods := make(map[string]bool)
+		for _, m := range role.Methods {
+			methods[m] = true
+		}
+		a.roles[role.Name] = methods
+	}
+	rpcAuth = a
+}
+
+// authenticate 校验 token, 返回对应的角色, 空的 token 返回匿名角色
+func (a *tokenAuth) authenticate(token string) ([]string, error) {
+	if token == "" {
+		return a.anonymous, nil
+	}
+	if roles, ok := a.apiKeys[token]; ok {
+		return roles, nil
+	}
+	if len(a.jwtSecret) == 0 || strings.Count(token, ".") != 2 {
+		return nil, types.ErrInvalidToken
+	}
+	return a.parseJWT(token)
+}
+
+func (a *tokenAuth) parseJWT(token string) ([]string, error) {
+	parts := strings.Split(token, ".")
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, types.ErrInvalidToken
+	}
+	var header jwtHeader
+	if err = json.Unmarshal(data, &header); err != nil {
+		return nil, types.ErrInvalidToken
+	}
+	var h func() hash.Hash
+	switch header.Alg {
+	case "HS256":
+		h = sha256.New
+	case "HS384":
+		h = sha512.New384
+	case "HS512":
+		h = sha512.New
+	default:
+		return nil, types.ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, types.ErrInvalidToken
+	}
+	mac := hmac.New(h, a.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return nil, types.ErrInvalidToken
+	}
+	if data, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return nil, types.ErrInvalidToken
+	}
+	var claims jwtClaims
+	if err = json.Unmarshal(data, &claims); err != nil {
+		return nil, types.ErrInvalidToken
+	}
+	now := types.Now().Unix()
+	if claims.Exp != 0 && now >= claims.Exp {
+		return nil, types.ErrTokenExpired
+	}
+	if claims.Nbf != 0 && now < claims.Nbf {
+		return nil, types.ErrInvalidToken
+	}
+	return claims.Roles, nil
+}
+
+func (a *tokenAuth) allowMethod(roles []string, funcName string) bool {
+	for _, role := range roles {
+		methods := a.roles[role]
+		if methods["*"] || methods[funcName] {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcCaller 一次 http 请求或者一个 websocket 连接的调用者
+type rpcCaller struct {
+	ip    string
+	roles []string
+	err   error
+}
+
+func newRPCCaller(ip, token string) *rpcCaller {
+	c := &rpcCaller{ip: ip}
+	if rpcAuth != nil {
+		c.roles, c.err = rpcAuth.authenticate(token)
+	}
+	return c
+}
+
+// checkAdmin 管理接口需要 admin 角色, 本地回环地址也需要认证,
+// 没有开启认证时只有本地回环地址可以调用, authOnlyMethods 中的接口不能调用
+func (c *rpcCaller) checkAdmin(funcName string) error {
+	if !adminMethods[funcName] {
+		return nil
+	}
+	if rpcAuth == nil {
+		if !authOnlyMethods[funcName] && net.ParseIP(c.ip).IsLoopback() {
+			return nil
+		}
+		return types.ErrNotAllow
+	}
+	if c.err != nil {
+		return c.err
+	}
+	for _, role := range c.roles {
+		if role == adminRole {
+			return nil
+		}
+	}
+	return types.ErrNotAllow
+}
+
+// checkToken 检查调用者的角色是否可以调用这个方法, 没有开启认证或者本地回环地址直接通过
+func (c *rpcCaller) checkToken(funcName string) error {
+	if rpcAuth == nil || net.ParseIP(c.ip).IsLoopback() {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	if !rpcAuth.allowMethod(c.roles, funcName) {
+		return types.ErrNotAllow
+	}
+	return nil
+}
+
+func httpBearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if strings.HasPrefix(auth, bearerPrefix) {
+		return strings.TrimSpace(auth[len(bearerPrefix):])
+	}
+	return ""
+}
+
+func grpcBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, auth := range md.Get("authorization") {
+		if strings.HasPrefix(auth, bearerPrefix) {
+			return strings.TrimSpace(auth[len(bearerPrefix):])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func signJWT(secret, claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestTokenAuth(t *testing.T) {
+	InitAuth(&types.RPC{Auth: &types.RPCAuth{
+		APIKeys:        []*types.RPCAPIKey{{Key: "readkey", Roles: []string{"read"}}},
+		JwtSecret:      "secret",
+		Roles:          []*types.RPCRole{{Name: "read", Methods: []string{"GetBlocks", "Version"}}, {Name: "admin", Methods: []string{"*"}}},
+		AnonymousRoles: []string{"public"},
+	}})
+	defer InitAuth(&types.RPC{})
+	jwhitelist, gwhitelist, ipwhitelist := jrpcFuncWhitelist, grpcFuncWhitelist, remoteIPWhitelist
+	jrpcFuncWhitelist = map[string]bool{"*": true}
+	grpcFuncWhitelist = map[string]bool{"*": true}
+	remoteIPWhitelist = map[string]bool{"192.168.1.1": true}
+	defer func() {
+		jrpcFuncWhitelist, grpcFuncWhitelist, remoteIPWhitelist = jwhitelist, gwhitelist, ipwhitelist
+	}()
+
+	remote := "192.168.1.1"
+	caller := newRPCCaller(remote, "readkey")
+	assert.Nil(t, checkJrpcFuncAuth(caller, "Chain33.GetBlocks"))
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(caller, "Chain33.DumpPrivkey"))
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller(remote, ""), "Chain33.GetBlocks"))
+	assert.Equal(t, types.ErrInvalidToken, checkJrpcFuncAuth(newRPCCaller(remote, "badkey"), "Chain33.GetBlocks"))
+	//本地地址不需要认证
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", ""), "Chain33.DumpPrivkey"))
+
+	admin := signJWT("secret", `{"roles":["admin"]}`)
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller(remote, admin), "Chain33.DumpPrivkey"))
+	expired := signJWT("secret", `{"roles":["admin"],"exp":1}`)
+	assert.Equal(t, types.ErrTokenExpired, newRPCCaller(remote, expired).err)
+	forged := signJWT("other", `{"roles":["admin"]}`)
+	assert.Equal(t, types.ErrInvalidToken, newRPCCaller(remote, forged).err)
+
+	addr := new(Addr)
+	addr.On("String").Return("192.168.1.1:8802")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	assert.Equal(t, types.ErrNotAllow, auth(ctx, "/types.chain33/Version"))
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer readkey"))
+	assert.Nil(t, auth(ctx, "/types.chain33/Version"))
+	assert.Equal(t, types.ErrNotAllow, auth(ctx, "/types.chain33/DumpPrivkey"))
+}
+
+func TestAdminAuth(t *testing.T) {
+	jwhitelist := jrpcFuncWhitelist
+	jrpcFuncWhitelist = map[string]bool{"*": true}
+	defer func() { jrpcFuncWhitelist = jwhitelist }()
+
+	//没有开启认证时只有本地回环地址可以调用管理接口
+	InitAuth(&types.RPC{})
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", ""), "Chain33.AddSeqCallBack"))
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller("192.168.1.1", ""), "Chain33.AddSeqCallBack"))
+	//EvictMempoolTx 必须开启认证
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", ""), "Chain33.EvictMempoolTx"))
+
+	InitAuth(&types.RPC{Auth: &types.RPCAuth{
+		APIKeys: []*types.RPCAPIKey{{Key: "readkey", Roles: []string{"read"}}, {Key: "adminkey", Roles: []string{"admin"}}},
+		Roles:   []*types.RPCRole{{Name: "read", Methods: []string{"*"}}, {Name: "admin", Methods: []string{"*"}}},
+	}})
+	defer InitAuth(&types.RPC{})
+	//开启认证之后本地回环地址也需要 admin 角色
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", ""), "Chain33.DelSeqCallBack"))
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller("192.168.1.1", "readkey"), "Chain33.AddSeqCallBack"))
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("192.168.1.1", "readkey"), "Chain33.GetBlocks"))
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", "adminkey"), "Chain33.ListSeqCallBack"))
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("192.168.1.1", "adminkey"), "Chain33.PauseSeqCallBack"))
+
+	//配置了黑名单之后仍然需要 admin 角色, grpc 也一样
+	jblacklist := jrpcFuncBlacklist
+	jrpcFuncBlacklist = map[string]bool{"CloseQueue": true}
+	defer func() { jrpcFuncBlacklist = jblacklist }()
+	assert.Equal(t, types.ErrNotAllow, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", "readkey"), "Chain33.EvictMempoolTx"))
+	assert.Nil(t, checkJrpcFuncAuth(newRPCCaller("127.0.0.1", "adminkey"), "Chain33.EvictMempoolTx"))
+	addr := new(Addr)
+	addr.On("String").Return("127.0.0.1:8802")
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	assert.Equal(t, types.ErrNotAllow, auth(ctx, "/types.chain33/EvictMempoolTx"))
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer readkey"))
+	assert.Equal(t, types.ErrNotAllow, auth(ctx, "/types.chain33/EvictMempoolTx"))
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer adminkey"))
+	assert.Nil(t, auth(ctx, "/types.chain33/EvictMempoolTx"))
+}
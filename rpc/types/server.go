@@ -6,6 +6,7 @@ package types
 
 import (
 	"net/rpc"
+	"sync"
 
 	"github.com/33cn/chain33/account"
 	"github.com/33cn/chain33/client"
@@ -20,6 +21,22 @@ type RPCServer interface {
 	JRPC() *rpc.Server
 }
 
+var (
+	servicesMu   sync.Mutex
+	jrpcServices = make(map[string]interface{})
+)
+
+// JRPCServices 返回插件注册的 jsonrpc 服务, 用于生成接口文档
+func JRPCServices() map[string]interface{} {
+	servicesMu.Lock()
+	defer servicesMu.Unlock()
+	services := make(map[string]interface{}, len(jrpcServices))
+	for name, s := range jrpcServices {
+		services[name] = s
+	}
+	return services
+}
+
 // ChannelClient interface
 type ChannelClient struct {
 	client.QueueProtocolAPI
@@ -35,6 +52,9 @@ func (c *ChannelClient) Init(name string, s RPCServer, jrpc, grpc interface{}) {
 	}
 	if jrpc != nil {
 		s.JRPC().RegisterName(name, jrpc)
+		servicesMu.Lock()
+		jrpcServices[name] = jrpc
+		servicesMu.Unlock()
 	}
 	c.grpc = grpc
 	c.jrpc = jrpc
@@ -279,6 +279,9 @@ type Query4Jrpc struct {
 	Execer   string          `json:"execer"`
 	FuncName string          `json:"funcName"`
 	Payload  json.RawMessage `json:"payload"`
+	// Height 和 StateHash 用于在历史状态上查询, 都为空时查询最新状态, Height 为0 时查询创世区块的状态
+	Height    *int64 `json:"height,omitempty"`
+	StateHash string `json:"stateHash,omitempty"`
 }
 
 // ChainExecutor chain executor
@@ -359,3 +362,228 @@ type ExecAccount struct {
 type ExecNameParm struct {
 	ExecName string `json:"execname"`
 }
+
+// SubscribeParam websocket subscribe parameter
+type SubscribeParam struct {
+	Type     string   `json:"type"`
+	Hashes   []string `json:"hashes,omitempty"`
+	Execer   string   `json:"execer,omitempty"`
+	LogTypes []int32  `json:"logTypes,omitempty"`
+}
+
+// UnsubscribeParam websocket unsubscribe parameter
+type UnsubscribeParam struct {
+	ID string `json:"id"`
+}
+
+// SubscriptionNotify websocket subscription notification
+type SubscriptionNotify struct {
+	Method string              `json:"method"`
+	Params *SubscriptionResult `json:"params"`
+}
+
+// SubscriptionResult subscription result, removed is true when the block is detached by reorg,
+// gap is true when some notifications are lost and the subscriber should resync from the chain
+type SubscriptionResult struct {
+	Subscription string      `json:"subscription"`
+	Removed      bool        `json:"removed"`
+	Gap          bool        `json:"gap,omitempty"`
+	Result       interface{} `json:"result"`
+}
+
+// TxConfirmResult transaction confirmation notification
+type TxConfirmResult struct {
+	Hash      string             `json:"hash"`
+	Height    int64              `json:"height"`
+	Index     int64              `json:"index"`
+	BlockHash string             `json:"blockHash"`
+	Receipt   *ReceiptDataResult `json:"receipt"`
+}
+
+// ReceiptLogNotify receipt log notification
+type ReceiptLogNotify struct {
+	Execer    string          `json:"execer"`
+	Height    int64           `json:"height"`
+	BlockHash string          `json:"blockHash"`
+	TxHash    string          `json:"txHash"`
+	TxIndex   int64           `json:"txIndex"`
+	LogIndex  int64           `json:"logIndex"`
+	Ty        int32           `json:"ty"`
+	TyName    string          `json:"tyName"`
+	Log       json.RawMessage `json:"log"`
+	RawLog    string          `json:"rawLog"`
+	From      string          `json:"from,omitempty"`
+	To        string          `json:"to,omitempty"`
+}
+
+// KeyValueResult hex 格式的 key value
+type KeyValueResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// SimulateTxResult 模拟执行交易的结果, fee 为交易会被扣除的手续费
+type SimulateTxResult struct {
+	Height    int64               `json:"height"`
+	StateHash string              `json:"stateHash"`
+	Ty        int32               `json:"ty"`
+	TyName    string              `json:"tyName"`
+	Fee       int64               `json:"fee"`
+	KV        []*KeyValueResult   `json:"kv"`
+	Logs      []*ReceiptLogResult `json:"logs"`
+}
+
+// ReqGetLogs 查询回执日志, toHeight 为 -1 表示到最新高度, cursor 为上一页返回的 cursor
+type ReqGetLogs struct {
+	Execer     string   `json:"execer"`
+	LogTypes   []int32  `json:"logTypes"`
+	FromHeight int64    `json:"fromHeight"`
+	ToHeight   int64    `json:"toHeight"`
+	Addrs      []string `json:"addrs"`
+	Count      int32    `json:"count"`
+	Cursor     string   `json:"cursor"`
+}
+
+// ReplyGetLogs 回执日志列表, cursor 为空表示没有更多的日志
+type ReplyGetLogs struct {
+	Logs   []*ReceiptLogNotify `json:"logs"`
+	Cursor string              `json:"cursor"`
+}
+
+// ReqTxsByAddrFilter 按照执行器, action, 方向, 执行结果以及区块时间查询地址的交易
+// flag 0 所有, 1 from, 2 to; status 0 所有, 1 成功, 2 失败; direction 0 从新到旧, 1 从旧到新
+type ReqTxsByAddrFilter struct {
+	Addr       string `json:"addr"`
+	Execer     string `json:"execer"`
+	ActionName string `json:"actionName"`
+	Flag       int32  `json:"flag"`
+	Status     int32  `json:"status"`
+	StartTime  int64  `json:"startTime"`
+	EndTime    int64  `json:"endTime"`
+	Count      int32  `json:"count"`
+	Direction  int32  `json:"direction"`
+	Cursor     string `json:"cursor"`
+}
+
+// AddrTxIndex 地址的交易, direction 1 from, 2 to, 3 from 和 to
+type AddrTxIndex struct {
+	Hash       string `json:"hash"`
+	Height     int64  `json:"height"`
+	Index      int64  `json:"index"`
+	Execer     string `json:"execer"`
+	ActionName string `json:"actionName"`
+	Ty         int32  `json:"ty"`
+	Blocktime  int64  `json:"blocktime"`
+	Direction  int32  `json:"direction"`
+}
+
+// ReplyTxsByAddrFilter total 为符合条件的交易总数, -1 表示超过扫描上限; cursor 为空表示没有更多的交易
+type ReplyTxsByAddrFilter struct {
+	Txs    []*AddrTxIndex `json:"txs"`
+	Total  int64          `json:"total"`
+	Cursor string         `json:"cursor"`
+}
+
+// Checkpoint the current checkpoint and finalized height
+type Checkpoint struct {
+	Height          int64  `json:"height"`
+	Hash            string `json:"hash"`
+	FinalizedDepth  int64  `json:"finalizedDepth"`
+	FinalizedHeight int64  `json:"finalizedHeight"`
+}
+
+// ReqStateProof key is the raw state db key, such as mavl-coins-bty-{addr}; height < 0 means the last block
+type ReqStateProof struct {
+	Key    string `json:"key"`
+	Height int64  `json:"height"`
+}
+
+// StateProof state value and mavl proof of the key
+type StateProof struct {
+	Height    int64  `json:"height"`
+	StateHash string `json:"stateHash"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	Proof     string `json:"proof"`
+}
+
+// ReqReorgHistory count is the number of the latest reorg records, default 10
+type ReqReorgHistory struct {
+	Count int32 `json:"count"`
+}
+
+// ReorgRecord one chain reorganization, detached from high to low and attached from low to high
+type ReorgRecord struct {
+	Index      int64    `json:"index"`
+	ForkHeight int64    `json:"forkHeight"`
+	ForkHash   string   `json:"forkHash"`
+	Detached   []string `json:"detached"`
+	Attached   []string `json:"attached"`
+	Pid        string   `json:"pid"`
+	Time       int64    `json:"time"`
+	OldTd      string   `json:"oldTd"`
+	NewTd      string   `json:"newTd"`
+}
+
+// OrphanPoolStat orphan block pool statistics
+type OrphanPoolStat struct {
+	Count     int64 `json:"count"`
+	Parents   int64 `json:"parents"`
+	MaxCount  int64 `json:"maxCount"`
+	MinHeight int64 `json:"minHeight"`
+	MaxHeight int64 `json:"maxHeight"`
+}
+
+// ReorgHistory reorg records from new to old and orphan pool statistics
+type ReorgHistory struct {
+	Items   []*ReorgRecord  `json:"items"`
+	Orphans *OrphanPoolStat `json:"orphans"`
+}
+
+// MempoolTx pending transaction in mempool, feeRate is the fee per kilobyte
+type MempoolTx struct {
+	Tx        *Transaction `json:"tx"`
+	Hash      string       `json:"hash"`
+	EnterTime int64        `json:"enterTime"`
+	FeeRate   int64        `json:"feeRate"`
+	Size      int64        `json:"size"`
+}
+
+// MempoolFeeBucket number of txs whose fee rate is between minFeeRate and the next bucket
+type MempoolFeeBucket struct {
+	MinFeeRate int64 `json:"minFeeRate"`
+	Count      int64 `json:"count"`
+}
+
+// MempoolExecerCount number of txs of one executor
+type MempoolExecerCount struct {
+	Execer string `json:"execer"`
+	Count  int64  `json:"count"`
+}
+
+// MempoolStat mempool statistics, oldestAge is the seconds since the oldest tx entered mempool
+type MempoolStat struct {
+	Size            int64                 `json:"size"`
+	Bytes           int64                 `json:"bytes"`
+	FeeHistogram    []*MempoolFeeBucket   `json:"feeHistogram"`
+	Execers         []*MempoolExecerCount `json:"execers"`
+	OldestHash      string                `json:"oldestHash"`
+	OldestEnterTime int64                 `json:"oldestEnterTime"`
+	OldestAge       int64                 `json:"oldestAge"`
+}
+
+// RateLimitStats rate limit counters of one rpc server
+type RateLimitStats struct {
+	Allowed            int64            `json:"allowed"`
+	RateLimited        int64            `json:"rateLimited"`
+	ConcurrencyLimited int64            `json:"concurrencyLimited"`
+	InFlight           int64            `json:"inFlight"`
+	MaxConcurrent      int64            `json:"maxConcurrent"`
+	MethodLimited      map[string]int64 `json:"methodLimited"`
+}
+
+// RPCLimitStats rate limit counters of jsonrpc and grpc
+type RPCLimitStats struct {
+	Jrpc *RateLimitStats `json:"jrpc"`
+	Grpc *RateLimitStats `json:"grpc"`
+}
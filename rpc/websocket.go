@@ -0,0 +1,441 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+)
+
+// websocket 订阅类型
+const (
+	SubNewHeads  = "newHeads"
+	SubNewBlocks = "newBlocks"
+	SubTxConfirm = "txConfirm"
+	SubLogs      = "logs"
+)
+
+const (
+	wsSubscribeMethod   = "Chain33.Subscribe"
+	wsUnsubscribeMethod = "Chain33.Unsubscribe"
+	wsNotifyMethod      = "Chain33.Subscription"
+	wsSendBuffer        = 256
+	wsMaxSubscriptions  = 64
+)
+
+var (
+	errWsSubscriptionType  = errors.New("ErrSubscriptionType")
+	errWsSubscriptionLimit = errors.New("ErrSubscriptionLimit")
+	errWsSubscriptionID    = errors.New("ErrSubscriptionNotFound")
+)
+
+type wsRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	ID     *json.RawMessage  `json:"id"`
+}
+
+type wsResponse struct {
+	ID     *json.RawMessage `json:"id"`
+	Result interface{}      `json:"result"`
+	Error  interface{}      `json:"error"`
+}
+
+type wsSubscription struct {
+	id       string
+	ty       string
+	client   *wsClient
+	hashes   map[string]bool
+	execer   string
+	logTypes map[int32]bool
+	//有通知没有发送出去, 下一次发送通知之前先发送 gap 通知
+	lost bool
+}
+
+// wsClient 一个websocket 连接，订阅由 wsHub 统一管理
+type wsClient struct {
+	conn      *wsConn
+	ip        string
+	caller    *rpcCaller
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	subs      map[string]*wsSubscription
+}
+
+func newWsClient(conn *wsConn, caller *rpcCaller) *wsClient {
+	return &wsClient{
+		conn:   conn,
+		ip:     caller.ip,
+		caller: caller,
+		send:   make(chan []byte, wsSendBuffer),
+		done:   make(chan struct{}),
+		subs:   make(map[string]*wsSubscription),
+	}
+}
+
+func (c *wsClient) writeLoop() {
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.conn.WriteMessage(data); err != nil {
+				log.Debug("websocket write", "ip", c.ip, "err", err)
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// sendRaw 不阻塞发送请求的应答，缓存满了说明客户端太慢，直接断开
+func (c *wsClient) sendRaw(data []byte) {
+	select {
+	case c.send <- data:
+	case <-c.done:
+	default:
+		log.Error("websocket client too slow, disconnect", "ip", c.ip)
+		c.close()
+	}
+}
+
+// trySend 推送订阅的通知, 缓存满了的时候返回 false, 由 wsHub 记录丢失的通知
+func (c *wsClient) trySend(data []byte) bool {
+	select {
+	case c.send <- data:
+		return true
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *wsClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// wsHub 管理所有的websocket 订阅
+type wsHub struct {
+	mu     sync.RWMutex
+	subs   map[string]*wsSubscription
+	nextID uint64
+	//最后推送的区块事件之后的 tip, 用来发现丢失的区块事件
+	tip []byte
+}
+
+func newWsHub() *wsHub {
+	return &wsHub{subs: make(map[string]*wsSubscription)}
+}
+
+func (h *wsHub) subscribe(c *wsClient, param *rpctypes.SubscribeParam) (string, error) {
+	sub := &wsSubscription{ty: param.Type, client: c}
+	switch param.Type {
+	case SubNewHeads, SubNewBlocks:
+	case SubTxConfirm:
+		if len(param.Hashes) == 0 {
+			return "", types.ErrInvalidParam
+		}
+		sub.hashes = make(map[string]bool)
+		for _, hash := range param.Hashes {
+			data, err := common.FromHex(hash)
+			if err != nil {
+				return "", err
+			}
+			sub.hashes[common.ToHex(data)] = true
+		}
+	case SubLogs:
+		sub.execer = param.Execer
+		if len(param.LogTypes) > 0 {
+			sub.logTypes = make(map[int32]bool)
+			for _, ty := range param.LogTypes {
+				sub.logTypes[ty] = true
+			}
+		}
+	default:
+		return "", errWsSubscriptionType
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(c.subs) >= wsMaxSubscriptions {
+		return "", errWsSubscriptionLimit
+	}
+	h.nextID++
+	sub.id = fmt.Sprintf("0x%x", h.nextID)
+	h.subs[sub.id] = sub
+	c.subs[sub.id] = sub
+	return sub.id, nil
+}
+
+func (h *wsHub) unsubscribe(c *wsClient, id string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := c.subs[id]; !ok {
+		return errWsSubscriptionID
+	}
+	delete(c.subs, id)
+	delete(h.subs, id)
+	return nil
+}
+
+func (h *wsHub) removeClient(c *wsClient) {
+	h.mu.Lock()
+	for id := range c.subs {
+		delete(h.subs, id)
+	}
+	c.subs = make(map[string]*wsSubscription)
+	h.mu.Unlock()
+	c.close()
+}
+
+// notify 客户端太慢缓存满了的时候丢弃通知, 不断开连接, 缓存有空间之后先发送 gap 通知, 客户端收到之后需要重新同步
+func (h *wsHub) notify(sub *wsSubscription, removed bool, result interface{}) {
+	if sub.lost && !h.notifyGap(sub) {
+		return
+	}
+	data, err := json.Marshal(&rpctypes.SubscriptionNotify{
+		Method: wsNotifyMethod,
+		Params: &rpctypes.SubscriptionResult{Subscription: sub.id, Removed: removed, Result: result},
+	})
+	if err != nil {
+		log.Error("websocket marshal", "err", err)
+		return
+	}
+	if !sub.client.trySend(data) {
+		log.Error("websocket client too slow, notification lost", "ip", sub.client.ip, "subscription", sub.id)
+		sub.lost = true
+	}
+}
+
+func (h *wsHub) notifyGap(sub *wsSubscription) bool {
+	data := mustMarshal(&rpctypes.SubscriptionNotify{
+		Method: wsNotifyMethod,
+		Params: &rpctypes.SubscriptionResult{Subscription: sub.id, Gap: true},
+	})
+	if !sub.client.trySend(data) {
+		return false
+	}
+	sub.lost = false
+	return true
+}
+
+// notifyBlock 由 blockchain 的 EventAddBlock/EventDelBlock 驱动, 回滚的区块 removed = true.
+// 区块事件和之前的 tip 连接不上时说明有区块事件丢失, 给所有的订阅发送 gap 通知
+func (h *wsHub) notifyBlock(detail *types.BlockDetail, removed bool) {
+	if detail == nil || detail.Block == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hash := detail.Block.Hash()
+	prev := h.tip
+	if removed {
+		h.tip = detail.Block.ParentHash
+	} else {
+		h.tip = hash
+	}
+	if len(h.subs) == 0 {
+		return
+	}
+	if prev != nil && ((!removed && !bytes.Equal(detail.Block.ParentHash, prev)) || (removed && !bytes.Equal(hash, prev))) {
+		log.Error("notifyBlock block event lost", "height", detail.Block.Height, "removed", removed)
+		for _, sub := range h.subs {
+			sub.lost = true
+			h.notifyGap(sub)
+		}
+	}
+	var header *rpctypes.Header
+	var block *rpctypes.BlockDetail
+	blockHash := common.ToHex(hash)
+	for _, sub := range h.subs {
+		switch sub.ty {
+		case SubNewHeads:
+			if header == nil {
+				header = fmtHeader(detail.Block.GetHeader())
+				header.Hash = blockHash
+			}
+			h.notify(sub, removed, header)
+		case SubNewBlocks:
+			if block == nil {
+				var err error
+				block, err = fmtBlockDetail(detail, true)
+				if err != nil {
+					log.Error("notifyBlock", "height", detail.Block.Height, "err", err)
+					continue
+				}
+			}
+			h.notify(sub, removed, block)
+		case SubTxConfirm:
+			for _, confirm := range filterTxConfirm(sub, detail, blockHash) {
+				h.notify(sub, removed, confirm)
+			}
+		case SubLogs:
+			for _, l := range filterReceiptLogs(sub, detail, blockHash) {
+				h.notify(sub, removed, l)
+			}
+		}
+	}
+}
+
+func filterTxConfirm(sub *wsSubscription, detail *types.BlockDetail, blockHash string) []*rpctypes.TxConfirmResult {
+	var result []*rpctypes.TxConfirmResult
+	for i, tx := range detail.Block.Txs {
+		hash := common.ToHex(tx.Hash())
+		if !sub.hashes[hash] {
+			continue
+		}
+		confirm := &rpctypes.TxConfirmResult{
+			Hash:      hash,
+			Height:    detail.Block.Height,
+			Index:     int64(i),
+			BlockHash: blockHash,
+		}
+		if i < len(detail.Receipts) {
+			confirm.Receipt, _ = decodeReceipt(tx.Execer, detail.Receipts[i])
+		}
+		result = append(result, confirm)
+	}
+	return result
+}
+
+func filterReceiptLogs(sub *wsSubscription, detail *types.BlockDetail, blockHash string) []*rpctypes.ReceiptLogNotify {
+	var result []*rpctypes.ReceiptLogNotify
+	for i, receipt := range detail.Receipts {
+		if i >= len(detail.Block.Txs) {
+			break
+		}
+		tx := detail.Block.Txs[i]
+		if sub.execer != "" && sub.execer != string(tx.Execer) {
+			continue
+		}
+		var txHash string
+		for j, l := range receipt.Logs {
+			if sub.logTypes != nil && !sub.logTypes[l.Ty] {
+				continue
+			}
+			if txHash == "" {
+				txHash = common.ToHex(tx.Hash())
+			}
+			notify := &rpctypes.ReceiptLogNotify{
+				Execer:    string(tx.Execer),
+				Height:    detail.Block.Height,
+				BlockHash: blockHash,
+				TxHash:    txHash,
+				TxIndex:   int64(i),
+				LogIndex:  int64(j),
+				Ty:        l.Ty,
+				TyName:    "unkownType",
+				RawLog:    common.ToHex(l.Log),
+			}
+			logType := types.LoadLog(tx.Execer, int64(l.Ty))
+			if logType != nil {
+				notify.TyName = logType.Name()
+				notify.Log, _ = logType.JSON(l.Log)
+			}
+			result = append(result, notify)
+		}
+	}
+	return result
+}
+
+// wsRPCConn 把一个请求适配为 jsonrpc codec 需要的 ReadWriteCloser
+type wsRPCConn struct {
+	in  io.Reader
+	out *bytes.Buffer
+}
+
+func (c *wsRPCConn) Read(p []byte) (n int, err error)  { return c.in.Read(p) }
+func (c *wsRPCConn) Write(d []byte) (n int, err error) { return c.out.Write(d) }
+func (c *wsRPCConn) Close() error                      { return nil }
+
+// serveWebsocket websocket 和 http 共用一个端口, 除了订阅以外的请求按照普通的jsonrpc 处理
+func (j *JSONRPCServer) serveWebsocket(w http.ResponseWriter, r *http.Request, ip string) {
+	conn, err := upgradeWebsocket(w, r)
+	if err != nil {
+		log.Debug("upgradeWebsocket", "ip", ip, "err", err)
+		return
+	}
+	//token 在握手的时候校验, 整个连接使用同一个调用者
+	c := newWsClient(conn, newRPCCaller(ip, httpBearerToken(r)))
+	go c.writeLoop()
+	defer j.hub.removeClient(c)
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			log.Debug("websocket read", "ip", ip, "err", err)
+			return
+		}
+		if resp := j.handleWsMessage(c, data); len(resp) > 0 {
+			c.sendRaw(resp)
+		}
+	}
+}
+
+func (j *JSONRPCServer) handleWsMessage(c *wsClient, data []byte) []byte {
+	if isJSONRPCBatch(data) {
+		return j.serveJSONRPC(data, c.caller)
+	}
+	var req wsRequest
+	resp := &wsResponse{}
+	if err := json.Unmarshal(data, &req); err != nil {
+		resp.Error = fmt.Sprintf(`parse request err %s`, err.Error())
+		return mustMarshal(resp)
+	}
+	resp.ID = req.ID
+	if req.Method != wsSubscribeMethod && req.Method != wsUnsubscribeMethod {
+		return bytes.TrimSpace(j.serveJSONRPC(data, c.caller))
+	}
+	if err := checkJrpcFuncAuth(c.caller, req.Method); err != nil {
+		resp.Error = err.Error()
+		return mustMarshal(resp)
+	}
+	switch req.Method {
+	case wsSubscribeMethod:
+		var param rpctypes.SubscribeParam
+		if len(req.Params) != 1 || json.Unmarshal(req.Params[0], &param) != nil {
+			resp.Error = types.ErrInvalidParam.Error()
+			break
+		}
+		id, err := j.hub.subscribe(c, &param)
+		if err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		resp.Result = id
+	case wsUnsubscribeMethod:
+		var param rpctypes.UnsubscribeParam
+		if len(req.Params) != 1 || json.Unmarshal(req.Params[0], &param) != nil {
+			resp.Error = types.ErrInvalidParam.Error()
+			break
+		}
+		if err := j.hub.unsubscribe(c, param.ID); err != nil {
+			resp.Error = err.Error()
+			break
+		}
+		resp.Result = true
+	}
+	return mustMarshal(resp)
+}
+
+func mustMarshal(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
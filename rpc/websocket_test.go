@@ -0,0 +1,232 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common"
+	qmocks "github.com/33cn/chain33/queue/mocks"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWsAcceptKey(t *testing.T) {
+	//RFC 6455 中的例子
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", wsAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func writeClientFrame(w io.Writer, data []byte) error {
+	frame := []byte{0x80 | wsOpText}
+	if len(data) < 126 {
+		frame = append(frame, 0x80|byte(len(data)))
+	} else {
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(data)))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i, b := range data {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := w.Write(frame)
+	return err
+}
+
+func readServerFrame(r *bufio.Reader) ([]byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	data := make([]byte, length)
+	_, err := io.ReadFull(r, data)
+	return data, err
+}
+
+func TestWebsocketSubscribe(t *testing.T) {
+	rpcCfg = new(types.RPC)
+	rpcCfg.JrpcBindAddr = "127.0.0.1:8202"
+	rpcCfg.EnableWebsocket = true
+	InitCfg(rpcCfg)
+	api := new(mocks.QueueProtocolAPI)
+	server := NewJSONRPCServer(&qmocks.Client{}, api)
+	_, err := server.Listen()
+	assert.Nil(t, err)
+	defer server.l.Close()
+
+	conn, err := net.Dial("tcp", rpcCfg.JrpcBindAddr)
+	assert.Nil(t, err)
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	handshake := "GET / HTTP/1.1\r\nHost: " + rpcCfg.JrpcBindAddr + "\r\n" +
+		"Connection: Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	_, err = conn.Write([]byte(handshake))
+	assert.Nil(t, err)
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	err = writeClientFrame(conn, []byte(`{"id":1,"method":"Chain33.Subscribe","params":[{"type":"newHeads"}]}`))
+	assert.Nil(t, err)
+	data, err := readServerFrame(br)
+	assert.Nil(t, err)
+	var reply struct {
+		ID     int64  `json:"id"`
+		Result string `json:"result"`
+	}
+	assert.Nil(t, json.Unmarshal(data, &reply))
+	assert.Equal(t, int64(1), reply.ID)
+	assert.Equal(t, "0x1", reply.Result)
+
+	block := &types.BlockDetail{Block: &types.Block{Height: 10}}
+	server.hub.notifyBlock(block, false)
+	server.hub.notifyBlock(block, true)
+	for _, removed := range []bool{false, true} {
+		data, err = readServerFrame(br)
+		assert.Nil(t, err)
+		var notify struct {
+			Method string `json:"method"`
+			Params struct {
+				Subscription string           `json:"subscription"`
+				Removed      bool             `json:"removed"`
+				Result       *rpctypes.Header `json:"result"`
+			} `json:"params"`
+		}
+		assert.Nil(t, json.Unmarshal(data, &notify))
+		assert.Equal(t, wsNotifyMethod, notify.Method)
+		assert.Equal(t, "0x1", notify.Params.Subscription)
+		assert.Equal(t, removed, notify.Params.Removed)
+		assert.Equal(t, int64(10), notify.Params.Result.Height)
+	}
+
+	err = writeClientFrame(conn, []byte(`{"id":2,"method":"Chain33.Unsubscribe","params":[{"id":"0x1"}]}`))
+	assert.Nil(t, err)
+	data, err = readServerFrame(br)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":2,"result":true,"error":null}`, string(data))
+}
+
+func TestWsHubFilter(t *testing.T) {
+	tx1 := &types.Transaction{Execer: []byte("coins"), Nonce: 1}
+	tx2 := &types.Transaction{Execer: []byte("none"), Nonce: 2}
+	detail := &types.BlockDetail{
+		Block: &types.Block{Height: 5, Txs: []*types.Transaction{tx1, tx2}},
+		Receipts: []*types.ReceiptData{
+			{Ty: types.ExecOk, Logs: []*types.ReceiptLog{{Ty: types.TyLogFee}, {Ty: types.TyLogTransfer}}},
+			{Ty: types.ExecOk, Logs: []*types.ReceiptLog{{Ty: types.TyLogFee}}},
+		},
+	}
+	hub := newWsHub()
+	c := &wsClient{subs: make(map[string]*wsSubscription)}
+
+	_, err := hub.subscribe(c, &rpctypes.SubscribeParam{Type: "unknown"})
+	assert.Equal(t, errWsSubscriptionType, err)
+	_, err = hub.subscribe(c, &rpctypes.SubscribeParam{Type: SubTxConfirm})
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	id, err := hub.subscribe(c, &rpctypes.SubscribeParam{Type: SubLogs, Execer: "coins", LogTypes: []int32{types.TyLogTransfer}})
+	assert.Nil(t, err)
+	logs := filterReceiptLogs(hub.subs[id], detail, "0x00")
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, int32(types.TyLogTransfer), logs[0].Ty)
+	assert.Equal(t, int64(1), logs[0].LogIndex)
+
+	id, err = hub.subscribe(c, &rpctypes.SubscribeParam{Type: SubLogs, LogTypes: []int32{types.TyLogFee}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(filterReceiptLogs(hub.subs[id], detail, "0x00")))
+
+	id, err = hub.subscribe(c, &rpctypes.SubscribeParam{Type: SubTxConfirm, Hashes: []string{common.ToHex(tx2.Hash())}})
+	assert.Nil(t, err)
+	confirms := filterTxConfirm(hub.subs[id], detail, "0x00")
+	assert.Equal(t, 1, len(confirms))
+	assert.Equal(t, int64(1), confirms[0].Index)
+
+	assert.Nil(t, hub.unsubscribe(c, id))
+	assert.Equal(t, errWsSubscriptionID, hub.unsubscribe(c, id))
+	hub.removeClient(&wsClient{subs: c.subs, done: make(chan struct{}), conn: &wsConn{closed: true}})
+	assert.Equal(t, 0, len(hub.subs))
+}
+
+func TestWsHubGap(t *testing.T) {
+	hub := newWsHub()
+	c := &wsClient{send: make(chan []byte, 2), done: make(chan struct{}), subs: make(map[string]*wsSubscription)}
+	id, err := hub.subscribe(c, &rpctypes.SubscribeParam{Type: SubNewHeads})
+	assert.Nil(t, err)
+	type notify struct {
+		Params struct {
+			Subscription string           `json:"subscription"`
+			Gap          bool             `json:"gap"`
+			Result       *rpctypes.Header `json:"result"`
+		} `json:"params"`
+	}
+	next := func() *notify {
+		select {
+		case data := <-c.send:
+			var n notify
+			assert.Nil(t, json.Unmarshal(data, &n))
+			assert.Equal(t, id, n.Params.Subscription)
+			return &n
+		default:
+			t.Fatal("no notification")
+			return nil
+		}
+	}
+	var blocks []*types.BlockDetail
+	var parent []byte
+	for i := int64(1); i <= 5; i++ {
+		block := &types.Block{Height: i, ParentHash: parent}
+		blocks = append(blocks, &types.BlockDetail{Block: block})
+		parent = block.Hash()
+	}
+
+	//客户端太慢, 缓存满了之后丢弃通知, 不断开连接
+	for _, block := range blocks[:3] {
+		hub.notifyBlock(block, false)
+	}
+	assert.Equal(t, int64(1), next().Params.Result.Height)
+	assert.Equal(t, int64(2), next().Params.Result.Height)
+	assert.True(t, hub.subs[id].lost)
+	//缓存有空间之后先发送 gap 通知
+	hub.notifyBlock(blocks[3], false)
+	assert.True(t, next().Params.Gap)
+	assert.Equal(t, int64(4), next().Params.Result.Height)
+	assert.False(t, hub.subs[id].lost)
+
+	//区块事件连接不上时说明 blockchain 丢弃了区块事件, 同样发送 gap 通知
+	hub.notifyBlock(blocks[3], true)
+	assert.Equal(t, int64(4), next().Params.Result.Height)
+	hub.notifyBlock(blocks[4], false)
+	assert.True(t, next().Params.Gap)
+	assert.Equal(t, int64(5), next().Params.Result.Height)
+	hub.notifyBlock(blocks[4], true)
+	assert.False(t, next().Params.Gap)
+}
@@ -0,0 +1,233 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rpc
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RFC 6455 websocket 的最小实现，只实现服务端需要的部分
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+
+	wsMaxMessageSize = 1 << 20
+	wsWriteTimeout   = 10 * time.Second
+	wsAcceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+var (
+	errWsBadHandshake = errors.New("ErrWebsocketBadHandshake")
+	errWsBadFrame     = errors.New("ErrWebsocketBadFrame")
+	errWsTooLarge     = errors.New("ErrWebsocketMessageTooLarge")
+	errWsClosed       = errors.New("ErrWebsocketClosed")
+)
+
+// wsConn websocket 连接
+type wsConn struct {
+	conn   net.Conn
+	br     *bufio.Reader
+	wmu    sync.Mutex
+	closed bool
+}
+
+func isWebsocketRequest(r *http.Request) bool {
+	return headerContains(r.Header, "Connection", "upgrade") &&
+		headerContains(r.Header, "Upgrade", "websocket")
+}
+
+func headerContains(h http.Header, name, value string) bool {
+	for _, v := range h[http.CanonicalHeaderKey(name)] {
+		for _, s := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(s), value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func wsAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// upgradeWebsocket 把http 连接升级为websocket 连接
+func upgradeWebsocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if r.Method != "GET" || !isWebsocketRequest(r) {
+		return nil, errWsBadHandshake
+	}
+	if r.Header.Get("Sec-Websocket-Version") != "13" {
+		return nil, errWsBadHandshake
+	}
+	key := r.Header.Get("Sec-Websocket-Key")
+	if key == "" {
+		return nil, errWsBadHandshake
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errWsBadHandshake
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + wsAcceptKey(key) + "\r\n\r\n"
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if _, err = conn.Write([]byte(resp)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetWriteDeadline(time.Time{})
+	return &wsConn{conn: conn, br: brw.Reader}, nil
+}
+
+// ReadMessage 读取一个完整的消息, ping 自动回复 pong
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			c.writeFrame(wsOpClose, nil)
+			c.Close()
+			return nil, errWsClosed
+		case wsOpText, wsOpBinary:
+			msg = payload
+		case wsOpContinuation:
+			if msg == nil {
+				return nil, errWsBadFrame
+			}
+			msg = append(msg, payload...)
+		default:
+			return nil, errWsBadFrame
+		}
+		if len(msg) > wsMaxMessageSize {
+			return nil, errWsTooLarge
+		}
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (fin bool, op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return
+	}
+	fin = head[0]&0x80 != 0
+	op = head[0] & 0x0f
+	masked := head[1]&0x80 != 0
+	//客户端发送的帧必须带掩码
+	if !masked {
+		err = errWsBadFrame
+		return
+	}
+	length := uint64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > wsMaxMessageSize {
+		err = errWsTooLarge
+		return
+	}
+	var mask [4]byte
+	if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return
+}
+
+// WriteMessage 发送一个文本消息
+func (c *wsConn) WriteMessage(data []byte) error {
+	return c.writeFrame(wsOpText, data)
+}
+
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.closed {
+		return errWsClosed
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	_, err := c.conn.Write(encodeWsFrame(op, payload))
+	return err
+}
+
+// 服务端发送的帧不带掩码
+func encodeWsFrame(op byte, payload []byte) []byte {
+	frame := make([]byte, 0, len(payload)+10)
+	frame = append(frame, 0x80|op)
+	length := len(payload)
+	switch {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+	return append(frame, payload...)
+}
+
+// Close 关闭连接
+func (c *wsConn) Close() error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.conn.Close()
+}
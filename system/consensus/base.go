@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 
 	"github.com/33cn/chain33/client"
+	"github.com/33cn/chain33/common/address"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/common/merkle"
 	"github.com/33cn/chain33/queue"
@@ -40,6 +41,11 @@ type Miner interface {
 	ProcEvent(msg queue.Message) bool
 }
 
+//HeaderChecker 共识模块可以实现的区块头检查, 只有区块头没有交易时(先同步区块头, 轻节点)使用
+type HeaderChecker interface {
+	CheckHeader(parent *types.Header, header *types.Header) error
+}
+
 //BaseClient ...
 type BaseClient struct {
 	client       queue.Client
@@ -213,6 +219,10 @@ func (bc *BaseClient) EventLoop() {
 				block := msg.GetData().(*types.BlockDetail)
 				err := bc.CheckBlock(block)
 				msg.ReplyErr("EventCheckBlock", err)
+			} else if msg.Ty == types.EventCheckHeaders {
+				headers := msg.GetData().(*types.Headers)
+				err := bc.CheckHeaders(headers.GetItems())
+				msg.ReplyErr("EventCheckHeaders", err)
 			} else if msg.Ty == types.EventMinerStart {
 				if !atomic.CompareAndSwapInt32(&bc.minerStart, 0, 1) {
 					msg.ReplyErr("EventMinerStart", types.ErrMinerIsStared)
@@ -264,6 +274,45 @@ func (bc *BaseClient) CheckBlock(block *types.BlockDetail) error {
 	return err
 }
 
+//CheckHeaders 检查连续的区块头, headers[0] 为已经验证过的父区块头,
+//之后的区块头和 CheckBlock 一样检查高度, 时间以及父区块hash, 再由共识模块检查.
+//共识模块没有实现 HeaderChecker 时不能只根据区块头验证, 返回 ErrNotSupport
+func (bc *BaseClient) CheckHeaders(headers []*types.Header) error {
+	checker, ok := bc.child.(HeaderChecker)
+	if !ok {
+		return types.ErrNotSupport
+	}
+	for i := 1; i < len(headers); i++ {
+		parent, header := headers[i-1], headers[i]
+		if parent.Height+1 != header.Height {
+			return types.ErrBlockHeight
+		}
+		if types.IsFork(header.Height, "ForkCheckBlockTime") && parent.BlockTime > header.BlockTime {
+			return types.ErrBlockTime
+		}
+		if string(header.GetParentHash()) != string(parent.GetHash()) {
+			return types.ErrParentHash
+		}
+		if err := checker.CheckHeader(parent, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//CheckHeaderSigner 检查区块头的签名, 并且签名的地址必须是共识允许的出块地址(ticket 的矿工, 授权的节点等),
+//给共识模块实现 CheckHeader 时使用
+func CheckHeaderSigner(header *types.Header, allowed func(addr string) bool) error {
+	sign := header.GetSignature()
+	if sign == nil || !types.CheckSign(header.Hash, "", sign) {
+		return types.ErrSign
+	}
+	if !allowed(address.PubKeyToAddress(sign.Pubkey).String()) {
+		return types.ErrSign
+	}
+	return nil
+}
+
 //RequestTx Mempool中取交易列表
 func (bc *BaseClient) RequestTx(listSize int, txHashList [][]byte) []*types.Transaction {
 	if bc.client == nil {
@@ -35,6 +35,8 @@ type subConfig struct {
 	Genesis          string `json:"genesis"`
 	GenesisBlockTime int64  `json:"genesisBlockTime"`
 	WaitTxMs         int64  `json:"waitTxMs"`
+	//Signers 允许给区块头签名的地址, 轻节点只接受这些地址签名的区块头
+	Signers []string `json:"signers"`
 }
 
 //New new
@@ -86,6 +88,25 @@ func (client *Client) CheckBlock(parent *types.Block, current *types.BlockDetail
 	return nil
 }
 
+//CheckHeader solo 出块的区块头没有签名, 没有配置 signers 时只接受没有签名的区块头,
+//配置了 signers 时区块头必须由其中的地址签名
+func (client *Client) CheckHeader(parent *types.Header, header *types.Header) error {
+	if len(client.subcfg.Signers) == 0 {
+		if header.GetSignature() != nil {
+			return types.ErrSign
+		}
+		return nil
+	}
+	return drivers.CheckHeaderSigner(header, func(addr string) bool {
+		for _, signer := range client.subcfg.Signers {
+			if signer == addr {
+				return true
+			}
+		}
+		return false
+	})
+}
+
 //CreateBlock 创建区块
 func (client *Client) CreateBlock() {
 	issleep := true
@@ -7,8 +7,14 @@ package solo
 import (
 	"testing"
 
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
 	"github.com/33cn/chain33/util/testnode"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	//加载系统内置store, 不要依赖plugin
 	_ "github.com/33cn/chain33/system/dapp/init"
@@ -30,3 +36,58 @@ func TestSolo(t *testing.T) {
 	}
 	mock33.WaitHeight(2)
 }
+
+func TestCheckHeaders(t *testing.T) {
+	mock33 := testnode.New("", nil)
+	defer mock33.Close()
+	for i := int64(1); i <= 3; i++ {
+		mock33.SendTx(util.CreateCoinsTx(mock33.GetGenesisKey(), mock33.GetHotAddress(), types.Coin))
+		require.Nil(t, mock33.WaitHeight(i))
+	}
+	headers, err := mock33.GetAPI().GetHeaders(&types.ReqBlocks{Start: 0, End: 3})
+	require.Nil(t, err)
+	client := mock33.GetClient()
+	assert.Nil(t, util.CheckHeaders(client, headers))
+
+	bad := proto.Clone(headers).(*types.Headers)
+	bad.Items[2].ParentHash = bad.Items[0].Hash
+	assert.Equal(t, types.ErrParentHash.Error(), util.CheckHeaders(client, bad).Error())
+	bad = proto.Clone(headers).(*types.Headers)
+	bad.Items = append(bad.Items[:1], bad.Items[2:]...)
+	assert.Equal(t, types.ErrBlockHeight.Error(), util.CheckHeaders(client, bad).Error())
+	//没有配置 signers 时, 伪造签名的区块头不能通过
+	bad = proto.Clone(headers).(*types.Headers)
+	signHeader(t, bad.Items[2], nil)
+	assert.Equal(t, types.ErrSign.Error(), util.CheckHeaders(client, bad).Error())
+}
+
+func signHeader(t *testing.T, header *types.Header, priv crypto.PrivKey) crypto.PrivKey {
+	if priv == nil {
+		cr, err := crypto.New(types.GetSignName("", types.SECP256K1))
+		require.Nil(t, err)
+		priv, err = cr.GenKey()
+		require.Nil(t, err)
+	}
+	header.Signature = &types.Signature{Ty: types.SECP256K1, Pubkey: priv.PubKey().Bytes(), Signature: priv.Sign(header.Hash).Bytes()}
+	return priv
+}
+
+func TestCheckHeaderSigners(t *testing.T) {
+	signer := signHeader(t, &types.Header{}, nil)
+	sub := []byte(`{"signers":["` + address.PubKeyToAddress(signer.PubKey().Bytes()).String() + `"]}`)
+	client := New(&types.Consensus{Name: "solo"}, sub).(*Client)
+	parent := &types.Header{Height: 1, Hash: []byte("parent")}
+	header := &types.Header{Height: 2, ParentHash: parent.Hash, Hash: []byte("header")}
+	assert.Equal(t, types.ErrSign, client.CheckHeader(parent, header))
+	signHeader(t, header, signer)
+	assert.Nil(t, client.CheckHeader(parent, header))
+
+	//签名正确但不是配置的地址签名的区块头
+	forged := proto.Clone(header).(*types.Header)
+	signHeader(t, forged, nil)
+	assert.Equal(t, types.ErrSign, client.CheckHeader(parent, forged))
+	//签名和区块头hash不一致
+	forged = proto.Clone(header).(*types.Header)
+	forged.Hash = []byte("forged")
+	assert.Equal(t, types.ErrSign, client.CheckHeader(parent, forged))
+}
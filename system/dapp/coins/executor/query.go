@@ -28,6 +28,16 @@ func (c *Coins) Query_GetAddrTxsCount(in *types.ReqKey) (types.Message, error) {
 	return c.GetAddrTxsCount(in)
 }
 
+// Query_GetLogs query receipt logs of the execer
+func (c *Coins) Query_GetLogs(in *types.ReqGetLogs) (types.Message, error) {
+	return c.GetLogs(in)
+}
+
+// Query_GetTxsByAddrFilter query txs of the address filtered by execer, action, direction, status and block time
+func (c *Coins) Query_GetTxsByAddrFilter(in *types.ReqTxsByAddrFilter) (types.Message, error) {
+	return c.GetTxsByAddrFilter(in)
+}
+
 // GetAddrReciver get address reciver by address
 func (c *Coins) GetAddrReciver(addr *types.ReqAddr) (types.Message, error) {
 	reciver := types.Int64{}
@@ -35,6 +35,7 @@ func BlockCmd() *cobra.Command {
 		GetBlockByHashsCmd(),
 		GetBlockSequencesCmd(),
 		GetLastBlockSequenceCmd(),
+		GetReorgHistoryCmd(),
 	)
 
 	return cmd
@@ -266,6 +267,26 @@ func blockSequencesCmdFlags(cmd *cobra.Command) {
 	cmd.MarkFlagRequired("end")
 }
 
+// GetReorgHistoryCmd get the latest reorg records
+func GetReorgHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reorgs",
+		Short: "Get the latest chain reorganizations and orphan pool statistics",
+		Run:   getReorgHistory,
+	}
+	cmd.Flags().Int32P("count", "c", 10, "number of the latest reorg records")
+	return cmd
+}
+
+func getReorgHistory(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	count, _ := cmd.Flags().GetInt32("count")
+	params := rpctypes.ReqReorgHistory{Count: count}
+	var res rpctypes.ReorgHistory
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.GetReorgHistory", params, &res)
+	ctx.Run()
+}
+
 // GetBlockByHashsCmd get Block Details By block Hashs
 func GetBlockByHashsCmd() *cobra.Command {
 	cmd := &cobra.Command{
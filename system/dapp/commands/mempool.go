@@ -5,9 +5,12 @@
 package commands
 
 import (
+	"strings"
+
 	"github.com/33cn/chain33/rpc/jsonclient"
 	rpctypes "github.com/33cn/chain33/rpc/types"
-	"github.com/33cn/chain33/system/dapp/commands/types"
+	commandtypes "github.com/33cn/chain33/system/dapp/commands/types"
+	"github.com/33cn/chain33/types"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +25,10 @@ func MempoolCmd() *cobra.Command {
 	cmd.AddCommand(
 		GetMempoolCmd(),
 		GetLastMempoolCmd(),
+		GetMempoolTxCmd(),
+		GetMempoolTxsByAddrCmd(),
+		EvictMempoolTxCmd(),
+		GetMempoolStatCmd(),
 	)
 
 	return cmd
@@ -47,9 +54,9 @@ func listMempoolTxs(cmd *cobra.Command, args []string) {
 
 func parseListMempoolTxsRes(arg interface{}) (interface{}, error) {
 	res := arg.(*rpctypes.ReplyTxList)
-	var result types.TxListResult
+	var result commandtypes.TxListResult
 	for _, v := range res.Txs {
-		result.Txs = append(result.Txs, types.DecodeTransaction(v))
+		result.Txs = append(result.Txs, commandtypes.DecodeTransaction(v))
 	}
 	return result, nil
 }
@@ -74,9 +81,90 @@ func lastMempoolTxs(cmd *cobra.Command, args []string) {
 
 func parselastMempoolTxsRes(arg interface{}) (interface{}, error) {
 	res := arg.(*rpctypes.ReplyTxList)
-	var result types.TxListResult
+	var result commandtypes.TxListResult
 	for _, v := range res.Txs {
-		result.Txs = append(result.Txs, types.DecodeTransaction(v))
+		result.Txs = append(result.Txs, commandtypes.DecodeTransaction(v))
 	}
 	return result, nil
 }
+
+// GetMempoolTxCmd get the pending tx in mempool by hash
+func GetMempoolTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tx",
+		Short: "Get the pending tx in mempool by hash",
+		Run:   getMempoolTx,
+	}
+	cmd.Flags().StringP("hash", "s", "", "transaction hash")
+	cmd.MarkFlagRequired("hash")
+	return cmd
+}
+
+func getMempoolTx(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	hash, _ := cmd.Flags().GetString("hash")
+	params := rpctypes.QueryParm{Hash: hash}
+	var res rpctypes.MempoolTx
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.GetMempoolTx", params, &res)
+	ctx.Run()
+}
+
+// GetMempoolTxsByAddrCmd get the pending txs in mempool of the addresses
+func GetMempoolTxsByAddrCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "addr_txs",
+		Short: "Get the pending txs in mempool of the addresses",
+		Run:   getMempoolTxsByAddr,
+	}
+	cmd.Flags().StringP("addrs", "a", "", "account address(es), separated by space")
+	cmd.MarkFlagRequired("addrs")
+	return cmd
+}
+
+func getMempoolTxsByAddr(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	addrs, _ := cmd.Flags().GetString("addrs")
+	params := types.ReqAddrs{Addrs: strings.Fields(addrs)}
+	var res rpctypes.TransactionDetails
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.GetMempoolTxsByAddr", params, &res)
+	ctx.SetResultCb(parseQueryTxsByHashesRes)
+	ctx.Run()
+}
+
+// EvictMempoolTxCmd remove the pending tx from mempool
+func EvictMempoolTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evict",
+		Short: "Remove the pending tx from mempool (admin role of the rpc auth only)",
+		Run:   evictMempoolTx,
+	}
+	cmd.Flags().StringP("hash", "s", "", "transaction hash")
+	cmd.MarkFlagRequired("hash")
+	return cmd
+}
+
+func evictMempoolTx(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	hash, _ := cmd.Flags().GetString("hash")
+	params := rpctypes.QueryParm{Hash: hash}
+	var res rpctypes.Reply
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.EvictMempoolTx", params, &res)
+	ctx.Run()
+}
+
+// GetMempoolStatCmd get mempool statistics
+func GetMempoolStatCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stat",
+		Short: "Get mempool size, bytes, fee histogram, executor counts and the oldest tx",
+		Run:   getMempoolStat,
+	}
+	return cmd
+}
+
+func getMempoolStat(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	var res rpctypes.MempoolStat
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.GetMempoolStat", nil, &res)
+	ctx.Run()
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"reflect"
 
+	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/types"
 	"github.com/golang/protobuf/proto"
 )
@@ -95,6 +96,272 @@ func (d *DriverBase) GetAddrTxsCount(reqkey *types.ReqKey) (types.Message, error
 	return &counts, nil
 }
 
+// MaxLogsPerQuery GetLogs 每次最多返回的日志数
+const MaxLogsPerQuery = 1000
+
+// maxLogsScan GetLogs 每次最多扫描的索引数, 防止过滤条件太严格时扫描整个索引
+const maxLogsScan = 100 * MaxLogsPerQuery
+
+// GetLogs query the receipt logs of the execer by log type, height range and address,
+// need exec.enableLogIndex, use the cursor of the reply to get next page
+func (d *DriverBase) GetLogs(req *types.ReqGetLogs) (types.Message, error) {
+	if req.Execer == "" || req.Count < 0 || req.Count > MaxLogsPerQuery || req.FromHeight < 0 {
+		return nil, types.ErrInvalidParam
+	}
+	if req.ToHeight >= 0 && req.ToHeight < req.FromHeight {
+		return nil, types.ErrStartBigThanEnd
+	}
+	count := req.Count
+	if count == 0 {
+		count = MaxLogsPerQuery
+	}
+	//只查询一种日志类型时使用类型索引, 否则在执行器索引中过滤, 类型索引的值是执行器索引的 key
+	var prefix []byte
+	var startKey func(position string) []byte
+	byType := len(req.LogTypes) == 1
+	if byType {
+		prefix = types.CalcLogIndexTyKey(req.Execer, req.LogTypes[0], "")
+		startKey = func(position string) []byte { return types.CalcLogIndexTyKey(req.Execer, req.LogTypes[0], position) }
+	} else {
+		prefix = types.CalcLogIndexKey(req.Execer, "")
+		startKey = func(position string) []byte { return types.CalcLogIndexKey(req.Execer, position) }
+	}
+	logTypes := make(map[int32]bool)
+	for _, ty := range req.LogTypes {
+		logTypes[ty] = true
+	}
+	addrs := make(map[string]bool)
+	for _, addr := range req.Addrs {
+		addrs[addr] = true
+	}
+
+	db := d.GetLocalDB()
+	var reply types.ReplyGetLogs
+	cursor := req.Cursor
+	if cursor == "" {
+		//第一页: 从 fromHeight 之前的最后一条日志之后开始查找
+		values, err := db.List(prefix, startKey(HeightIndexStr(req.FromHeight, 0)), 1, dbm.ListSeek)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		if len(values) == 2 {
+			cursor = string(values[0][len(prefix):])
+		}
+	}
+	scanned := 0
+	for scanned < maxLogsScan {
+		var values [][]byte
+		var err error
+		if cursor == "" {
+			values, err = db.List(prefix, nil, count, dbm.ListASC)
+		} else {
+			values, err = db.List(prefix, startKey(cursor), count, dbm.ListASC)
+		}
+		if err == types.ErrNotFound || len(values) == 0 {
+			return &reply, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			scanned++
+			if byType {
+				value, err = db.Get(value)
+				if err != nil {
+					return nil, err
+				}
+			}
+			var l types.ReceiptLogIndex
+			if err := types.Decode(value, &l); err != nil {
+				return nil, err
+			}
+			if req.ToHeight >= 0 && l.Height > req.ToHeight {
+				return &reply, nil
+			}
+			cursor = types.LogIndexPosition(l.Height, l.TxIndex, l.LogIndex)
+			if len(logTypes) > 0 && !logTypes[l.Ty] {
+				continue
+			}
+			if len(addrs) > 0 && !addrs[l.From] && !addrs[l.To] {
+				continue
+			}
+			reply.Logs = append(reply.Logs, &l)
+			if int32(len(reply.Logs)) == count {
+				reply.Cursor = cursor
+				return &reply, nil
+			}
+		}
+	}
+	//扫描的数量达到上限, 返回 cursor 继续查询
+	reply.Cursor = cursor
+	return &reply, nil
+}
+
+// MaxTxsPerAddrQuery GetTxsByAddrFilter 每次最多返回的交易数
+const MaxTxsPerAddrQuery = 1000
+
+// maxTxsByAddrScan GetTxsByAddrFilter 每次最多扫描的索引数
+const maxTxsByAddrScan = 100 * MaxTxsPerAddrQuery
+
+//txsByAddrFilter 地址交易的过滤条件
+type txsByAddrFilter struct {
+	req      *types.ReqTxsByAddrFilter
+	prefix   []byte
+	startKey func(heightindex string) []byte
+}
+
+func newTxsByAddrFilter(req *types.ReqTxsByAddrFilter) *txsByAddrFilter {
+	f := &txsByAddrFilter{req: req}
+	switch {
+	case req.ActionName != "":
+		f.startKey = func(heightindex string) []byte {
+			return types.CalcTxAddrActionKey(req.Addr, req.Execer, req.ActionName, heightindex)
+		}
+	case req.Execer != "":
+		f.startKey = func(heightindex string) []byte { return types.CalcTxAddrExecKey(req.Addr, req.Execer, heightindex) }
+	default:
+		f.startKey = func(heightindex string) []byte { return types.CalcTxAddrIndexKey(req.Addr, heightindex) }
+	}
+	f.prefix = f.startKey("")
+	return f
+}
+
+//match 方向和执行结果是否符合条件
+func (f *txsByAddrFilter) match(direction, ty int32) bool {
+	if f.req.Flag > 0 && direction&f.req.Flag == 0 {
+		return false
+	}
+	if f.req.Status == 1 && ty != types.ExecOk {
+		return false
+	}
+	if f.req.Status == 2 && ty == types.ExecOk {
+		return false
+	}
+	return true
+}
+
+//checkTime 区块时间是否在范围之内, done 表示之后的交易都不在范围之内(区块时间随高度递增)
+func (f *txsByAddrFilter) checkTime(blocktime int64) (ok bool, done bool) {
+	req := f.req
+	if req.StartTime > 0 && blocktime < req.StartTime {
+		return false, req.Direction == dbm.ListDESC
+	}
+	if req.EndTime > 0 && blocktime > req.EndTime {
+		return false, req.Direction == dbm.ListASC
+	}
+	return true, false
+}
+
+//total 没有时间范围时使用统计的交易数, 否则扫描索引, 超过扫描上限时返回 -1
+func (f *txsByAddrFilter) total(db dbm.KVDB) (int64, error) {
+	req := f.req
+	var total int64
+	if req.StartTime == 0 && req.EndTime == 0 {
+		values, err := db.List(types.CalcTxAddrCountPrefix(req.Addr, req.Execer, req.ActionName), nil, 0, dbm.ListASC)
+		if err != nil && err != types.ErrNotFound {
+			return 0, err
+		}
+		for _, value := range values {
+			var count types.AddrTxCount
+			if err := types.Decode(value, &count); err != nil {
+				return 0, err
+			}
+			if f.match(count.Direction, count.Ty) {
+				total += count.Count
+			}
+		}
+		return total, nil
+	}
+	var key []byte
+	for scanned := 0; scanned < maxTxsByAddrScan; {
+		values, err := db.List(f.prefix, key, MaxTxsPerAddrQuery, req.Direction)
+		if err == types.ErrNotFound || len(values) == 0 {
+			return total, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, value := range values {
+			scanned++
+			var tx types.AddrTxIndex
+			if err := types.Decode(value, &tx); err != nil {
+				return 0, err
+			}
+			key = f.startKey(HeightIndexStr(tx.Height, tx.Index))
+			ok, done := f.checkTime(tx.Blocktime)
+			if done {
+				return total, nil
+			}
+			if ok && f.match(tx.Direction, tx.Ty) {
+				total++
+			}
+		}
+	}
+	return -1, nil
+}
+
+// GetTxsByAddrFilter query the transactions of the address filtered by execer, action, direction, status and block time,
+// use the cursor of the reply to get next page
+func (d *DriverBase) GetTxsByAddrFilter(req *types.ReqTxsByAddrFilter) (types.Message, error) {
+	if req.Addr == "" || (req.ActionName != "" && req.Execer == "") || req.Flag < 0 || req.Flag > 2 ||
+		req.Status < 0 || req.Status > 2 || req.Count < 0 || req.Count > MaxTxsPerAddrQuery ||
+		(req.Direction != dbm.ListDESC && req.Direction != dbm.ListASC) {
+		return nil, types.ErrInvalidParam
+	}
+	if req.EndTime > 0 && req.EndTime < req.StartTime {
+		return nil, types.ErrStartBigThanEnd
+	}
+	count := req.Count
+	if count == 0 {
+		count = MaxTxsPerAddrQuery
+	}
+	f := newTxsByAddrFilter(req)
+	db := d.GetLocalDB()
+	total, err := f.total(db)
+	if err != nil {
+		return nil, err
+	}
+	reply := &types.ReplyTxsByAddrFilter{Total: total}
+	cursor := req.Cursor
+	scanned := 0
+	for scanned < maxTxsByAddrScan {
+		var key []byte
+		if cursor != "" {
+			key = f.startKey(cursor)
+		}
+		values, err := db.List(f.prefix, key, count, req.Direction)
+		if err == types.ErrNotFound || len(values) == 0 {
+			return reply, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			scanned++
+			var tx types.AddrTxIndex
+			if err := types.Decode(value, &tx); err != nil {
+				return nil, err
+			}
+			cursor = HeightIndexStr(tx.Height, tx.Index)
+			ok, done := f.checkTime(tx.Blocktime)
+			if done {
+				return reply, nil
+			}
+			if !ok || !f.match(tx.Direction, tx.Ty) {
+				continue
+			}
+			reply.Txs = append(reply.Txs, &tx)
+			if int32(len(reply.Txs)) == count {
+				reply.Cursor = cursor
+				return reply, nil
+			}
+		}
+	}
+	//扫描的数量达到上限, 返回 cursor 继续查询
+	reply.Cursor = cursor
+	return reply, nil
+}
+
 // Query defines query function
 func (d *DriverBase) Query(funcname string, params []byte) (msg types.Message, err error) {
 	funcmap := d.child.GetFuncMap()
@@ -0,0 +1,115 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mavl
+
+import (
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// MaxSnapshotChunk 状态快照每一段最多包含的叶子节点数
+const MaxSnapshotChunk = 1024
+
+// GetStateSnapshot 从 stateHash 对应的状态树中按照序号读取一段叶子节点, 每个叶子都带有 proof
+func GetStateSnapshot(db dbm.DB, req *types.ReqStateSnapshot) (*types.StateSnapshotChunk, error) {
+	if enableMavlPrefix || enableMvcc || enablePrune {
+		return nil, types.ErrNotSupport
+	}
+	tree := NewTree(db, true)
+	err := tree.Load(req.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	total := tree.Size()
+	if total == 0 {
+		return nil, types.ErrNotFound
+	}
+	if req.Start < 0 || req.Start >= total {
+		return nil, types.ErrInvalidParam
+	}
+	count := req.Count
+	if count <= 0 || count > MaxSnapshotChunk {
+		count = MaxSnapshotChunk
+	}
+	if count > total-req.Start {
+		count = total - req.Start
+	}
+	chunk := &types.StateSnapshotChunk{StateHash: req.StateHash, Start: req.Start, Total: total}
+	for i := req.Start; i < req.Start+count; i++ {
+		key, value := tree.GetByIndex(i)
+		_, proof, exists := tree.Proof(key)
+		if !exists {
+			return nil, types.ErrDataBaseDamage
+		}
+		chunk.Kvs = append(chunk.Kvs, &types.KeyValue{Key: key, Value: value})
+		chunk.Proofs = append(chunk.Proofs, proof)
+	}
+	return chunk, nil
+}
+
+//根据 proof 计算叶子节点在树中的序号, 以及状态树的叶子总数
+func proofLeafIndex(branches []*types.InnerNode) (index int32, total int32) {
+	total = 1
+	for _, branch := range branches {
+		//叶子在右子树中, 序号需要加上左子树的叶子数
+		if len(branch.LeftHash) != 0 {
+			index += branch.Size - total
+		}
+		total = branch.Size
+	}
+	return index, total
+}
+
+func marshalStoreNode(node *types.StoreNode) []byte {
+	data, err := proto.Marshal(node)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// ImportStateSnapshot 验证快照中的叶子节点都属于 stateHash 对应的状态树, 并且序号从 chunk.Start 开始连续,
+// 然后写入叶子节点以及以这个叶子作为右子树最左叶子的内部节点.
+// 按照序号导入所有的段之后, 每个内部节点都正好写入一次, 得到的状态树和原来完全一致
+func ImportStateSnapshot(db dbm.DB, chunk *types.StateSnapshotChunk) error {
+	if enableMavlPrefix || enableMvcc || enablePrune {
+		return types.ErrNotSupport
+	}
+	if len(chunk.Kvs) == 0 || len(chunk.Kvs) != len(chunk.Proofs) {
+		return types.ErrInvalidParam
+	}
+	batch := db.NewBatch(true)
+	for i, kv := range chunk.Kvs {
+		var mavlproof types.MAVLProof
+		err := proto.Unmarshal(chunk.Proofs[i], &mavlproof)
+		if err != nil {
+			return err
+		}
+		leaf := types.LeafNode{Key: kv.Key, Value: kv.Value, Height: 0, Size: 1}
+		hash := leaf.Hash()
+		proof := &Proof{LeafHash: hash, InnerNodes: mavlproof.InnerNodes, RootHash: chunk.StateHash}
+		if !proof.Verify(kv.Key, kv.Value, chunk.StateHash) {
+			return types.ErrSnapshotProof
+		}
+		index, total := proofLeafIndex(mavlproof.InnerNodes)
+		if index != chunk.Start+int32(i) || total != chunk.Total {
+			return types.ErrSnapshotProof
+		}
+		batch.Set(hash, marshalStoreNode(&types.StoreNode{Key: kv.Key, Value: kv.Value, Height: 0, Size: 1}))
+
+		//沿着 proof 向上, 找到第一个叶子位于右子树的内部节点, 它的 key 就是这个叶子的 key
+		for _, branch := range mavlproof.InnerNodes {
+			parent := InnerNodeProofHash(hash, branch)
+			if len(branch.LeftHash) != 0 {
+				batch.Set(parent, marshalStoreNode(&types.StoreNode{Key: kv.Key, Height: branch.Height, Size: branch.Size,
+					LeftHash: branch.LeftHash, RightHash: hash}))
+				break
+			}
+			hash = parent
+		}
+	}
+	return batch.Write()
+}
@@ -0,0 +1,68 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mavl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datastore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	src := db.NewDB("mavltree", "leveldb", dir, 100)
+	dst := db.NewDB("snapshot", "leveldb", dir, 100)
+
+	tree := NewTree(src, true)
+	for i := 0; i < 100; i++ {
+		tree.Set([]byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
+	}
+	stateHash := tree.Save()
+
+	_, err = GetStateSnapshot(src, &types.ReqStateSnapshot{StateHash: stateHash, Start: 100})
+	assert.Equal(t, types.ErrInvalidParam, err)
+
+	//段的序号不连续时导入失败
+	chunk, err := GetStateSnapshot(src, &types.ReqStateSnapshot{StateHash: stateHash, Start: 7, Count: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int32(100), chunk.Total)
+	chunk.Start = 0
+	assert.Equal(t, types.ErrSnapshotProof, ImportStateSnapshot(dst, chunk))
+	//数据被修改之后 proof 验证失败
+	chunk.Start = 7
+	chunk.Kvs[1].Value = []byte("fake")
+	assert.Equal(t, types.ErrSnapshotProof, ImportStateSnapshot(dst, chunk))
+
+	var start int32
+	for start < 100 {
+		chunk, err := GetStateSnapshot(src, &types.ReqStateSnapshot{StateHash: stateHash, Start: start, Count: 7})
+		require.NoError(t, err)
+		require.NoError(t, ImportStateSnapshot(dst, chunk))
+		start += int32(len(chunk.Kvs))
+	}
+
+	imported := NewTree(dst, true)
+	require.NoError(t, imported.Load(stateHash))
+	assert.Equal(t, int32(100), imported.Size())
+	for i := 0; i < 100; i++ {
+		_, value, exists := imported.Get([]byte(fmt.Sprintf("key:%d", i)))
+		assert.True(t, exists)
+		assert.Equal(t, []byte(fmt.Sprintf("value:%d", i)), value)
+	}
+	//导入的树可以继续修改, 结果和原来的树一致
+	tree = NewTree(src, true)
+	require.NoError(t, tree.Load(stateHash))
+	tree.Set([]byte("key:new"), []byte("value:new"))
+	imported.Set([]byte("key:new"), []byte("value:new"))
+	assert.Equal(t, tree.Save(), imported.Save())
+}
@@ -440,6 +440,30 @@ func GetKVPairProof(db dbm.DB, roothash []byte, key []byte) ([]byte, error) {
 	return nil, nil
 }
 
+// GetStateProof 获取 key 在 stateHash 下的值以及 proof 证明
+func GetStateProof(db dbm.DB, req *types.ReqStateProof) (*types.StateProof, error) {
+	tree := NewTree(db, true)
+	err := tree.Load(req.StateHash)
+	if err != nil {
+		return nil, err
+	}
+	value, proof, exist := tree.Proof(req.Key)
+	if !exist {
+		return nil, types.ErrNotFound
+	}
+	return &types.StateProof{Height: req.Height, StateHash: req.StateHash, Key: req.Key, Value: value, Proof: proof}, nil
+}
+
+// VerifyStateProof 验证 key:value 属于 stateHash 对应的状态
+func VerifyStateProof(proof *types.StateProof) bool {
+	leafNode := types.LeafNode{Key: proof.Key, Value: proof.Value, Height: 0, Size: 1}
+	node, err := ReadProof(proof.StateHash, leafNode.Hash(), proof.Proof)
+	if err != nil {
+		return false
+	}
+	return node.Verify(proof.Key, proof.Value, proof.StateHash)
+}
+
 // DelKVPair 剔除key对应的节点在本次tree中，返回新的roothash和key对应的value
 func DelKVPair(db dbm.DB, storeDel *types.StoreGet) ([]byte, [][]byte, error) {
 	tree := NewTree(db, true)
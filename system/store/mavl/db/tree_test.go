@@ -676,6 +676,35 @@ func TestGetAndVerifyKVPairProof(t *testing.T) {
 	db.Close()
 }
 
+func TestGetAndVerifyStateProof(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datastore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	db := db.NewDB("mavltree", "leveldb", dir, 100)
+	defer db.Close()
+
+	storeSet := &types.StoreSet{StateHash: emptyRoot[:]}
+	for i := 0; i < 10; i++ {
+		storeSet.KV = append(storeSet.KV, &types.KeyValue{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))})
+	}
+	hash, err := SetKVPair(db, storeSet, true)
+	require.Nil(t, err)
+
+	_, err = GetStateProof(db, &types.ReqStateProof{StateHash: hash, Key: []byte("nokey")})
+	assert.Equal(t, types.ErrNotFound, err)
+	proof, err := GetStateProof(db, &types.ReqStateProof{StateHash: hash, Key: []byte("key3"), Height: 1})
+	require.Nil(t, err)
+	assert.Equal(t, []byte("value3"), proof.Value)
+	assert.Equal(t, int64(1), proof.Height)
+	assert.True(t, VerifyStateProof(proof))
+
+	proof.Value = []byte("value4")
+	assert.False(t, VerifyStateProof(proof))
+	proof.Value = []byte("value3")
+	proof.StateHash = emptyRoot[:]
+	assert.False(t, VerifyStateProof(proof))
+}
+
 type traverser struct {
 	Values []string
 }
@@ -179,9 +179,37 @@ func (mavls *Store) IterateRangeByStateHash(statehash []byte, start []byte, end
 	mavl.IterateRangeByStateHash(mavls.GetDB(), statehash, start, end, ascending, fn)
 }
 
-// ProcEvent not support message
+// ProcEvent 处理状态快照的导出和导入, 状态证明以及状态hash 是否存在, 其他消息不支持
 func (mavls *Store) ProcEvent(msg queue.Message) {
-	msg.ReplyErr("Store", types.ErrActionNotSupport)
+	switch msg.Ty {
+	case types.EventStoreGetSnapshot:
+		chunk, err := mavl.GetStateSnapshot(mavls.GetDB(), msg.GetData().(*types.ReqStateSnapshot))
+		if err != nil {
+			mlog.Error("store mavl get snapshot", "err", err)
+			msg.Reply(mavls.GetQueueClient().NewMessage("", types.EventStateSnapshotChunk, err))
+			return
+		}
+		msg.Reply(mavls.GetQueueClient().NewMessage("", types.EventStateSnapshotChunk, chunk))
+	case types.EventStoreImportSnapshot:
+		err := mavl.ImportStateSnapshot(mavls.GetDB(), msg.GetData().(*types.StateSnapshotChunk))
+		msg.ReplyErr("StoreImportSnapshot", err)
+	case types.EventStoreGetProof:
+		proof, err := mavl.GetStateProof(mavls.GetDB(), msg.GetData().(*types.ReqStateProof))
+		if err != nil {
+			mlog.Error("store mavl get proof", "err", err)
+			msg.Reply(mavls.GetQueueClient().NewMessage("", types.EventStateProof, err))
+			return
+		}
+		msg.Reply(mavls.GetQueueClient().NewMessage("", types.EventStateProof, proof))
+	case types.EventStoreHasRoot:
+		err := mavl.NewTree(mavls.GetDB(), true).Load(msg.GetData().(*types.ReqHash).Hash)
+		if err != nil {
+			err = types.ErrHashNotFound
+		}
+		msg.ReplyErr("StoreHasRoot", err)
+	default:
+		msg.ReplyErr("Store", types.ErrActionNotSupport)
+	}
 }
 
 // Del ...
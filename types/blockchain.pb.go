@@ -4,10 +4,12 @@
 package types
 
 import (
+	context "context"
 	fmt "fmt"
 	math "math"
 
 	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -285,10 +287,19 @@ func (m *Blocks) GetItems() []*Block {
 	return nil
 }
 
+// 推送区块序列号的订阅
+//	 transport : 推送方式 http(默认), grpc, file
+//	 batchSize : 每次推送的序列号个数, 默认为1
+//	 secret : 非空时 http 推送用 HMAC-SHA256 对数据签名
+//	 maxRetry : 连续失败的次数达到 maxRetry 后进入 deadletter 状态, 0 表示一直重试
 type BlockSeqCB struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	URL                  string   `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
 	Encode               string   `protobuf:"bytes,3,opt,name=encode,proto3" json:"encode,omitempty"`
+	BatchSize            int32    `protobuf:"varint,4,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+	Transport            string   `protobuf:"bytes,5,opt,name=transport,proto3" json:"transport,omitempty"`
+	Secret               string   `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`
+	MaxRetry             int32    `protobuf:"varint,7,opt,name=maxRetry,proto3" json:"maxRetry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -340,6 +351,34 @@ func (m *BlockSeqCB) GetEncode() string {
 	return ""
 }
 
+func (m *BlockSeqCB) GetBatchSize() int32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *BlockSeqCB) GetTransport() string {
+	if m != nil {
+		return m.Transport
+	}
+	return ""
+}
+
+func (m *BlockSeqCB) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *BlockSeqCB) GetMaxRetry() int32 {
+	if m != nil {
+		return m.MaxRetry
+	}
+	return 0
+}
+
 type BlockSeq struct {
 	Num                  int64          `protobuf:"varint,1,opt,name=num,proto3" json:"num,omitempty"`
 	Seq                  *BlockSequence `protobuf:"bytes,2,opt,name=seq,proto3" json:"seq,omitempty"`
@@ -349,48 +388,1131 @@ type BlockSeq struct {
 	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *BlockSeq) Reset()         { *m = BlockSeq{} }
-func (m *BlockSeq) String() string { return proto.CompactTextString(m) }
-func (*BlockSeq) ProtoMessage()    {}
-func (*BlockSeq) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{4}
+func (m *BlockSeq) Reset()         { *m = BlockSeq{} }
+func (m *BlockSeq) String() string { return proto.CompactTextString(m) }
+func (*BlockSeq) ProtoMessage()    {}
+func (*BlockSeq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{4}
+}
+
+func (m *BlockSeq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSeq.Unmarshal(m, b)
+}
+func (m *BlockSeq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSeq.Marshal(b, m, deterministic)
+}
+func (m *BlockSeq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSeq.Merge(m, src)
+}
+func (m *BlockSeq) XXX_Size() int {
+	return xxx_messageInfo_BlockSeq.Size(m)
+}
+func (m *BlockSeq) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSeq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSeq proto.InternalMessageInfo
+
+func (m *BlockSeq) GetNum() int64 {
+	if m != nil {
+		return m.Num
+	}
+	return 0
+}
+
+func (m *BlockSeq) GetSeq() *BlockSequence {
+	if m != nil {
+		return m.Seq
+	}
+	return nil
+}
+
+func (m *BlockSeq) GetDetail() *BlockDetail {
+	if m != nil {
+		return m.Detail
+	}
+	return nil
+}
+
+type BlockSeqs struct {
+	Seqs                 []*BlockSeq `protobuf:"bytes,1,rep,name=seqs,proto3" json:"seqs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *BlockSeqs) Reset()         { *m = BlockSeqs{} }
+func (m *BlockSeqs) String() string { return proto.CompactTextString(m) }
+func (*BlockSeqs) ProtoMessage()    {}
+func (*BlockSeqs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{5}
+}
+
+func (m *BlockSeqs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSeqs.Unmarshal(m, b)
+}
+func (m *BlockSeqs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSeqs.Marshal(b, m, deterministic)
+}
+func (m *BlockSeqs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSeqs.Merge(m, src)
+}
+func (m *BlockSeqs) XXX_Size() int {
+	return xxx_messageInfo_BlockSeqs.Size(m)
+}
+func (m *BlockSeqs) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSeqs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSeqs proto.InternalMessageInfo
+
+func (m *BlockSeqs) GetSeqs() []*BlockSeq {
+	if m != nil {
+		return m.Seqs
+	}
+	return nil
+}
+
+// 推送任务的状态
+//	 status : 0 正常, 1 暂停, 2 deadletter
+//	 failures : 连续失败的次数
+//	 nextRetry : 下次重试的时间(unix 秒)
+type BlockSeqCBState struct {
+	Status               int32    `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Failures             int32    `protobuf:"varint,2,opt,name=failures,proto3" json:"failures,omitempty"`
+	LastError            string   `protobuf:"bytes,3,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	NextRetry            int64    `protobuf:"varint,4,opt,name=nextRetry,proto3" json:"nextRetry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockSeqCBState) Reset()         { *m = BlockSeqCBState{} }
+func (m *BlockSeqCBState) String() string { return proto.CompactTextString(m) }
+func (*BlockSeqCBState) ProtoMessage()    {}
+func (*BlockSeqCBState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{6}
+}
+
+func (m *BlockSeqCBState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSeqCBState.Unmarshal(m, b)
+}
+func (m *BlockSeqCBState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSeqCBState.Marshal(b, m, deterministic)
+}
+func (m *BlockSeqCBState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSeqCBState.Merge(m, src)
+}
+func (m *BlockSeqCBState) XXX_Size() int {
+	return xxx_messageInfo_BlockSeqCBState.Size(m)
+}
+func (m *BlockSeqCBState) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSeqCBState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSeqCBState proto.InternalMessageInfo
+
+func (m *BlockSeqCBState) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *BlockSeqCBState) GetFailures() int32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *BlockSeqCBState) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *BlockSeqCBState) GetNextRetry() int64 {
+	if m != nil {
+		return m.NextRetry
+	}
+	return 0
+}
+
+type BlockSeqCBInfo struct {
+	Cb                   *BlockSeqCB      `protobuf:"bytes,1,opt,name=cb,proto3" json:"cb,omitempty"`
+	LastNum              int64            `protobuf:"varint,2,opt,name=lastNum,proto3" json:"lastNum,omitempty"`
+	State                *BlockSeqCBState `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BlockSeqCBInfo) Reset()         { *m = BlockSeqCBInfo{} }
+func (m *BlockSeqCBInfo) String() string { return proto.CompactTextString(m) }
+func (*BlockSeqCBInfo) ProtoMessage()    {}
+func (*BlockSeqCBInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{7}
+}
+
+func (m *BlockSeqCBInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSeqCBInfo.Unmarshal(m, b)
+}
+func (m *BlockSeqCBInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSeqCBInfo.Marshal(b, m, deterministic)
+}
+func (m *BlockSeqCBInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSeqCBInfo.Merge(m, src)
+}
+func (m *BlockSeqCBInfo) XXX_Size() int {
+	return xxx_messageInfo_BlockSeqCBInfo.Size(m)
+}
+func (m *BlockSeqCBInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSeqCBInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSeqCBInfo proto.InternalMessageInfo
+
+func (m *BlockSeqCBInfo) GetCb() *BlockSeqCB {
+	if m != nil {
+		return m.Cb
+	}
+	return nil
+}
+
+func (m *BlockSeqCBInfo) GetLastNum() int64 {
+	if m != nil {
+		return m.LastNum
+	}
+	return 0
+}
+
+func (m *BlockSeqCBInfo) GetState() *BlockSeqCBState {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type BlockSeqCBs struct {
+	Items                []*BlockSeqCBInfo `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BlockSeqCBs) Reset()         { *m = BlockSeqCBs{} }
+func (m *BlockSeqCBs) String() string { return proto.CompactTextString(m) }
+func (*BlockSeqCBs) ProtoMessage()    {}
+func (*BlockSeqCBs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{8}
+}
+
+func (m *BlockSeqCBs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockSeqCBs.Unmarshal(m, b)
+}
+func (m *BlockSeqCBs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockSeqCBs.Marshal(b, m, deterministic)
+}
+func (m *BlockSeqCBs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSeqCBs.Merge(m, src)
+}
+func (m *BlockSeqCBs) XXX_Size() int {
+	return xxx_messageInfo_BlockSeqCBs.Size(m)
+}
+func (m *BlockSeqCBs) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSeqCBs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSeqCBs proto.InternalMessageInfo
+
+func (m *BlockSeqCBs) GetItems() []*BlockSeqCBInfo {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// 暂停或者恢复推送, 恢复时清除失败的记录
+type ReqSetSeqCBStatus struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               int32    `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqSetSeqCBStatus) Reset()         { *m = ReqSetSeqCBStatus{} }
+func (m *ReqSetSeqCBStatus) String() string { return proto.CompactTextString(m) }
+func (*ReqSetSeqCBStatus) ProtoMessage()    {}
+func (*ReqSetSeqCBStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{9}
+}
+
+func (m *ReqSetSeqCBStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSetSeqCBStatus.Unmarshal(m, b)
+}
+func (m *ReqSetSeqCBStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSetSeqCBStatus.Marshal(b, m, deterministic)
+}
+func (m *ReqSetSeqCBStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSetSeqCBStatus.Merge(m, src)
+}
+func (m *ReqSetSeqCBStatus) XXX_Size() int {
+	return xxx_messageInfo_ReqSetSeqCBStatus.Size(m)
+}
+func (m *ReqSetSeqCBStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSetSeqCBStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSetSeqCBStatus proto.InternalMessageInfo
+
+func (m *ReqSetSeqCBStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReqSetSeqCBStatus) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+// 状态快照的请求, 按照叶子节点在状态树中的序号分段获取
+//	 height : 快照对应的区块高度, start 为 0 时同时返回这个高度的区块
+//	 pid : 从指定的节点获取, 只在本地使用
+type ReqStateSnapshot struct {
+	StateHash            []byte   `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Start                int32    `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	Count                int32    `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Pid                  string   `protobuf:"bytes,5,opt,name=pid,proto3" json:"pid,omitempty"`
+	Version              int32    `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqStateSnapshot) Reset()         { *m = ReqStateSnapshot{} }
+func (m *ReqStateSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ReqStateSnapshot) ProtoMessage()    {}
+func (*ReqStateSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{10}
+}
+
+func (m *ReqStateSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqStateSnapshot.Unmarshal(m, b)
+}
+func (m *ReqStateSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqStateSnapshot.Marshal(b, m, deterministic)
+}
+func (m *ReqStateSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqStateSnapshot.Merge(m, src)
+}
+func (m *ReqStateSnapshot) XXX_Size() int {
+	return xxx_messageInfo_ReqStateSnapshot.Size(m)
+}
+func (m *ReqStateSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqStateSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqStateSnapshot proto.InternalMessageInfo
+
+func (m *ReqStateSnapshot) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReqStateSnapshot) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReqStateSnapshot) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ReqStateSnapshot) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqStateSnapshot) GetPid() string {
+	if m != nil {
+		return m.Pid
+	}
+	return ""
+}
+
+func (m *ReqStateSnapshot) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// 状态快照的一段, 每个 kv 都带有到 stateHash 的 mavl 证明
+//	 total : 状态树中叶子节点的总数
+type StateSnapshotChunk struct {
+	StateHash            []byte       `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Start                int32        `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Total                int32        `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Kvs                  []*KeyValue  `protobuf:"bytes,4,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	Proofs               [][]byte     `protobuf:"bytes,5,rep,name=proofs,proto3" json:"proofs,omitempty"`
+	Detail               *BlockDetail `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StateSnapshotChunk) Reset()         { *m = StateSnapshotChunk{} }
+func (m *StateSnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*StateSnapshotChunk) ProtoMessage()    {}
+func (*StateSnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{11}
+}
+
+func (m *StateSnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSnapshotChunk.Unmarshal(m, b)
+}
+func (m *StateSnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSnapshotChunk.Marshal(b, m, deterministic)
+}
+func (m *StateSnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSnapshotChunk.Merge(m, src)
+}
+func (m *StateSnapshotChunk) XXX_Size() int {
+	return xxx_messageInfo_StateSnapshotChunk.Size(m)
+}
+func (m *StateSnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSnapshotChunk proto.InternalMessageInfo
+
+func (m *StateSnapshotChunk) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *StateSnapshotChunk) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *StateSnapshotChunk) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *StateSnapshotChunk) GetKvs() []*KeyValue {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+func (m *StateSnapshotChunk) GetProofs() [][]byte {
+	if m != nil {
+		return m.Proofs
+	}
+	return nil
+}
+
+func (m *StateSnapshotChunk) GetDetail() *BlockDetail {
+	if m != nil {
+		return m.Detail
+	}
+	return nil
+}
+
+// 区块归档文件中一个区块的索引
+//	 offset : 压缩之后的区块在文件中的位置, 不包括长度前缀
+//	 checksum : 压缩之后数据的 sha256
+type BlockArchiveEntry struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Offset               int64    `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Size                 int32    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Checksum             []byte   `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockArchiveEntry) Reset()         { *m = BlockArchiveEntry{} }
+func (m *BlockArchiveEntry) String() string { return proto.CompactTextString(m) }
+func (*BlockArchiveEntry) ProtoMessage()    {}
+func (*BlockArchiveEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{12}
+}
+
+func (m *BlockArchiveEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockArchiveEntry.Unmarshal(m, b)
+}
+func (m *BlockArchiveEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockArchiveEntry.Marshal(b, m, deterministic)
+}
+func (m *BlockArchiveEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockArchiveEntry.Merge(m, src)
+}
+func (m *BlockArchiveEntry) XXX_Size() int {
+	return xxx_messageInfo_BlockArchiveEntry.Size(m)
+}
+func (m *BlockArchiveEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockArchiveEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockArchiveEntry proto.InternalMessageInfo
+
+func (m *BlockArchiveEntry) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockArchiveEntry) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *BlockArchiveEntry) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *BlockArchiveEntry) GetSize() int32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *BlockArchiveEntry) GetChecksum() []byte {
+	if m != nil {
+		return m.Checksum
+	}
+	return nil
+}
+
+// 区块归档文件的索引, 保存在文件的末尾
+type BlockArchiveIndex struct {
+	Entries              []*BlockArchiveEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BlockArchiveIndex) Reset()         { *m = BlockArchiveIndex{} }
+func (m *BlockArchiveIndex) String() string { return proto.CompactTextString(m) }
+func (*BlockArchiveIndex) ProtoMessage()    {}
+func (*BlockArchiveIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{13}
+}
+
+func (m *BlockArchiveIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockArchiveIndex.Unmarshal(m, b)
+}
+func (m *BlockArchiveIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockArchiveIndex.Marshal(b, m, deterministic)
+}
+func (m *BlockArchiveIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockArchiveIndex.Merge(m, src)
+}
+func (m *BlockArchiveIndex) XXX_Size() int {
+	return xxx_messageInfo_BlockArchiveIndex.Size(m)
+}
+func (m *BlockArchiveIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockArchiveIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockArchiveIndex proto.InternalMessageInfo
+
+func (m *BlockArchiveIndex) GetEntries() []*BlockArchiveEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// 链的检查点, 检查点以及之下的区块不会被回滚
+type ChainCheckpoint struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainCheckpoint) Reset()         { *m = ChainCheckpoint{} }
+func (m *ChainCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ChainCheckpoint) ProtoMessage()    {}
+func (*ChainCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{14}
+}
+
+func (m *ChainCheckpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainCheckpoint.Unmarshal(m, b)
+}
+func (m *ChainCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainCheckpoint.Marshal(b, m, deterministic)
+}
+func (m *ChainCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainCheckpoint.Merge(m, src)
+}
+func (m *ChainCheckpoint) XXX_Size() int {
+	return xxx_messageInfo_ChainCheckpoint.Size(m)
+}
+func (m *ChainCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainCheckpoint proto.InternalMessageInfo
+
+func (m *ChainCheckpoint) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ChainCheckpoint) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// 当前生效的检查点
+//	 finalizedDepth : 配置的最终确认深度, 0 表示不启用
+//	 finalizedHeight : 这个高度以及之下的区块不会被回滚
+type ReplyCheckpoint struct {
+	Checkpoint           *ChainCheckpoint `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	FinalizedDepth       int64            `protobuf:"varint,2,opt,name=finalizedDepth,proto3" json:"finalizedDepth,omitempty"`
+	FinalizedHeight      int64            `protobuf:"varint,3,opt,name=finalizedHeight,proto3" json:"finalizedHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReplyCheckpoint) Reset()         { *m = ReplyCheckpoint{} }
+func (m *ReplyCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ReplyCheckpoint) ProtoMessage()    {}
+func (*ReplyCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{15}
+}
+
+func (m *ReplyCheckpoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyCheckpoint.Unmarshal(m, b)
+}
+func (m *ReplyCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyCheckpoint.Marshal(b, m, deterministic)
+}
+func (m *ReplyCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyCheckpoint.Merge(m, src)
+}
+func (m *ReplyCheckpoint) XXX_Size() int {
+	return xxx_messageInfo_ReplyCheckpoint.Size(m)
+}
+func (m *ReplyCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyCheckpoint proto.InternalMessageInfo
+
+func (m *ReplyCheckpoint) GetCheckpoint() *ChainCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+func (m *ReplyCheckpoint) GetFinalizedDepth() int64 {
+	if m != nil {
+		return m.FinalizedDepth
+	}
+	return 0
+}
+
+func (m *ReplyCheckpoint) GetFinalizedHeight() int64 {
+	if m != nil {
+		return m.FinalizedHeight
+	}
+	return 0
+}
+
+//轻节点从全节点获取交易以及交易的默克尔证明
+type ReqTxProof struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Pid                  string   `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqTxProof) Reset()         { *m = ReqTxProof{} }
+func (m *ReqTxProof) String() string { return proto.CompactTextString(m) }
+func (*ReqTxProof) ProtoMessage()    {}
+func (*ReqTxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{16}
+}
+
+func (m *ReqTxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTxProof.Unmarshal(m, b)
+}
+func (m *ReqTxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTxProof.Marshal(b, m, deterministic)
+}
+func (m *ReqTxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTxProof.Merge(m, src)
+}
+func (m *ReqTxProof) XXX_Size() int {
+	return xxx_messageInfo_ReqTxProof.Size(m)
+}
+func (m *ReqTxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTxProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTxProof proto.InternalMessageInfo
+
+func (m *ReqTxProof) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ReqTxProof) GetPid() string {
+	if m != nil {
+		return m.Pid
+	}
+	return ""
+}
+
+//获取状态数据的 mavl 证明, stateHash 为空时使用 height 高度的区块头, height 小于 0 时为最新高度
+type ReqStateProof struct {
+	StateHash            []byte   `protobuf:"bytes,1,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Height               int64    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Pid                  string   `protobuf:"bytes,4,opt,name=pid,proto3" json:"pid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqStateProof) Reset()         { *m = ReqStateProof{} }
+func (m *ReqStateProof) String() string { return proto.CompactTextString(m) }
+func (*ReqStateProof) ProtoMessage()    {}
+func (*ReqStateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{17}
+}
+
+func (m *ReqStateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqStateProof.Unmarshal(m, b)
+}
+func (m *ReqStateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqStateProof.Marshal(b, m, deterministic)
+}
+func (m *ReqStateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqStateProof.Merge(m, src)
+}
+func (m *ReqStateProof) XXX_Size() int {
+	return xxx_messageInfo_ReqStateProof.Size(m)
+}
+func (m *ReqStateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqStateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqStateProof proto.InternalMessageInfo
+
+func (m *ReqStateProof) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *ReqStateProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ReqStateProof) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReqStateProof) GetPid() string {
+	if m != nil {
+		return m.Pid
+	}
+	return ""
+}
+
+type StateProof struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	StateHash            []byte   `protobuf:"bytes,2,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
+	Key                  []byte   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte   `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Proof                []byte   `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateProof) Reset()         { *m = StateProof{} }
+func (m *StateProof) String() string { return proto.CompactTextString(m) }
+func (*StateProof) ProtoMessage()    {}
+func (*StateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{18}
+}
+
+func (m *StateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProof.Unmarshal(m, b)
+}
+func (m *StateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProof.Marshal(b, m, deterministic)
+}
+func (m *StateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProof.Merge(m, src)
+}
+func (m *StateProof) XXX_Size() int {
+	return xxx_messageInfo_StateProof.Size(m)
+}
+func (m *StateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProof proto.InternalMessageInfo
+
+func (m *StateProof) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *StateProof) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
+func (m *StateProof) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
 }
 
-func (m *BlockSeq) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BlockSeq.Unmarshal(m, b)
+func (m *StateProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
-func (m *BlockSeq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BlockSeq.Marshal(b, m, deterministic)
+
+func (m *StateProof) GetProof() []byte {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
 }
-func (m *BlockSeq) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BlockSeq.Merge(m, src)
+
+//区块链重组的记录
+//	 forkHeight, forkHash : 新旧主链的共同祖先
+//	 detached : 从主链删除的区块 hash, 从高到低
+//	 attached : 加入主链的区块 hash, 从低到高
+//	 pid : 触发重组的区块的来源节点
+//	 oldTd, newTd : 重组前后主链的总难度, 十进制字符串
+type ReorgRecord struct {
+	Index                int64    `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ForkHeight           int64    `protobuf:"varint,2,opt,name=forkHeight,proto3" json:"forkHeight,omitempty"`
+	ForkHash             []byte   `protobuf:"bytes,3,opt,name=forkHash,proto3" json:"forkHash,omitempty"`
+	Detached             [][]byte `protobuf:"bytes,4,rep,name=detached,proto3" json:"detached,omitempty"`
+	Attached             [][]byte `protobuf:"bytes,5,rep,name=attached,proto3" json:"attached,omitempty"`
+	Pid                  string   `protobuf:"bytes,6,opt,name=pid,proto3" json:"pid,omitempty"`
+	Time                 int64    `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	OldTd                string   `protobuf:"bytes,8,opt,name=oldTd,proto3" json:"oldTd,omitempty"`
+	NewTd                string   `protobuf:"bytes,9,opt,name=newTd,proto3" json:"newTd,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *BlockSeq) XXX_Size() int {
-	return xxx_messageInfo_BlockSeq.Size(m)
+
+func (m *ReorgRecord) Reset()         { *m = ReorgRecord{} }
+func (m *ReorgRecord) String() string { return proto.CompactTextString(m) }
+func (*ReorgRecord) ProtoMessage()    {}
+func (*ReorgRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{19}
 }
-func (m *BlockSeq) XXX_DiscardUnknown() {
-	xxx_messageInfo_BlockSeq.DiscardUnknown(m)
+
+func (m *ReorgRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReorgRecord.Unmarshal(m, b)
+}
+func (m *ReorgRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReorgRecord.Marshal(b, m, deterministic)
+}
+func (m *ReorgRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReorgRecord.Merge(m, src)
+}
+func (m *ReorgRecord) XXX_Size() int {
+	return xxx_messageInfo_ReorgRecord.Size(m)
+}
+func (m *ReorgRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReorgRecord.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BlockSeq proto.InternalMessageInfo
+var xxx_messageInfo_ReorgRecord proto.InternalMessageInfo
 
-func (m *BlockSeq) GetNum() int64 {
+func (m *ReorgRecord) GetIndex() int64 {
 	if m != nil {
-		return m.Num
+		return m.Index
 	}
 	return 0
 }
 
-func (m *BlockSeq) GetSeq() *BlockSequence {
+func (m *ReorgRecord) GetForkHeight() int64 {
 	if m != nil {
-		return m.Seq
+		return m.ForkHeight
+	}
+	return 0
+}
+
+func (m *ReorgRecord) GetForkHash() []byte {
+	if m != nil {
+		return m.ForkHash
 	}
 	return nil
 }
 
-func (m *BlockSeq) GetDetail() *BlockDetail {
+func (m *ReorgRecord) GetDetached() [][]byte {
 	if m != nil {
-		return m.Detail
+		return m.Detached
+	}
+	return nil
+}
+
+func (m *ReorgRecord) GetAttached() [][]byte {
+	if m != nil {
+		return m.Attached
+	}
+	return nil
+}
+
+func (m *ReorgRecord) GetPid() string {
+	if m != nil {
+		return m.Pid
+	}
+	return ""
+}
+
+func (m *ReorgRecord) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReorgRecord) GetOldTd() string {
+	if m != nil {
+		return m.OldTd
+	}
+	return ""
+}
+
+func (m *ReorgRecord) GetNewTd() string {
+	if m != nil {
+		return m.NewTd
+	}
+	return ""
+}
+
+//获取最新的 count 条重组记录
+type ReqReorgHistory struct {
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqReorgHistory) Reset()         { *m = ReqReorgHistory{} }
+func (m *ReqReorgHistory) String() string { return proto.CompactTextString(m) }
+func (*ReqReorgHistory) ProtoMessage()    {}
+func (*ReqReorgHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{20}
+}
+
+func (m *ReqReorgHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqReorgHistory.Unmarshal(m, b)
+}
+func (m *ReqReorgHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqReorgHistory.Marshal(b, m, deterministic)
+}
+func (m *ReqReorgHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqReorgHistory.Merge(m, src)
+}
+func (m *ReqReorgHistory) XXX_Size() int {
+	return xxx_messageInfo_ReqReorgHistory.Size(m)
+}
+func (m *ReqReorgHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqReorgHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqReorgHistory proto.InternalMessageInfo
+
+func (m *ReqReorgHistory) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+//孤儿池的统计信息, 孤儿池为空时 minHeight, maxHeight 为 -1
+type OrphanPoolStat struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Parents              int64    `protobuf:"varint,2,opt,name=parents,proto3" json:"parents,omitempty"`
+	MaxCount             int64    `protobuf:"varint,3,opt,name=maxCount,proto3" json:"maxCount,omitempty"`
+	MinHeight            int64    `protobuf:"varint,4,opt,name=minHeight,proto3" json:"minHeight,omitempty"`
+	MaxHeight            int64    `protobuf:"varint,5,opt,name=maxHeight,proto3" json:"maxHeight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OrphanPoolStat) Reset()         { *m = OrphanPoolStat{} }
+func (m *OrphanPoolStat) String() string { return proto.CompactTextString(m) }
+func (*OrphanPoolStat) ProtoMessage()    {}
+func (*OrphanPoolStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{21}
+}
+
+func (m *OrphanPoolStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OrphanPoolStat.Unmarshal(m, b)
+}
+func (m *OrphanPoolStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OrphanPoolStat.Marshal(b, m, deterministic)
+}
+func (m *OrphanPoolStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OrphanPoolStat.Merge(m, src)
+}
+func (m *OrphanPoolStat) XXX_Size() int {
+	return xxx_messageInfo_OrphanPoolStat.Size(m)
+}
+func (m *OrphanPoolStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_OrphanPoolStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OrphanPoolStat proto.InternalMessageInfo
+
+func (m *OrphanPoolStat) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *OrphanPoolStat) GetParents() int64 {
+	if m != nil {
+		return m.Parents
+	}
+	return 0
+}
+
+func (m *OrphanPoolStat) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
+	}
+	return 0
+}
+
+func (m *OrphanPoolStat) GetMinHeight() int64 {
+	if m != nil {
+		return m.MinHeight
+	}
+	return 0
+}
+
+func (m *OrphanPoolStat) GetMaxHeight() int64 {
+	if m != nil {
+		return m.MaxHeight
+	}
+	return 0
+}
+
+//重组记录从新到旧排列
+type ReorgHistory struct {
+	Items                []*ReorgRecord  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Orphans              *OrphanPoolStat `protobuf:"bytes,2,opt,name=orphans,proto3" json:"orphans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReorgHistory) Reset()         { *m = ReorgHistory{} }
+func (m *ReorgHistory) String() string { return proto.CompactTextString(m) }
+func (*ReorgHistory) ProtoMessage()    {}
+func (*ReorgHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{22}
+}
+
+func (m *ReorgHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReorgHistory.Unmarshal(m, b)
+}
+func (m *ReorgHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReorgHistory.Marshal(b, m, deterministic)
+}
+func (m *ReorgHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReorgHistory.Merge(m, src)
+}
+func (m *ReorgHistory) XXX_Size() int {
+	return xxx_messageInfo_ReorgHistory.Size(m)
+}
+func (m *ReorgHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReorgHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReorgHistory proto.InternalMessageInfo
+
+func (m *ReorgHistory) GetItems() []*ReorgRecord {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *ReorgHistory) GetOrphans() *OrphanPoolStat {
+	if m != nil {
+		return m.Orphans
 	}
 	return nil
 }
@@ -408,7 +1530,7 @@ func (m *BlockPid) Reset()         { *m = BlockPid{} }
 func (m *BlockPid) String() string { return proto.CompactTextString(m) }
 func (*BlockPid) ProtoMessage()    {}
 func (*BlockPid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{5}
+	return fileDescriptor_e9ac6287ce250c9a, []int{23}
 }
 
 func (m *BlockPid) XXX_Unmarshal(b []byte) error {
@@ -455,7 +1577,7 @@ func (m *BlockDetails) Reset()         { *m = BlockDetails{} }
 func (m *BlockDetails) String() string { return proto.CompactTextString(m) }
 func (*BlockDetails) ProtoMessage()    {}
 func (*BlockDetails) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{6}
+	return fileDescriptor_e9ac6287ce250c9a, []int{24}
 }
 
 func (m *BlockDetails) XXX_Unmarshal(b []byte) error {
@@ -495,7 +1617,7 @@ func (m *Headers) Reset()         { *m = Headers{} }
 func (m *Headers) String() string { return proto.CompactTextString(m) }
 func (*Headers) ProtoMessage()    {}
 func (*Headers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{7}
+	return fileDescriptor_e9ac6287ce250c9a, []int{25}
 }
 
 func (m *Headers) XXX_Unmarshal(b []byte) error {
@@ -535,7 +1657,7 @@ func (m *HeadersPid) Reset()         { *m = HeadersPid{} }
 func (m *HeadersPid) String() string { return proto.CompactTextString(m) }
 func (*HeadersPid) ProtoMessage()    {}
 func (*HeadersPid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{8}
+	return fileDescriptor_e9ac6287ce250c9a, []int{26}
 }
 
 func (m *HeadersPid) XXX_Unmarshal(b []byte) error {
@@ -587,7 +1709,7 @@ func (m *BlockOverview) Reset()         { *m = BlockOverview{} }
 func (m *BlockOverview) String() string { return proto.CompactTextString(m) }
 func (*BlockOverview) ProtoMessage()    {}
 func (*BlockOverview) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{9}
+	return fileDescriptor_e9ac6287ce250c9a, []int{27}
 }
 
 func (m *BlockOverview) XXX_Unmarshal(b []byte) error {
@@ -646,7 +1768,7 @@ func (m *BlockDetail) Reset()         { *m = BlockDetail{} }
 func (m *BlockDetail) String() string { return proto.CompactTextString(m) }
 func (*BlockDetail) ProtoMessage()    {}
 func (*BlockDetail) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{10}
+	return fileDescriptor_e9ac6287ce250c9a, []int{28}
 }
 
 func (m *BlockDetail) XXX_Unmarshal(b []byte) error {
@@ -706,7 +1828,7 @@ func (m *Receipts) Reset()         { *m = Receipts{} }
 func (m *Receipts) String() string { return proto.CompactTextString(m) }
 func (*Receipts) ProtoMessage()    {}
 func (*Receipts) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{11}
+	return fileDescriptor_e9ac6287ce250c9a, []int{29}
 }
 
 func (m *Receipts) XXX_Unmarshal(b []byte) error {
@@ -745,7 +1867,7 @@ func (m *PrivacyKV) Reset()         { *m = PrivacyKV{} }
 func (m *PrivacyKV) String() string { return proto.CompactTextString(m) }
 func (*PrivacyKV) ProtoMessage()    {}
 func (*PrivacyKV) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{12}
+	return fileDescriptor_e9ac6287ce250c9a, []int{30}
 }
 
 func (m *PrivacyKV) XXX_Unmarshal(b []byte) error {
@@ -787,7 +1909,7 @@ func (m *PrivacyKVToken) Reset()         { *m = PrivacyKVToken{} }
 func (m *PrivacyKVToken) String() string { return proto.CompactTextString(m) }
 func (*PrivacyKVToken) ProtoMessage()    {}
 func (*PrivacyKVToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{13}
+	return fileDescriptor_e9ac6287ce250c9a, []int{31}
 }
 
 func (m *PrivacyKVToken) XXX_Unmarshal(b []byte) error {
@@ -848,7 +1970,7 @@ func (m *ReceiptsAndPrivacyKV) Reset()         { *m = ReceiptsAndPrivacyKV{} }
 func (m *ReceiptsAndPrivacyKV) String() string { return proto.CompactTextString(m) }
 func (*ReceiptsAndPrivacyKV) ProtoMessage()    {}
 func (*ReceiptsAndPrivacyKV) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{14}
+	return fileDescriptor_e9ac6287ce250c9a, []int{32}
 }
 
 func (m *ReceiptsAndPrivacyKV) XXX_Unmarshal(b []byte) error {
@@ -894,7 +2016,7 @@ func (m *ReceiptCheckTxList) Reset()         { *m = ReceiptCheckTxList{} }
 func (m *ReceiptCheckTxList) String() string { return proto.CompactTextString(m) }
 func (*ReceiptCheckTxList) ProtoMessage()    {}
 func (*ReceiptCheckTxList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{15}
+	return fileDescriptor_e9ac6287ce250c9a, []int{33}
 }
 
 func (m *ReceiptCheckTxList) XXX_Unmarshal(b []byte) error {
@@ -939,7 +2061,7 @@ func (m *ChainStatus) Reset()         { *m = ChainStatus{} }
 func (m *ChainStatus) String() string { return proto.CompactTextString(m) }
 func (*ChainStatus) ProtoMessage()    {}
 func (*ChainStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{16}
+	return fileDescriptor_e9ac6287ce250c9a, []int{34}
 }
 
 func (m *ChainStatus) XXX_Unmarshal(b []byte) error {
@@ -1000,7 +2122,7 @@ func (m *ReqBlocks) Reset()         { *m = ReqBlocks{} }
 func (m *ReqBlocks) String() string { return proto.CompactTextString(m) }
 func (*ReqBlocks) ProtoMessage()    {}
 func (*ReqBlocks) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{17}
+	return fileDescriptor_e9ac6287ce250c9a, []int{35}
 }
 
 func (m *ReqBlocks) XXX_Unmarshal(b []byte) error {
@@ -1060,7 +2182,7 @@ func (m *MempoolSize) Reset()         { *m = MempoolSize{} }
 func (m *MempoolSize) String() string { return proto.CompactTextString(m) }
 func (*MempoolSize) ProtoMessage()    {}
 func (*MempoolSize) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{18}
+	return fileDescriptor_e9ac6287ce250c9a, []int{36}
 }
 
 func (m *MempoolSize) XXX_Unmarshal(b []byte) error {
@@ -1099,7 +2221,7 @@ func (m *ReplyBlockHeight) Reset()         { *m = ReplyBlockHeight{} }
 func (m *ReplyBlockHeight) String() string { return proto.CompactTextString(m) }
 func (*ReplyBlockHeight) ProtoMessage()    {}
 func (*ReplyBlockHeight) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{19}
+	return fileDescriptor_e9ac6287ce250c9a, []int{37}
 }
 
 func (m *ReplyBlockHeight) XXX_Unmarshal(b []byte) error {
@@ -1142,7 +2264,7 @@ func (m *BlockBody) Reset()         { *m = BlockBody{} }
 func (m *BlockBody) String() string { return proto.CompactTextString(m) }
 func (*BlockBody) ProtoMessage()    {}
 func (*BlockBody) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{20}
+	return fileDescriptor_e9ac6287ce250c9a, []int{38}
 }
 
 func (m *BlockBody) XXX_Unmarshal(b []byte) error {
@@ -1189,7 +2311,7 @@ func (m *IsCaughtUp) Reset()         { *m = IsCaughtUp{} }
 func (m *IsCaughtUp) String() string { return proto.CompactTextString(m) }
 func (*IsCaughtUp) ProtoMessage()    {}
 func (*IsCaughtUp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{21}
+	return fileDescriptor_e9ac6287ce250c9a, []int{39}
 }
 
 func (m *IsCaughtUp) XXX_Unmarshal(b []byte) error {
@@ -1229,7 +2351,7 @@ func (m *IsNtpClockSync) Reset()         { *m = IsNtpClockSync{} }
 func (m *IsNtpClockSync) String() string { return proto.CompactTextString(m) }
 func (*IsNtpClockSync) ProtoMessage()    {}
 func (*IsNtpClockSync) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{22}
+	return fileDescriptor_e9ac6287ce250c9a, []int{40}
 }
 
 func (m *IsNtpClockSync) XXX_Unmarshal(b []byte) error {
@@ -1263,7 +2385,10 @@ type ChainExecutor struct {
 	StateHash []byte `protobuf:"bytes,3,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
 	Param     []byte `protobuf:"bytes,4,opt,name=param,proto3" json:"param,omitempty"`
 	//扩展字段，用于额外的用途
-	Extra                []byte   `protobuf:"bytes,5,opt,name=extra,proto3" json:"extra,omitempty"`
+	Extra []byte `protobuf:"bytes,5,opt,name=extra,proto3" json:"extra,omitempty"`
+	//hasHeight 为true 时在 height 的状态上查询, 高度可以为0
+	Height               int64    `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	HasHeight            bool     `protobuf:"varint,7,opt,name=hasHeight,proto3" json:"hasHeight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1273,7 +2398,7 @@ func (m *ChainExecutor) Reset()         { *m = ChainExecutor{} }
 func (m *ChainExecutor) String() string { return proto.CompactTextString(m) }
 func (*ChainExecutor) ProtoMessage()    {}
 func (*ChainExecutor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{23}
+	return fileDescriptor_e9ac6287ce250c9a, []int{41}
 }
 
 func (m *ChainExecutor) XXX_Unmarshal(b []byte) error {
@@ -1329,6 +2454,20 @@ func (m *ChainExecutor) GetExtra() []byte {
 	return nil
 }
 
+func (m *ChainExecutor) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ChainExecutor) GetHasHeight() bool {
+	if m != nil {
+		return m.HasHeight
+	}
+	return false
+}
+
 //  通过block hash记录block的操作类型及add/del：1/2
 type BlockSequence struct {
 	Hash                 []byte   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
@@ -1342,7 +2481,7 @@ func (m *BlockSequence) Reset()         { *m = BlockSequence{} }
 func (m *BlockSequence) String() string { return proto.CompactTextString(m) }
 func (*BlockSequence) ProtoMessage()    {}
 func (*BlockSequence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{24}
+	return fileDescriptor_e9ac6287ce250c9a, []int{42}
 }
 
 func (m *BlockSequence) XXX_Unmarshal(b []byte) error {
@@ -1389,7 +2528,7 @@ func (m *BlockSequences) Reset()         { *m = BlockSequences{} }
 func (m *BlockSequences) String() string { return proto.CompactTextString(m) }
 func (*BlockSequences) ProtoMessage()    {}
 func (*BlockSequences) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{25}
+	return fileDescriptor_e9ac6287ce250c9a, []int{43}
 }
 
 func (m *BlockSequences) XXX_Unmarshal(b []byte) error {
@@ -1417,6 +2556,47 @@ func (m *BlockSequences) GetItems() []*BlockSequence {
 	return nil
 }
 
+// 订阅区块序列号的请求
+//	 start : 起始的序列号, 断点续传时为最后确认的序列号+1
+type ReqBlockSeqStream struct {
+	Start                int64    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqBlockSeqStream) Reset()         { *m = ReqBlockSeqStream{} }
+func (m *ReqBlockSeqStream) String() string { return proto.CompactTextString(m) }
+func (*ReqBlockSeqStream) ProtoMessage()    {}
+func (*ReqBlockSeqStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ac6287ce250c9a, []int{44}
+}
+
+func (m *ReqBlockSeqStream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqBlockSeqStream.Unmarshal(m, b)
+}
+func (m *ReqBlockSeqStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqBlockSeqStream.Marshal(b, m, deterministic)
+}
+func (m *ReqBlockSeqStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqBlockSeqStream.Merge(m, src)
+}
+func (m *ReqBlockSeqStream) XXX_Size() int {
+	return xxx_messageInfo_ReqBlockSeqStream.Size(m)
+}
+func (m *ReqBlockSeqStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqBlockSeqStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqBlockSeqStream proto.InternalMessageInfo
+
+func (m *ReqBlockSeqStream) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
 //平行链区块详细信息
 // 	 blockdetail : 区块详细信息
 //	 sequence :区块序列号
@@ -1432,7 +2612,7 @@ func (m *ParaChainBlockDetail) Reset()         { *m = ParaChainBlockDetail{} }
 func (m *ParaChainBlockDetail) String() string { return proto.CompactTextString(m) }
 func (*ParaChainBlockDetail) ProtoMessage()    {}
 func (*ParaChainBlockDetail) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e9ac6287ce250c9a, []int{26}
+	return fileDescriptor_e9ac6287ce250c9a, []int{45}
 }
 
 func (m *ParaChainBlockDetail) XXX_Unmarshal(b []byte) error {
@@ -1473,6 +2653,24 @@ func init() {
 	proto.RegisterType((*Blocks)(nil), "types.Blocks")
 	proto.RegisterType((*BlockSeqCB)(nil), "types.BlockSeqCB")
 	proto.RegisterType((*BlockSeq)(nil), "types.BlockSeq")
+	proto.RegisterType((*BlockSeqs)(nil), "types.BlockSeqs")
+	proto.RegisterType((*BlockSeqCBState)(nil), "types.BlockSeqCBState")
+	proto.RegisterType((*BlockSeqCBInfo)(nil), "types.BlockSeqCBInfo")
+	proto.RegisterType((*BlockSeqCBs)(nil), "types.BlockSeqCBs")
+	proto.RegisterType((*ReqSetSeqCBStatus)(nil), "types.ReqSetSeqCBStatus")
+	proto.RegisterType((*ReqStateSnapshot)(nil), "types.ReqStateSnapshot")
+	proto.RegisterType((*StateSnapshotChunk)(nil), "types.StateSnapshotChunk")
+	proto.RegisterType((*BlockArchiveEntry)(nil), "types.BlockArchiveEntry")
+	proto.RegisterType((*BlockArchiveIndex)(nil), "types.BlockArchiveIndex")
+	proto.RegisterType((*ChainCheckpoint)(nil), "types.ChainCheckpoint")
+	proto.RegisterType((*ReplyCheckpoint)(nil), "types.ReplyCheckpoint")
+	proto.RegisterType((*ReqTxProof)(nil), "types.ReqTxProof")
+	proto.RegisterType((*ReqStateProof)(nil), "types.ReqStateProof")
+	proto.RegisterType((*StateProof)(nil), "types.StateProof")
+	proto.RegisterType((*ReorgRecord)(nil), "types.ReorgRecord")
+	proto.RegisterType((*ReqReorgHistory)(nil), "types.ReqReorgHistory")
+	proto.RegisterType((*OrphanPoolStat)(nil), "types.OrphanPoolStat")
+	proto.RegisterType((*ReorgHistory)(nil), "types.ReorgHistory")
 	proto.RegisterType((*BlockPid)(nil), "types.BlockPid")
 	proto.RegisterType((*BlockDetails)(nil), "types.BlockDetails")
 	proto.RegisterType((*Headers)(nil), "types.Headers")
@@ -1494,79 +2692,233 @@ func init() {
 	proto.RegisterType((*ChainExecutor)(nil), "types.ChainExecutor")
 	proto.RegisterType((*BlockSequence)(nil), "types.BlockSequence")
 	proto.RegisterType((*BlockSequences)(nil), "types.BlockSequences")
+	proto.RegisterType((*ReqBlockSeqStream)(nil), "types.ReqBlockSeqStream")
 	proto.RegisterType((*ParaChainBlockDetail)(nil), "types.ParaChainBlockDetail")
 }
 
 func init() { proto.RegisterFile("blockchain.proto", fileDescriptor_e9ac6287ce250c9a) }
 
 var fileDescriptor_e9ac6287ce250c9a = []byte{
-	// 1085 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xdf, 0x6f, 0xe3, 0xc4,
-	0x13, 0x97, 0xf3, 0xab, 0xc9, 0x24, 0xcd, 0xb7, 0xdf, 0x55, 0x40, 0xd6, 0x09, 0xb8, 0xdc, 0x72,
-	0x3a, 0x45, 0xc7, 0x29, 0x95, 0x5a, 0x04, 0xf7, 0x00, 0x12, 0x34, 0x45, 0x6a, 0xaf, 0xc7, 0x51,
-	0xb6, 0xbd, 0x3e, 0xf0, 0xb6, 0xb5, 0xb7, 0xb5, 0xd5, 0xf8, 0x47, 0xbd, 0xeb, 0x10, 0xf3, 0x3f,
-	0xf0, 0x57, 0xf0, 0x86, 0xf8, 0x23, 0xd1, 0xce, 0xae, 0x63, 0x3b, 0xb4, 0x48, 0x48, 0xbc, 0xf0,
-	0xb6, 0xf3, 0x7b, 0xe6, 0x33, 0x9e, 0x19, 0xc3, 0xde, 0xf5, 0x32, 0xf1, 0xee, 0xbc, 0x80, 0x87,
-	0xf1, 0x3c, 0xcd, 0x12, 0x95, 0x90, 0xae, 0x2a, 0x52, 0x21, 0x9f, 0xfc, 0x5f, 0x65, 0x3c, 0x96,
-	0xdc, 0x53, 0x61, 0x62, 0x25, 0x4f, 0x46, 0x5e, 0x12, 0x45, 0x25, 0x45, 0xff, 0x68, 0x41, 0xef,
-	0x44, 0x70, 0x5f, 0x64, 0xc4, 0x85, 0x9d, 0x95, 0xc8, 0x64, 0x98, 0xc4, 0xae, 0x33, 0x75, 0x66,
-	0x6d, 0x56, 0x92, 0xe4, 0x13, 0x80, 0x94, 0x67, 0x22, 0x56, 0x27, 0x5c, 0x06, 0x6e, 0x6b, 0xea,
-	0xcc, 0x46, 0xac, 0xc6, 0x21, 0x1f, 0x42, 0x4f, 0xad, 0x51, 0xd6, 0x46, 0x99, 0xa5, 0xc8, 0x47,
-	0x30, 0x90, 0x8a, 0x2b, 0x81, 0xa2, 0x0e, 0x8a, 0x2a, 0x86, 0xb6, 0x0a, 0x44, 0x78, 0x1b, 0x28,
-	0xb7, 0x8b, 0xe1, 0x2c, 0xa5, 0xad, 0xb0, 0x9c, 0xcb, 0x30, 0x12, 0x6e, 0x0f, 0x45, 0x15, 0x43,
-	0x67, 0xa9, 0xd6, 0x8b, 0x24, 0x8f, 0x95, 0x3b, 0x30, 0x59, 0x5a, 0x92, 0x10, 0xe8, 0x04, 0x3a,
-	0x10, 0x60, 0x20, 0x7c, 0xeb, 0xcc, 0xfd, 0xf0, 0xe6, 0x26, 0xf4, 0xf2, 0xa5, 0x2a, 0xdc, 0xe1,
-	0xd4, 0x99, 0xed, 0xb2, 0x1a, 0x87, 0xcc, 0x61, 0x20, 0xc3, 0xdb, 0x98, 0xab, 0x3c, 0x13, 0x6e,
-	0x7f, 0xea, 0xcc, 0x86, 0x07, 0x7b, 0x73, 0x84, 0x6e, 0x7e, 0x51, 0xf2, 0x59, 0xa5, 0x42, 0x7f,
-	0x6b, 0x41, 0xf7, 0x48, 0xe7, 0xf2, 0x1f, 0x41, 0xeb, 0x5f, 0xae, 0x9f, 0x3c, 0x87, 0xb6, 0x5a,
-	0x4b, 0x77, 0x67, 0xda, 0x9e, 0x0d, 0x0f, 0x88, 0xd5, 0xbc, 0xac, 0xbe, 0x31, 0xa6, 0xc5, 0xf4,
-	0x15, 0xf4, 0x10, 0x24, 0x49, 0x28, 0x74, 0x43, 0x25, 0x22, 0xe9, 0x3a, 0x68, 0x31, 0xb2, 0x16,
-	0x28, 0x65, 0x46, 0x44, 0xdf, 0x00, 0x20, 0x7d, 0x21, 0xee, 0x17, 0x47, 0xba, 0x8b, 0x31, 0x8f,
-	0x04, 0x82, 0x3a, 0x60, 0xf8, 0x26, 0x7b, 0xd0, 0x7e, 0xcf, 0xde, 0x22, 0x94, 0x03, 0xa6, 0x9f,
-	0x1a, 0x0d, 0x11, 0x7b, 0x89, 0x2f, 0x10, 0xc3, 0x01, 0xb3, 0x14, 0x4d, 0xa1, 0x5f, 0xfa, 0xd2,
-	0x56, 0x71, 0x1e, 0xd9, 0xee, 0xe8, 0x27, 0x79, 0x01, 0x6d, 0x29, 0xee, 0xd1, 0xcf, 0xf0, 0x60,
-	0x52, 0xcf, 0xe5, 0x42, 0xdc, 0xe7, 0x22, 0xf6, 0x04, 0xd3, 0x0a, 0xe4, 0x25, 0xf4, 0x7c, 0xa1,
-	0x78, 0xb8, 0x44, 0xef, 0x55, 0xa1, 0xa8, 0x7a, 0x8c, 0x12, 0x66, 0x35, 0xe8, 0x37, 0x36, 0xe2,
-	0x79, 0xe8, 0xeb, 0x88, 0x69, 0xe8, 0xdb, 0xd4, 0xf5, 0x53, 0xd7, 0x8f, 0xcd, 0xb0, 0x31, 0xb7,
-	0xea, 0x47, 0x11, 0x7d, 0x0d, 0xa3, 0x9a, 0x63, 0x49, 0x66, 0x4d, 0xcc, 0x1e, 0x0a, 0x6e, 0x91,
-	0x9b, 0xc3, 0x8e, 0x99, 0x5d, 0x49, 0x3e, 0x6d, 0x1a, 0xed, 0x5a, 0x23, 0x23, 0x2e, 0xf5, 0x4f,
-	0x00, 0xac, 0xfe, 0xc3, 0xd9, 0xce, 0x60, 0x27, 0x30, 0x72, 0x9b, 0xef, 0xb8, 0xe1, 0x46, 0xb2,
-	0x52, 0x4c, 0x03, 0xd8, 0xc5, 0x7c, 0x7e, 0x58, 0x89, 0x6c, 0x15, 0x8a, 0x9f, 0xc9, 0x33, 0xe8,
-	0x68, 0x19, 0x7a, 0xfb, 0x4b, 0x78, 0x14, 0xd5, 0x27, 0xb7, 0xd5, 0x9c, 0xdc, 0x27, 0xd0, 0x37,
-	0x33, 0x20, 0xa4, 0xdb, 0x9e, 0xb6, 0x67, 0x23, 0xb6, 0xa1, 0xe9, 0xef, 0x0e, 0x0c, 0x6b, 0xa5,
-	0x57, 0x88, 0x3a, 0x8f, 0x22, 0x4a, 0xe6, 0xd0, 0xcf, 0x84, 0x27, 0xc2, 0x54, 0xe9, 0x42, 0xea,
-	0x20, 0x32, 0xc3, 0x3e, 0xe6, 0x8a, 0xb3, 0x8d, 0x0e, 0x79, 0x0a, 0xad, 0xb3, 0x2b, 0x8c, 0x3c,
-	0x3c, 0xf8, 0x9f, 0xd5, 0x3c, 0x13, 0xc5, 0x15, 0x5f, 0xe6, 0x82, 0xb5, 0xce, 0xae, 0xc8, 0x0b,
-	0x18, 0xa7, 0x99, 0x58, 0x5d, 0x28, 0xae, 0x72, 0x59, 0x9b, 0xcf, 0x2d, 0x2e, 0xfd, 0x02, 0xfa,
-	0xac, 0x74, 0xfa, 0xb2, 0x96, 0x84, 0x69, 0xca, 0xb8, 0x99, 0x44, 0x95, 0x00, 0x7d, 0x03, 0x83,
-	0xf3, 0x2c, 0x5c, 0x71, 0xaf, 0x38, 0xbb, 0x22, 0x5f, 0xeb, 0x60, 0x96, 0xb8, 0x4c, 0xee, 0x44,
-	0x6c, 0xcd, 0x3f, 0xb0, 0xe6, 0xe7, 0x0d, 0x21, 0xdb, 0x52, 0xa6, 0x05, 0x8c, 0x9b, 0x1a, 0x64,
-	0x02, 0x5d, 0x65, 0xfd, 0xe8, 0x56, 0x1b, 0xc2, 0xb4, 0xe3, 0x34, 0xf6, 0xc5, 0x1a, 0xdb, 0xd1,
-	0x65, 0x25, 0x69, 0x16, 0x54, 0xd0, 0x58, 0x50, 0xb8, 0x4c, 0x0d, 0x4c, 0x9d, 0x47, 0x61, 0xa2,
-	0x12, 0x26, 0x65, 0xf9, 0xdf, 0xc6, 0x7e, 0x55, 0xd1, 0x67, 0x0d, 0x28, 0x9c, 0x9a, 0x79, 0xa9,
-	0x5e, 0x6b, 0xc6, 0x1c, 0x06, 0x9b, 0x8a, 0xec, 0x67, 0xb8, 0xb7, 0x5d, 0x39, 0xab, 0x54, 0xe8,
-	0x0c, 0x88, 0xf5, 0xb2, 0x08, 0x84, 0x77, 0x77, 0xb9, 0x7e, 0x1b, 0x4a, 0x3c, 0x06, 0x22, 0xcb,
-	0x0c, 0xf2, 0x03, 0x86, 0x6f, 0x5a, 0xc0, 0x70, 0xa1, 0x4f, 0xa4, 0x69, 0x18, 0x79, 0x0e, 0xbb,
-	0x5e, 0x9e, 0xe1, 0x5a, 0x36, 0x8b, 0xd5, 0x6c, 0x8a, 0x26, 0x93, 0x4c, 0x61, 0x18, 0x89, 0x28,
-	0x4d, 0x92, 0xe5, 0x45, 0xf8, 0x8b, 0xb0, 0x5f, 0x6e, 0x9d, 0x45, 0x28, 0x8c, 0x22, 0x79, 0xfb,
-	0x63, 0x2e, 0x72, 0x81, 0x2a, 0x6d, 0x54, 0x69, 0xf0, 0x28, 0x87, 0x01, 0x13, 0xf7, 0x76, 0x29,
-	0x4e, 0xa0, 0x2b, 0x15, 0xcf, 0xca, 0x80, 0x86, 0xd0, 0xe3, 0x28, 0x62, 0xdf, 0x06, 0xd0, 0x4f,
-	0x3d, 0x16, 0xa1, 0x3c, 0xae, 0x16, 0x51, 0x9f, 0x6d, 0xe8, 0x72, 0x78, 0x3b, 0x58, 0x9e, 0x7e,
-	0xd2, 0x67, 0x30, 0xfc, 0xbe, 0x96, 0x15, 0x81, 0x8e, 0xd4, 0xd9, 0x98, 0x18, 0xf8, 0xa6, 0x2f,
-	0x61, 0x8f, 0x89, 0x74, 0x59, 0x60, 0x1e, 0xb6, 0xbe, 0xea, 0xae, 0x38, 0xf5, 0xbb, 0xa2, 0x33,
-	0x46, 0xb5, 0xa3, 0xc4, 0x2f, 0xca, 0xb5, 0xef, 0xfc, 0xed, 0xda, 0xff, 0xa7, 0x63, 0x47, 0x5f,
-	0x01, 0x9c, 0xca, 0x05, 0xcf, 0x6f, 0x03, 0xf5, 0x3e, 0xd5, 0xa7, 0xea, 0x54, 0x7a, 0x48, 0xe5,
-	0x29, 0x26, 0xd3, 0x67, 0x35, 0x0e, 0x7d, 0x0d, 0xe3, 0x53, 0xf9, 0x4e, 0xa5, 0x0b, 0xdc, 0xd7,
-	0x45, 0xec, 0xe9, 0xa9, 0x0c, 0x65, 0xac, 0x52, 0x0f, 0x61, 0x2d, 0x62, 0xcf, 0x5a, 0x6d, 0x71,
-	0xe9, 0xaf, 0x0e, 0xec, 0x62, 0xe3, 0xbf, 0x5b, 0x0b, 0x2f, 0x57, 0x49, 0xa6, 0x8b, 0xf6, 0xb3,
-	0x70, 0x25, 0x32, 0x3b, 0x12, 0x96, 0xd2, 0x88, 0xdf, 0xe4, 0xb1, 0xf7, 0x4e, 0x1f, 0x20, 0x73,
-	0x6d, 0x36, 0x74, 0xf3, 0x3c, 0xb7, 0xb7, 0xcf, 0xf3, 0x04, 0xba, 0x29, 0xcf, 0x78, 0x64, 0x17,
-	0x83, 0x21, 0x34, 0x57, 0xac, 0x55, 0xc6, 0xf1, 0x66, 0x8f, 0x98, 0x21, 0xe8, 0x97, 0x76, 0x79,
-	0x96, 0x47, 0x47, 0xf7, 0x0a, 0xbd, 0x3a, 0xe6, 0xcf, 0x05, 0x1d, 0x12, 0xe8, 0x5c, 0x16, 0x69,
-	0xf9, 0xc1, 0xe1, 0x9b, 0x7e, 0x05, 0xe3, 0x86, 0xa1, 0x5e, 0x32, 0x8d, 0xb5, 0xff, 0xf0, 0x4d,
-	0xb3, 0xdb, 0x3f, 0x80, 0xc9, 0x39, 0xcf, 0x38, 0x22, 0x51, 0xdf, 0xa8, 0x9f, 0xc3, 0x10, 0xd7,
-	0xa6, 0x3d, 0x79, 0xce, 0xa3, 0x27, 0xaf, 0xae, 0xa6, 0xa1, 0x92, 0x36, 0x80, 0xcd, 0x71, 0x43,
-	0x1f, 0x3d, 0xfd, 0xe9, 0xe3, 0xdb, 0x50, 0x05, 0xf9, 0xf5, 0xdc, 0x4b, 0xa2, 0xfd, 0xc3, 0x43,
-	0x2f, 0xde, 0xc7, 0x7f, 0xd3, 0xc3, 0xc3, 0x7d, 0xf4, 0x7a, 0xdd, 0xc3, 0x9f, 0xcf, 0xc3, 0x3f,
-	0x03, 0x00, 0x00, 0xff, 0xff, 0x97, 0x32, 0x81, 0xf9, 0xb8, 0x0a, 0x00, 0x00,
+	// 1871 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x58, 0x4b, 0x6f, 0xe4, 0x44,
+	0x10, 0xc6, 0x33, 0xc9, 0x24, 0xd3, 0x93, 0x64, 0xb3, 0xd6, 0x82, 0x46, 0x2b, 0x1e, 0x4b, 0x83,
+	0x20, 0x2c, 0x28, 0x8b, 0x12, 0x5e, 0x42, 0x20, 0x20, 0xd9, 0x15, 0x09, 0x0b, 0x4b, 0xe8, 0x04,
+	0x0e, 0xdc, 0x1c, 0x4f, 0x4f, 0x6c, 0x65, 0xc6, 0xf6, 0xba, 0xdb, 0x61, 0x06, 0x2e, 0x70, 0xe2,
+	0x2f, 0x20, 0x71, 0x41, 0xe2, 0x86, 0xf8, 0x05, 0x5c, 0x11, 0x3f, 0x86, 0x7f, 0x41, 0x55, 0x75,
+	0xb7, 0xdd, 0x9e, 0x4d, 0x40, 0x48, 0x5c, 0xb8, 0x75, 0x3d, 0xdc, 0xfd, 0x55, 0x75, 0xbd, 0xda,
+	0x6c, 0xf3, 0x74, 0x92, 0xc7, 0xe7, 0x71, 0x12, 0xa5, 0xd9, 0x76, 0x51, 0xe6, 0x3a, 0x0f, 0x97,
+	0xf5, 0xbc, 0x90, 0xea, 0xe6, 0x75, 0x5d, 0x46, 0x99, 0x8a, 0x62, 0x9d, 0xe6, 0x56, 0x72, 0x73,
+	0x2d, 0xce, 0xa7, 0x53, 0x47, 0xf1, 0x5f, 0x3b, 0xac, 0x77, 0x20, 0xa3, 0x91, 0x2c, 0xc3, 0x21,
+	0x5b, 0xb9, 0x90, 0xa5, 0x02, 0xcd, 0x61, 0x70, 0x2b, 0xd8, 0xea, 0x0a, 0x47, 0x86, 0x4f, 0x33,
+	0x56, 0x44, 0xa5, 0xcc, 0xf4, 0x41, 0xa4, 0x92, 0x61, 0x07, 0x84, 0x6b, 0xc2, 0xe3, 0x84, 0x4f,
+	0xb0, 0x9e, 0x9e, 0x91, 0xac, 0x4b, 0x32, 0x4b, 0x85, 0x4f, 0xb2, 0xbe, 0xd2, 0x91, 0x96, 0x24,
+	0x5a, 0x22, 0x51, 0xc3, 0xc0, 0xaf, 0x12, 0x99, 0x9e, 0x25, 0x7a, 0xb8, 0x4c, 0xc7, 0x59, 0x0a,
+	0xbf, 0x22, 0x73, 0x4e, 0xd2, 0xa9, 0x1c, 0xf6, 0x48, 0xd4, 0x30, 0x10, 0xa5, 0x9e, 0xed, 0xe7,
+	0x55, 0xa6, 0x87, 0x7d, 0x83, 0xd2, 0x92, 0x61, 0xc8, 0x96, 0x12, 0x3c, 0x88, 0xd1, 0x41, 0xb4,
+	0x46, 0xe4, 0xa3, 0x74, 0x3c, 0x4e, 0xe3, 0x6a, 0xa2, 0xe7, 0xc3, 0x01, 0x48, 0xd6, 0x85, 0xc7,
+	0x09, 0xb7, 0x01, 0x61, 0x7a, 0x96, 0x45, 0xba, 0x2a, 0xe5, 0x70, 0x15, 0xc4, 0x83, 0x9d, 0xcd,
+	0x6d, 0x72, 0xdd, 0xf6, 0xb1, 0xe3, 0x8b, 0x46, 0x85, 0xff, 0xdc, 0x61, 0xcb, 0x7b, 0x88, 0xe5,
+	0x7f, 0xe2, 0xad, 0xff, 0xd8, 0xfe, 0xf0, 0x79, 0xd6, 0xd5, 0x33, 0x35, 0x5c, 0xb9, 0xd5, 0x05,
+	0xcd, 0xd0, 0x6a, 0x9e, 0x34, 0x31, 0x26, 0x50, 0xcc, 0x5f, 0x61, 0x3d, 0x72, 0x92, 0x0a, 0x39,
+	0x5b, 0x4e, 0xb5, 0x9c, 0x2a, 0xf0, 0x11, 0x7e, 0xb1, 0x66, 0xbf, 0x20, 0xa9, 0x30, 0x22, 0xfe,
+	0x5b, 0xc0, 0x18, 0x31, 0x8e, 0xe5, 0xc3, 0xfd, 0x3d, 0xbc, 0xc6, 0x2c, 0x02, 0x5b, 0xd0, 0xab,
+	0x7d, 0x41, 0xeb, 0x70, 0x93, 0x75, 0x3f, 0x17, 0x1f, 0x93, 0x2f, 0xfb, 0x02, 0x97, 0xe8, 0x0e,
+	0x99, 0xc5, 0xf9, 0x48, 0x92, 0x13, 0xfb, 0xc2, 0x52, 0xe4, 0x8e, 0x48, 0xc7, 0xc9, 0x71, 0xfa,
+	0xb5, 0x24, 0x27, 0x2e, 0x8b, 0x86, 0x81, 0x52, 0x4a, 0x88, 0x22, 0x2f, 0x8d, 0x1f, 0xfb, 0xa2,
+	0x61, 0xe0, 0x9e, 0x4a, 0xc6, 0xa5, 0xd4, 0xe4, 0x47, 0xd8, 0xd3, 0x50, 0xe1, 0x4d, 0xb6, 0x3a,
+	0x8d, 0x66, 0x42, 0xea, 0x72, 0x0e, 0x96, 0xe3, 0x96, 0x35, 0xcd, 0x0b, 0xb6, 0xea, 0xb0, 0x23,
+	0xca, 0xac, 0x9a, 0xda, 0x70, 0xc0, 0x65, 0xf8, 0x02, 0xeb, 0x2a, 0xf9, 0x90, 0x70, 0x0f, 0x76,
+	0x6e, 0xf8, 0xc6, 0x83, 0x7e, 0x05, 0x90, 0xa5, 0x40, 0x85, 0xf0, 0x36, 0xeb, 0x8d, 0xa4, 0x8e,
+	0xd2, 0x09, 0x59, 0xd3, 0x78, 0x96, 0x54, 0xef, 0x92, 0x44, 0x58, 0x0d, 0xfe, 0x2a, 0xeb, 0xbb,
+	0x1d, 0x54, 0xf8, 0x1c, 0x5b, 0x82, 0xef, 0x9d, 0x7b, 0xaf, 0x2d, 0x9c, 0x20, 0x48, 0xc8, 0xbf,
+	0x0b, 0xd8, 0xb5, 0xc6, 0xc1, 0xc7, 0x18, 0x52, 0x64, 0x2b, 0x2c, 0x2a, 0x45, 0x70, 0x97, 0x85,
+	0xa5, 0xd0, 0xd6, 0x31, 0x9c, 0x02, 0x77, 0xad, 0x08, 0x36, 0xd8, 0xea, 0x68, 0xf4, 0xde, 0x24,
+	0x52, 0xfa, 0x5e, 0x59, 0xe6, 0xa5, 0x75, 0x7b, 0xc3, 0x40, 0x69, 0x26, 0x67, 0xda, 0xb8, 0x69,
+	0xc9, 0x04, 0x62, 0xcd, 0xe0, 0xdf, 0xb0, 0x8d, 0x06, 0xc2, 0x61, 0x36, 0xce, 0xc3, 0x67, 0x59,
+	0x27, 0x3e, 0xa5, 0xd3, 0x07, 0x3b, 0xd7, 0x17, 0x80, 0xef, 0xef, 0x09, 0x10, 0x62, 0x8e, 0xe1,
+	0xfe, 0x0f, 0xc0, 0xa9, 0x1d, 0x93, 0x63, 0x96, 0x0c, 0x5f, 0x61, 0xcb, 0x94, 0x1a, 0xd6, 0x5f,
+	0x4f, 0x3c, 0xf2, 0x3d, 0x59, 0x29, 0x8c, 0x12, 0x7f, 0x9b, 0x0d, 0x1a, 0x89, 0x0a, 0x5f, 0x6e,
+	0x07, 0xe5, 0xe3, 0x8f, 0x7c, 0x8c, 0xf8, 0x5c, 0x74, 0xbe, 0xc7, 0xae, 0x0b, 0xf9, 0xf0, 0x58,
+	0xea, 0x7a, 0x5b, 0xf0, 0xd2, 0x65, 0x31, 0xda, 0x78, 0xb4, 0xe3, 0x7b, 0x94, 0xff, 0x14, 0xb0,
+	0x4d, 0xdc, 0x01, 0x91, 0x1c, 0x67, 0x51, 0xa1, 0x92, 0x5c, 0xb7, 0x73, 0x3d, 0xb8, 0x3a, 0xd7,
+	0x3b, 0xad, 0x5c, 0xbf, 0x41, 0x56, 0x43, 0xe8, 0x76, 0xe9, 0x04, 0x43, 0x20, 0x37, 0xa6, 0x7a,
+	0x68, 0xc2, 0xdd, 0x10, 0x18, 0x8c, 0x45, 0x3a, 0xb2, 0x41, 0x8e, 0x4b, 0xbf, 0x62, 0xf5, 0x48,
+	0xd3, 0x91, 0xfc, 0x8f, 0x80, 0x85, 0x2d, 0x7c, 0xfb, 0x49, 0x95, 0x9d, 0xff, 0x03, 0xc8, 0x1a,
+	0x4c, 0x67, 0x01, 0x8c, 0xce, 0x75, 0x34, 0x71, 0x10, 0x89, 0x80, 0xbb, 0xee, 0x9e, 0x5f, 0x28,
+	0x00, 0xe8, 0x47, 0xe9, 0x7d, 0x39, 0xff, 0x22, 0x9a, 0x54, 0x90, 0x02, 0x20, 0x43, 0x9b, 0xa1,
+	0x23, 0xe5, 0x63, 0x05, 0x90, 0xbb, 0x58, 0x15, 0x0d, 0xe5, 0xa5, 0x46, 0xef, 0x1f, 0x53, 0xe3,
+	0xfb, 0x80, 0x5d, 0x27, 0xfe, 0x07, 0x65, 0x9c, 0xa4, 0x17, 0xf2, 0x5e, 0x06, 0xa1, 0xe7, 0x79,
+	0x33, 0x68, 0x79, 0xd3, 0xf5, 0x8b, 0x8e, 0xd7, 0x2f, 0x40, 0x37, 0x1f, 0x8f, 0x95, 0x34, 0x2e,
+	0x06, 0x5d, 0x43, 0xa1, 0xae, 0x6a, 0x2a, 0x0a, 0xad, 0x31, 0x55, 0xe2, 0x44, 0x42, 0x95, 0x83,
+	0xf0, 0x5c, 0xa6, 0x3d, 0x6a, 0x9a, 0x7f, 0xd8, 0x06, 0x72, 0x98, 0x8d, 0xe4, 0x2c, 0xdc, 0x61,
+	0x2b, 0xd0, 0x03, 0xca, 0x54, 0xba, 0xc8, 0x1b, 0xfa, 0xb6, 0xf8, 0x98, 0x85, 0x53, 0xe4, 0xef,
+	0xb2, 0x6b, 0xfb, 0xd8, 0xd6, 0xf7, 0x71, 0xe7, 0x22, 0x4f, 0x33, 0xfd, 0x6f, 0xec, 0xe1, 0x3f,
+	0x42, 0xea, 0x0b, 0x59, 0x4c, 0xe6, 0xde, 0xf7, 0x6f, 0x30, 0x16, 0xd7, 0x94, 0x4d, 0x40, 0x97,
+	0x40, 0x0b, 0x67, 0x09, 0x4f, 0x13, 0x8a, 0xd9, 0xc6, 0x38, 0xcd, 0xa2, 0x09, 0xd8, 0x3e, 0xba,
+	0x2b, 0x0b, 0x9d, 0xd8, 0xe8, 0x5c, 0xe0, 0x86, 0x5b, 0xec, 0x5a, 0xcd, 0x39, 0x30, 0x40, 0x8d,
+	0x33, 0x17, 0xd9, 0x7c, 0x87, 0x31, 0xc8, 0x8c, 0x93, 0xd9, 0x11, 0x5e, 0x75, 0x8d, 0x3f, 0xf0,
+	0xee, 0xc3, 0x46, 0x71, 0xa7, 0x8e, 0x62, 0x9e, 0xb2, 0x75, 0x97, 0x4d, 0xe6, 0xb3, 0xbf, 0x8f,
+	0x52, 0xd8, 0xe0, 0x5c, 0xce, 0xad, 0x4f, 0x70, 0xe9, 0xb9, 0xaf, 0xdb, 0x72, 0x9f, 0x3d, 0x6a,
+	0xa9, 0x39, 0xea, 0x5b, 0x68, 0x4c, 0xde, 0x41, 0x57, 0xf9, 0xbd, 0x05, 0xa0, 0x73, 0x05, 0x80,
+	0x6e, 0x03, 0x00, 0x52, 0xe4, 0x02, 0xe3, 0xde, 0xf6, 0x78, 0x43, 0x20, 0x97, 0x22, 0xde, 0x86,
+	0x92, 0x21, 0xf8, 0x9f, 0x01, 0x1b, 0x08, 0x99, 0x97, 0x67, 0x42, 0xc6, 0x79, 0x39, 0x42, 0xad,
+	0x14, 0x63, 0xc9, 0x42, 0x30, 0x04, 0x76, 0xf9, 0x71, 0x5e, 0x9e, 0x1f, 0xf8, 0x35, 0xc3, 0xe3,
+	0x50, 0x51, 0x47, 0xaa, 0x99, 0x39, 0x6a, 0x1a, 0x65, 0x98, 0x3d, 0x70, 0xcf, 0x23, 0xca, 0x4f,
+	0x90, 0x39, 0x1a, 0x65, 0x91, 0xb6, 0x32, 0x93, 0x95, 0x35, 0xed, 0xdc, 0xd5, 0x6b, 0xea, 0x0b,
+	0xdc, 0x9f, 0xc6, 0x21, 0x64, 0x85, 0xce, 0xa7, 0x35, 0xe2, 0xcd, 0x27, 0xa3, 0x93, 0x11, 0xcd,
+	0x16, 0x7d, 0x61, 0x08, 0xe4, 0x66, 0xf2, 0x2b, 0xe0, 0xf6, 0x0d, 0x97, 0x08, 0xfe, 0x22, 0x86,
+	0xea, 0x43, 0xb2, 0xf6, 0x20, 0x55, 0x3a, 0x2f, 0xe7, 0x4d, 0x69, 0x0b, 0xbc, 0xd2, 0xc6, 0x7f,
+	0x08, 0xd8, 0xc6, 0xa7, 0x65, 0x91, 0x44, 0xd9, 0x51, 0x9e, 0x4f, 0xf0, 0x86, 0xda, 0x8a, 0x5d,
+	0x57, 0x03, 0xa1, 0xe2, 0x99, 0xb9, 0x4b, 0xb9, 0xfe, 0x61, 0x49, 0xdb, 0xd2, 0xcd, 0x18, 0x69,
+	0xc2, 0xa0, 0xa6, 0xf1, 0x3e, 0xa7, 0x69, 0x66, 0x9d, 0x69, 0x1b, 0x59, 0xcd, 0x20, 0x69, 0x34,
+	0x3b, 0xf0, 0x47, 0xb1, 0x86, 0x01, 0xd1, 0xb9, 0xd6, 0x32, 0x60, 0xab, 0xdd, 0x6a, 0x5c, 0xf1,
+	0xf2, 0xae, 0xd4, 0xf6, 0x99, 0xf0, 0x0e, 0x5b, 0xc9, 0xc9, 0x26, 0x65, 0xc7, 0x05, 0xd7, 0x96,
+	0xda, 0x96, 0x0a, 0xa7, 0xc5, 0xdf, 0xb7, 0x93, 0xc7, 0x51, 0x5a, 0x5f, 0x46, 0xd0, 0x5c, 0x06,
+	0x0c, 0x5e, 0x34, 0x05, 0xda, 0xcd, 0x16, 0x06, 0x2f, 0x12, 0xf1, 0xb7, 0xd8, 0x9a, 0x57, 0x45,
+	0xd5, 0x55, 0x60, 0xfd, 0x4a, 0x6b, 0x9b, 0xe2, 0x36, 0x5b, 0x31, 0x8f, 0x06, 0x9c, 0x40, 0x5a,
+	0x1f, 0xad, 0xdb, 0x8f, 0x8c, 0xd8, 0xe9, 0x1f, 0x30, 0x66, 0xf5, 0x2f, 0x47, 0xbb, 0xc5, 0x56,
+	0x12, 0x23, 0xb7, 0x78, 0x37, 0x5a, 0xdb, 0x28, 0xe1, 0xc4, 0x3c, 0x61, 0xeb, 0x84, 0xe7, 0x53,
+	0xe8, 0x5d, 0x17, 0xa9, 0xfc, 0x0a, 0x5a, 0xcb, 0x12, 0xca, 0x6c, 0x1d, 0x5b, 0x38, 0x9e, 0x44,
+	0xfe, 0x93, 0xa1, 0xd3, 0x7e, 0x32, 0x40, 0x18, 0x98, 0xe1, 0x1b, 0x4a, 0x72, 0xd7, 0x04, 0xb8,
+	0xa3, 0xf9, 0x2f, 0x81, 0x9d, 0x1a, 0x8c, 0xe9, 0x8d, 0x47, 0x83, 0x2b, 0x3d, 0x0a, 0xe3, 0xf4,
+	0x6a, 0x29, 0x63, 0x99, 0x16, 0x14, 0x71, 0xed, 0x1b, 0x27, 0xf6, 0xdd, 0x48, 0x47, 0xa2, 0xd6,
+	0x09, 0x9f, 0x61, 0x9d, 0xfb, 0x5f, 0xd0, 0xc9, 0x97, 0xb4, 0x45, 0x10, 0x61, 0xcd, 0x2d, 0x4a,
+	0x79, 0x61, 0xc6, 0x0e, 0xef, 0x61, 0xb0, 0xc0, 0xe5, 0x6f, 0xb0, 0x55, 0xe1, 0x36, 0xbd, 0xed,
+	0x81, 0x30, 0x97, 0xb2, 0xd1, 0x06, 0xd1, 0x00, 0xe0, 0x1f, 0xb1, 0xfe, 0x51, 0x99, 0x5e, 0x44,
+	0xf1, 0x1c, 0x0e, 0x7b, 0x17, 0x0f, 0xb3, 0xc4, 0x49, 0x7e, 0x2e, 0xb3, 0x85, 0x01, 0xe9, 0xa8,
+	0x25, 0x14, 0x0b, 0xca, 0x7c, 0xce, 0x36, 0xda, 0x1a, 0x66, 0x18, 0x30, 0xfb, 0x50, 0x9e, 0x13,
+	0x61, 0xae, 0x83, 0x3a, 0xa2, 0x1d, 0x1d, 0x1c, 0x69, 0x5e, 0x46, 0x49, 0xeb, 0x65, 0x44, 0x5d,
+	0xc0, 0xb8, 0x69, 0xe9, 0x4a, 0x37, 0x71, 0xc5, 0x6e, 0x38, 0xf3, 0x3f, 0xc8, 0x46, 0x8d, 0x45,
+	0x2f, 0xb7, 0x5c, 0x11, 0x78, 0x9f, 0x3b, 0x75, 0xef, 0x32, 0xe0, 0x2d, 0x54, 0x5b, 0x64, 0xc3,
+	0x70, 0x73, 0xd1, 0x72, 0xd1, 0xa8, 0xf0, 0x2d, 0x16, 0xda, 0x5d, 0xa8, 0x61, 0x9e, 0xcc, 0x3e,
+	0x86, 0xa4, 0xc7, 0x2a, 0x28, 0xcb, 0xd2, 0x78, 0x1e, 0x46, 0x43, 0x5c, 0x83, 0x67, 0x06, 0xd4,
+	0x58, 0xed, 0xf4, 0xf8, 0x3c, 0x5b, 0x8f, 0xab, 0x92, 0xde, 0x83, 0x7e, 0x3f, 0x69, 0x33, 0xc3,
+	0x5b, 0x6c, 0x30, 0x95, 0xd3, 0x02, 0xf3, 0x1e, 0x27, 0x0f, 0x13, 0xb9, 0x3e, 0x0b, 0x22, 0x72,
+	0x6d, 0xaa, 0xce, 0x3e, 0xab, 0x64, 0x25, 0x49, 0xc5, 0x14, 0xb2, 0x16, 0x8f, 0x47, 0xac, 0x0f,
+	0x45, 0xd5, 0xbe, 0xc6, 0xea, 0x91, 0xcd, 0x56, 0x49, 0x33, 0xb2, 0x41, 0x3a, 0xca, 0x6c, 0x64,
+	0x0f, 0xc0, 0x25, 0xa6, 0x45, 0xaa, 0xee, 0x36, 0x0f, 0x92, 0x55, 0x51, 0xd3, 0x4d, 0x9b, 0xec,
+	0xba, 0x36, 0xf9, 0x2c, 0x1b, 0x7c, 0xe2, 0xa1, 0x72, 0xa3, 0x92, 0x39, 0x83, 0xd6, 0xfc, 0x36,
+	0x8e, 0xc0, 0x30, 0x85, 0x10, 0x0e, 0x6b, 0xdf, 0x15, 0xed, 0x14, 0x11, 0x93, 0xda, 0x5e, 0x3e,
+	0x9a, 0xbb, 0xf7, 0x66, 0xf0, 0xb7, 0xef, 0xcd, 0x7f, 0x9b, 0x76, 0xf0, 0x3e, 0x65, 0x87, 0x6a,
+	0x3f, 0xaa, 0xe0, 0xb8, 0xcf, 0x0b, 0xec, 0x9e, 0x87, 0x2a, 0x26, 0xaa, 0x2a, 0x08, 0xcc, 0xaa,
+	0xf0, 0x38, 0x50, 0x26, 0x37, 0x0e, 0xd5, 0x03, 0x5d, 0xec, 0xd3, 0xfb, 0x60, 0x9e, 0xc5, 0x98,
+	0x95, 0xa9, 0xca, 0x74, 0x11, 0x93, 0x5b, 0x81, 0x63, 0xbf, 0x5a, 0xe0, 0xf2, 0xdf, 0x03, 0xb6,
+	0x4e, 0x17, 0x7f, 0x6f, 0x26, 0xe3, 0x0a, 0x1a, 0x02, 0x1a, 0x3d, 0x82, 0x00, 0x92, 0xa5, 0x4d,
+	0x09, 0x4b, 0x51, 0x87, 0xae, 0xb2, 0xf8, 0x01, 0x3e, 0x2a, 0xcc, 0xb0, 0x53, 0xd3, 0xed, 0xf9,
+	0xa2, 0x7b, 0xc9, 0x18, 0x0e, 0x4d, 0x2d, 0x9a, 0xba, 0x69, 0x82, 0x08, 0xe4, 0xc2, 0xd3, 0xab,
+	0x8c, 0xdc, 0x34, 0x41, 0x84, 0xe7, 0xf2, 0xde, 0xe2, 0x04, 0x03, 0x79, 0x66, 0x83, 0x71, 0x85,
+	0x4c, 0x69, 0x18, 0xfc, 0x4d, 0x5b, 0x72, 0xdd, 0x93, 0x15, 0x6f, 0xd8, 0x1b, 0xb6, 0x68, 0x8d,
+	0xbc, 0x13, 0xf0, 0xb8, 0x8d, 0x22, 0x5a, 0xf3, 0x77, 0x9a, 0x37, 0x1f, 0x7d, 0x88, 0xa5, 0xa9,
+	0xd5, 0x2c, 0x2e, 0x7f, 0x11, 0xdb, 0x9e, 0xf1, 0x12, 0x3d, 0xbc, 0x9c, 0xe8, 0x58, 0x97, 0xd2,
+	0xd8, 0xf5, 0x68, 0x04, 0x43, 0x53, 0xb8, 0x71, 0x04, 0x66, 0x93, 0xab, 0xfd, 0x92, 0xfd, 0x1a,
+	0x1b, 0x50, 0x5d, 0xb6, 0x0f, 0x88, 0xe0, 0xca, 0x07, 0x84, 0xaf, 0x86, 0x77, 0xa1, 0x2c, 0x16,
+	0x6b, 0x4e, 0x4d, 0xef, 0xdc, 0x63, 0x6b, 0xa7, 0x0e, 0x51, 0x0a, 0x4f, 0xa4, 0xd7, 0xd9, 0xfa,
+	0x51, 0xa5, 0x92, 0xe6, 0x41, 0xbe, 0xb9, 0x60, 0x92, 0xba, 0xb9, 0x56, 0x87, 0x22, 0x24, 0x00,
+	0x7f, 0x6c, 0x2b, 0x78, 0x35, 0xd8, 0x7b, 0xe6, 0xcb, 0xa7, 0xce, 0x52, 0x9d, 0x54, 0xa7, 0xdb,
+	0x71, 0x3e, 0xbd, 0xb3, 0xbb, 0x1b, 0x67, 0x77, 0xe8, 0xe7, 0xdd, 0xee, 0xee, 0x1d, 0x52, 0x3e,
+	0xed, 0xd1, 0xdf, 0xb9, 0xdd, 0xbf, 0x00, 0x2a, 0x20, 0x3f, 0xd9, 0xd9, 0x13, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BlockSeqSinkClient is the client API for BlockSeqSink service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockSeqSinkClient interface {
+	PushBlockSeqs(ctx context.Context, opts ...grpc.CallOption) (BlockSeqSink_PushBlockSeqsClient, error)
+}
+
+type blockSeqSinkClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlockSeqSinkClient(cc *grpc.ClientConn) BlockSeqSinkClient {
+	return &blockSeqSinkClient{cc}
+}
+
+func (c *blockSeqSinkClient) PushBlockSeqs(ctx context.Context, opts ...grpc.CallOption) (BlockSeqSink_PushBlockSeqsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockSeqSink_serviceDesc.Streams[0], "/types.blockSeqSink/PushBlockSeqs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockSeqSinkPushBlockSeqsClient{stream}
+	return x, nil
+}
+
+type BlockSeqSink_PushBlockSeqsClient interface {
+	Send(*BlockSeqs) error
+	Recv() (*Reply, error)
+	grpc.ClientStream
+}
+
+type blockSeqSinkPushBlockSeqsClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockSeqSinkPushBlockSeqsClient) Send(m *BlockSeqs) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *blockSeqSinkPushBlockSeqsClient) Recv() (*Reply, error) {
+	m := new(Reply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockSeqSinkServer is the server API for BlockSeqSink service.
+type BlockSeqSinkServer interface {
+	PushBlockSeqs(BlockSeqSink_PushBlockSeqsServer) error
+}
+
+func RegisterBlockSeqSinkServer(s *grpc.Server, srv BlockSeqSinkServer) {
+	s.RegisterService(&_BlockSeqSink_serviceDesc, srv)
+}
+
+func _BlockSeqSink_PushBlockSeqs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BlockSeqSinkServer).PushBlockSeqs(&blockSeqSinkPushBlockSeqsServer{stream})
+}
+
+type BlockSeqSink_PushBlockSeqsServer interface {
+	Send(*Reply) error
+	Recv() (*BlockSeqs, error)
+	grpc.ServerStream
+}
+
+type blockSeqSinkPushBlockSeqsServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockSeqSinkPushBlockSeqsServer) Send(m *Reply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *blockSeqSinkPushBlockSeqsServer) Recv() (*BlockSeqs, error) {
+	m := new(BlockSeqs)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _BlockSeqSink_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "types.blockSeqSink",
+	HandlerType: (*BlockSeqSinkServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PushBlockSeqs",
+			Handler:       _BlockSeqSink_PushBlockSeqs_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "blockchain.proto",
 }
@@ -58,6 +58,26 @@ type MemPool struct {
 	MinTxFee           int64 `protobuf:"varint,2,opt,name=minTxFee" json:"minTxFee,omitempty"`
 	ForceAccept        bool  `protobuf:"varint,3,opt,name=forceAccept" json:"forceAccept,omitempty"`
 	MaxTxNumPerAccount int64 `protobuf:"varint,4,opt,name=maxTxNumPerAccount" json:"maxTxNumPerAccount,omitempty"`
+	// 交易日志文件, 重启之后重新加载 mempool 中的交易, 为空表示不保存
+	JournalFile string `protobuf:"bytes,5,opt,name=journalFile" json:"journalFile,omitempty"`
+	// 交易日志文件的最大值（单位：兆）
+	JournalMaxSize int64 `protobuf:"varint,6,opt,name=journalMaxSize" json:"journalMaxSize,omitempty"`
+	// 交易日志压缩的间隔（单位：秒）
+	JournalCompactInterval int64 `protobuf:"varint,7,opt,name=journalCompactInterval" json:"journalCompactInterval,omitempty"`
+	// 单个执行器的交易准入策略
+	ExecPolicies []*MempoolExecPolicy `protobuf:"bytes,8,rep,name=execPolicies" json:"execPolicies,omitempty"`
+	// manage 执行器中禁止发送交易的地址列表的配置项, 为空表示不启用
+	DenySendersKey string `protobuf:"bytes,9,opt,name=denySendersKey" json:"denySendersKey,omitempty"`
+}
+
+// MempoolExecPolicy 单个执行器的交易进入 mempool 的限制, 0 表示不限制
+// rate 为每秒进入 mempool 的交易数, minFee 只能提高 mempool 的最低手续费
+type MempoolExecPolicy struct {
+	Execer         string `protobuf:"bytes,1,opt,name=execer" json:"execer,omitempty"`
+	Rate           int64  `protobuf:"varint,2,opt,name=rate" json:"rate,omitempty"`
+	Burst          int64  `protobuf:"varint,3,opt,name=burst" json:"burst,omitempty"`
+	MinFee         int64  `protobuf:"varint,4,opt,name=minFee" json:"minFee,omitempty"`
+	MaxPayloadSize int64  `protobuf:"varint,5,opt,name=maxPayloadSize" json:"maxPayloadSize,omitempty"`
 }
 
 // Consensus 配置
@@ -109,6 +129,23 @@ type BlockChain struct {
 	IsRecordBlockSequence bool   `protobuf:"varint,11,opt,name=isRecordBlockSequence" json:"isRecordBlockSequence,omitempty"`
 	IsParaChain           bool   `protobuf:"varint,12,opt,name=isParaChain" json:"isParaChain,omitempty"`
 	EnableTxQuickIndex    bool   `protobuf:"varint,13,opt,name=enableTxQuickIndex" json:"enableTxQuickIndex,omitempty"`
+	// 新节点从其他节点同步可信高度的状态快照, 不再从创世区块开始执行
+	EnableSnapshotSync bool `protobuf:"varint,14,opt,name=enableSnapshotSync" json:"enableSnapshotSync,omitempty"`
+	// 快照的区块高度以及这个高度的区块hash
+	SnapshotHeight int64  `protobuf:"varint,15,opt,name=snapshotHeight" json:"snapshotHeight,omitempty"`
+	SnapshotHash   string `protobuf:"bytes,16,opt,name=snapshotHash" json:"snapshotHash,omitempty"`
+	// 最终确认深度, 低于 最新高度-finalizedDepth 的区块不会被回滚, 0 表示不启用
+	FinalizedDepth int64 `protobuf:"varint,17,opt,name=finalizedDepth" json:"finalizedDepth,omitempty"`
+	// 检查点, 格式为 "height:hash", 可以在各个title的默认配置中固定
+	Checkpoints []string `protobuf:"bytes,18,rep,name=checkpoints" json:"checkpoints,omitempty"`
+	// 先同步并验证区块头, 然后从多个节点并行下载区块
+	EnableHeadersFirstSync bool `protobuf:"varint,19,opt,name=enableHeadersFirstSync" json:"enableHeadersFirstSync,omitempty"`
+	// 只保留最新的 pruneKeepBlocks 个区块的区块体以及交易信息, 更早的区块只保留区块头, 0 表示不裁剪
+	PruneKeepBlocks int64 `protobuf:"varint,20,opt,name=pruneKeepBlocks" json:"pruneKeepBlocks,omitempty"`
+	// 轻节点只同步区块头, 交易和状态数据从全节点获取默克尔证明之后验证
+	EnableLightClient bool `protobuf:"varint,21,opt,name=enableLightClient" json:"enableLightClient,omitempty"`
+	// file 方式推送的订阅只能写到这个目录下, 为空时不支持 file 方式
+	PushFileDir string `protobuf:"bytes,22,opt,name=pushFileDir" json:"pushFileDir,omitempty"`
 }
 
 // P2P 配置
@@ -133,15 +170,58 @@ type P2P struct {
 
 // RPC 配置
 type RPC struct {
-	JrpcBindAddr      string   `protobuf:"bytes,1,opt,name=jrpcBindAddr" json:"jrpcBindAddr,omitempty"`
-	GrpcBindAddr      string   `protobuf:"bytes,2,opt,name=grpcBindAddr" json:"grpcBindAddr,omitempty"`
-	Whitlist          []string `protobuf:"bytes,3,rep,name=whitlist" json:"whitlist,omitempty"`
-	Whitelist         []string `protobuf:"bytes,4,rep,name=whitelist" json:"whitelist,omitempty"`
-	JrpcFuncWhitelist []string `protobuf:"bytes,5,rep,name=jrpcFuncWhitelist" json:"jrpcFuncWhitelist,omitempty"`
-	GrpcFuncWhitelist []string `protobuf:"bytes,6,rep,name=grpcFuncWhitelist" json:"grpcFuncWhitelist,omitempty"`
-	JrpcFuncBlacklist []string `protobuf:"bytes,7,rep,name=jrpcFuncBlacklist" json:"jrpcFuncBlacklist,omitempty"`
-	GrpcFuncBlacklist []string `protobuf:"bytes,8,rep,name=grpcFuncBlacklist" json:"grpcFuncBlacklist,omitempty"`
-	MainnetJrpcAddr   string   `protobuf:"bytes,9,opt,name=mainnetJrpcAddr" json:"mainnetJrpcAddr,omitempty"`
+	JrpcBindAddr      string        `protobuf:"bytes,1,opt,name=jrpcBindAddr" json:"jrpcBindAddr,omitempty"`
+	GrpcBindAddr      string        `protobuf:"bytes,2,opt,name=grpcBindAddr" json:"grpcBindAddr,omitempty"`
+	Whitlist          []string      `protobuf:"bytes,3,rep,name=whitlist" json:"whitlist,omitempty"`
+	Whitelist         []string      `protobuf:"bytes,4,rep,name=whitelist" json:"whitelist,omitempty"`
+	JrpcFuncWhitelist []string      `protobuf:"bytes,5,rep,name=jrpcFuncWhitelist" json:"jrpcFuncWhitelist,omitempty"`
+	GrpcFuncWhitelist []string      `protobuf:"bytes,6,rep,name=grpcFuncWhitelist" json:"grpcFuncWhitelist,omitempty"`
+	JrpcFuncBlacklist []string      `protobuf:"bytes,7,rep,name=jrpcFuncBlacklist" json:"jrpcFuncBlacklist,omitempty"`
+	GrpcFuncBlacklist []string      `protobuf:"bytes,8,rep,name=grpcFuncBlacklist" json:"grpcFuncBlacklist,omitempty"`
+	MainnetJrpcAddr   string        `protobuf:"bytes,9,opt,name=mainnetJrpcAddr" json:"mainnetJrpcAddr,omitempty"`
+	EnableWebsocket   bool          `protobuf:"varint,10,opt,name=enableWebsocket" json:"enableWebsocket,omitempty"`
+	RateLimit         *RPCRateLimit `protobuf:"bytes,11,opt,name=rateLimit" json:"rateLimit,omitempty"`
+	Auth              *RPCAuth      `protobuf:"bytes,12,opt,name=auth" json:"auth,omitempty"`
+	EnableTLS         bool          `protobuf:"varint,13,opt,name=enableTLS" json:"enableTLS,omitempty"`
+	CertFile          string        `protobuf:"bytes,14,opt,name=certFile" json:"certFile,omitempty"`
+	KeyFile           string        `protobuf:"bytes,15,opt,name=keyFile" json:"keyFile,omitempty"`
+	ClientCAFile      string        `protobuf:"bytes,16,opt,name=clientCAFile" json:"clientCAFile,omitempty"`
+}
+
+// RPCAuth rpc 的 token 认证配置, 支持静态的 api key 和 HMAC 签名的 jwt, 本地回环地址不需要认证
+type RPCAuth struct {
+	APIKeys        []*RPCAPIKey `protobuf:"bytes,1,rep,name=apiKeys" json:"apiKeys,omitempty"`
+	JwtSecret      string       `protobuf:"bytes,2,opt,name=jwtSecret" json:"jwtSecret,omitempty"`
+	Roles          []*RPCRole   `protobuf:"bytes,3,rep,name=roles" json:"roles,omitempty"`
+	AnonymousRoles []string     `protobuf:"bytes,4,rep,name=anonymousRoles" json:"anonymousRoles,omitempty"`
+}
+
+// RPCAPIKey api key 以及对应的角色
+type RPCAPIKey struct {
+	Key   string   `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Roles []string `protobuf:"bytes,2,rep,name=roles" json:"roles,omitempty"`
+}
+
+// RPCRole 角色可以调用的方法, "*" 表示所有方法
+type RPCRole struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Methods []string `protobuf:"bytes,2,rep,name=methods" json:"methods,omitempty"`
+}
+
+// RPCRateLimit rpc 限流配置, 速率为每秒的请求数, 0 表示不限制, 本地回环地址不受限制
+type RPCRateLimit struct {
+	IPRate            int64             `protobuf:"varint,1,opt,name=ipRate" json:"ipRate,omitempty"`
+	IPBurst           int64             `protobuf:"varint,2,opt,name=ipBurst" json:"ipBurst,omitempty"`
+	JrpcMaxConcurrent int64             `protobuf:"varint,3,opt,name=jrpcMaxConcurrent" json:"jrpcMaxConcurrent,omitempty"`
+	GrpcMaxConcurrent int64             `protobuf:"varint,4,opt,name=grpcMaxConcurrent" json:"grpcMaxConcurrent,omitempty"`
+	Methods           []*RPCMethodLimit `protobuf:"bytes,5,rep,name=methods" json:"methods,omitempty"`
+}
+
+// RPCMethodLimit 单个方法对每个ip 的限流配置
+type RPCMethodLimit struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Rate  int64  `protobuf:"varint,2,opt,name=rate" json:"rate,omitempty"`
+	Burst int64  `protobuf:"varint,3,opt,name=burst" json:"burst,omitempty"`
 }
 
 // Exec 配置
@@ -153,6 +233,7 @@ type Exec struct {
 	DisableAddrIndex bool     `protobuf:"varint,7,opt,name=disableAddrIndex" json:"disableAddrIndex,omitempty"`
 	Alias            []string `protobuf:"bytes,5,rep,name=alias" json:"alias,omitempty"`
 	SaveTokenTxList  bool     `protobuf:"varint,6,opt,name=saveTokenTxList" json:"saveTokenTxList,omitempty"`
+	EnableLogIndex   bool     `protobuf:"varint,8,opt,name=enableLogIndex" json:"enableLogIndex,omitempty"`
 }
 
 // Pprof 配置
@@ -117,6 +117,20 @@ var SystemLog = map[int64]*LogInfo{
 	TyLogGenesisDeposit:  {reflect.TypeOf(ReceiptAccountTransfer{}), "LogGenesisDeposit"},
 }
 
+//推送区块序列号任务的状态
+const (
+	SeqCBActive     = 0
+	SeqCBPaused     = 1
+	SeqCBDeadLetter = 2
+)
+
+//推送区块序列号的方式
+const (
+	SeqCBTransportHTTP = "http"
+	SeqCBTransportGRPC = "grpc"
+	SeqCBTransportFile = "file"
+)
+
 //exec type
 const (
 	ExecErr  = 0
@@ -0,0 +1,235 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import "fmt"
+
+// 错误码, 给 jsonrpc 2.0 等需要数字错误码的接口使用
+const (
+	// ErrCodeUnknown 没有注册错误码的错误
+	ErrCodeUnknown int32 = -32000
+	// ErrCodeBase chain33 系统错误码的起始值
+	ErrCodeBase int32 = 10000
+	// ErrCodePluginBase 插件自定义错误码的起始值
+	ErrCodePluginBase int32 = 20000
+)
+
+// errCodeList 中错误的错误码为 ErrCodeBase + 下标, 为了保持错误码不变, 只能在末尾追加
+var errCodeList = []error{
+	ErrTooManySeqCB,
+	ErrPushSeqPostData,
+	ErrMethodReturnType,
+	ErrMethodNotFound,
+	ErrExecBlockNil,
+	ErrNotAllow,
+	ErrCanOnlyDelTopVersion,
+	ErrPrevVersion,
+	ErrNoExecerInMavlKey,
+	ErrMavlKeyNotStartWithMavl,
+	ErrNotFound,
+	ErrBlockExec,
+	ErrCheckStateHash,
+	ErrCheckTxHash,
+	ErrReRunGenesis,
+	ErrActionNotSupport,
+	ErrQueryNotSupport,
+	ErrChannelFull,
+	ErrAmount,
+	ErrMinerIsStared,
+	ErrMinerNotStared,
+	ErrMinerNotClosed,
+	ErrNoPeer,
+	ErrExecNameNotMatch,
+	ErrChannelClosed,
+	ErrNotMinered,
+	ErrFromAddr,
+	ErrBlockHeight,
+	ErrBlockTime,
+	ErrCoinBaseExecer,
+	ErrCoinBaseTxType,
+	ErrCoinBaseExecErr,
+	ErrCoinBaseTarget,
+	ErrCoinbaseReward,
+	ErrNotAllowDeposit,
+	ErrCoinBaseIndex,
+	ErrCoinBaseTicketStatus,
+	ErrBlockNotFound,
+	ErrLogType,
+	ErrInvalidParam,
+	ErrInvalidAddress,
+	ErrNotInited,
+	ErrStartBigThanEnd,
+	ErrToAddrNotSameToExecAddr,
+	ErrTypeAsset,
+	ErrEmpty,
+	ErrSendSameToRecv,
+	ErrExecNameNotAllow,
+	ErrLocalDBPerfix,
+	ErrTimeout,
+	ErrBlockHeaderDifficulty,
+	ErrNoTx,
+	ErrTxExist,
+	ErrManyTx,
+	ErrDupTx,
+	ErrMemFull,
+	ErrNoBalance,
+	ErrBalanceLessThanTenTimesFee,
+	ErrTxExpire,
+	ErrHeaderNotSet,
+	ErrSign,
+	ErrFeeTooLow,
+	ErrEmptyTx,
+	ErrTxFeeTooLow,
+	ErrTxMsgSizeTooBig,
+	ErrFutureBlock,
+	ErrHashNotFound,
+	ErrTxDup,
+	ErrNotSync,
+	ErrSize,
+	ErrHashNotExist,
+	ErrHeightNotExist,
+	ErrTxNotExist,
+	ErrAddrNotExist,
+	ErrStartHeight,
+	ErrEndLessThanStartHeight,
+	ErrClientNotBindQueue,
+	ErrContinueBack,
+	ErrUnmarshal,
+	ErrMarshal,
+	ErrBlockExist,
+	ErrParentBlockNoExist,
+	ErrBlockHeightNoMatch,
+	ErrParentTdNoExist,
+	ErrBlockHashNoMatch,
+	ErrIsClosed,
+	ErrDecode,
+	ErrNotRollBack,
+	ErrPeerInfoIsNil,
+	ErrWalletIsLocked,
+	ErrSaveSeedFirst,
+	ErrUnLockFirst,
+	ErrLabelHasUsed,
+	ErrPrivkeyExist,
+	ErrPrivkey,
+	ErrInsufficientBalance,
+	ErrInsufficientTokenBal,
+	ErrInsuffSellOrder,
+	ErrVerifyOldpasswdFail,
+	ErrInputPassword,
+	ErrSeedlang,
+	ErrSeedNotExist,
+	ErrSubPubKeyVerifyFail,
+	ErrLabelNotExist,
+	ErrAccountNotExist,
+	ErrSeedExist,
+	ErrNotSupport,
+	ErrSeedWordNum,
+	ErrPubKeyLen,
+	ErrPrivateKeyLen,
+	ErrSeedWord,
+	ErrNoPrivKeyOrAddr,
+	ErrNewWalletFromSeed,
+	ErrNewKeyPair,
+	ErrPrivkeyToPub,
+	ErrOnlyTicketUnLocked,
+	ErrNewCrypto,
+	ErrFromHex,
+	ErrPrivKeyFromBytes,
+	ErrParentHash,
+	ErrPing,
+	ErrVersion,
+	ErrStreamPing,
+	ErrPeerStop,
+	ErrBlockSize,
+	ErrTxGroupIndex,
+	ErrTxGroupFormat,
+	ErrTxGroupCountLessThanTwo,
+	ErrTxGroupHeader,
+	ErrTxGroupNext,
+	ErrTxGroupCountBigThanMaxSize,
+	ErrTxGroupEmpty,
+	ErrTxGroupCount,
+	ErrTxGroupFeeNotZero,
+	ErrNomalTx,
+	ErrUnknowDriver,
+	ErrUnRegistedDriver,
+	ErrSymbolNameNotAllow,
+	ErrTxGroupNotSupport,
+	ErrNotAllowKey,
+	ErrNotAllowMemSetKey,
+	ErrDataBaseDamage,
+	ErrIndex,
+	ErrTxGroupParaCount,
+	ErrInvalidMainnetRPCAddr,
+	ErrDBFlag,
+	ErrLocalPrefix,
+	ErrLocalKeyLen,
+	ErrCloneForkFrom,
+	ErrCloneForkToExist,
+	ErrQueryThistIsNotSet,
+	ErrRateLimited,
+	ErrConcurrencyLimited,
+	ErrInvalidToken,
+	ErrTokenExpired,
+	ErrStateHashPruned,
+	ErrSeqCBNotFound,
+	ErrSnapshotProof,
+	ErrSnapshotHash,
+	ErrArchiveFormat,
+	ErrArchiveChecksum,
+	ErrReorgBelowFinalized,
+	ErrCheckpointMismatch,
+	ErrBlockPruned,
+	ErrTxPruned,
+	ErrInvalidTxProof,
+	ErrInvalidStateProof,
+	ErrReplaceFeeTooLow,
+	ErrJournalFormat,
+	ErrTxRateLimited,
+	ErrExecFeeTooLow,
+	ErrTxPayloadTooBig,
+	ErrSenderDenied,
+	ErrJournalFull,
+	ErrSnapshotNoSequence,
+	ErrPolicyNotReady,
+}
+
+var (
+	errCodes    = make(map[string]int32)
+	errCodeUsed = make(map[int32]bool)
+)
+
+func init() {
+	for i, err := range errCodeList {
+		registerErrCode(ErrCodeBase+int32(i), err)
+	}
+}
+
+func registerErrCode(code int32, err error) {
+	if errCodeUsed[code] {
+		panic(fmt.Sprintf("error code %d registered twice", code))
+	}
+	errCodeUsed[code] = true
+	//错误信息相同的错误使用第一个注册的错误码
+	if _, ok := errCodes[err.Error()]; !ok {
+		errCodes[err.Error()] = code
+	}
+}
+
+// RegisterErrCode 插件注册自定义错误的错误码, code 必须大于等于 ErrCodePluginBase
+func RegisterErrCode(code int32, err error) {
+	if code < ErrCodePluginBase {
+		panic(fmt.Sprintf("plugin error code %d must not less than %d", code, ErrCodePluginBase))
+	}
+	registerErrCode(code, err)
+}
+
+// GetErrCode 根据错误信息获取错误码, 没有注册的返回 ErrCodeUnknown
+func GetErrCode(errstr string) int32 {
+	if code, ok := errCodes[errstr]; ok {
+		return code
+	}
+	return ErrCodeUnknown
+}
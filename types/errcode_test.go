@@ -0,0 +1,24 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrCode(t *testing.T) {
+	assert.Equal(t, ErrCodeBase, GetErrCode(ErrTooManySeqCB.Error()))
+	assert.Equal(t, ErrCodeBase+1, GetErrCode(ErrPushSeqPostData.Error()))
+	assert.Equal(t, ErrCodeUnknown, GetErrCode("some error"))
+
+	errPlugin := errors.New("ErrTestPlugin")
+	RegisterErrCode(ErrCodePluginBase+999, errPlugin)
+	assert.Equal(t, ErrCodePluginBase+999, GetErrCode(errPlugin.Error()))
+	assert.Panics(t, func() { RegisterErrCode(ErrCodePluginBase+999, errors.New("ErrTestPlugin2")) })
+	assert.Panics(t, func() { RegisterErrCode(ErrCodeBase, errors.New("ErrTestPlugin3")) })
+}
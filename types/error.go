@@ -173,4 +173,30 @@ var (
 	ErrCloneForkFrom      = errors.New("ErrCloneForkFrom")
 	ErrCloneForkToExist   = errors.New("ErrCloneForkToExist")
 	ErrQueryThistIsNotSet = errors.New("ErrQueryThistIsNotSet")
+
+	ErrRateLimited         = errors.New("ErrRateLimited")
+	ErrConcurrencyLimited  = errors.New("ErrConcurrencyLimited")
+	ErrInvalidToken        = errors.New("ErrInvalidToken")
+	ErrTokenExpired        = errors.New("ErrTokenExpired")
+	ErrStateHashPruned     = errors.New("ErrStateHashPruned")
+	ErrSeqCBNotFound       = errors.New("ErrSeqCBNotFound")
+	ErrSnapshotProof       = errors.New("ErrSnapshotProof")
+	ErrSnapshotHash        = errors.New("ErrSnapshotHash")
+	ErrArchiveFormat       = errors.New("ErrArchiveFormat")
+	ErrArchiveChecksum     = errors.New("ErrArchiveChecksum")
+	ErrReorgBelowFinalized = errors.New("ErrReorgBelowFinalized")
+	ErrCheckpointMismatch  = errors.New("ErrCheckpointMismatch")
+	ErrBlockPruned         = errors.New("ErrBlockPruned")
+	ErrTxPruned            = errors.New("ErrTxPruned")
+	ErrInvalidTxProof      = errors.New("ErrInvalidTxProof")
+	ErrInvalidStateProof   = errors.New("ErrInvalidStateProof")
+	ErrReplaceFeeTooLow    = errors.New("ErrReplaceFeeTooLow")
+	ErrJournalFormat       = errors.New("ErrJournalFormat")
+	ErrTxRateLimited       = errors.New("ErrTxRateLimited")
+	ErrExecFeeTooLow       = errors.New("ErrExecFeeTooLow")
+	ErrTxPayloadTooBig     = errors.New("ErrTxPayloadTooBig")
+	ErrSenderDenied        = errors.New("ErrSenderDenied")
+	ErrJournalFull         = errors.New("ErrJournalFull")
+	ErrSnapshotNoSequence  = errors.New("ErrSnapshotNoSequence")
+	ErrPolicyNotReady      = errors.New("ErrPolicyNotReady")
 )
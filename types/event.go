@@ -136,6 +136,33 @@ const (
 	EventWalletCreateTx          = 129
 	EventStoreList               = 130
 	EventStoreListReply          = 131
+	EventListBlockSeqCB          = 132
+	EventSetBlockSeqCBStatus     = 133
+	EventDelBlockSeqCB           = 134
+	EventStoreGetSnapshot        = 135
+	EventStateSnapshotChunk      = 136
+	EventStoreImportSnapshot     = 137
+	EventFetchStateSnapshot      = 138
+	EventFetchPeerHeaders        = 139
+	EventGetCheckpoint           = 140
+	EventReplyCheckpoint         = 141
+	EventFetchPeerBlocks         = 142
+	EventFetchTxProof            = 143
+	EventFetchStateProof         = 144
+	EventStoreGetProof           = 145
+	EventStateProof              = 146
+	EventGetStateProof           = 147
+	EventGetReorgHistory         = 148
+	EventReplyReorgHistory       = 149
+	EventGetMempoolTx            = 150
+	EventReplyMempoolTx          = 151
+	EventEvictMempoolTx          = 152
+	EventGetMempoolStat          = 153
+	EventReplyMempoolStat        = 154
+	EventStoreHasRoot            = 155
+	EventCheckHeaders            = 156
+	EventGetPruneHeight          = 157
+	EventGetMempoolTxs           = 158
 	//exec
 	EventBlockChainQuery = 212
 	EventConsensusQuery  = 213
@@ -266,6 +293,33 @@ var eventName = map[int]string{
 	126: "EventAddParaChainBlockDetail",
 	127: "EventGetSeqByHash",
 	128: "EventLocalPrefixCount",
+	132: "EventListBlockSeqCB",
+	133: "EventSetBlockSeqCBStatus",
+	134: "EventDelBlockSeqCB",
+	135: "EventStoreGetSnapshot",
+	136: "EventStateSnapshotChunk",
+	137: "EventStoreImportSnapshot",
+	138: "EventFetchStateSnapshot",
+	139: "EventFetchPeerHeaders",
+	140: "EventGetCheckpoint",
+	141: "EventReplyCheckpoint",
+	142: "EventFetchPeerBlocks",
+	143: "EventFetchTxProof",
+	144: "EventFetchStateProof",
+	145: "EventStoreGetProof",
+	146: "EventStateProof",
+	147: "EventGetStateProof",
+	148: "EventGetReorgHistory",
+	149: "EventReplyReorgHistory",
+	150: "EventGetMempoolTx",
+	151: "EventReplyMempoolTx",
+	152: "EventEvictMempoolTx",
+	153: "EventGetMempoolStat",
+	154: "EventReplyMempoolStat",
+	155: "EventStoreHasRoot",
+	156: "EventCheckHeaders",
+	157: "EventGetPruneHeight",
+	158: "EventGetMempoolTxs",
 	//todo: 这个可能后面会删除
 	EventWalletCreateTx: "EventWalletCreateTx",
 	EventStoreList:      "EventStoreList",
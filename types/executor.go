@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -73,6 +74,16 @@ func RegistorExecutor(exec string, util ExecutorType) {
 	}
 }
 
+// ListExecutorType 返回所有注册的执行器名称, 按照名称排序
+func ListExecutorType() []string {
+	names := make([]string, 0, len(executorMap))
+	for name := range executorMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // LoadExecutorType 加载执行器
 func LoadExecutorType(execstr string) ExecutorType {
 	//尽可能的加载执行器
@@ -256,6 +267,8 @@ type ExecutorType interface {
 	GetFuncMap() map[string]reflect.Method
 	GetRPCFuncMap() map[string]reflect.Method
 	GetExecFuncMap() map[string]reflect.Method
+	//Query_ 接口名称(不含前缀) -> 函数类型
+	GetQueryMap() map[string]reflect.Type
 	CreateTransaction(action string, data Message) (*Transaction, error)
 	// collect assets the tx deal with
 	GetAssets(tx *Transaction) ([]*Asset, error)
@@ -362,6 +375,11 @@ func (base *ExecTypeBase) GetRPCFuncMap() map[string]reflect.Method {
 	return base.rpclist
 }
 
+// GetQueryMap  获取查询接口的列表
+func (base *ExecTypeBase) GetQueryMap() map[string]reflect.Type {
+	return base.queryMap
+}
+
 // GetExecFuncMap  获取执行交易的接口列表
 func (base *ExecTypeBase) GetExecFuncMap() map[string]reflect.Method {
 	return base.execFuncList
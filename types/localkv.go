@@ -19,6 +19,13 @@ var (
 	TxAddrHash        = []byte("TxAddrHash:")
 	TxAddrDirHash     = []byte("TxAddrDirHash:")
 	AddrTxsCount      = []byte("AddrTxsCount:")
+	FlagLogIndex      = []byte("FLAG:logIndexFlag")
+	LogIndex          = []byte("LogIndex:")
+	LogIndexTy        = []byte("LogIndexTy:")
+	TxAddrIndex       = []byte("TxAddrIndex:")
+	TxAddrExec        = []byte("TxAddrExec:")
+	TxAddrAction      = []byte("TxAddrAction:")
+	TxAddrCount       = []byte("TxAddrCount:")
 )
 
 // GetLocalDBKeyList 获取localdb的key列表
@@ -58,6 +65,54 @@ func CalcAddrTxsCountKey(addr string) []byte {
 	return append(AddrTxsCount, []byte(addr)...)
 }
 
+//CalcTxAddrIndexKey 地址交易的过滤索引, key=TxAddrIndex:addr:height*100000+index
+func CalcTxAddrIndexKey(addr string, heightindex string) []byte {
+	return append(TxAddrIndex, []byte(fmt.Sprintf("%s:%s", addr, heightindex))...)
+}
+
+//CalcTxAddrExecKey 地址某个执行器的交易索引, key=TxAddrExec:addr:execer:height*100000+index
+func CalcTxAddrExecKey(addr, execer string, heightindex string) []byte {
+	return append(TxAddrExec, []byte(fmt.Sprintf("%s:%s:%s", addr, execer, heightindex))...)
+}
+
+//CalcTxAddrActionKey 地址某个执行器某个 action 的交易索引, key=TxAddrAction:addr:execer:action:height*100000+index
+func CalcTxAddrActionKey(addr, execer, action string, heightindex string) []byte {
+	return append(TxAddrAction, []byte(fmt.Sprintf("%s:%s:%s:%s", addr, execer, action, heightindex))...)
+}
+
+//CalcTxAddrCountKey 地址按照 执行器/action/方向/回执类型 统计的交易数, key=TxAddrCount:addr:execer:action:direction:ty
+func CalcTxAddrCountKey(addr, execer, action string, direction, ty int32) []byte {
+	return append(TxAddrCount, []byte(fmt.Sprintf("%s:%s:%s:%d:%d", addr, execer, action, direction, ty))...)
+}
+
+//CalcTxAddrCountPrefix 统计交易数的前缀, execer 或者 action 为空时不加入前缀
+func CalcTxAddrCountPrefix(addr, execer, action string) []byte {
+	prefix := append(append([]byte{}, TxAddrCount...), []byte(addr+":")...)
+	if execer == "" {
+		return prefix
+	}
+	prefix = append(prefix, []byte(execer+":")...)
+	if action == "" {
+		return prefix
+	}
+	return append(prefix, []byte(action+":")...)
+}
+
+//CalcLogIndexKey 执行器的回执日志索引, key=LogIndex:execer:height*100000+index:logindex
+func CalcLogIndexKey(execer string, position string) []byte {
+	return append(LogIndex, []byte(fmt.Sprintf("%s:%s", execer, position))...)
+}
+
+//CalcLogIndexTyKey 执行器某一类回执日志的索引, key=LogIndexTy:execer:ty:height*100000+index:logindex
+func CalcLogIndexTyKey(execer string, ty int32, position string) []byte {
+	return append(LogIndexTy, []byte(fmt.Sprintf("%s:%d:%s", execer, ty, position))...)
+}
+
+//LogIndexPosition 回执日志在链上的位置, 按照字符串排序和按照位置排序一致
+func LogIndexPosition(height int64, txIndex, logIndex int32) string {
+	return fmt.Sprintf("%018d:%05d", height*MaxTxsPerBlock+int64(txIndex), logIndex)
+}
+
 //StatisticFlag 用于记录统计的key
 func StatisticFlag() []byte {
 	return []byte("Statistics:Flag")
@@ -192,6 +192,36 @@ func (_m *Chain33Client) DumpPrivkey(ctx context.Context, in *types.ReqString, o
 	return r0, r1
 }
 
+// EvictMempoolTx provides a mock function with given fields: ctx, in, opts
+func (_m *Chain33Client) EvictMempoolTx(ctx context.Context, in *types.ReqHash, opts ...grpc.CallOption) (*types.Reply, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *types.Reply
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ReqHash, ...grpc.CallOption) *types.Reply); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Reply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ReqHash, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecWallet provides a mock function with given fields: ctx, in, opts
 func (_m *Chain33Client) ExecWallet(ctx context.Context, in *types.ChainExecutor, opts ...grpc.CallOption) (*types.Reply, error) {
 	_va := make([]interface{}, len(opts))
@@ -1422,6 +1452,36 @@ func (_m *Chain33Client) SignRawTx(ctx context.Context, in *types.ReqSignRawTx,
 	return r0, r1
 }
 
+// SubscribeBlockSequence provides a mock function with given fields: ctx, in, opts
+func (_m *Chain33Client) SubscribeBlockSequence(ctx context.Context, in *types.ReqBlockSeqStream, opts ...grpc.CallOption) (types.Chain33_SubscribeBlockSequenceClient, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 types.Chain33_SubscribeBlockSequenceClient
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ReqBlockSeqStream, ...grpc.CallOption) types.Chain33_SubscribeBlockSequenceClient); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Chain33_SubscribeBlockSequenceClient)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.ReqBlockSeqStream, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnLock provides a mock function with given fields: ctx, in, opts
 func (_m *Chain33Client) UnLock(ctx context.Context, in *types.WalletUnLock, opts ...grpc.CallOption) (*types.Reply, error) {
 	_va := make([]interface{}, len(opts))
@@ -23,7 +23,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
-//*
+// *
 // 请求获取远程节点的节点信息
 type P2PGetPeerInfo struct {
 	/// p2p版本
@@ -65,7 +65,7 @@ func (m *P2PGetPeerInfo) GetVersion() int32 {
 	return 0
 }
 
-//*
+// *
 // 节点信息
 type P2PPeerInfo struct {
 	///节点的IP地址
@@ -77,7 +77,9 @@ type P2PPeerInfo struct {
 	/// mempool 的大小
 	MempoolSize int32 `protobuf:"varint,4,opt,name=mempoolSize,proto3" json:"mempoolSize,omitempty"`
 	///节点当前高度头部数据
-	Header               *Header  `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"`
+	Header *Header `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"`
+	///低于这个高度的区块已经裁剪, 不能提供下载
+	PruneHeight          int64    `protobuf:"varint,6,opt,name=pruneHeight,proto3" json:"pruneHeight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -143,7 +145,14 @@ func (m *P2PPeerInfo) GetHeader() *Header {
 	return nil
 }
 
-//*
+func (m *P2PPeerInfo) GetPruneHeight() int64 {
+	if m != nil {
+		return m.PruneHeight
+	}
+	return 0
+}
+
+// *
 // p2p节点间发送版本数据结构
 type P2PVersion struct {
 	///当前版本
@@ -248,7 +257,7 @@ func (m *P2PVersion) GetStartHeight() int64 {
 	return 0
 }
 
-//*
+// *
 // P2P 版本返回
 type P2PVerAck struct {
 	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -305,7 +314,7 @@ func (m *P2PVerAck) GetNonce() int64 {
 	return 0
 }
 
-//*
+// *
 // P2P 心跳包
 type P2PPing struct {
 	///随机数
@@ -374,7 +383,7 @@ func (m *P2PPing) GetSign() *Signature {
 	return nil
 }
 
-//*
+// *
 // 心跳返回包
 type P2PPong struct {
 	Nonce                int64    `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
@@ -415,7 +424,7 @@ func (m *P2PPong) GetNonce() int64 {
 	return 0
 }
 
-//*
+// *
 // 获取对方节点所连接的其他节点地址的请求包
 type P2PGetAddr struct {
 	Nonce                int64    `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
@@ -456,7 +465,7 @@ func (m *P2PGetAddr) GetNonce() int64 {
 	return 0
 }
 
-//*
+// *
 // 返回请求地址列表的社保
 type P2PAddr struct {
 	Nonce int64 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
@@ -553,7 +562,7 @@ func (m *P2PAddrList) GetPeerinfo() []*P2PPeerInfo {
 	return nil
 }
 
-//*
+// *
 // 节点外网信息
 type P2PExternalInfo struct {
 	///节点的外网地址
@@ -604,7 +613,7 @@ func (m *P2PExternalInfo) GetIsoutside() bool {
 	return false
 }
 
-//*
+// *
 // 获取区间区块
 type P2PGetBlocks struct {
 	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -661,7 +670,7 @@ func (m *P2PGetBlocks) GetEndHeight() int64 {
 	return 0
 }
 
-//*
+// *
 // 获取mempool
 type P2PGetMempool struct {
 	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -800,7 +809,7 @@ func (m *Inventory) GetHeight() int64 {
 	return 0
 }
 
-//*
+// *
 // 通过invs 下载数据
 type P2PGetData struct {
 	/// p2p版本
@@ -851,7 +860,7 @@ func (m *P2PGetData) GetInvs() []*Inventory {
 	return nil
 }
 
-//*
+// *
 // p2p 发送交易协议
 type P2PTx struct {
 	Tx                   *Transaction `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
@@ -892,7 +901,7 @@ func (m *P2PTx) GetTx() *Transaction {
 	return nil
 }
 
-//*
+// *
 // p2p 发送区块协议
 type P2PBlock struct {
 	Block                *Block   `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
@@ -933,7 +942,7 @@ func (m *P2PBlock) GetBlock() *Block {
 	return nil
 }
 
-//*
+// *
 // p2p 协议和软件版本
 type Versions struct {
 	P2Pversion           int32    `protobuf:"varint,1,opt,name=p2pversion,proto3" json:"p2pversion,omitempty"`
@@ -990,7 +999,7 @@ func (m *Versions) GetPeername() string {
 	return ""
 }
 
-//*
+// *
 // p2p 广播数据协议
 type BroadCastData struct {
 	// Types that are valid to be assigned to Value:
@@ -998,6 +1007,8 @@ type BroadCastData struct {
 	//	*BroadCastData_Block
 	//	*BroadCastData_Ping
 	//	*BroadCastData_Version
+	//	*BroadCastData_Invs
+	//	*BroadCastData_GetData
 	Value                isBroadCastData_Value `protobuf_oneof:"value"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
@@ -1049,6 +1060,14 @@ type BroadCastData_Version struct {
 	Version *Versions `protobuf:"bytes,4,opt,name=version,proto3,oneof"`
 }
 
+type BroadCastData_Invs struct {
+	Invs *P2PInv `protobuf:"bytes,5,opt,name=invs,proto3,oneof"`
+}
+
+type BroadCastData_GetData struct {
+	GetData *P2PGetData `protobuf:"bytes,6,opt,name=getData,proto3,oneof"`
+}
+
 func (*BroadCastData_Tx) isBroadCastData_Value() {}
 
 func (*BroadCastData_Block) isBroadCastData_Value() {}
@@ -1057,6 +1076,10 @@ func (*BroadCastData_Ping) isBroadCastData_Value() {}
 
 func (*BroadCastData_Version) isBroadCastData_Value() {}
 
+func (*BroadCastData_Invs) isBroadCastData_Value() {}
+
+func (*BroadCastData_GetData) isBroadCastData_Value() {}
+
 func (m *BroadCastData) GetValue() isBroadCastData_Value {
 	if m != nil {
 		return m.Value
@@ -1092,6 +1115,20 @@ func (m *BroadCastData) GetVersion() *Versions {
 	return nil
 }
 
+func (m *BroadCastData) GetInvs() *P2PInv {
+	if x, ok := m.GetValue().(*BroadCastData_Invs); ok {
+		return x.Invs
+	}
+	return nil
+}
+
+func (m *BroadCastData) GetGetData() *P2PGetData {
+	if x, ok := m.GetValue().(*BroadCastData_GetData); ok {
+		return x.GetData
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*BroadCastData) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _BroadCastData_OneofMarshaler, _BroadCastData_OneofUnmarshaler, _BroadCastData_OneofSizer, []interface{}{
@@ -1099,6 +1136,8 @@ func (*BroadCastData) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer)
 		(*BroadCastData_Block)(nil),
 		(*BroadCastData_Ping)(nil),
 		(*BroadCastData_Version)(nil),
+		(*BroadCastData_Invs)(nil),
+		(*BroadCastData_GetData)(nil),
 	}
 }
 
@@ -1126,6 +1165,16 @@ func _BroadCastData_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Version); err != nil {
 			return err
 		}
+	case *BroadCastData_Invs:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Invs); err != nil {
+			return err
+		}
+	case *BroadCastData_GetData:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.GetData); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("BroadCastData.Value has unexpected type %T", x)
@@ -1168,6 +1217,22 @@ func _BroadCastData_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.
 		err := b.DecodeMessage(msg)
 		m.Value = &BroadCastData_Version{msg}
 		return true, err
+	case 5: // value.invs
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(P2PInv)
+		err := b.DecodeMessage(msg)
+		m.Value = &BroadCastData_Invs{msg}
+		return true, err
+	case 6: // value.getData
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(P2PGetData)
+		err := b.DecodeMessage(msg)
+		m.Value = &BroadCastData_GetData{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1197,6 +1262,16 @@ func _BroadCastData_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *BroadCastData_Invs:
+		s := proto.Size(x.Invs)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *BroadCastData_GetData:
+		s := proto.Size(x.GetData)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1204,7 +1279,7 @@ func _BroadCastData_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-//*
+// *
 // p2p 获取区块区间头部信息协议
 type P2PGetHeaders struct {
 	Version              int32    `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -1261,7 +1336,7 @@ func (m *P2PGetHeaders) GetEndHeight() int64 {
 	return 0
 }
 
-//*
+// *
 // p2p 区块头传输协议
 type P2PHeaders struct {
 	Headers              []*Header `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
@@ -1302,7 +1377,7 @@ func (m *P2PHeaders) GetHeaders() []*Header {
 	return nil
 }
 
-//*
+// *
 // inv 请求协议
 type InvData struct {
 	// Types that are valid to be assigned to Value:
@@ -1458,7 +1533,7 @@ func _InvData_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
-//*
+// *
 // inv 返回数据
 type InvDatas struct {
 	Items                []*InvData `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -1499,7 +1574,7 @@ func (m *InvDatas) GetItems() []*InvData {
 	return nil
 }
 
-//*
+// *
 // peer 信息
 type Peer struct {
 	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
@@ -1508,6 +1583,7 @@ type Peer struct {
 	Self                 bool     `protobuf:"varint,4,opt,name=self,proto3" json:"self,omitempty"`
 	MempoolSize          int32    `protobuf:"varint,5,opt,name=mempoolSize,proto3" json:"mempoolSize,omitempty"`
 	Header               *Header  `protobuf:"bytes,6,opt,name=header,proto3" json:"header,omitempty"`
+	PruneHeight          int64    `protobuf:"varint,7,opt,name=pruneHeight,proto3" json:"pruneHeight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1580,7 +1656,14 @@ func (m *Peer) GetHeader() *Header {
 	return nil
 }
 
-//*
+func (m *Peer) GetPruneHeight() int64 {
+	if m != nil {
+		return m.PruneHeight
+	}
+	return 0
+}
+
+// *
 // peer 列表
 type PeerList struct {
 	Peers                []*Peer  `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
@@ -1621,8 +1704,8 @@ func (m *PeerList) GetPeers() []*Peer {
 	return nil
 }
 
-//*
-//当前节点的网络信息
+// *
+// 当前节点的网络信息
 type NodeNetInfo struct {
 	Externaladdr         string   `protobuf:"bytes,1,opt,name=externaladdr,proto3" json:"externaladdr,omitempty"`
 	Localaddr            string   `protobuf:"bytes,2,opt,name=localaddr,proto3" json:"localaddr,omitempty"`
@@ -1838,88 +1921,94 @@ func init() {
 func init() { proto.RegisterFile("p2p.proto", fileDescriptor_e7fdddb109e6467a) }
 
 var fileDescriptor_e7fdddb109e6467a = []byte{
-	// 1294 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xcf, 0x72, 0x1b, 0x45,
-	0x13, 0xdf, 0xd5, 0x1f, 0x4b, 0x6a, 0xd9, 0xb2, 0x33, 0x5f, 0xbe, 0xaf, 0x54, 0xaa, 0x7c, 0x49,
-	0x98, 0x0a, 0xc4, 0x90, 0x8a, 0x92, 0xac, 0x20, 0x54, 0x11, 0x2e, 0x76, 0x80, 0xd8, 0x55, 0x21,
-	0xb5, 0xb5, 0x32, 0x1c, 0xb8, 0xad, 0x57, 0x63, 0x69, 0x2a, 0xd2, 0xcc, 0xb2, 0x3b, 0x52, 0xd9,
-	0xdc, 0xb9, 0x71, 0xe2, 0x05, 0x38, 0xf0, 0x0a, 0x3c, 0x16, 0x0f, 0x41, 0x4d, 0xef, 0xcc, 0xfe,
-	0x91, 0x64, 0x1d, 0xa0, 0xb8, 0x6d, 0xff, 0xba, 0x7b, 0xfa, 0x7f, 0xb7, 0x04, 0x9d, 0xd8, 0x8b,
-	0x87, 0x71, 0x22, 0x95, 0x24, 0x4d, 0x75, 0x13, 0xb3, 0x74, 0x70, 0x47, 0x25, 0xa1, 0x48, 0xc3,
-	0x48, 0x71, 0x29, 0x32, 0xce, 0x60, 0x3f, 0x92, 0x8b, 0x45, 0x4e, 0x1d, 0x5d, 0xce, 0x65, 0xf4,
-	0x3e, 0x9a, 0x85, 0xdc, 0x20, 0xf4, 0x13, 0xe8, 0xf9, 0x9e, 0xff, 0x86, 0x29, 0x9f, 0xb1, 0xe4,
-	0x5c, 0x5c, 0x49, 0xd2, 0x87, 0xd6, 0x8a, 0x25, 0x29, 0x97, 0xa2, 0xef, 0x3e, 0x74, 0x8f, 0x9b,
-	0x81, 0x25, 0xe9, 0xaf, 0x2e, 0x74, 0x7d, 0xcf, 0xcf, 0x25, 0x09, 0x34, 0xc2, 0xc9, 0x24, 0x41,
-	0xb1, 0x4e, 0x80, 0xdf, 0x1a, 0x8b, 0x65, 0xa2, 0xfa, 0x35, 0x54, 0xc5, 0x6f, 0x8d, 0x89, 0x70,
-	0xc1, 0xfa, 0xf5, 0x4c, 0x4e, 0x7f, 0x93, 0x87, 0xd0, 0x5d, 0xb0, 0x45, 0x2c, 0xe5, 0x7c, 0xcc,
-	0x7f, 0x62, 0xfd, 0x06, 0x8a, 0x97, 0x21, 0xf2, 0x21, 0xec, 0xcd, 0x58, 0x38, 0x61, 0x49, 0xbf,
-	0xf9, 0xd0, 0x3d, 0xee, 0x7a, 0x07, 0x43, 0x0c, 0x72, 0x78, 0x86, 0x60, 0x60, 0x98, 0xf4, 0x4f,
-	0x17, 0xc0, 0xf7, 0xfc, 0xef, 0x33, 0x1f, 0x6f, 0xf7, 0x5e, 0x73, 0x52, 0x96, 0xac, 0x78, 0xc4,
-	0xd0, 0xb9, 0x7a, 0x60, 0x49, 0x72, 0x0f, 0x3a, 0x8a, 0x2f, 0x58, 0xaa, 0xc2, 0x45, 0x8c, 0x4e,
-	0xd6, 0x83, 0x02, 0x20, 0x03, 0x68, 0xeb, 0xc8, 0x02, 0x16, 0xad, 0xd0, 0xcd, 0x4e, 0x90, 0xd3,
-	0x96, 0xf7, 0x4d, 0x22, 0x17, 0xe8, 0xa5, 0xe1, 0x69, 0x9a, 0xdc, 0x85, 0xa6, 0x90, 0x22, 0x62,
-	0xfd, 0x3d, 0x7c, 0x31, 0x23, 0xb4, 0xad, 0x65, 0xca, 0x92, 0x93, 0x29, 0x13, 0xaa, 0xdf, 0x42,
-	0x95, 0x02, 0xd0, 0x59, 0x49, 0x55, 0x98, 0xa8, 0x33, 0xc6, 0xa7, 0x33, 0xd5, 0x6f, 0xa3, 0x66,
-	0x19, 0xa2, 0xdf, 0x41, 0x27, 0x8b, 0xf6, 0x24, 0x7a, 0xff, 0xb7, 0x82, 0xcd, 0xdd, 0xaa, 0x97,
-	0xdc, 0xa2, 0x0b, 0x68, 0xe9, 0xca, 0x72, 0x31, 0x2d, 0x04, 0xdc, 0xb2, 0xdf, 0xb6, 0xd6, 0xb5,
-	0x2d, 0xb5, 0xae, 0x97, 0x6a, 0xfd, 0x08, 0x1a, 0x29, 0x9f, 0x0a, 0xcc, 0x54, 0xd7, 0x3b, 0x32,
-	0x35, 0x1b, 0xf3, 0xa9, 0x08, 0xd5, 0x32, 0x61, 0x01, 0x72, 0xe9, 0x83, 0xcc, 0x9c, 0xbc, 0xcd,
-	0x1c, 0xa5, 0x58, 0xd4, 0x37, 0x4c, 0x9d, 0x68, 0x43, 0xdb, 0x65, 0x5e, 0xe1, 0x23, 0xb7, 0x0b,
-	0xd8, 0xea, 0xcc, 0x79, 0xaa, 0xfb, 0xb1, 0x6e, 0xab, 0xa3, 0x69, 0x3a, 0xc6, 0x56, 0xd6, 0xca,
-	0x6f, 0x79, 0xaa, 0x6e, 0x79, 0x60, 0x08, 0xed, 0x98, 0xb1, 0x84, 0x8b, 0x2b, 0x89, 0x0f, 0x74,
-	0x3d, 0x62, 0x02, 0x2a, 0x8d, 0x41, 0x90, 0xcb, 0xd0, 0xd7, 0x70, 0xe8, 0x7b, 0xfe, 0xd7, 0xd7,
-	0x8a, 0x25, 0x22, 0x9c, 0xdf, 0x3a, 0x23, 0xf7, 0xa0, 0xc3, 0x53, 0xb9, 0x54, 0x29, 0x9f, 0x64,
-	0xe5, 0x69, 0x07, 0x05, 0x40, 0x67, 0xb0, 0x9f, 0x85, 0x7e, 0xaa, 0x67, 0x35, 0xdd, 0x51, 0xe4,
-	0xb5, 0x6e, 0xa9, 0x6d, 0x74, 0x8b, 0xb6, 0xc4, 0xc4, 0xc4, 0xf0, 0x4d, 0x67, 0xe7, 0x00, 0xfd,
-	0x18, 0x0e, 0x32, 0x4b, 0xdf, 0x66, 0x63, 0xb7, 0x63, 0xf4, 0x87, 0xb0, 0xe7, 0x7b, 0xfe, 0xb9,
-	0x58, 0xe9, 0x02, 0x73, 0xb1, 0x4a, 0xfb, 0x2e, 0xe6, 0xc3, 0x16, 0xf8, 0x5c, 0xac, 0x98, 0x50,
-	0x32, 0xb9, 0x09, 0x90, 0x4b, 0xdf, 0x40, 0x27, 0x87, 0x48, 0x0f, 0x6a, 0xea, 0xc6, 0xbc, 0x58,
-	0x53, 0x37, 0x3a, 0x27, 0xb3, 0x30, 0x9d, 0xa1, 0xc3, 0xfb, 0x01, 0x7e, 0x93, 0xff, 0xe9, 0x69,
-	0x2f, 0xb9, 0x69, 0x28, 0xfa, 0xd6, 0x36, 0xc2, 0x57, 0xa1, 0x0a, 0x77, 0xe4, 0xc2, 0xba, 0x55,
-	0xdb, 0xe9, 0xd6, 0x13, 0x68, 0xfa, 0x9e, 0x7f, 0x71, 0x4d, 0x28, 0xd4, 0xd4, 0x35, 0xbe, 0x51,
-	0xd4, 0xf4, 0xa2, 0x58, 0x9e, 0x41, 0x4d, 0x5d, 0xd3, 0x21, 0xb4, 0x7d, 0xcf, 0xc7, 0x2a, 0x10,
-	0x0a, 0x4d, 0x5c, 0x9d, 0x46, 0x65, 0xdf, 0xa8, 0x20, 0x33, 0xc8, 0x58, 0x74, 0x06, 0x6d, 0xb3,
-	0x85, 0x52, 0x72, 0x1f, 0x20, 0xf6, 0xe2, 0xaa, 0xaf, 0x25, 0x04, 0x4b, 0x27, 0xaf, 0x94, 0x15,
-	0xc8, 0xa6, 0xaa, 0x0c, 0xe9, 0xe6, 0xd5, 0x7d, 0x55, 0x5a, 0x9c, 0x39, 0x4d, 0xff, 0x70, 0xe1,
-	0xe0, 0x34, 0x91, 0xe1, 0xe4, 0x75, 0x98, 0x66, 0x89, 0xb9, 0x5f, 0x8a, 0x67, 0xbf, 0xe8, 0xd1,
-	0x8b, 0xeb, 0x33, 0x47, 0xc7, 0x42, 0x1e, 0x5b, 0xff, 0x6b, 0x28, 0x72, 0x58, 0x88, 0x60, 0x08,
-	0x67, 0x8e, 0x09, 0x42, 0xe7, 0x31, 0xe6, 0x62, 0x8a, 0x26, 0xbb, 0x5e, 0xaf, 0xd4, 0xee, 0x5c,
-	0x4c, 0xcf, 0x9c, 0x00, 0xb9, 0xe4, 0x49, 0x51, 0x87, 0x46, 0xe5, 0x41, 0x9b, 0x80, 0x33, 0x27,
-	0x2f, 0xcd, 0x69, 0x0b, 0x9a, 0xab, 0x70, 0xbe, 0x64, 0x94, 0xdb, 0x7e, 0xcb, 0x56, 0xf8, 0xbf,
-	0xd9, 0xda, 0x9f, 0x61, 0xdb, 0x58, 0x3b, 0x8f, 0xa1, 0x95, 0x5d, 0x0b, 0xdb, 0xb6, 0x6b, 0xb7,
-	0xc4, 0x72, 0xa9, 0x80, 0xd6, 0xb9, 0x58, 0x61, 0x46, 0x1f, 0xed, 0xee, 0x10, 0x93, 0xd7, 0x47,
-	0xd5, 0xbc, 0x56, 0xfa, 0xa2, 0x48, 0x6a, 0x36, 0x00, 0x75, 0x3b, 0x00, 0x45, 0x46, 0x9e, 0x43,
-	0xdb, 0xd8, 0x4b, 0xf5, 0x53, 0x5c, 0xb1, 0x85, 0x75, 0xb1, 0x57, 0xb4, 0xb0, 0xe6, 0x07, 0x19,
-	0x93, 0xfe, 0xe6, 0x42, 0x43, 0x6f, 0x9e, 0x7f, 0x74, 0x7c, 0x09, 0x34, 0x52, 0x36, 0xbf, 0xc2,
-	0xda, 0xb5, 0x03, 0xfc, 0x5e, 0x3f, 0xc8, 0xcd, 0x5d, 0x07, 0x79, 0x6f, 0xd7, 0x41, 0x7e, 0x0a,
-	0x6d, 0xed, 0x20, 0xae, 0xd5, 0x0f, 0xa0, 0xa9, 0x9b, 0xd6, 0xc6, 0xd4, 0xb5, 0xed, 0xc4, 0x58,
-	0x12, 0x64, 0x1c, 0xfa, 0xbb, 0x0b, 0xdd, 0x77, 0x72, 0xc2, 0xde, 0x31, 0x85, 0x0b, 0x93, 0xc2,
-	0x3e, 0x33, 0x0b, 0xb4, 0x14, 0x5f, 0x05, 0xd3, 0xb5, 0x9f, 0xcb, 0xc8, 0x08, 0x64, 0xb3, 0x53,
-	0x00, 0xe5, 0xdb, 0x57, 0xc7, 0x00, 0xcb, 0x87, 0x5e, 0x2e, 0xd5, 0xa5, 0x5c, 0x8a, 0x49, 0x6a,
-	0x7e, 0x72, 0x14, 0x80, 0x9e, 0x38, 0x2e, 0x0c, 0x33, 0x0b, 0x3f, 0xa7, 0xe9, 0xa7, 0x00, 0xda,
-	0xe9, 0x34, 0x60, 0xf1, 0xfc, 0x86, 0x7c, 0x54, 0x0d, 0xeb, 0xa8, 0x14, 0x56, 0x8a, 0x27, 0xc1,
-	0xc4, 0xf6, 0xb3, 0x0b, 0x9d, 0x1c, 0xcc, 0x2b, 0xe1, 0x96, 0x2a, 0xd1, 0x83, 0x1a, 0x8f, 0x4d,
-	0x08, 0x35, 0x1e, 0x6f, 0x3d, 0xa9, 0x6b, 0xbb, 0xa2, 0xb1, 0xb9, 0x2b, 0xaa, 0xdb, 0xa6, 0xb9,
-	0xbe, 0x6d, 0xbc, 0x5f, 0x5a, 0xd0, 0x8d, 0xbd, 0x78, 0x6a, 0xf3, 0xf0, 0x04, 0xba, 0xf9, 0xfa,
-	0xb8, 0xb8, 0x26, 0x95, 0x85, 0x31, 0xb0, 0x14, 0x86, 0x4a, 0x1d, 0xf2, 0x02, 0x7a, 0xb9, 0x70,
-	0xb6, 0x0c, 0xd7, 0xb7, 0xc7, 0x86, 0xca, 0x31, 0x34, 0xf0, 0xa7, 0xc4, 0xda, 0xfa, 0x18, 0x94,
-	0x69, 0x29, 0xa6, 0xd4, 0x21, 0x43, 0x68, 0xd9, 0x23, 0x7f, 0xa7, 0x60, 0x1a, 0xa8, 0x2c, 0xaf,
-	0x69, 0xea, 0x90, 0x97, 0xd0, 0x35, 0x4c, 0xec, 0xaf, 0x2d, 0x3a, 0xa4, 0xaa, 0xa3, 0xc5, 0xa8,
-	0x43, 0x9e, 0x43, 0xcb, 0xfe, 0x42, 0x2c, 0xe9, 0x18, 0x68, 0x70, 0x54, 0x81, 0x4e, 0xa2, 0xf7,
-	0xd4, 0x21, 0x5e, 0xbe, 0xcd, 0xbd, 0x6d, 0x2a, 0x9b, 0x10, 0x75, 0xc8, 0x53, 0xe8, 0x8e, 0xe5,
-	0x95, 0xb2, 0x96, 0xd6, 0xc3, 0xdf, 0xcc, 0x6c, 0xa7, 0x38, 0xf3, 0xff, 0xa9, 0x84, 0x92, 0x81,
-	0x83, 0x83, 0x02, 0x3c, 0x17, 0x2b, 0xea, 0x90, 0x11, 0x40, 0x76, 0xaf, 0x7d, 0x7d, 0xaf, 0xef,
-	0x56, 0x74, 0xcc, 0x15, 0xdf, 0x54, 0x7a, 0x81, 0x49, 0xc6, 0xad, 0x56, 0x4d, 0x98, 0x86, 0x06,
-	0x87, 0xd5, 0x45, 0x93, 0x52, 0xe7, 0xb9, 0x4b, 0x3e, 0x47, 0x3b, 0x76, 0x7f, 0x56, 0xed, 0x18,
-	0xb4, 0x9c, 0x02, 0x03, 0x51, 0x87, 0x7c, 0x81, 0x05, 0xca, 0xff, 0x22, 0xfc, 0xb7, 0xa2, 0x69,
-	0xe1, 0xc1, 0x96, 0x9f, 0x51, 0xd4, 0x21, 0xaf, 0xe0, 0x68, 0xcc, 0x92, 0x15, 0x4b, 0xc6, 0x2a,
-	0x61, 0xe1, 0x22, 0x60, 0xe1, 0x24, 0x37, 0x5d, 0x39, 0x77, 0x79, 0x88, 0x01, 0xfb, 0xf1, 0x1d,
-	0x9f, 0x53, 0xe7, 0xd8, 0x25, 0x5f, 0x56, 0x95, 0xc7, 0x4c, 0x4c, 0x36, 0x0a, 0xb0, 0xf5, 0x31,
-	0x8c, 0x77, 0x04, 0xbd, 0xd7, 0x72, 0x3e, 0x67, 0x91, 0x3a, 0x17, 0x38, 0xb1, 0x1b, 0xba, 0x87,
-	0xa5, 0x21, 0x37, 0x4d, 0xf5, 0x12, 0x0e, 0xab, 0x4a, 0xde, 0x86, 0xd6, 0x9d, 0xf2, 0x6a, 0x30,
-	0x75, 0x3f, 0x7d, 0xf0, 0xc3, 0xff, 0xa7, 0x5c, 0xcd, 0x96, 0x97, 0xc3, 0x48, 0x2e, 0x9e, 0x8d,
-	0x46, 0x91, 0x78, 0x86, 0x7f, 0xc9, 0x46, 0xa3, 0x67, 0x28, 0x7d, 0xb9, 0x87, 0xff, 0xcd, 0x46,
-	0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x73, 0x56, 0x36, 0x8a, 0xe2, 0x0d, 0x00, 0x00,
+	// 1384 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb5, 0x57, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0xde, 0xfc, 0x35, 0xc9, 0x24, 0x4d, 0x53, 0x53, 0x20, 0x44, 0xb0, 0xbb, 0x0c, 0x85, 0x2d,
+	0x54, 0x4d, 0xbb, 0x0e, 0x2c, 0x12, 0xcb, 0x4d, 0xdb, 0x85, 0xed, 0x4a, 0x4b, 0x55, 0x39, 0x85,
+	0x0b, 0xee, 0xdc, 0x64, 0x9a, 0x58, 0x9b, 0xd8, 0xc6, 0x9e, 0x44, 0x29, 0xf7, 0x48, 0xbc, 0x07,
+	0xaf, 0xc0, 0x0b, 0xf0, 0x04, 0xbc, 0x08, 0x0f, 0xc1, 0x99, 0x33, 0x33, 0xf6, 0x38, 0x4e, 0x23,
+	0x01, 0xe2, 0xce, 0x73, 0x7e, 0xe6, 0xfc, 0x7f, 0x73, 0x4c, 0xea, 0xa1, 0x1d, 0xf6, 0xc2, 0x28,
+	0xe0, 0x81, 0x55, 0xe1, 0x77, 0x21, 0x8b, 0xbb, 0xbb, 0x3c, 0x72, 0xfd, 0xd8, 0x1d, 0x72, 0x2f,
+	0xf0, 0x25, 0xa7, 0xdb, 0x1c, 0x06, 0xb3, 0x59, 0x72, 0x6a, 0xdf, 0x4c, 0x83, 0xe1, 0x9b, 0xe1,
+	0xc4, 0xf5, 0x14, 0x85, 0x7e, 0x46, 0x5a, 0x57, 0xf6, 0xd5, 0x4b, 0xc6, 0xaf, 0x18, 0x8b, 0x5e,
+	0xf9, 0xb7, 0x81, 0xd5, 0x21, 0xd5, 0x05, 0x8b, 0x62, 0xb8, 0xa2, 0x53, 0x78, 0x5c, 0x38, 0xa8,
+	0x38, 0xfa, 0x48, 0x7f, 0x2f, 0x90, 0x06, 0x08, 0x27, 0x92, 0x16, 0x29, 0xbb, 0xa3, 0x51, 0x84,
+	0x62, 0x75, 0x07, 0xbf, 0x05, 0x2d, 0x0c, 0x22, 0xde, 0x29, 0xa2, 0x2a, 0x7e, 0x0b, 0x9a, 0xef,
+	0xce, 0x58, 0xa7, 0x24, 0xe5, 0xc4, 0xb7, 0xf5, 0x98, 0x34, 0x66, 0x6c, 0x16, 0x06, 0xc1, 0x74,
+	0xe0, 0xfd, 0xcc, 0x3a, 0x65, 0x14, 0x37, 0x49, 0xd6, 0xc7, 0x64, 0x6b, 0xc2, 0xdc, 0x11, 0x8b,
+	0x3a, 0x15, 0x60, 0x36, 0xec, 0xed, 0x1e, 0x06, 0xd9, 0xbb, 0x40, 0xa2, 0xa3, 0x98, 0xe2, 0xa2,
+	0x30, 0x9a, 0xfb, 0xec, 0x82, 0x79, 0xe3, 0x09, 0xef, 0x6c, 0x81, 0x6c, 0xc9, 0x31, 0x49, 0xf4,
+	0xaf, 0x02, 0x21, 0xe0, 0xf6, 0x0f, 0x32, 0x8a, 0xfb, 0xe3, 0x13, 0x9c, 0x98, 0x45, 0x0b, 0x6f,
+	0xc8, 0xd0, 0xfd, 0x92, 0xa3, 0x8f, 0xd6, 0xfb, 0xa4, 0xce, 0xbd, 0x19, 0x8b, 0xb9, 0x3b, 0x0b,
+	0x31, 0x8c, 0x92, 0x93, 0x12, 0xac, 0x2e, 0xa9, 0x89, 0xd8, 0x1d, 0x36, 0x5c, 0x60, 0x20, 0x75,
+	0x27, 0x39, 0x6b, 0xde, 0xb7, 0x51, 0x30, 0xc3, 0x38, 0x14, 0x4f, 0x9c, 0xad, 0x3d, 0x52, 0xf1,
+	0x03, 0x1f, 0xac, 0x49, 0xa7, 0xe5, 0x41, 0xd8, 0x9a, 0x83, 0xdd, 0xd3, 0x31, 0xf3, 0x79, 0xa7,
+	0x8a, 0x2a, 0x29, 0x41, 0x84, 0x0b, 0x46, 0x23, 0xae, 0xc2, 0xad, 0xc9, 0x70, 0x0d, 0x12, 0xfd,
+	0x9e, 0xd4, 0x65, 0xb4, 0xa7, 0xc3, 0x37, 0xff, 0x2a, 0xd8, 0xc4, 0xad, 0x92, 0xe1, 0x16, 0x9d,
+	0x91, 0xaa, 0xa8, 0xbd, 0xe7, 0x8f, 0x53, 0x81, 0x82, 0xe9, 0xb7, 0xee, 0x86, 0xe2, 0x9a, 0x6e,
+	0x28, 0x19, 0xdd, 0xb0, 0x4f, 0xca, 0xb1, 0x37, 0xf6, 0x31, 0x53, 0x0d, 0xbb, 0xad, 0xaa, 0x3a,
+	0x00, 0x92, 0xcb, 0xe7, 0x11, 0x73, 0x90, 0x4b, 0x1f, 0x49, 0x73, 0xc1, 0x7d, 0xe6, 0x28, 0xc5,
+	0xa2, 0x42, 0xe3, 0x9e, 0x0a, 0x43, 0xeb, 0x65, 0x9e, 0xe3, 0x25, 0xf7, 0x0b, 0xe8, 0xea, 0x4c,
+	0xbd, 0x58, 0x74, 0x6c, 0x49, 0x57, 0x47, 0x9c, 0xe9, 0x00, 0x9b, 0x5d, 0x28, 0xbf, 0x86, 0xe3,
+	0x3d, 0x17, 0xf4, 0x48, 0x2d, 0x84, 0x71, 0xf0, 0x60, 0x1c, 0xf0, 0x82, 0x86, 0x6d, 0xa9, 0x80,
+	0x8c, 0x41, 0x71, 0x12, 0x19, 0x7a, 0x4e, 0x76, 0x80, 0xf1, 0xcd, 0x92, 0xb3, 0xc8, 0x77, 0xa7,
+	0xf7, 0x4e, 0x11, 0xf4, 0x80, 0x17, 0x07, 0x73, 0x1e, 0x7b, 0x23, 0x59, 0x9e, 0x9a, 0x93, 0x12,
+	0xe8, 0x84, 0x34, 0x65, 0xe8, 0x67, 0x62, 0x9a, 0xe3, 0x0d, 0x45, 0x5e, 0xe9, 0x96, 0x62, 0xae,
+	0x5b, 0x84, 0x25, 0xe6, 0x8f, 0x14, 0x5f, 0x75, 0x76, 0x42, 0xa0, 0x9f, 0x92, 0x6d, 0x69, 0xe9,
+	0x3b, 0x39, 0x98, 0x1b, 0xc0, 0xa1, 0x47, 0xb6, 0x40, 0xf4, 0x95, 0xbf, 0x10, 0x05, 0xf6, 0xfc,
+	0x45, 0x0c, 0x02, 0x25, 0xa3, 0xc0, 0xc0, 0x81, 0xfe, 0x0d, 0xa2, 0x3b, 0x07, 0xb9, 0xf4, 0x25,
+	0xa9, 0x27, 0x24, 0xab, 0x45, 0x8a, 0xfc, 0x4e, 0xdd, 0x08, 0x5f, 0x22, 0x27, 0x13, 0x37, 0x9e,
+	0xa0, 0xc3, 0x4d, 0x07, 0xbf, 0xad, 0x77, 0x04, 0x1e, 0x18, 0x6e, 0xaa, 0x13, 0x7d, 0xad, 0x1b,
+	0xe1, 0x85, 0xcb, 0xdd, 0x0d, 0xb9, 0xd0, 0x6e, 0x15, 0x37, 0xba, 0x75, 0x48, 0x2a, 0x70, 0xdb,
+	0xf5, 0xd2, 0xa2, 0xe0, 0xd2, 0x12, 0xef, 0x48, 0x6b, 0x7a, 0x9d, 0xc2, 0x2b, 0xb8, 0xb9, 0x84,
+	0x98, 0x6b, 0x20, 0x8c, 0x55, 0x00, 0xf9, 0x0a, 0x82, 0xab, 0x52, 0x69, 0x2a, 0x15, 0x64, 0x3a,
+	0x92, 0x05, 0x85, 0xab, 0x29, 0x14, 0x8a, 0xad, 0x87, 0x84, 0x00, 0x7e, 0x67, 0x7d, 0x35, 0x28,
+	0x58, 0xba, 0xe0, 0x96, 0x6b, 0x01, 0x39, 0x55, 0x26, 0x49, 0x34, 0xaf, 0xe8, 0x2b, 0x03, 0x5a,
+	0x93, 0x33, 0xfd, 0xb5, 0x48, 0xb6, 0xcf, 0xa2, 0xc0, 0x1d, 0x9d, 0xbb, 0xb1, 0x4c, 0xcc, 0x43,
+	0x23, 0x9e, 0x66, 0xda, 0xa3, 0xd7, 0xcb, 0x8b, 0x07, 0x22, 0x16, 0xeb, 0x89, 0xf6, 0xbf, 0x88,
+	0x22, 0x3b, 0xa9, 0x08, 0x86, 0x00, 0x52, 0x92, 0x2f, 0xf2, 0x18, 0x02, 0x0a, 0xa0, 0xc9, 0x86,
+	0xdd, 0x32, 0xda, 0x1d, 0xa8, 0x20, 0x86, 0x5c, 0xeb, 0x30, 0xad, 0x43, 0x39, 0x73, 0xa1, 0x4e,
+	0x00, 0x48, 0x26, 0xa5, 0xf9, 0x48, 0x95, 0x26, 0x0b, 0xf4, 0xb2, 0x9d, 0xc4, 0x8d, 0x82, 0x69,
+	0x1d, 0x91, 0xea, 0x58, 0x16, 0x19, 0xf1, 0xb2, 0x61, 0xef, 0xa6, 0x72, 0xaa, 0xfa, 0xe2, 0x4e,
+	0x25, 0x73, 0x56, 0x25, 0x95, 0x85, 0x3b, 0x9d, 0x33, 0xea, 0xe9, 0x1e, 0x96, 0x0f, 0xc7, 0xff,
+	0x39, 0x2e, 0x5f, 0x60, 0x2b, 0x6a, 0x3b, 0x4f, 0x48, 0x55, 0xbe, 0x51, 0x7a, 0x14, 0x56, 0x5e,
+	0x30, 0xcd, 0xa5, 0x3e, 0xa9, 0x42, 0xa0, 0x58, 0xa5, 0xfd, 0xcd, 0x5d, 0xa7, 0x6a, 0xb5, 0x9f,
+	0xad, 0x55, 0xa6, 0xd7, 0xd2, 0x42, 0xc9, 0xa1, 0x2a, 0xe9, 0xa1, 0x4a, 0x33, 0x72, 0x42, 0x6a,
+	0xca, 0x5e, 0x2c, 0xae, 0xf2, 0x38, 0x9b, 0x69, 0x17, 0x5b, 0xe9, 0x58, 0x08, 0xbe, 0x23, 0x99,
+	0xf4, 0x8f, 0x02, 0x29, 0x0b, 0x34, 0xfb, 0x4f, 0x4f, 0x3e, 0xd0, 0x62, 0x36, 0xbd, 0xc5, 0x7e,
+	0xa8, 0x39, 0xf8, 0xbd, 0xba, 0x06, 0x54, 0x36, 0xad, 0x01, 0x5b, 0xff, 0x60, 0x0d, 0xa8, 0xe6,
+	0xd7, 0x80, 0x23, 0x18, 0x56, 0x08, 0x01, 0xc1, 0xfc, 0x43, 0x52, 0x11, 0xa3, 0xa2, 0xa3, 0x6e,
+	0xe8, 0x4e, 0x02, 0x9a, 0x23, 0x39, 0xf4, 0x37, 0x58, 0x76, 0x2e, 0x83, 0x11, 0xbb, 0x64, 0x1c,
+	0x61, 0x9a, 0x92, 0x26, 0x53, 0xb0, 0x6d, 0x64, 0x20, 0x43, 0x13, 0xdd, 0x01, 0x99, 0x57, 0x02,
+	0x72, 0x62, 0x53, 0x82, 0xf9, 0xe2, 0x96, 0x30, 0x05, 0xe6, 0x7a, 0x01, 0xd8, 0x7e, 0x13, 0xcc,
+	0xfd, 0x51, 0xac, 0x56, 0xa1, 0x94, 0x20, 0xe6, 0xdc, 0xf3, 0x15, 0x53, 0x26, 0x28, 0x39, 0xd3,
+	0xcf, 0xa1, 0xe3, 0x84, 0xbb, 0x0e, 0x0b, 0xa7, 0x77, 0xd6, 0x27, 0xd9, 0xb0, 0xda, 0x46, 0x58,
+	0x31, 0x3e, 0x44, 0x2a, 0xb6, 0x5f, 0x0a, 0xb0, 0x23, 0x68, 0x62, 0x52, 0xab, 0x82, 0x51, 0x2b,
+	0xe8, 0x1d, 0x2f, 0x54, 0x21, 0xc0, 0xd7, 0xda, 0x87, 0x7c, 0x05, 0xa1, 0xca, 0x79, 0x84, 0xca,
+	0x62, 0x5c, 0x65, 0x15, 0xe3, 0xec, 0x3f, 0x6b, 0x50, 0x35, 0x3b, 0x1c, 0xeb, 0x3c, 0x1c, 0x92,
+	0x46, 0x02, 0x5a, 0x00, 0xc1, 0x19, 0x98, 0xea, 0xea, 0x13, 0x86, 0x4a, 0x1f, 0x58, 0x4f, 0x49,
+	0x2b, 0x11, 0x96, 0x10, 0xbc, 0x8a, 0x59, 0x39, 0x95, 0x03, 0xe8, 0x62, 0x01, 0x4e, 0x2b, 0xa0,
+	0xd5, 0x35, 0xcf, 0xb0, 0x71, 0x80, 0x64, 0x8f, 0x54, 0xf5, 0x6a, 0x91, 0x85, 0x19, 0x41, 0x32,
+	0xe5, 0xc5, 0x19, 0xe4, 0x9f, 0x91, 0x86, 0x62, 0x62, 0x7f, 0xad, 0xd1, 0xb1, 0xb2, 0x3a, 0x42,
+	0x0c, 0xf4, 0x4e, 0x48, 0x55, 0xef, 0xa5, 0x86, 0x8e, 0x22, 0x75, 0xdb, 0x19, 0x12, 0xec, 0x73,
+	0xa0, 0x61, 0x27, 0x6f, 0x88, 0xbd, 0x4e, 0x25, 0x4f, 0x02, 0x9d, 0x23, 0xd2, 0x18, 0x40, 0x59,
+	0xb4, 0xa5, 0xd5, 0xf0, 0xf3, 0x99, 0xad, 0xa7, 0xcb, 0xc5, 0x5b, 0x99, 0x50, 0x24, 0xb1, 0x9b,
+	0x85, 0x68, 0x50, 0xe9, 0x13, 0x22, 0xb7, 0x84, 0x2b, 0xb1, 0x25, 0xec, 0x65, 0x74, 0xd4, 0xee,
+	0x90, 0x57, 0x7a, 0x8a, 0x49, 0x46, 0xdc, 0xcb, 0x63, 0x79, 0x77, 0x27, 0x0b, 0x45, 0x31, 0x7d,
+	0x70, 0x52, 0xb0, 0xbe, 0x44, 0x3b, 0x1a, 0x61, 0xb3, 0x76, 0x14, 0xd5, 0x4c, 0x81, 0x22, 0x81,
+	0xad, 0x0b, 0xd2, 0x06, 0x91, 0x01, 0x77, 0x39, 0x1b, 0xf8, 0x6e, 0x18, 0x4f, 0x02, 0x6e, 0xbd,
+	0x9b, 0xc4, 0xfd, 0x53, 0x86, 0xd1, 0x7d, 0x4f, 0x2f, 0xa5, 0x26, 0xf5, 0x7c, 0x32, 0xf7, 0x45,
+	0x01, 0x9e, 0xa3, 0x0b, 0xd7, 0xcb, 0xab, 0x28, 0x08, 0x6e, 0x13, 0xc7, 0xe1, 0x0e, 0x45, 0xea,
+	0x76, 0xf2, 0xb8, 0xfd, 0x82, 0x71, 0xd7, 0x9b, 0x82, 0xf2, 0x57, 0x64, 0x5b, 0xbb, 0x21, 0xf5,
+	0xf7, 0x56, 0x7c, 0x90, 0x57, 0xec, 0x9a, 0x0e, 0x20, 0x09, 0x75, 0x1b, 0xe6, 0x7f, 0xda, 0xdb,
+	0x99, 0xe0, 0x35, 0xb9, 0xbb, 0x66, 0xff, 0x44, 0xa7, 0xdb, 0x03, 0x18, 0x32, 0x16, 0x0d, 0x78,
+	0xc4, 0xdc, 0x99, 0x03, 0x79, 0x49, 0x4c, 0x67, 0xf6, 0x84, 0xa4, 0x4a, 0xe0, 0xd0, 0xa5, 0x70,
+	0xf9, 0xa0, 0x60, 0x7d, 0x9d, 0x55, 0x1e, 0xc0, 0x83, 0x97, 0xeb, 0xa1, 0xb5, 0x97, 0x61, 0xc9,
+	0xfa, 0xa4, 0x75, 0x1e, 0x4c, 0xa7, 0x6c, 0x08, 0x38, 0x8a, 0xa0, 0x93, 0xd3, 0xdd, 0x31, 0x70,
+	0x4a, 0xcd, 0xc5, 0x33, 0xb2, 0x93, 0x55, 0xb2, 0x73, 0x5a, 0xbb, 0x26, 0xba, 0xa9, 0xd6, 0x3d,
+	0x7b, 0xf4, 0xe3, 0x07, 0x63, 0x8f, 0x4f, 0xe6, 0x37, 0x3d, 0xf8, 0xef, 0x3d, 0xee, 0xf7, 0x87,
+	0xfe, 0x31, 0xfe, 0xed, 0xf6, 0xfb, 0xc7, 0x28, 0x7d, 0xb3, 0x85, 0xbf, 0xbd, 0xfd, 0xbf, 0x01,
+	0xec, 0x8a, 0x92, 0x08, 0x3d, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1957,6 +2046,12 @@ type P2PgserviceClient interface {
 	GetData(ctx context.Context, in *P2PGetData, opts ...grpc.CallOption) (P2Pgservice_GetDataClient, error)
 	//获取头部
 	GetHeaders(ctx context.Context, in *P2PGetHeaders, opts ...grpc.CallOption) (*P2PHeaders, error)
+	//获取状态快照
+	GetStateSnapshot(ctx context.Context, in *ReqStateSnapshot, opts ...grpc.CallOption) (*StateSnapshotChunk, error)
+	//轻节点获取交易以及交易的默克尔证明
+	GetTxProof(ctx context.Context, in *ReqTxProof, opts ...grpc.CallOption) (*TransactionDetail, error)
+	//轻节点获取状态数据的 mavl 证明
+	GetStateProof(ctx context.Context, in *ReqStateProof, opts ...grpc.CallOption) (*StateProof, error)
 	//获取 peerinfo
 	GetPeerInfo(ctx context.Context, in *P2PGetPeerInfo, opts ...grpc.CallOption) (*P2PPeerInfo, error)
 	// grpc server 读客户端发送来的数据
@@ -2107,6 +2202,33 @@ func (c *p2PgserviceClient) GetHeaders(ctx context.Context, in *P2PGetHeaders, o
 	return out, nil
 }
 
+func (c *p2PgserviceClient) GetStateSnapshot(ctx context.Context, in *ReqStateSnapshot, opts ...grpc.CallOption) (*StateSnapshotChunk, error) {
+	out := new(StateSnapshotChunk)
+	err := c.cc.Invoke(ctx, "/types.p2pgservice/GetStateSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *p2PgserviceClient) GetTxProof(ctx context.Context, in *ReqTxProof, opts ...grpc.CallOption) (*TransactionDetail, error) {
+	out := new(TransactionDetail)
+	err := c.cc.Invoke(ctx, "/types.p2pgservice/GetTxProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *p2PgserviceClient) GetStateProof(ctx context.Context, in *ReqStateProof, opts ...grpc.CallOption) (*StateProof, error) {
+	out := new(StateProof)
+	err := c.cc.Invoke(ctx, "/types.p2pgservice/GetStateProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *p2PgserviceClient) GetPeerInfo(ctx context.Context, in *P2PGetPeerInfo, opts ...grpc.CallOption) (*P2PPeerInfo, error) {
 	out := new(P2PPeerInfo)
 	err := c.cc.Invoke(ctx, "/types.p2pgservice/GetPeerInfo", in, out, opts...)
@@ -2225,6 +2347,12 @@ type P2PgserviceServer interface {
 	GetData(*P2PGetData, P2Pgservice_GetDataServer) error
 	//获取头部
 	GetHeaders(context.Context, *P2PGetHeaders) (*P2PHeaders, error)
+	//获取状态快照
+	GetStateSnapshot(context.Context, *ReqStateSnapshot) (*StateSnapshotChunk, error)
+	//轻节点获取交易以及交易的默克尔证明
+	GetTxProof(context.Context, *ReqTxProof) (*TransactionDetail, error)
+	//轻节点获取状态数据的 mavl 证明
+	GetStateProof(context.Context, *ReqStateProof) (*StateProof, error)
 	//获取 peerinfo
 	GetPeerInfo(context.Context, *P2PGetPeerInfo) (*P2PPeerInfo, error)
 	// grpc server 读客户端发送来的数据
@@ -2459,6 +2587,60 @@ func _P2Pgservice_GetHeaders_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _P2Pgservice_GetStateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqStateSnapshot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PgserviceServer).GetStateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.p2pgservice/GetStateSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PgserviceServer).GetStateSnapshot(ctx, req.(*ReqStateSnapshot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _P2Pgservice_GetTxProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqTxProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PgserviceServer).GetTxProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.p2pgservice/GetTxProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PgserviceServer).GetTxProof(ctx, req.(*ReqTxProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _P2Pgservice_GetStateProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqStateProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(P2PgserviceServer).GetStateProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.p2pgservice/GetStateProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(P2PgserviceServer).GetStateProof(ctx, req.(*ReqStateProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _P2Pgservice_GetPeerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(P2PGetPeerInfo)
 	if err := dec(in); err != nil {
@@ -2608,6 +2790,18 @@ var _P2Pgservice_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHeaders",
 			Handler:    _P2Pgservice_GetHeaders_Handler,
 		},
+		{
+			MethodName: "GetStateSnapshot",
+			Handler:    _P2Pgservice_GetStateSnapshot_Handler,
+		},
+		{
+			MethodName: "GetTxProof",
+			Handler:    _P2Pgservice_GetTxProof_Handler,
+		},
+		{
+			MethodName: "GetStateProof",
+			Handler:    _P2Pgservice_GetStateProof_Handler,
+		},
 		{
 			MethodName: "GetPeerInfo",
 			Handler:    _P2Pgservice_GetPeerInfo_Handler,
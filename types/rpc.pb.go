@@ -26,71 +26,73 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
 
 var fileDescriptor_77a6da22d6a3feb1 = []byte{
-	// 1020 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x6f, 0xdb, 0x36,
-	0x10, 0xd7, 0xc3, 0xd6, 0x34, 0xac, 0xed, 0x38, 0x8c, 0x13, 0xb4, 0xc2, 0x8a, 0x02, 0x02, 0x86,
-	0x3d, 0x0c, 0xb5, 0x57, 0x7b, 0xcb, 0xbe, 0x07, 0xc4, 0xc9, 0xec, 0x18, 0x70, 0x3d, 0x37, 0x72,
-	0x37, 0x60, 0x6f, 0xb4, 0x7c, 0x73, 0x84, 0xc8, 0xa4, 0x22, 0x52, 0xb1, 0xfc, 0x3f, 0xec, 0x8f,
-	0x1e, 0x48, 0x89, 0xfa, 0x76, 0x9a, 0xbd, 0x89, 0x77, 0xf7, 0xbb, 0x0f, 0xf1, 0x77, 0x77, 0x44,
-	0x87, 0x81, 0xef, 0x74, 0xfd, 0x80, 0x09, 0x86, 0x3f, 0x17, 0x3b, 0x1f, 0xb8, 0xd9, 0x70, 0xd8,
-	0x66, 0xc3, 0x68, 0x2c, 0x34, 0x8f, 0x45, 0x40, 0x28, 0x27, 0x8e, 0x70, 0x53, 0x51, 0x7b, 0xe9,
-	0x31, 0xe7, 0xce, 0xb9, 0x25, 0xae, 0x96, 0x34, 0xb6, 0xc4, 0xf3, 0x40, 0x24, 0xa7, 0x43, 0xbf,
-	0xef, 0x27, 0x9f, 0x4d, 0xe2, 0x38, 0x2c, 0xa4, 0x5a, 0xd3, 0x82, 0x08, 0x9c, 0x50, 0xb0, 0x20,
-	0x3e, 0xf7, 0xff, 0x3d, 0x43, 0x07, 0xca, 0xcf, 0x60, 0x80, 0xdf, 0xa2, 0xc3, 0x31, 0x88, 0xa1,
-	0x74, 0xcd, 0x71, 0xbb, 0xab, 0x72, 0xe9, 0xde, 0xc0, 0x7d, 0x2c, 0x31, 0x1b, 0xa9, 0xc4, 0xf7,
-	0x76, 0x96, 0x81, 0x7b, 0xa8, 0x39, 0x06, 0x31, 0x25, 0x5c, 0x5c, 0x03, 0x59, 0x41, 0x80, 0x9b,
-	0x19, 0x64, 0xe6, 0x7a, 0xa6, 0x3e, 0xc6, 0x5a, 0xcb, 0xc0, 0x3f, 0xa1, 0xce, 0x65, 0x00, 0x44,
-	0xc0, 0x0d, 0xd9, 0x2e, 0xb2, 0x9a, 0xf0, 0x51, 0x62, 0x18, 0x2b, 0x17, 0x91, 0xa9, 0x05, 0x1f,
-	0x29, 0x77, 0xd7, 0x74, 0x11, 0x59, 0x06, 0xbe, 0x42, 0xed, 0x0c, 0x1b, 0x8d, 0x03, 0x16, 0xfa,
-	0xf8, 0x75, 0x11, 0x97, 0x79, 0x54, 0xea, 0x3a, 0x2f, 0xdf, 0x21, 0x6c, 0x03, 0x5d, 0xed, 0x89,
-	0x6f, 0xbb, 0x6b, 0x0a, 0xab, 0x45, 0x54, 0xa9, 0xf4, 0x37, 0xd4, 0xfe, 0x10, 0x42, 0xb0, 0xcb,
-	0x83, 0x5a, 0x59, 0xb1, 0xd7, 0x84, 0xdf, 0x9a, 0x2f, 0x93, 0x73, 0xce, 0xe6, 0x0a, 0x04, 0x71,
-	0x3d, 0x15, 0xf6, 0x48, 0x86, 0xcd, 0xc3, 0x71, 0xd5, 0xbc, 0x12, 0xf6, 0x57, 0xd4, 0x19, 0x83,
-	0xc8, 0x59, 0x0c, 0x77, 0x17, 0xab, 0x55, 0x90, 0x0f, 0x2d, 0xcf, 0xe6, 0x49, 0x1e, 0xb7, 0x88,
-	0x26, 0xf4, 0x1f, 0xc6, 0x2d, 0x03, 0x8f, 0xd1, 0x59, 0x19, 0x2e, 0x33, 0x85, 0xc2, 0xdd, 0xc6,
-	0x12, 0xf3, 0xd5, 0xbe, 0xec, 0xa5, 0xa3, 0x77, 0x08, 0x8d, 0x41, 0xbc, 0x87, 0xcd, 0x9c, 0x31,
-	0xaf, 0x7c, 0xcb, 0xb8, 0x18, 0x7c, 0xea, 0x72, 0xa1, 0x2a, 0x7e, 0x31, 0x06, 0x71, 0x11, 0x53,
-	0x8f, 0x97, 0x31, 0xa7, 0xc9, 0xf1, 0x2f, 0xc5, 0x59, 0x6d, 0xa5, 0x18, 0x82, 0x66, 0xb0, 0x4d,
-	0x04, 0xb8, 0x93, 0x43, 0xa5, 0x52, 0xb3, 0x53, 0x07, 0xb6, 0x0c, 0x7c, 0x83, 0x4e, 0x63, 0x51,
-	0xae, 0x06, 0x99, 0x0d, 0x7e, 0x93, 0xb9, 0xa9, 0x35, 0x30, 0xcf, 0x0a, 0x1e, 0x17, 0x51, 0x56,
-	0xf9, 0x08, 0x35, 0x27, 0x1b, 0x9f, 0x05, 0x62, 0x1e, 0xb8, 0x0f, 0x77, 0xb0, 0x4b, 0x29, 0x97,
-	0xfa, 0x2a, 0xa8, 0xf7, 0xe6, 0x36, 0x44, 0x4d, 0x45, 0x00, 0x26, 0xef, 0x0b, 0x38, 0xaf, 0xfa,
-	0x29, 0xa8, 0xcd, 0x76, 0xfe, 0xa7, 0xca, 0x2b, 0xb2, 0x0c, 0xdc, 0x47, 0xcf, 0x6d, 0x99, 0xdd,
-	0x08, 0x00, 0x9f, 0x55, 0xe1, 0x62, 0x04, 0x50, 0x61, 0xd0, 0xcf, 0xe8, 0xc0, 0x96, 0x2d, 0xba,
-	0xf4, 0xf0, 0xcb, 0x1a, 0xc8, 0x94, 0x2c, 0xc1, 0x7b, 0x24, 0xe9, 0xc6, 0x7b, 0x08, 0xd6, 0x30,
-	0x24, 0x1e, 0xa1, 0x0e, 0xe0, 0x2f, 0xca, 0x1e, 0xf2, 0xda, 0x22, 0x0f, 0x62, 0x56, 0x59, 0x06,
-	0x3e, 0x47, 0x87, 0x36, 0x88, 0x39, 0xe1, 0x7c, 0xbb, 0xc2, 0xaf, 0x6a, 0x52, 0x88, 0x55, 0x95,
-	0xc4, 0xbf, 0x44, 0x9f, 0x4d, 0x99, 0x73, 0x57, 0x26, 0x4e, 0xd9, 0xec, 0x2d, 0x7a, 0xf6, 0x91,
-	0x2a, 0xc3, 0x93, 0x42, 0x11, 0xb1, 0xb0, 0x66, 0x62, 0x49, 0x56, 0xce, 0x01, 0x02, 0xd9, 0x23,
-	0x65, 0xe7, 0x7a, 0x0c, 0x48, 0x7d, 0x4a, 0xe3, 0x56, 0x32, 0xe2, 0xfe, 0x17, 0xfb, 0xbf, 0x47,
-	0x47, 0x63, 0x10, 0x49, 0x8d, 0x82, 0x88, 0xb0, 0xd2, 0x01, 0xc5, 0x74, 0x63, 0x1b, 0xc5, 0xff,
-	0xb6, 0x9e, 0xc0, 0x7f, 0x3c, 0x40, 0xf0, 0xe0, 0xc2, 0xb6, 0x32, 0x68, 0xf4, 0x75, 0x15, 0xac,
-	0x2c, 0x03, 0xff, 0xa0, 0x82, 0x4a, 0x06, 0xd5, 0x41, 0x0b, 0x83, 0x22, 0x6f, 0xa4, 0xfa, 0xbb,
-	0xa1, 0xa3, 0xca, 0x08, 0xf9, 0x5c, 0x27, 0x54, 0xd4, 0x92, 0xf1, 0x1d, 0x3a, 0x18, 0x03, 0xb5,
-	0x01, 0x56, 0xe9, 0x24, 0x4b, 0xce, 0x53, 0x42, 0xd7, 0x45, 0x88, 0x94, 0x6a, 0x88, 0x28, 0x41,
-	0xd4, 0x79, 0xb8, 0x9b, 0x6f, 0x6b, 0x21, 0x3d, 0xf4, 0xdc, 0x26, 0x0f, 0xa0, 0x30, 0x3a, 0x77,
-	0x2d, 0x50, 0xa0, 0xf2, 0x05, 0xf7, 0xd5, 0xa4, 0xd2, 0x84, 0x3d, 0xce, 0xad, 0xb0, 0x84, 0xa5,
-	0xfa, 0x8e, 0x73, 0x33, 0xa7, 0x8f, 0x90, 0x1a, 0xee, 0x97, 0x72, 0x0b, 0xa6, 0x33, 0x47, 0x9d,
-	0x7e, 0x4f, 0x76, 0x65, 0x5d, 0x1c, 0xa9, 0x8b, 0x6f, 0xef, 0x89, 0x98, 0x73, 0xd4, 0x8a, 0xe3,
-	0x30, 0xca, 0x81, 0xf2, 0x90, 0x3f, 0x11, 0xf7, 0x23, 0x3a, 0xae, 0x2c, 0xb8, 0xb4, 0x34, 0xbd,
-	0x32, 0x27, 0xb4, 0x6e, 0xdd, 0x7d, 0xa3, 0xe8, 0x7b, 0x0d, 0xd1, 0x22, 0x8a, 0x67, 0x7f, 0x85,
-	0x4c, 0x8d, 0x74, 0x47, 0x47, 0xc9, 0x82, 0x7c, 0x71, 0x15, 0x6e, 0x7c, 0x3d, 0xee, 0x72, 0x8b,
-	0xc2, 0x16, 0x81, 0x4b, 0xd7, 0x45, 0xc2, 0xc7, 0x32, 0xcb, 0xc0, 0x5d, 0x74, 0xf0, 0x27, 0x04,
-	0x5c, 0x66, 0xb6, 0xa7, 0x41, 0x12, 0xb5, 0xec, 0x3b, 0xcb, 0xc0, 0x5f, 0xa1, 0x67, 0x13, 0x6e,
-	0xef, 0xa8, 0xf3, 0xa9, 0x06, 0xef, 0xa1, 0xd6, 0x84, 0xcf, 0x84, 0x7f, 0x29, 0xc9, 0xf9, 0x14,
-	0x40, 0x17, 0x1d, 0xcc, 0x40, 0xd4, 0xb5, 0xb7, 0xce, 0x64, 0xc6, 0x56, 0x90, 0x98, 0xa8, 0x5f,
-	0x24, 0xbb, 0x66, 0x44, 0x04, 0xf1, 0x46, 0xc4, 0xf5, 0xc2, 0x00, 0xf6, 0x45, 0x98, 0x50, 0x31,
-	0xe8, 0xab, 0x5f, 0xd4, 0x49, 0x66, 0x82, 0xea, 0x18, 0x1b, 0xee, 0x43, 0x90, 0x6c, 0xdb, 0x0f,
-	0x3b, 0xff, 0x56, 0xbd, 0x21, 0x8e, 0x75, 0x93, 0x69, 0x48, 0xdd, 0x23, 0xeb, 0x34, 0xdf, 0xdd,
-	0xa9, 0xa1, 0x1a, 0xe5, 0xe9, 0x68, 0x78, 0x64, 0x8f, 0x9f, 0xe4, 0xe1, 0xd9, 0x1e, 0xfb, 0x1a,
-	0xa1, 0x4b, 0x8f, 0x71, 0xf8, 0x10, 0x42, 0x08, 0x9f, 0xfa, 0x85, 0xbf, 0xa8, 0x4c, 0x2f, 0x3c,
-	0x4f, 0x52, 0x52, 0xf7, 0x52, 0x79, 0x94, 0xe8, 0x3c, 0x8b, 0x66, 0x8a, 0xae, 0x87, 0xf2, 0x1d,
-	0xa5, 0x9e, 0x69, 0xf8, 0x24, 0xc7, 0x1f, 0x2d, 0x4c, 0xa1, 0x31, 0x85, 0xb4, 0xd8, 0x32, 0xf0,
-	0x04, 0x99, 0x31, 0x9f, 0x67, 0x2c, 0xf1, 0x57, 0xf7, 0x62, 0xca, 0x94, 0x8f, 0xb8, 0x3a, 0x47,
-	0x0d, 0xd5, 0x6c, 0x37, 0x84, 0xae, 0x66, 0xe1, 0x06, 0x67, 0xb4, 0xbd, 0x97, 0x22, 0xc5, 0xfd,
-	0x9a, 0xb9, 0x36, 0x7c, 0xf3, 0xf7, 0xeb, 0xb5, 0x2b, 0x6e, 0xc3, 0x65, 0xd7, 0x61, 0x9b, 0xde,
-	0x60, 0xe0, 0xd0, 0x5e, 0xf2, 0x3a, 0xee, 0x29, 0xe3, 0xe5, 0x33, 0xf5, 0x6c, 0x1e, 0xfc, 0x17,
-	0x00, 0x00, 0xff, 0xff, 0x95, 0xf0, 0xb7, 0x53, 0xb5, 0x0b, 0x00, 0x00,
+	// 1055 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x95, 0x56, 0x6d, 0x6f, 0xdb, 0x36,
+	0x10, 0xee, 0x80, 0x2d, 0x99, 0x59, 0xdb, 0x71, 0x18, 0x27, 0x4b, 0x85, 0x15, 0x05, 0x0a, 0x0c,
+	0xfb, 0x30, 0xd4, 0x6e, 0xed, 0x2d, 0x7b, 0x1f, 0x10, 0x27, 0x4d, 0x62, 0x20, 0xf5, 0xbc, 0xc8,
+	0xdd, 0x80, 0x7d, 0xa3, 0xe5, 0x5b, 0x22, 0x54, 0x96, 0x14, 0x91, 0xb2, 0xe5, 0x5f, 0xb0, 0xbf,
+	0xbd, 0x23, 0x25, 0xea, 0xdd, 0x69, 0xf6, 0x4d, 0xbc, 0xbb, 0xe7, 0x5e, 0xc4, 0xe7, 0xee, 0x48,
+	0x1a, 0x81, 0x6f, 0xf5, 0xfc, 0xc0, 0x13, 0x1e, 0xfd, 0x4c, 0x6c, 0x7c, 0xe0, 0x46, 0xd3, 0xf2,
+	0x96, 0x4b, 0xcf, 0x8d, 0x85, 0xc6, 0xbe, 0x08, 0x98, 0xcb, 0x99, 0x25, 0xec, 0x54, 0xd4, 0x99,
+	0x3b, 0x9e, 0xf5, 0xc1, 0xba, 0x63, 0xb6, 0x96, 0x34, 0xd7, 0xcc, 0x71, 0x40, 0x24, 0xa7, 0x86,
+	0x3f, 0xf0, 0x93, 0xcf, 0x16, 0xb3, 0x2c, 0x2f, 0x74, 0xb5, 0xa6, 0x0d, 0x11, 0x58, 0xa1, 0xf0,
+	0x82, 0xf8, 0x3c, 0xf8, 0xf7, 0x0b, 0xb2, 0xab, 0xfc, 0x0c, 0x87, 0xf4, 0x15, 0x69, 0x5c, 0x82,
+	0x18, 0x49, 0xd7, 0x9c, 0x76, 0x7a, 0x2a, 0x97, 0xde, 0x0d, 0xdc, 0xc7, 0x12, 0xa3, 0x99, 0x4a,
+	0x7c, 0x67, 0xf3, 0xf2, 0x09, 0xed, 0x93, 0x16, 0x9a, 0x5f, 0x33, 0x2e, 0xae, 0x80, 0x2d, 0x20,
+	0xa0, 0xad, 0x0c, 0x32, 0xb1, 0x1d, 0x43, 0x1f, 0x63, 0x2d, 0x02, 0x7e, 0x22, 0xdd, 0xb3, 0x00,
+	0x98, 0x80, 0x1b, 0xb6, 0x9e, 0x65, 0x35, 0xd1, 0xbd, 0xc4, 0x30, 0x56, 0xce, 0x22, 0x43, 0x0b,
+	0xde, 0xbb, 0xdc, 0xbe, 0x75, 0x67, 0x11, 0x62, 0xcf, 0x49, 0x27, 0xc3, 0x46, 0x97, 0x81, 0x17,
+	0xfa, 0xf4, 0x79, 0x11, 0x97, 0x79, 0x54, 0xea, 0x3a, 0x2f, 0xdf, 0x11, 0x6a, 0x82, 0xbb, 0xd8,
+	0x12, 0xdf, 0x44, 0x33, 0x58, 0x60, 0xfc, 0x72, 0xa5, 0xbf, 0x91, 0xce, 0x1f, 0x21, 0x04, 0x9b,
+	0x3c, 0xa8, 0x9d, 0x15, 0x7b, 0xc5, 0xf8, 0x9d, 0x71, 0x9c, 0x9c, 0x73, 0x36, 0xe7, 0x20, 0x98,
+	0xed, 0xa8, 0xb0, 0x7b, 0x32, 0x6c, 0x1e, 0x4e, 0xab, 0xe6, 0x95, 0xb0, 0xbf, 0x92, 0x2e, 0xfe,
+	0xe0, 0x9c, 0xc5, 0x68, 0x73, 0xba, 0x58, 0x04, 0xf9, 0xd0, 0xf2, 0x6c, 0x1c, 0xe4, 0x71, 0xb3,
+	0x68, 0xec, 0xfe, 0xe3, 0x71, 0x84, 0x5f, 0x92, 0xa3, 0x32, 0x5c, 0x66, 0x0a, 0x85, 0xbb, 0x8d,
+	0x25, 0xc6, 0xb3, 0x6d, 0xd9, 0x4b, 0x47, 0x6f, 0x08, 0x41, 0x47, 0xef, 0x60, 0x39, 0xf5, 0x3c,
+	0xa7, 0x7c, 0xcb, 0xb4, 0x18, 0xfc, 0xda, 0xe6, 0x42, 0x55, 0xfc, 0x14, 0x21, 0xa7, 0x31, 0xf5,
+	0x78, 0x19, 0x73, 0x98, 0x1c, 0xff, 0x52, 0x9c, 0xd5, 0x56, 0x8a, 0x21, 0x64, 0x02, 0xeb, 0x44,
+	0x40, 0xbb, 0x39, 0x54, 0x2a, 0x35, 0xba, 0x75, 0x60, 0xc4, 0xde, 0x90, 0xc3, 0x58, 0x94, 0xab,
+	0x41, 0x66, 0x43, 0x5f, 0x64, 0x6e, 0x6a, 0x0d, 0x8c, 0xa3, 0x82, 0xc7, 0x59, 0x94, 0x55, 0x7e,
+	0x41, 0x5a, 0xe3, 0xa5, 0xef, 0x05, 0x62, 0x1a, 0xd8, 0xab, 0x0f, 0xb0, 0x49, 0x29, 0x97, 0xfa,
+	0x2a, 0xa8, 0xb7, 0xe6, 0x36, 0x22, 0x2d, 0x45, 0x00, 0x4f, 0xde, 0x17, 0x70, 0x5e, 0xf5, 0x53,
+	0x50, 0x1b, 0x9d, 0xfc, 0x4f, 0x95, 0x57, 0x84, 0x3e, 0x06, 0xe4, 0x73, 0x53, 0x66, 0x77, 0x01,
+	0x40, 0x8f, 0xaa, 0x70, 0x81, 0xf2, 0x0a, 0x83, 0x7e, 0x26, 0xbb, 0xa6, 0x6c, 0xd1, 0xb9, 0x43,
+	0x8f, 0x6b, 0x20, 0xa8, 0x00, 0xe7, 0x81, 0xa4, 0x9b, 0xef, 0x20, 0xb8, 0x85, 0x11, 0x73, 0x98,
+	0x6b, 0x01, 0xfd, 0xb2, 0xec, 0x21, 0xaf, 0x2d, 0xf2, 0x20, 0x66, 0x15, 0xfa, 0x38, 0x21, 0x0d,
+	0x8c, 0x33, 0x65, 0x9c, 0xaf, 0x17, 0xf4, 0x59, 0x4d, 0x0a, 0xb1, 0xaa, 0x92, 0xf8, 0x57, 0xe4,
+	0xd3, 0x6b, 0x1c, 0x3a, 0x65, 0xe2, 0x94, 0xcd, 0x5e, 0x91, 0x9d, 0xf7, 0xae, 0x32, 0x3c, 0x28,
+	0x14, 0x11, 0x0b, 0x6b, 0x26, 0x96, 0x64, 0xe5, 0x14, 0x20, 0x90, 0x3d, 0x52, 0x76, 0xae, 0xc7,
+	0x80, 0xd4, 0xa7, 0x34, 0x6e, 0x27, 0x23, 0xee, 0x7f, 0xb1, 0xff, 0x7b, 0xb2, 0x87, 0xb0, 0xa4,
+	0x46, 0xc1, 0x44, 0x58, 0xe9, 0x80, 0x62, 0xba, 0xb1, 0x8d, 0xe2, 0x7f, 0x47, 0x4f, 0xe0, 0xdf,
+	0x57, 0x10, 0xac, 0x6c, 0x58, 0x57, 0x06, 0x8d, 0xbe, 0xae, 0x82, 0x15, 0x62, 0x7f, 0x50, 0x41,
+	0x25, 0x83, 0xea, 0xa0, 0x85, 0x41, 0x91, 0x37, 0x52, 0xfd, 0xdd, 0xd4, 0x51, 0x65, 0x84, 0x7c,
+	0xae, 0x63, 0x6c, 0xb8, 0x3a, 0x32, 0xbe, 0x21, 0xbb, 0x97, 0xe0, 0x9a, 0x00, 0x8b, 0x74, 0x92,
+	0x25, 0xe7, 0x6b, 0xe6, 0xde, 0x16, 0x21, 0x52, 0xaa, 0x21, 0xa2, 0x04, 0x51, 0xe7, 0xd1, 0x66,
+	0xba, 0xae, 0x85, 0xf4, 0x91, 0xf2, 0x6c, 0x05, 0x0a, 0xa3, 0x73, 0xd7, 0x02, 0x05, 0x2a, 0x5f,
+	0xf0, 0x40, 0x4d, 0x2a, 0x4d, 0xd8, 0xfd, 0xdc, 0x0a, 0x4b, 0x58, 0xaa, 0xef, 0x38, 0x37, 0x73,
+	0x10, 0xa3, 0x86, 0xfb, 0x99, 0xdc, 0x82, 0xe9, 0xcc, 0x51, 0xa7, 0xb7, 0xc9, 0xae, 0xac, 0x8b,
+	0x23, 0x75, 0xf1, 0xed, 0x3d, 0x12, 0x73, 0x42, 0xda, 0x71, 0x1c, 0xcf, 0xe5, 0xe0, 0x72, 0xe4,
+	0xc4, 0xe3, 0x70, 0x3f, 0x92, 0xfd, 0xca, 0x82, 0x4b, 0x4b, 0xd3, 0x2b, 0x73, 0xec, 0xd6, 0xad,
+	0xbb, 0xd7, 0x8a, 0xbe, 0x57, 0x10, 0xcd, 0xa2, 0x78, 0xf6, 0x57, 0xc8, 0xd4, 0x4c, 0x77, 0x74,
+	0x94, 0x2c, 0xc8, 0xa7, 0xe7, 0xe1, 0xd2, 0xd7, 0xe3, 0x2e, 0xb7, 0x28, 0x4c, 0x11, 0xd8, 0x78,
+	0xb3, 0x05, 0xc2, 0xc7, 0x32, 0x84, 0xf5, 0xc8, 0xee, 0x9f, 0x10, 0x70, 0x99, 0xd9, 0x96, 0x06,
+	0x49, 0xd4, 0xb2, 0xef, 0xd0, 0xfe, 0x6b, 0xb2, 0x33, 0xe6, 0xe6, 0xc6, 0xb5, 0x3e, 0xd6, 0xe0,
+	0x7d, 0xd2, 0x1e, 0xf3, 0x89, 0xf0, 0xcf, 0x24, 0x39, 0x1f, 0x03, 0xc0, 0x4c, 0x26, 0x38, 0x95,
+	0x6b, 0xda, 0x5b, 0x67, 0x32, 0xf1, 0x16, 0x90, 0x98, 0xa8, 0x5f, 0x24, 0xbb, 0xe6, 0x82, 0x09,
+	0xe6, 0x5c, 0xe0, 0xd0, 0x0f, 0x03, 0xd8, 0x16, 0x01, 0x5b, 0x61, 0x38, 0x50, 0xbf, 0xa8, 0x9b,
+	0xcc, 0x04, 0xd5, 0x31, 0x26, 0xdc, 0x87, 0x20, 0xd9, 0xb6, 0x1d, 0x76, 0xf2, 0xad, 0x7a, 0x43,
+	0xec, 0xeb, 0x26, 0xd3, 0x90, 0xba, 0x47, 0xd6, 0x61, 0xbe, 0xbb, 0x53, 0x43, 0x35, 0xca, 0xd3,
+	0xd1, 0xf0, 0xc0, 0x1e, 0x3f, 0xc8, 0xc3, 0xb3, 0x3d, 0x86, 0x4f, 0x01, 0x33, 0x9c, 0x73, 0x2b,
+	0xb0, 0xe7, 0x50, 0xcc, 0xfa, 0xb8, 0x94, 0x81, 0xa9, 0x6e, 0x1a, 0xd8, 0x32, 0xe5, 0x93, 0x16,
+	0xbf, 0x7c, 0xf2, 0xfa, 0x13, 0xfa, 0x0d, 0x21, 0x78, 0x15, 0x1c, 0x90, 0xc9, 0x21, 0x7c, 0xec,
+	0x2e, 0x7e, 0x51, 0x25, 0x9f, 0x3a, 0x8e, 0xe4, 0xb6, 0x6e, 0xca, 0xf2, 0x4c, 0xd2, 0x05, 0x17,
+	0xcd, 0x14, 0xef, 0x1b, 0xf2, 0x41, 0xa6, 0xde, 0x7b, 0xf4, 0x20, 0x47, 0x44, 0x2d, 0x4c, 0xa1,
+	0x31, 0x17, 0xb5, 0x18, 0xa1, 0x63, 0x62, 0xc4, 0x8d, 0x31, 0xf1, 0x12, 0x7f, 0x75, 0x4f, 0xaf,
+	0x4c, 0xf9, 0x80, 0xab, 0x13, 0xd2, 0x54, 0x5d, 0x7b, 0xc3, 0xdc, 0xc5, 0x24, 0x5c, 0xd2, 0x8c,
+	0xff, 0xf7, 0x52, 0xa4, 0x9a, 0xa8, 0x6e, 0x40, 0x62, 0xeb, 0xbd, 0x5d, 0xd9, 0x96, 0xdc, 0x1b,
+	0x3e, 0xee, 0x0d, 0x2c, 0x61, 0x5b, 0xeb, 0x25, 0x7f, 0x6b, 0xf4, 0xe2, 0xef, 0xe7, 0xb7, 0xb6,
+	0xb8, 0x0b, 0xe7, 0x3d, 0x7c, 0xfd, 0xf7, 0x87, 0x43, 0xcb, 0xed, 0x27, 0x0f, 0xf3, 0xbe, 0x32,
+	0x9c, 0xef, 0xa8, 0x17, 0xfb, 0xf0, 0x3f, 0xbd, 0x26, 0xc6, 0xea, 0x30, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -192,6 +194,8 @@ type Chain33Client interface {
 	GetBlockSequences(ctx context.Context, in *ReqBlocks, opts ...grpc.CallOption) (*BlockSequences, error)
 	//通过block hash 获取对应的blocks信息
 	GetBlockByHashes(ctx context.Context, in *ReqHashes, opts ...grpc.CallOption) (*BlockDetails, error)
+	//从指定的序列号开始推送区块的add/del序列以及对应的区块详情
+	SubscribeBlockSequence(ctx context.Context, in *ReqBlockSeqStream, opts ...grpc.CallOption) (Chain33_SubscribeBlockSequenceClient, error)
 	//关闭chain33
 	CloseQueue(ctx context.Context, in *ReqNil, opts ...grpc.CallOption) (*Reply, error)
 	//获取地址所以合约下的余额
@@ -201,6 +205,8 @@ type Chain33Client interface {
 	CreateNoBalanceTransaction(ctx context.Context, in *NoBalanceTx, opts ...grpc.CallOption) (*ReplySignRawTx, error)
 	// 获取随机HASH
 	QueryRandNum(ctx context.Context, in *ReqRandHash, opts ...grpc.CallOption) (*ReplyHash, error)
+	// 从mempool中删除交易, 只有admin角色可以调用
+	EvictMempoolTx(ctx context.Context, in *ReqHash, opts ...grpc.CallOption) (*Reply, error)
 }
 
 type chain33Client struct {
@@ -616,6 +622,38 @@ func (c *chain33Client) GetBlockByHashes(ctx context.Context, in *ReqHashes, opt
 	return out, nil
 }
 
+func (c *chain33Client) SubscribeBlockSequence(ctx context.Context, in *ReqBlockSeqStream, opts ...grpc.CallOption) (Chain33_SubscribeBlockSequenceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Chain33_serviceDesc.Streams[0], "/types.chain33/SubscribeBlockSequence", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chain33SubscribeBlockSequenceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Chain33_SubscribeBlockSequenceClient interface {
+	Recv() (*BlockSeq, error)
+	grpc.ClientStream
+}
+
+type chain33SubscribeBlockSequenceClient struct {
+	grpc.ClientStream
+}
+
+func (x *chain33SubscribeBlockSequenceClient) Recv() (*BlockSeq, error) {
+	m := new(BlockSeq)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *chain33Client) CloseQueue(ctx context.Context, in *ReqNil, opts ...grpc.CallOption) (*Reply, error) {
 	out := new(Reply)
 	err := c.cc.Invoke(ctx, "/types.chain33/CloseQueue", in, out, opts...)
@@ -661,6 +699,15 @@ func (c *chain33Client) QueryRandNum(ctx context.Context, in *ReqRandHash, opts
 	return out, nil
 }
 
+func (c *chain33Client) EvictMempoolTx(ctx context.Context, in *ReqHash, opts ...grpc.CallOption) (*Reply, error) {
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, "/types.chain33/EvictMempoolTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Chain33Server is the server API for Chain33 service.
 type Chain33Server interface {
 	// chain33 对外提供服务的接口
@@ -750,6 +797,8 @@ type Chain33Server interface {
 	GetBlockSequences(context.Context, *ReqBlocks) (*BlockSequences, error)
 	//通过block hash 获取对应的blocks信息
 	GetBlockByHashes(context.Context, *ReqHashes) (*BlockDetails, error)
+	//从指定的序列号开始推送区块的add/del序列以及对应的区块详情
+	SubscribeBlockSequence(*ReqBlockSeqStream, Chain33_SubscribeBlockSequenceServer) error
 	//关闭chain33
 	CloseQueue(context.Context, *ReqNil) (*Reply, error)
 	//获取地址所以合约下的余额
@@ -759,6 +808,8 @@ type Chain33Server interface {
 	CreateNoBalanceTransaction(context.Context, *NoBalanceTx) (*ReplySignRawTx, error)
 	// 获取随机HASH
 	QueryRandNum(context.Context, *ReqRandHash) (*ReplyHash, error)
+	// 从mempool中删除交易, 只有admin角色可以调用
+	EvictMempoolTx(context.Context, *ReqHash) (*Reply, error)
 }
 
 func RegisterChain33Server(s *grpc.Server, srv Chain33Server) {
@@ -1575,6 +1626,27 @@ func _Chain33_GetBlockByHashes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Chain33_SubscribeBlockSequence_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqBlockSeqStream)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(Chain33Server).SubscribeBlockSequence(m, &chain33SubscribeBlockSequenceServer{stream})
+}
+
+type Chain33_SubscribeBlockSequenceServer interface {
+	Send(*BlockSeq) error
+	grpc.ServerStream
+}
+
+type chain33SubscribeBlockSequenceServer struct {
+	grpc.ServerStream
+}
+
+func (x *chain33SubscribeBlockSequenceServer) Send(m *BlockSeq) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Chain33_CloseQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqNil)
 	if err := dec(in); err != nil {
@@ -1665,6 +1737,24 @@ func _Chain33_QueryRandNum_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Chain33_EvictMempoolTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Chain33Server).EvictMempoolTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.chain33/EvictMempoolTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Chain33Server).EvictMempoolTx(ctx, req.(*ReqHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Chain33_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.chain33",
 	HandlerType: (*Chain33Server)(nil),
@@ -1869,7 +1959,17 @@ var _Chain33_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRandNum",
 			Handler:    _Chain33_QueryRandNum_Handler,
 		},
+		{
+			MethodName: "EvictMempoolTx",
+			Handler:    _Chain33_EvictMempoolTx_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeBlockSequence",
+			Handler:       _Chain33_SubscribeBlockSequence_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
 }
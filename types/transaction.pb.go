@@ -1819,6 +1819,258 @@ func (m *ReqAddrs) GetAddrs() []string {
 	return nil
 }
 
+// mempool 中的交易
+//	 enterTime : 加入 mempool 的时间
+//	 feeRate : 每千字节的手续费
+//	 size : 交易编码之后的字节数
+type MempoolTx struct {
+	Tx                   *Transaction `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	EnterTime            int64        `protobuf:"varint,2,opt,name=enterTime,proto3" json:"enterTime,omitempty"`
+	FeeRate              int64        `protobuf:"varint,3,opt,name=feeRate,proto3" json:"feeRate,omitempty"`
+	Size                 int64        `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MempoolTx) Reset()         { *m = MempoolTx{} }
+func (m *MempoolTx) String() string { return proto.CompactTextString(m) }
+func (*MempoolTx) ProtoMessage()    {}
+func (*MempoolTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{31}
+}
+
+func (m *MempoolTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolTx.Unmarshal(m, b)
+}
+func (m *MempoolTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolTx.Marshal(b, m, deterministic)
+}
+func (m *MempoolTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolTx.Merge(m, src)
+}
+func (m *MempoolTx) XXX_Size() int {
+	return xxx_messageInfo_MempoolTx.Size(m)
+}
+func (m *MempoolTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolTx proto.InternalMessageInfo
+
+func (m *MempoolTx) GetTx() *Transaction {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *MempoolTx) GetEnterTime() int64 {
+	if m != nil {
+		return m.EnterTime
+	}
+	return 0
+}
+
+func (m *MempoolTx) GetFeeRate() int64 {
+	if m != nil {
+		return m.FeeRate
+	}
+	return 0
+}
+
+func (m *MempoolTx) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// 手续费率在 [minFeeRate, 下一个 bucket 的 minFeeRate) 之间的交易个数
+type MempoolFeeBucket struct {
+	MinFeeRate           int64    `protobuf:"varint,1,opt,name=minFeeRate,proto3" json:"minFeeRate,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolFeeBucket) Reset()         { *m = MempoolFeeBucket{} }
+func (m *MempoolFeeBucket) String() string { return proto.CompactTextString(m) }
+func (*MempoolFeeBucket) ProtoMessage()    {}
+func (*MempoolFeeBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{32}
+}
+
+func (m *MempoolFeeBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolFeeBucket.Unmarshal(m, b)
+}
+func (m *MempoolFeeBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolFeeBucket.Marshal(b, m, deterministic)
+}
+func (m *MempoolFeeBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolFeeBucket.Merge(m, src)
+}
+func (m *MempoolFeeBucket) XXX_Size() int {
+	return xxx_messageInfo_MempoolFeeBucket.Size(m)
+}
+func (m *MempoolFeeBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolFeeBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolFeeBucket proto.InternalMessageInfo
+
+func (m *MempoolFeeBucket) GetMinFeeRate() int64 {
+	if m != nil {
+		return m.MinFeeRate
+	}
+	return 0
+}
+
+func (m *MempoolFeeBucket) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type MempoolExecerCount struct {
+	Execer               string   `protobuf:"bytes,1,opt,name=execer,proto3" json:"execer,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MempoolExecerCount) Reset()         { *m = MempoolExecerCount{} }
+func (m *MempoolExecerCount) String() string { return proto.CompactTextString(m) }
+func (*MempoolExecerCount) ProtoMessage()    {}
+func (*MempoolExecerCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{33}
+}
+
+func (m *MempoolExecerCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolExecerCount.Unmarshal(m, b)
+}
+func (m *MempoolExecerCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolExecerCount.Marshal(b, m, deterministic)
+}
+func (m *MempoolExecerCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolExecerCount.Merge(m, src)
+}
+func (m *MempoolExecerCount) XXX_Size() int {
+	return xxx_messageInfo_MempoolExecerCount.Size(m)
+}
+func (m *MempoolExecerCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolExecerCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolExecerCount proto.InternalMessageInfo
+
+func (m *MempoolExecerCount) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *MempoolExecerCount) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// mempool 统计信息
+//	 bytes : 所有交易的字节数
+//	 oldestHash, oldestEnterTime, oldestAge : 最早加入的交易, 加入的时间以及已经等待的秒数
+type MempoolStat struct {
+	Size                 int64                 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Bytes                int64                 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	FeeHistogram         []*MempoolFeeBucket   `protobuf:"bytes,3,rep,name=feeHistogram,proto3" json:"feeHistogram,omitempty"`
+	Execers              []*MempoolExecerCount `protobuf:"bytes,4,rep,name=execers,proto3" json:"execers,omitempty"`
+	OldestHash           []byte                `protobuf:"bytes,5,opt,name=oldestHash,proto3" json:"oldestHash,omitempty"`
+	OldestEnterTime      int64                 `protobuf:"varint,6,opt,name=oldestEnterTime,proto3" json:"oldestEnterTime,omitempty"`
+	OldestAge            int64                 `protobuf:"varint,7,opt,name=oldestAge,proto3" json:"oldestAge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MempoolStat) Reset()         { *m = MempoolStat{} }
+func (m *MempoolStat) String() string { return proto.CompactTextString(m) }
+func (*MempoolStat) ProtoMessage()    {}
+func (*MempoolStat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{34}
+}
+
+func (m *MempoolStat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MempoolStat.Unmarshal(m, b)
+}
+func (m *MempoolStat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MempoolStat.Marshal(b, m, deterministic)
+}
+func (m *MempoolStat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MempoolStat.Merge(m, src)
+}
+func (m *MempoolStat) XXX_Size() int {
+	return xxx_messageInfo_MempoolStat.Size(m)
+}
+func (m *MempoolStat) XXX_DiscardUnknown() {
+	xxx_messageInfo_MempoolStat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MempoolStat proto.InternalMessageInfo
+
+func (m *MempoolStat) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *MempoolStat) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *MempoolStat) GetFeeHistogram() []*MempoolFeeBucket {
+	if m != nil {
+		return m.FeeHistogram
+	}
+	return nil
+}
+
+func (m *MempoolStat) GetExecers() []*MempoolExecerCount {
+	if m != nil {
+		return m.Execers
+	}
+	return nil
+}
+
+func (m *MempoolStat) GetOldestHash() []byte {
+	if m != nil {
+		return m.OldestHash
+	}
+	return nil
+}
+
+func (m *MempoolStat) GetOldestEnterTime() int64 {
+	if m != nil {
+		return m.OldestEnterTime
+	}
+	return 0
+}
+
+func (m *MempoolStat) GetOldestAge() int64 {
+	if m != nil {
+		return m.OldestAge
+	}
+	return 0
+}
+
 type ReqDecodeRawTransaction struct {
 	TxHex                string   `protobuf:"bytes,1,opt,name=txHex,proto3" json:"txHex,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1830,7 +2082,7 @@ func (m *ReqDecodeRawTransaction) Reset()         { *m = ReqDecodeRawTransaction
 func (m *ReqDecodeRawTransaction) String() string { return proto.CompactTextString(m) }
 func (*ReqDecodeRawTransaction) ProtoMessage()    {}
 func (*ReqDecodeRawTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2cc4e03d2c28c490, []int{31}
+	return fileDescriptor_2cc4e03d2c28c490, []int{35}
 }
 
 func (m *ReqDecodeRawTransaction) XXX_Unmarshal(b []byte) error {
@@ -1870,7 +2122,7 @@ func (m *UserWrite) Reset()         { *m = UserWrite{} }
 func (m *UserWrite) String() string { return proto.CompactTextString(m) }
 func (*UserWrite) ProtoMessage()    {}
 func (*UserWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2cc4e03d2c28c490, []int{32}
+	return fileDescriptor_2cc4e03d2c28c490, []int{36}
 }
 
 func (m *UserWrite) XXX_Unmarshal(b []byte) error {
@@ -1918,7 +2170,7 @@ func (m *UpgradeMeta) Reset()         { *m = UpgradeMeta{} }
 func (m *UpgradeMeta) String() string { return proto.CompactTextString(m) }
 func (*UpgradeMeta) ProtoMessage()    {}
 func (*UpgradeMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2cc4e03d2c28c490, []int{33}
+	return fileDescriptor_2cc4e03d2c28c490, []int{37}
 }
 
 func (m *UpgradeMeta) XXX_Unmarshal(b []byte) error {
@@ -1960,6 +2212,603 @@ func (m *UpgradeMeta) GetHeight() int64 {
 	return 0
 }
 
+//回执日志索引, 按照 执行器/日志类型/高度 保存在localdb 中
+type ReceiptLogIndex struct {
+	TxHash               []byte   `protobuf:"bytes,1,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	BlockHash            []byte   `protobuf:"bytes,2,opt,name=blockHash,proto3" json:"blockHash,omitempty"`
+	Height               int64    `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	TxIndex              int32    `protobuf:"varint,4,opt,name=txIndex,proto3" json:"txIndex,omitempty"`
+	LogIndex             int32    `protobuf:"varint,5,opt,name=logIndex,proto3" json:"logIndex,omitempty"`
+	Execer               string   `protobuf:"bytes,6,opt,name=execer,proto3" json:"execer,omitempty"`
+	Ty                   int32    `protobuf:"varint,7,opt,name=ty,proto3" json:"ty,omitempty"`
+	Log                  []byte   `protobuf:"bytes,8,opt,name=log,proto3" json:"log,omitempty"`
+	From                 string   `protobuf:"bytes,9,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,10,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReceiptLogIndex) Reset()         { *m = ReceiptLogIndex{} }
+func (m *ReceiptLogIndex) String() string { return proto.CompactTextString(m) }
+func (*ReceiptLogIndex) ProtoMessage()    {}
+func (*ReceiptLogIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{38}
+}
+
+func (m *ReceiptLogIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReceiptLogIndex.Unmarshal(m, b)
+}
+func (m *ReceiptLogIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReceiptLogIndex.Marshal(b, m, deterministic)
+}
+func (m *ReceiptLogIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReceiptLogIndex.Merge(m, src)
+}
+func (m *ReceiptLogIndex) XXX_Size() int {
+	return xxx_messageInfo_ReceiptLogIndex.Size(m)
+}
+func (m *ReceiptLogIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReceiptLogIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReceiptLogIndex proto.InternalMessageInfo
+
+func (m *ReceiptLogIndex) GetTxHash() []byte {
+	if m != nil {
+		return m.TxHash
+	}
+	return nil
+}
+
+func (m *ReceiptLogIndex) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *ReceiptLogIndex) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ReceiptLogIndex) GetTxIndex() int32 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *ReceiptLogIndex) GetLogIndex() int32 {
+	if m != nil {
+		return m.LogIndex
+	}
+	return 0
+}
+
+func (m *ReceiptLogIndex) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *ReceiptLogIndex) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *ReceiptLogIndex) GetLog() []byte {
+	if m != nil {
+		return m.Log
+	}
+	return nil
+}
+
+func (m *ReceiptLogIndex) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ReceiptLogIndex) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+//查询回执日志
+//	 logTypes : 为空时查询执行器的所有日志
+//	 toHeight : -1 表示到最新的高度
+//	 addrs : 交易的 from 或者 to 是其中的地址
+//	 cursor : 分页查询, 上一次返回的 cursor
+type ReqGetLogs struct {
+	Execer               string   `protobuf:"bytes,1,opt,name=execer,proto3" json:"execer,omitempty"`
+	LogTypes             []int32  `protobuf:"varint,2,rep,packed,name=logTypes,proto3" json:"logTypes,omitempty"`
+	FromHeight           int64    `protobuf:"varint,3,opt,name=fromHeight,proto3" json:"fromHeight,omitempty"`
+	ToHeight             int64    `protobuf:"varint,4,opt,name=toHeight,proto3" json:"toHeight,omitempty"`
+	Addrs                []string `protobuf:"bytes,5,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Count                int32    `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	Cursor               string   `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqGetLogs) Reset()         { *m = ReqGetLogs{} }
+func (m *ReqGetLogs) String() string { return proto.CompactTextString(m) }
+func (*ReqGetLogs) ProtoMessage()    {}
+func (*ReqGetLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{39}
+}
+
+func (m *ReqGetLogs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqGetLogs.Unmarshal(m, b)
+}
+func (m *ReqGetLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqGetLogs.Marshal(b, m, deterministic)
+}
+func (m *ReqGetLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqGetLogs.Merge(m, src)
+}
+func (m *ReqGetLogs) XXX_Size() int {
+	return xxx_messageInfo_ReqGetLogs.Size(m)
+}
+func (m *ReqGetLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqGetLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqGetLogs proto.InternalMessageInfo
+
+func (m *ReqGetLogs) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *ReqGetLogs) GetLogTypes() []int32 {
+	if m != nil {
+		return m.LogTypes
+	}
+	return nil
+}
+
+func (m *ReqGetLogs) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *ReqGetLogs) GetToHeight() int64 {
+	if m != nil {
+		return m.ToHeight
+	}
+	return 0
+}
+
+func (m *ReqGetLogs) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *ReqGetLogs) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqGetLogs) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+//cursor 为空表示已经查询完
+type ReplyGetLogs struct {
+	Logs                 []*ReceiptLogIndex `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Cursor               string             `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ReplyGetLogs) Reset()         { *m = ReplyGetLogs{} }
+func (m *ReplyGetLogs) String() string { return proto.CompactTextString(m) }
+func (*ReplyGetLogs) ProtoMessage()    {}
+func (*ReplyGetLogs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{40}
+}
+
+func (m *ReplyGetLogs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyGetLogs.Unmarshal(m, b)
+}
+func (m *ReplyGetLogs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyGetLogs.Marshal(b, m, deterministic)
+}
+func (m *ReplyGetLogs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyGetLogs.Merge(m, src)
+}
+func (m *ReplyGetLogs) XXX_Size() int {
+	return xxx_messageInfo_ReplyGetLogs.Size(m)
+}
+func (m *ReplyGetLogs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyGetLogs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyGetLogs proto.InternalMessageInfo
+
+func (m *ReplyGetLogs) GetLogs() []*ReceiptLogIndex {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *ReplyGetLogs) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+//地址交易的过滤索引
+//	 direction : 1 表示地址是 from, 2 表示地址是 to, 3 表示既是 from 又是 to
+//	 ty : 交易回执的类型, ExecOk 表示执行成功
+type AddrTxIndex struct {
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height               int64    `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Index                int64    `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Execer               string   `protobuf:"bytes,4,opt,name=execer,proto3" json:"execer,omitempty"`
+	ActionName           string   `protobuf:"bytes,5,opt,name=actionName,proto3" json:"actionName,omitempty"`
+	Ty                   int32    `protobuf:"varint,6,opt,name=ty,proto3" json:"ty,omitempty"`
+	Blocktime            int64    `protobuf:"varint,7,opt,name=blocktime,proto3" json:"blocktime,omitempty"`
+	Direction            int32    `protobuf:"varint,8,opt,name=direction,proto3" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddrTxIndex) Reset()         { *m = AddrTxIndex{} }
+func (m *AddrTxIndex) String() string { return proto.CompactTextString(m) }
+func (*AddrTxIndex) ProtoMessage()    {}
+func (*AddrTxIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{41}
+}
+
+func (m *AddrTxIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddrTxIndex.Unmarshal(m, b)
+}
+func (m *AddrTxIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddrTxIndex.Marshal(b, m, deterministic)
+}
+func (m *AddrTxIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrTxIndex.Merge(m, src)
+}
+func (m *AddrTxIndex) XXX_Size() int {
+	return xxx_messageInfo_AddrTxIndex.Size(m)
+}
+func (m *AddrTxIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrTxIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrTxIndex proto.InternalMessageInfo
+
+func (m *AddrTxIndex) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *AddrTxIndex) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *AddrTxIndex) GetIndex() int64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *AddrTxIndex) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *AddrTxIndex) GetActionName() string {
+	if m != nil {
+		return m.ActionName
+	}
+	return ""
+}
+
+func (m *AddrTxIndex) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *AddrTxIndex) GetBlocktime() int64 {
+	if m != nil {
+		return m.Blocktime
+	}
+	return 0
+}
+
+func (m *AddrTxIndex) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+//地址按照 执行器/action/方向/回执类型 统计的交易数
+type AddrTxCount struct {
+	Execer               string   `protobuf:"bytes,1,opt,name=execer,proto3" json:"execer,omitempty"`
+	ActionName           string   `protobuf:"bytes,2,opt,name=actionName,proto3" json:"actionName,omitempty"`
+	Direction            int32    `protobuf:"varint,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	Ty                   int32    `protobuf:"varint,4,opt,name=ty,proto3" json:"ty,omitempty"`
+	Count                int64    `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddrTxCount) Reset()         { *m = AddrTxCount{} }
+func (m *AddrTxCount) String() string { return proto.CompactTextString(m) }
+func (*AddrTxCount) ProtoMessage()    {}
+func (*AddrTxCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{42}
+}
+
+func (m *AddrTxCount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddrTxCount.Unmarshal(m, b)
+}
+func (m *AddrTxCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddrTxCount.Marshal(b, m, deterministic)
+}
+func (m *AddrTxCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrTxCount.Merge(m, src)
+}
+func (m *AddrTxCount) XXX_Size() int {
+	return xxx_messageInfo_AddrTxCount.Size(m)
+}
+func (m *AddrTxCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrTxCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrTxCount proto.InternalMessageInfo
+
+func (m *AddrTxCount) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *AddrTxCount) GetActionName() string {
+	if m != nil {
+		return m.ActionName
+	}
+	return ""
+}
+
+func (m *AddrTxCount) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func (m *AddrTxCount) GetTy() int32 {
+	if m != nil {
+		return m.Ty
+	}
+	return 0
+}
+
+func (m *AddrTxCount) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+//按照条件查询地址的交易
+//	 execer : 为空时查询所有执行器, actionName 需要和 execer 一起使用
+//	 flag : 0 所有, 1 地址是 from, 2 地址是 to
+//	 status : 0 所有, 1 执行成功, 2 执行失败
+//	 startTime, endTime : 区块时间范围, 为 0 表示不限制
+//	 direction : 0 从新到旧, 1 从旧到新
+//	 cursor : 上一页返回的 cursor
+type ReqTxsByAddrFilter struct {
+	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Execer               string   `protobuf:"bytes,2,opt,name=execer,proto3" json:"execer,omitempty"`
+	ActionName           string   `protobuf:"bytes,3,opt,name=actionName,proto3" json:"actionName,omitempty"`
+	Flag                 int32    `protobuf:"varint,4,opt,name=flag,proto3" json:"flag,omitempty"`
+	Status               int32    `protobuf:"varint,5,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime            int64    `protobuf:"varint,6,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64    `protobuf:"varint,7,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Count                int32    `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	Direction            int32    `protobuf:"varint,9,opt,name=direction,proto3" json:"direction,omitempty"`
+	Cursor               string   `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqTxsByAddrFilter) Reset()         { *m = ReqTxsByAddrFilter{} }
+func (m *ReqTxsByAddrFilter) String() string { return proto.CompactTextString(m) }
+func (*ReqTxsByAddrFilter) ProtoMessage()    {}
+func (*ReqTxsByAddrFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{43}
+}
+
+func (m *ReqTxsByAddrFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTxsByAddrFilter.Unmarshal(m, b)
+}
+func (m *ReqTxsByAddrFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTxsByAddrFilter.Marshal(b, m, deterministic)
+}
+func (m *ReqTxsByAddrFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTxsByAddrFilter.Merge(m, src)
+}
+func (m *ReqTxsByAddrFilter) XXX_Size() int {
+	return xxx_messageInfo_ReqTxsByAddrFilter.Size(m)
+}
+func (m *ReqTxsByAddrFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTxsByAddrFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTxsByAddrFilter proto.InternalMessageInfo
+
+func (m *ReqTxsByAddrFilter) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ReqTxsByAddrFilter) GetExecer() string {
+	if m != nil {
+		return m.Execer
+	}
+	return ""
+}
+
+func (m *ReqTxsByAddrFilter) GetActionName() string {
+	if m != nil {
+		return m.ActionName
+	}
+	return ""
+}
+
+func (m *ReqTxsByAddrFilter) GetFlag() int32 {
+	if m != nil {
+		return m.Flag
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetDirection() int32 {
+	if m != nil {
+		return m.Direction
+	}
+	return 0
+}
+
+func (m *ReqTxsByAddrFilter) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+//total 为符合条件的交易总数, 指定时间范围并且扫描的索引数达到上限时为 -1
+//cursor 为空表示已经查询完
+type ReplyTxsByAddrFilter struct {
+	Txs                  []*AddrTxIndex `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	Total                int64          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Cursor               string         `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ReplyTxsByAddrFilter) Reset()         { *m = ReplyTxsByAddrFilter{} }
+func (m *ReplyTxsByAddrFilter) String() string { return proto.CompactTextString(m) }
+func (*ReplyTxsByAddrFilter) ProtoMessage()    {}
+func (*ReplyTxsByAddrFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2cc4e03d2c28c490, []int{44}
+}
+
+func (m *ReplyTxsByAddrFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplyTxsByAddrFilter.Unmarshal(m, b)
+}
+func (m *ReplyTxsByAddrFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplyTxsByAddrFilter.Marshal(b, m, deterministic)
+}
+func (m *ReplyTxsByAddrFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplyTxsByAddrFilter.Merge(m, src)
+}
+func (m *ReplyTxsByAddrFilter) XXX_Size() int {
+	return xxx_messageInfo_ReplyTxsByAddrFilter.Size(m)
+}
+func (m *ReplyTxsByAddrFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplyTxsByAddrFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplyTxsByAddrFilter proto.InternalMessageInfo
+
+func (m *ReplyTxsByAddrFilter) GetTxs() []*AddrTxIndex {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *ReplyTxsByAddrFilter) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ReplyTxsByAddrFilter) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AssetsGenesis)(nil), "types.AssetsGenesis")
 	proto.RegisterType((*AssetsTransferToExec)(nil), "types.AssetsTransferToExec")
@@ -1992,95 +2841,138 @@ func init() {
 	proto.RegisterType((*TransactionDetail)(nil), "types.TransactionDetail")
 	proto.RegisterType((*TransactionDetails)(nil), "types.TransactionDetails")
 	proto.RegisterType((*ReqAddrs)(nil), "types.ReqAddrs")
+	proto.RegisterType((*MempoolTx)(nil), "types.MempoolTx")
+	proto.RegisterType((*MempoolFeeBucket)(nil), "types.MempoolFeeBucket")
+	proto.RegisterType((*MempoolExecerCount)(nil), "types.MempoolExecerCount")
+	proto.RegisterType((*MempoolStat)(nil), "types.MempoolStat")
 	proto.RegisterType((*ReqDecodeRawTransaction)(nil), "types.ReqDecodeRawTransaction")
 	proto.RegisterType((*UserWrite)(nil), "types.UserWrite")
 	proto.RegisterType((*UpgradeMeta)(nil), "types.UpgradeMeta")
+	proto.RegisterType((*ReceiptLogIndex)(nil), "types.ReceiptLogIndex")
+	proto.RegisterType((*ReqGetLogs)(nil), "types.ReqGetLogs")
+	proto.RegisterType((*ReplyGetLogs)(nil), "types.ReplyGetLogs")
+	proto.RegisterType((*AddrTxIndex)(nil), "types.AddrTxIndex")
+	proto.RegisterType((*AddrTxCount)(nil), "types.AddrTxCount")
+	proto.RegisterType((*ReqTxsByAddrFilter)(nil), "types.ReqTxsByAddrFilter")
+	proto.RegisterType((*ReplyTxsByAddrFilter)(nil), "types.ReplyTxsByAddrFilter")
 }
 
 func init() { proto.RegisterFile("transaction.proto", fileDescriptor_2cc4e03d2c28c490) }
 
 var fileDescriptor_2cc4e03d2c28c490 = []byte{
-	// 1300 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x57, 0xdd, 0x8e, 0x13, 0xc7,
-	0x12, 0xd6, 0xcc, 0xd8, 0x5e, 0xbb, 0x6c, 0x38, 0xec, 0x08, 0x81, 0x85, 0x38, 0xb0, 0xa7, 0xc5,
-	0x91, 0x10, 0x42, 0x5e, 0x69, 0x97, 0xbb, 0x73, 0xa4, 0x04, 0xd8, 0x08, 0xd0, 0x02, 0x49, 0x1a,
-	0x03, 0x51, 0x12, 0x45, 0xea, 0x1d, 0xd7, 0xda, 0x1d, 0xec, 0x69, 0xef, 0x4c, 0x7b, 0x19, 0xbf,
-	0x40, 0x6e, 0x92, 0xbb, 0x3c, 0x52, 0x5e, 0x20, 0x8f, 0x14, 0x75, 0x75, 0xf7, 0x4c, 0x7b, 0x7f,
-	0x10, 0x17, 0x91, 0x72, 0xd7, 0x5f, 0x75, 0xb9, 0x7e, 0xbf, 0xaa, 0x1e, 0xc3, 0xb6, 0x2e, 0x44,
-	0x5e, 0x8a, 0x4c, 0x4b, 0x95, 0x8f, 0x96, 0x85, 0xd2, 0x2a, 0x6d, 0xeb, 0xf5, 0x12, 0xcb, 0x5b,
-	0x83, 0x4c, 0x2d, 0x16, 0x5e, 0xc8, 0x5e, 0xc1, 0x95, 0xc7, 0x65, 0x89, 0xba, 0x7c, 0x86, 0x39,
-	0x96, 0xb2, 0x4c, 0x6f, 0x40, 0x47, 0x2c, 0xd4, 0x2a, 0xd7, 0xc3, 0x78, 0x27, 0xba, 0x9f, 0x70,
-	0x87, 0xd2, 0x7b, 0x70, 0xa5, 0x40, 0xbd, 0x2a, 0xf2, 0xc7, 0x93, 0x49, 0x81, 0x65, 0x39, 0x4c,
-	0x76, 0xa2, 0xfb, 0x3d, 0xbe, 0x29, 0x64, 0xbf, 0x45, 0x70, 0xdd, 0xda, 0x1b, 0x1b, 0xff, 0xc7,
-	0x58, 0x8c, 0xd5, 0x57, 0x15, 0x66, 0xe9, 0x6d, 0xe8, 0x65, 0x4a, 0xe6, 0x5a, 0x7d, 0xc0, 0x7c,
-	0x18, 0xd1, 0x4f, 0x1b, 0xc1, 0xa5, 0x4e, 0x53, 0x68, 0xe5, 0x4a, 0x23, 0xf9, 0x1a, 0x70, 0x3a,
-	0xa7, 0xb7, 0xa0, 0x8b, 0x15, 0x66, 0xaf, 0xc5, 0x02, 0x87, 0x2d, 0x32, 0x54, 0xe3, 0xf4, 0x2a,
-	0xc4, 0x5a, 0x0d, 0xdb, 0x24, 0x8d, 0xb5, 0x62, 0xbf, 0x44, 0x70, 0xd5, 0x86, 0xf3, 0x5e, 0xea,
-	0xd9, 0xa4, 0x10, 0x1f, 0xff, 0xa1, 0x40, 0x7e, 0xf6, 0x71, 0xf8, 0xb2, 0xfc, 0x8d, 0x71, 0x58,
-	0x5f, 0xad, 0xda, 0xd7, 0x21, 0xb4, 0xc9, 0x97, 0x51, 0x36, 0x01, 0x39, 0xeb, 0x74, 0x36, 0x86,
-	0xcb, 0xf5, 0xe2, 0x48, 0xcd, 0xc9, 0x70, 0x8f, 0x3b, 0x14, 0x38, 0x4c, 0x42, 0x87, 0xec, 0xcf,
-	0x08, 0xba, 0x4f, 0x0b, 0x14, 0x1a, 0xc7, 0x95, 0xf3, 0x14, 0x79, 0x4f, 0x97, 0x46, 0x79, 0x0d,
-	0x92, 0x63, 0x44, 0x67, 0xc9, 0x1c, 0xeb, 0xb8, 0x5b, 0x41, 0xdc, 0x77, 0x00, 0x64, 0xdd, 0x17,
-	0xaa, 0x55, 0x97, 0x07, 0x92, 0x74, 0x08, 0x5b, 0xb2, 0x1c, 0x53, 0x7d, 0x3a, 0x74, 0xe9, 0x61,
-	0xba, 0x03, 0x7d, 0x2a, 0xd3, 0x1b, 0x9b, 0xc9, 0x16, 0x05, 0x14, 0x8a, 0x36, 0x7a, 0xd3, 0xdd,
-	0xec, 0x0d, 0x7b, 0x00, 0x37, 0x5c, 0x46, 0xcd, 0x88, 0x3c, 0x2b, 0xd4, 0x6a, 0x69, 0xe2, 0xd6,
-	0x55, 0x39, 0x8c, 0x76, 0x92, 0xfb, 0x3d, 0x6e, 0x8e, 0xec, 0x0e, 0x74, 0xdf, 0xe6, 0xa5, 0x9c,
-	0xe6, 0xe3, 0xca, 0xe4, 0x30, 0x11, 0x5a, 0x50, 0xfe, 0x03, 0x4e, 0x67, 0xa6, 0xa0, 0xff, 0x5a,
-	0x3d, 0x11, 0x73, 0x91, 0x67, 0xa6, 0x40, 0xd7, 0xa1, 0xad, 0xab, 0xe7, 0x58, 0xb9, 0x1a, 0x59,
-	0x60, 0x12, 0x59, 0x8a, 0xb5, 0x19, 0x11, 0x57, 0x74, 0x0f, 0xe9, 0xa6, 0x90, 0xa7, 0x1f, 0x70,
-	0xed, 0xc6, 0xc9, 0x43, 0x53, 0x5a, 0xac, 0x96, 0xb2, 0xf0, 0xd4, 0x72, 0x88, 0xfd, 0x04, 0xdd,
-	0x37, 0x72, 0x9a, 0xe3, 0x64, 0x5c, 0x19, 0x9d, 0x15, 0x05, 0xe7, 0x42, 0x72, 0xc8, 0x04, 0x4a,
-	0xd2, 0xd8, 0x06, 0x4a, 0xb2, 0x1b, 0xd0, 0x59, 0xae, 0x8e, 0xbc, 0xa3, 0x01, 0x77, 0x88, 0x5a,
-	0xba, 0x26, 0x1f, 0x6d, 0x1e, 0xeb, 0x35, 0xfb, 0x35, 0x86, 0x7e, 0x50, 0x17, 0x1b, 0x07, 0x66,
-	0x58, 0x78, 0x1f, 0x16, 0xb9, 0x9c, 0xe6, 0x4a, 0x4c, 0x9c, 0x1b, 0x0f, 0xd3, 0x11, 0xf4, 0x8c,
-	0x47, 0xa1, 0x57, 0x85, 0xa5, 0x40, 0x7f, 0xef, 0xda, 0x88, 0x56, 0xcf, 0xe8, 0x8d, 0x97, 0xf3,
-	0x46, 0xc5, 0x93, 0xa5, 0xd5, 0x90, 0xa5, 0xc9, 0xbd, 0x6d, 0x69, 0x65, 0x91, 0xa9, 0x6e, 0xae,
-	0xf2, 0x0c, 0x89, 0x0e, 0x09, 0xb7, 0xc0, 0x91, 0x72, 0xab, 0x26, 0xe5, 0x1d, 0x80, 0xa9, 0xe9,
-	0xe6, 0x53, 0x22, 0x66, 0x97, 0x32, 0x0b, 0x24, 0xc6, 0xfa, 0x0c, 0xc5, 0x04, 0x8b, 0x61, 0xcf,
-	0x66, 0x64, 0x11, 0x51, 0x14, 0x2b, 0x3d, 0x04, 0x47, 0x51, 0xac, 0x34, 0x7b, 0x04, 0x83, 0xa0,
-	0x18, 0x65, 0x7a, 0xaf, 0x21, 0x48, 0x7f, 0x2f, 0x75, 0x59, 0x05, 0x1a, 0x96, 0x34, 0x5f, 0xc0,
-	0x15, 0x2e, 0xf3, 0x69, 0x9d, 0x6d, 0x3a, 0x82, 0xb6, 0xd4, 0xb8, 0xf0, 0x3f, 0x1c, 0xba, 0x1f,
-	0x6e, 0x28, 0xbd, 0xd0, 0xb8, 0xe0, 0x56, 0x8d, 0xbd, 0x80, 0xed, 0x73, 0x77, 0x41, 0x07, 0x8d,
-	0x95, 0xa6, 0x83, 0xb7, 0xc3, 0x7a, 0xc7, 0x74, 0xd5, 0x08, 0xd8, 0xb7, 0xd0, 0x6b, 0xe2, 0xb0,
-	0xcd, 0x8e, 0x7c, 0xb3, 0x03, 0x93, 0xf1, 0x06, 0x29, 0x6e, 0x9f, 0x6d, 0xe1, 0x86, 0xc9, 0x1f,
-	0x61, 0x60, 0xc8, 0xfb, 0xf5, 0x29, 0x16, 0xa7, 0x12, 0x69, 0x4e, 0x0b, 0xcc, 0xe4, 0xa9, 0xe3,
-	0x48, 0xc2, 0x3d, 0x34, 0x37, 0x47, 0x76, 0x36, 0xdc, 0x82, 0xf0, 0xd0, 0xdc, 0xe8, 0xea, 0x69,
-	0xb0, 0x6f, 0x3c, 0x64, 0xbf, 0x47, 0xb0, 0xc5, 0xf1, 0x84, 0xc6, 0x23, 0x85, 0x96, 0x30, 0x53,
-	0xe3, 0x16, 0x98, 0x70, 0xb2, 0xe3, 0xb9, 0x98, 0x92, 0xc1, 0x36, 0xa7, 0xb3, 0x21, 0x46, 0x56,
-	0xdb, 0x6a, 0x73, 0x0b, 0x4c, 0x16, 0x13, 0x59, 0x20, 0x35, 0xc6, 0x31, 0xbc, 0x11, 0x58, 0x1a,
-	0xc8, 0xe9, 0x4c, 0x7b, 0x92, 0x59, 0x64, 0x6c, 0xc9, 0x7c, 0x82, 0x95, 0x27, 0x19, 0x01, 0xf6,
-	0x1d, 0x00, 0xc7, 0x93, 0x6f, 0x0a, 0x79, 0x2a, 0xb2, 0x75, 0xe3, 0x2f, 0xba, 0xd4, 0x5f, 0x7c,
-	0xb9, 0xbf, 0x24, 0xf4, 0xc7, 0x6e, 0x42, 0xfb, 0x39, 0x56, 0x6e, 0xb9, 0x56, 0xf5, 0x72, 0xad,
-	0xd8, 0x0a, 0xfa, 0x1c, 0x97, 0xf3, 0xf5, 0xb8, 0x7a, 0x91, 0x1f, 0x2b, 0x93, 0xf7, 0x4c, 0x94,
-	0x33, 0xbf, 0x7d, 0xcc, 0x39, 0xb0, 0x19, 0x5f, 0x9c, 0x43, 0x12, 0xe4, 0x90, 0xde, 0x83, 0x8e,
-	0xa0, 0x37, 0x68, 0xd8, 0x22, 0x1a, 0x0e, 0x1c, 0x0d, 0xe9, 0xb1, 0xe0, 0xee, 0x8e, 0xfd, 0x07,
-	0x7a, 0x1c, 0x4f, 0xc6, 0xd5, 0x4b, 0x59, 0xea, 0xcd, 0x44, 0x13, 0x97, 0x28, 0xdb, 0xaf, 0x23,
-	0x23, 0xa5, 0xcf, 0x1b, 0x0a, 0x0e, 0x30, 0xae, 0x9e, 0x8b, 0x72, 0x46, 0xbf, 0x31, 0x91, 0x8b,
-	0x72, 0x86, 0xa5, 0x27, 0xb3, 0x45, 0x8d, 0xc3, 0x38, 0x70, 0x18, 0x2c, 0x84, 0x64, 0x27, 0x69,
-	0x16, 0x02, 0xfb, 0x3f, 0x0c, 0x82, 0x12, 0x95, 0xe9, 0x43, 0xc3, 0x2a, 0x3a, 0x9e, 0x89, 0x26,
-	0xd0, 0xe2, 0x5e, 0x85, 0x8d, 0x4c, 0x4f, 0x33, 0x94, 0x4b, 0xfd, 0x52, 0x4d, 0xcf, 0xcd, 0xc6,
-	0x35, 0x48, 0xe6, 0x6a, 0xea, 0x06, 0xc3, 0x1c, 0x99, 0x30, 0xc4, 0x24, 0xfd, 0x73, 0xca, 0x77,
-	0x21, 0x3e, 0x7c, 0x47, 0xc3, 0xd7, 0xdf, 0xfb, 0x97, 0xf3, 0x79, 0x88, 0xeb, 0x77, 0x62, 0xbe,
-	0x42, 0x1e, 0x1f, 0xbe, 0x4b, 0xff, 0x0b, 0xad, 0xb9, 0x9a, 0x96, 0x14, 0x7f, 0x7f, 0x6f, 0xbb,
-	0x0e, 0xcb, 0xbb, 0xe7, 0x74, 0xcd, 0x0e, 0x4c, 0x65, 0x49, 0x76, 0x20, 0xb4, 0x38, 0xe7, 0xe6,
-	0x33, 0xad, 0x98, 0x37, 0x7b, 0x5c, 0x71, 0x2c, 0x57, 0x73, 0x1d, 0x70, 0x24, 0xba, 0x98, 0x23,
-	0x96, 0xa9, 0x8e, 0x23, 0x8c, 0x48, 0x68, 0xb7, 0xf6, 0x45, 0xad, 0x8c, 0x75, 0x95, 0x3e, 0x82,
-	0x7e, 0x61, 0x5d, 0x4e, 0x84, 0x7b, 0xd2, 0xc3, 0x4a, 0xd7, 0xe1, 0xf3, 0x50, 0xcd, 0x4c, 0xc7,
-	0xd1, 0x5c, 0x65, 0x1f, 0xb4, 0x5c, 0xf8, 0xbd, 0xde, 0x08, 0xcc, 0xd2, 0xb6, 0x1e, 0xe8, 0xc5,
-	0xee, 0xd0, 0x10, 0x04, 0x12, 0xf6, 0x47, 0x0c, 0xdb, 0x41, 0x1c, 0x07, 0xa8, 0x85, 0x9c, 0xbb,
-	0x68, 0xa3, 0x4f, 0x46, 0xfb, 0x90, 0xb6, 0x93, 0x09, 0x83, 0x32, 0xbd, 0x38, 0x52, 0xaf, 0x42,
-	0x1b, 0xb1, 0x50, 0xea, 0xd8, 0xd6, 0xd8, 0x6c, 0x44, 0x42, 0x41, 0x15, 0x5b, 0x17, 0x57, 0xb1,
-	0x1d, 0x4e, 0xda, 0x46, 0xae, 0x9d, 0xb3, 0xb9, 0x36, 0x5f, 0x4d, 0x5b, 0x1b, 0x5f, 0x4d, 0xb7,
-	0xa0, 0x7b, 0x5c, 0xa8, 0x05, 0x6d, 0x3c, 0xf7, 0xcd, 0xe2, 0xf1, 0x99, 0xfa, 0xf4, 0xce, 0xd6,
-	0x27, 0x98, 0x6d, 0xf8, 0xc4, 0x6c, 0x7f, 0x09, 0xe9, 0xb9, 0x22, 0x96, 0xe9, 0x83, 0x70, 0x7e,
-	0x87, 0xe7, 0xcb, 0x68, 0xf5, 0xec, 0x14, 0xef, 0x40, 0xd7, 0x2d, 0x67, 0x9a, 0x55, 0x13, 0x9b,
-	0xff, 0x5e, 0xb2, 0x80, 0xed, 0xc2, 0x4d, 0x8e, 0x27, 0x07, 0x98, 0xa9, 0x09, 0x72, 0xf1, 0x31,
-	0xfc, 0x96, 0xb8, 0xf0, 0xeb, 0x88, 0xfd, 0x0f, 0x7a, 0x6f, 0x4b, 0x2c, 0xde, 0x17, 0x52, 0xd3,
-	0x13, 0xaf, 0xd5, 0x52, 0x66, 0xb5, 0x8a, 0x01, 0xe6, 0xb5, 0xc8, 0x54, 0xae, 0xd1, 0xed, 0x85,
-	0x1e, 0xf7, 0x90, 0xfd, 0x00, 0xfd, 0xb7, 0xcb, 0x69, 0x21, 0x26, 0xf8, 0x0a, 0xb5, 0x30, 0x25,
-	0xa4, 0x0e, 0xc8, 0x7c, 0x4a, 0x16, 0xba, 0xbc, 0xc6, 0xc6, 0xc8, 0x29, 0x16, 0xa5, 0x5f, 0xce,
-	0x3d, 0xee, 0xe1, 0x65, 0xab, 0xf9, 0xc9, 0xdd, 0xef, 0xff, 0x3d, 0x95, 0x7a, 0xb6, 0x3a, 0x1a,
-	0x65, 0x6a, 0xb1, 0xbb, 0xbf, 0x9f, 0xe5, 0xbb, 0xd9, 0x4c, 0xc8, 0x7c, 0x7f, 0x7f, 0x97, 0x8a,
-	0x74, 0xd4, 0xa1, 0xff, 0x50, 0xfb, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x44, 0x92, 0x22, 0xa5,
-	0x6d, 0x0d, 0x00, 0x00,
+	// 1813 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xeb, 0x8a, 0x1b, 0xc9,
+	0x15, 0xa6, 0x5b, 0xd7, 0x3e, 0x92, 0x6f, 0x8d, 0x19, 0x2b, 0xc6, 0xf1, 0x4e, 0x1a, 0x07, 0x8c,
+	0x59, 0xc6, 0xe0, 0xd9, 0x7f, 0x1b, 0x48, 0x3c, 0xbe, 0x8d, 0xf1, 0x7a, 0x93, 0x94, 0x65, 0x6f,
+	0x48, 0x42, 0xa0, 0xa6, 0x75, 0x24, 0xf5, 0x5a, 0xea, 0x92, 0xbb, 0x4a, 0xb3, 0xad, 0xe4, 0x7f,
+	0x20, 0x24, 0x3f, 0x12, 0xf2, 0x42, 0x81, 0xbc, 0x40, 0x20, 0x8f, 0x92, 0x17, 0x08, 0x75, 0xaa,
+	0xaa, 0xbb, 0x5a, 0x17, 0x63, 0x48, 0x60, 0xff, 0xd5, 0x77, 0xba, 0x74, 0xee, 0xb7, 0x12, 0xdc,
+	0x50, 0x05, 0xcf, 0x25, 0x4f, 0x55, 0x26, 0xf2, 0x93, 0x55, 0x21, 0x94, 0x88, 0x3b, 0x6a, 0xb3,
+	0x42, 0x79, 0x7b, 0x98, 0x8a, 0xe5, 0xd2, 0x11, 0x93, 0xd7, 0x70, 0xe5, 0xb1, 0x94, 0xa8, 0xe4,
+	0x0b, 0xcc, 0x51, 0x66, 0x32, 0x3e, 0x82, 0x2e, 0x5f, 0x8a, 0x75, 0xae, 0x46, 0xe1, 0x71, 0x70,
+	0xbf, 0xc5, 0x2c, 0x8a, 0xef, 0xc1, 0x95, 0x02, 0xd5, 0xba, 0xc8, 0x1f, 0x4f, 0x26, 0x05, 0x4a,
+	0x39, 0x6a, 0x1d, 0x07, 0xf7, 0x23, 0xd6, 0x24, 0x26, 0x7f, 0x09, 0xe0, 0xa6, 0xe1, 0x37, 0xd6,
+	0xf2, 0xa7, 0x58, 0x8c, 0xc5, 0xb3, 0x12, 0xd3, 0xf8, 0x0e, 0x44, 0xa9, 0xc8, 0x72, 0x25, 0xde,
+	0x63, 0x3e, 0x0a, 0xe8, 0xa7, 0x35, 0xe1, 0xa0, 0xd0, 0x18, 0xda, 0xb9, 0x50, 0x48, 0xb2, 0x86,
+	0x8c, 0xce, 0xf1, 0x6d, 0xe8, 0x63, 0x89, 0xe9, 0xd7, 0x7c, 0x89, 0xa3, 0x36, 0x31, 0xaa, 0x70,
+	0x7c, 0x15, 0x42, 0x25, 0x46, 0x1d, 0xa2, 0x86, 0x4a, 0x24, 0x7f, 0x0c, 0xe0, 0xaa, 0x51, 0xe7,
+	0x9b, 0x4c, 0xcd, 0x27, 0x05, 0xff, 0xee, 0x7b, 0x52, 0xe4, 0x5b, 0xb8, 0xda, 0x74, 0xcb, 0xff,
+	0x51, 0x0f, 0x23, 0xab, 0x5d, 0xc9, 0x7a, 0x05, 0x1d, 0x92, 0xa5, 0x2f, 0x6b, 0x85, 0x2c, 0x77,
+	0x3a, 0x6b, 0xc6, 0x72, 0xb3, 0xbc, 0x10, 0x0b, 0x62, 0x1c, 0x31, 0x8b, 0x3c, 0x81, 0x2d, 0x5f,
+	0x60, 0xf2, 0xaf, 0x00, 0xfa, 0x4f, 0x0a, 0xe4, 0x0a, 0xc7, 0xa5, 0x95, 0x14, 0x38, 0x49, 0x07,
+	0xb5, 0xbc, 0x0e, 0xad, 0x29, 0xa2, 0xe5, 0xa4, 0x8f, 0x95, 0xde, 0x6d, 0x4f, 0xef, 0xbb, 0x00,
+	0x59, 0x15, 0x17, 0xf2, 0x55, 0x9f, 0x79, 0x94, 0x78, 0x04, 0xbd, 0x4c, 0x8e, 0xc9, 0x3f, 0x5d,
+	0xfa, 0xe8, 0x60, 0x7c, 0x0c, 0x03, 0x72, 0xd3, 0x1b, 0x63, 0x49, 0x8f, 0x14, 0xf2, 0x49, 0x8d,
+	0xd8, 0xf4, 0x9b, 0xb1, 0x49, 0x1e, 0xc0, 0x91, 0xb5, 0xa8, 0x2e, 0x91, 0x17, 0x85, 0x58, 0xaf,
+	0xb4, 0xde, 0xaa, 0x94, 0xa3, 0xe0, 0xb8, 0x75, 0x3f, 0x62, 0xfa, 0x98, 0xdc, 0x85, 0xfe, 0xdb,
+	0x5c, 0x66, 0xb3, 0x7c, 0x5c, 0x6a, 0x1b, 0x26, 0x5c, 0x71, 0xb2, 0x7f, 0xc8, 0xe8, 0x9c, 0x08,
+	0x18, 0x7c, 0x2d, 0xce, 0xf8, 0x82, 0xe7, 0xa9, 0x76, 0xd0, 0x4d, 0xe8, 0xa8, 0xf2, 0x1c, 0x4b,
+	0xeb, 0x23, 0x03, 0xb4, 0x21, 0x2b, 0xbe, 0xd1, 0x25, 0x62, 0x9d, 0xee, 0x20, 0x7d, 0x29, 0xb2,
+	0xcb, 0xf7, 0xb8, 0xb1, 0xe5, 0xe4, 0xa0, 0x76, 0x2d, 0x96, 0xab, 0xac, 0x70, 0xa9, 0x65, 0x51,
+	0xf2, 0x3b, 0xe8, 0xbf, 0xc9, 0x66, 0x39, 0x4e, 0xc6, 0xa5, 0xbe, 0xb3, 0x26, 0xe5, 0xac, 0x4a,
+	0x16, 0x69, 0x45, 0x89, 0x1a, 0x1a, 0x45, 0x89, 0x76, 0x04, 0xdd, 0xd5, 0xfa, 0xc2, 0x09, 0x1a,
+	0x32, 0x8b, 0x28, 0xa4, 0x1b, 0x92, 0xd1, 0x61, 0xa1, 0xda, 0x24, 0x7f, 0x0e, 0x61, 0xe0, 0xf9,
+	0xc5, 0xe8, 0x81, 0x29, 0x16, 0x4e, 0x86, 0x41, 0xd6, 0xa6, 0x85, 0xe0, 0x13, 0x2b, 0xc6, 0xc1,
+	0xf8, 0x04, 0x22, 0x2d, 0x91, 0xab, 0x75, 0x61, 0x52, 0x60, 0xf0, 0xe8, 0xfa, 0x09, 0xb5, 0x9e,
+	0x93, 0x37, 0x8e, 0xce, 0xea, 0x2b, 0x2e, 0x59, 0xda, 0x75, 0xb2, 0xd4, 0xb6, 0x77, 0x4c, 0x5a,
+	0x19, 0xa4, 0xbd, 0x9b, 0x8b, 0x3c, 0x45, 0x4a, 0x87, 0x16, 0x33, 0xc0, 0x26, 0x65, 0xaf, 0x4a,
+	0xca, 0xbb, 0x00, 0x33, 0x1d, 0xcd, 0x27, 0x94, 0x98, 0x7d, 0xb2, 0xcc, 0xa3, 0x68, 0xee, 0x73,
+	0xe4, 0x13, 0x2c, 0x46, 0x91, 0xb1, 0xc8, 0x20, 0x4a, 0x51, 0x2c, 0xd5, 0x08, 0x6c, 0x8a, 0x62,
+	0xa9, 0x92, 0x2f, 0x60, 0xe8, 0x39, 0x43, 0xc6, 0xf7, 0xea, 0x04, 0x19, 0x3c, 0x8a, 0xad, 0x55,
+	0xde, 0x0d, 0x93, 0x34, 0x3f, 0x85, 0x2b, 0x2c, 0xcb, 0x67, 0x95, 0xb5, 0xf1, 0x09, 0x74, 0x32,
+	0x85, 0x4b, 0xf7, 0xc3, 0x91, 0xfd, 0x61, 0xe3, 0xd2, 0x4b, 0x85, 0x4b, 0x66, 0xae, 0x25, 0x2f,
+	0xe1, 0xc6, 0xce, 0x37, 0x2f, 0x82, 0x9a, 0x4b, 0x1d, 0xc1, 0x3b, 0xbe, 0xbf, 0x43, 0xfa, 0x54,
+	0x13, 0x92, 0x5f, 0x42, 0x54, 0xeb, 0x61, 0x82, 0x1d, 0xb8, 0x60, 0x7b, 0x2c, 0xc3, 0xe3, 0xe0,
+	0x10, 0x4b, 0x93, 0x2f, 0x1e, 0xcb, 0xdf, 0xc2, 0x50, 0x27, 0xef, 0xcf, 0x2f, 0xb1, 0xb8, 0xcc,
+	0x90, 0xea, 0xb4, 0xc0, 0x34, 0xbb, 0xb4, 0x39, 0xd2, 0x62, 0x0e, 0xea, 0x2f, 0x17, 0xa6, 0x36,
+	0x6c, 0x83, 0x70, 0x50, 0x7f, 0x51, 0xe5, 0x13, 0xaf, 0xdf, 0x38, 0x98, 0xfc, 0x3d, 0x80, 0x1e,
+	0xc3, 0x0f, 0x54, 0x1e, 0x31, 0xb4, 0xf9, 0x64, 0x62, 0xd8, 0x46, 0xac, 0xcd, 0x2d, 0x6d, 0xba,
+	0xe0, 0x33, 0x62, 0xd8, 0x61, 0x74, 0xd6, 0x89, 0x91, 0x56, 0xbc, 0x3a, 0xcc, 0x00, 0x6d, 0xc5,
+	0x24, 0x2b, 0x90, 0x02, 0x63, 0x33, 0xbc, 0x26, 0x98, 0x34, 0xc8, 0x66, 0x73, 0xe5, 0x92, 0xcc,
+	0x20, 0xcd, 0x2b, 0xcb, 0x27, 0x58, 0xba, 0x24, 0x23, 0x90, 0xfc, 0x0a, 0x80, 0xe1, 0x87, 0x5f,
+	0x14, 0xd9, 0x25, 0x4f, 0x37, 0xb5, 0xbc, 0xe0, 0xa0, 0xbc, 0xf0, 0xb0, 0xbc, 0x96, 0x2f, 0x2f,
+	0xb9, 0x05, 0x9d, 0x73, 0x2c, 0x6d, 0x73, 0x2d, 0xab, 0xe6, 0x5a, 0x26, 0x6b, 0x18, 0x30, 0x5c,
+	0x2d, 0x36, 0xe3, 0xf2, 0x65, 0x3e, 0x15, 0xda, 0xee, 0x39, 0x97, 0x73, 0xd7, 0x7d, 0xf4, 0xd9,
+	0xe3, 0x19, 0xee, 0xb7, 0xa1, 0xe5, 0xd9, 0x10, 0xdf, 0x83, 0x2e, 0xa7, 0x19, 0x34, 0x6a, 0x53,
+	0x1a, 0x0e, 0x6d, 0x1a, 0xd2, 0xb0, 0x60, 0xf6, 0x5b, 0xf2, 0x23, 0x88, 0x18, 0x7e, 0x18, 0x97,
+	0x5f, 0x65, 0x52, 0x35, 0x0d, 0x6d, 0x59, 0x43, 0x93, 0xd3, 0x4a, 0x33, 0xba, 0xf4, 0x69, 0x45,
+	0xc1, 0x00, 0xc6, 0xe5, 0x39, 0x97, 0x73, 0xfa, 0x8d, 0xd6, 0x9c, 0xcb, 0x39, 0x4a, 0x97, 0xcc,
+	0x06, 0xd5, 0x02, 0x43, 0x4f, 0xa0, 0xd7, 0x10, 0x5a, 0xc7, 0xad, 0xba, 0x21, 0x24, 0x3f, 0x81,
+	0xa1, 0xe7, 0x22, 0x19, 0x7f, 0xae, 0xb3, 0x8a, 0x8e, 0x5b, 0xda, 0x78, 0xb7, 0x98, 0xbb, 0x92,
+	0x9c, 0xe8, 0x98, 0xa6, 0x98, 0xad, 0xd4, 0x57, 0x62, 0xb6, 0x53, 0x1b, 0xd7, 0xa1, 0xb5, 0x10,
+	0x33, 0x5b, 0x18, 0xfa, 0x98, 0x70, 0xe8, 0xd9, 0xfb, 0x3b, 0x97, 0x3f, 0x83, 0xf0, 0xd5, 0x3b,
+	0x2a, 0xbe, 0xc1, 0xa3, 0x6b, 0x56, 0xe6, 0x2b, 0xdc, 0xbc, 0xe3, 0x8b, 0x35, 0xb2, 0xf0, 0xd5,
+	0xbb, 0xf8, 0xc7, 0xd0, 0x5e, 0x88, 0x99, 0x24, 0xfd, 0x07, 0x8f, 0x6e, 0x54, 0x6a, 0x39, 0xf1,
+	0x8c, 0x3e, 0x27, 0x4f, 0x61, 0x60, 0x69, 0x4f, 0xb9, 0xe2, 0x3b, 0x62, 0x3e, 0x91, 0x8b, 0x9e,
+	0xd9, 0xe3, 0x92, 0xa1, 0x5c, 0x2f, 0x94, 0x97, 0x23, 0xc1, 0xfe, 0x1c, 0x31, 0x99, 0x6a, 0x40,
+	0x9c, 0x50, 0x12, 0x9a, 0xae, 0xbd, 0x2f, 0x94, 0xa1, 0x2a, 0xe3, 0x2f, 0x60, 0x50, 0x18, 0x91,
+	0x13, 0x6e, 0x47, 0xba, 0xef, 0xe9, 0x4a, 0x7d, 0xe6, 0x5f, 0xd3, 0xd5, 0x71, 0xb1, 0x10, 0xe9,
+	0x7b, 0x95, 0x2d, 0x5d, 0x5f, 0xaf, 0x09, 0xba, 0x69, 0x1b, 0x09, 0x34, 0xb1, 0xbb, 0x54, 0x04,
+	0x1e, 0x25, 0xf9, 0x67, 0x08, 0x37, 0x3c, 0x3d, 0x9e, 0xa2, 0xe2, 0xd9, 0xc2, 0x6a, 0x1b, 0x7c,
+	0x54, 0xdb, 0xcf, 0xa1, 0x67, 0xd5, 0x18, 0x85, 0x8d, 0x8b, 0xbe, 0xa6, 0xee, 0x0a, 0x75, 0xc4,
+	0x42, 0x88, 0xa9, 0xf1, 0xf1, 0x90, 0x59, 0xe4, 0x79, 0xb1, 0xbd, 0xdf, 0x8b, 0x1d, 0xbf, 0xd2,
+	0x1a, 0xb6, 0x76, 0xb7, 0x6d, 0xad, 0xb7, 0xa6, 0x5e, 0x63, 0x6b, 0xba, 0x0d, 0xfd, 0x69, 0x21,
+	0x96, 0xd4, 0xf1, 0xec, 0xce, 0xe2, 0xf0, 0x96, 0x7f, 0xa2, 0x6d, 0xff, 0x78, 0xb5, 0x0d, 0x1f,
+	0xa9, 0xed, 0x9f, 0x41, 0xbc, 0xe3, 0x44, 0x19, 0x3f, 0xf0, 0xeb, 0x77, 0xb4, 0xeb, 0x46, 0x73,
+	0xcf, 0x54, 0xf1, 0x31, 0xf4, 0x6d, 0x73, 0xa6, 0x5a, 0xd5, 0xba, 0xb9, 0x7d, 0xc9, 0x80, 0xe4,
+	0x0f, 0x10, 0xbd, 0xc6, 0xe5, 0x4a, 0x88, 0xc5, 0xb8, 0xfc, 0xa4, 0x00, 0xdd, 0x81, 0x08, 0x73,
+	0x85, 0xc5, 0x58, 0x3b, 0xcb, 0x94, 0x7d, 0x4d, 0xd0, 0x83, 0x62, 0x8a, 0xc8, 0xb8, 0x72, 0xeb,
+	0xa4, 0x83, 0x66, 0xcb, 0xf9, 0xbd, 0x5b, 0x1c, 0xe8, 0x9c, 0x9c, 0xc3, 0x75, 0x2b, 0xfc, 0x39,
+	0xe2, 0xd9, 0x3a, 0x7d, 0x8f, 0x4a, 0xbb, 0x6e, 0x99, 0xe5, 0xcf, 0x2d, 0x13, 0x53, 0x04, 0x1e,
+	0x65, 0x7f, 0xcb, 0x49, 0xce, 0x20, 0xb6, 0x9c, 0x9e, 0xd1, 0xc2, 0xf3, 0xa4, 0x6e, 0x44, 0xd5,
+	0x36, 0x14, 0x55, 0xdb, 0xd0, 0x7e, 0x1e, 0x7f, 0x0b, 0x61, 0x60, 0x99, 0xbc, 0x51, 0x5c, 0x55,
+	0x1a, 0x07, 0xb5, 0xc6, 0xfa, 0x97, 0x17, 0x1b, 0x85, 0xd2, 0xfd, 0x92, 0x40, 0xfc, 0x25, 0x0c,
+	0xa7, 0x88, 0xe7, 0x99, 0x54, 0x62, 0x56, 0xf0, 0xa5, 0x2d, 0xf8, 0x5b, 0xd6, 0x83, 0xdb, 0x26,
+	0xb2, 0xc6, 0xe5, 0xf8, 0x14, 0x7a, 0x46, 0x2d, 0xd7, 0xe8, 0x7f, 0xd0, 0xfc, 0x9d, 0x67, 0x10,
+	0x73, 0x37, 0xb5, 0x97, 0xc4, 0x62, 0x82, 0x52, 0xe9, 0x16, 0x4d, 0xd9, 0x3c, 0x64, 0x1e, 0x25,
+	0xbe, 0x0f, 0xd7, 0x0c, 0x7a, 0x56, 0xc5, 0xca, 0x24, 0xf6, 0x36, 0x59, 0xc7, 0xd3, 0x90, 0x1e,
+	0xcf, 0xd0, 0x66, 0x78, 0x4d, 0x48, 0x1e, 0xc2, 0x2d, 0x86, 0x1f, 0x9e, 0x62, 0x2a, 0x26, 0xc8,
+	0xf8, 0x77, 0xfe, 0xaa, 0xb9, 0x77, 0x79, 0x4e, 0xbe, 0x84, 0xe8, 0xad, 0xc4, 0xe2, 0x9b, 0x22,
+	0x33, 0xb1, 0x52, 0x62, 0x95, 0xa5, 0xd5, 0x15, 0x0d, 0x74, 0x8e, 0xa4, 0x22, 0x57, 0x68, 0xfd,
+	0x1f, 0x31, 0x07, 0x93, 0xdf, 0xc0, 0xe0, 0xed, 0x6a, 0x56, 0xf0, 0x09, 0xbe, 0x46, 0xc5, 0x75,
+	0x85, 0x51, 0x81, 0x66, 0xf9, 0x8c, 0x38, 0xf4, 0x59, 0x85, 0x35, 0x93, 0x4b, 0x2c, 0xa4, 0x9b,
+	0xdd, 0x11, 0x73, 0xf0, 0xe0, 0xe4, 0xfe, 0x4f, 0x00, 0xd7, 0xea, 0xde, 0xfb, 0x92, 0x2a, 0xff,
+	0x08, 0xba, 0x8a, 0xa6, 0x9c, 0x5b, 0x97, 0x0d, 0xaa, 0x3a, 0x02, 0x7d, 0x32, 0x33, 0xa5, 0x26,
+	0x1c, 0x92, 0x60, 0xb6, 0x24, 0x62, 0x6c, 0xf7, 0x17, 0x07, 0xb5, 0x25, 0x0b, 0x2b, 0x93, 0x82,
+	0xd5, 0x61, 0x15, 0xf6, 0x92, 0xb4, 0xdb, 0x48, 0x52, 0x33, 0x4d, 0x7a, 0xdb, 0x13, 0xae, 0x5f,
+	0x4d, 0x38, 0xda, 0xad, 0x0a, 0xb1, 0xb4, 0xfd, 0x85, 0xce, 0x76, 0xbd, 0x86, 0xea, 0x75, 0xf9,
+	0x8f, 0x80, 0x56, 0xa1, 0x17, 0xa8, 0x8d, 0x96, 0x07, 0x2b, 0xc2, 0x28, 0x38, 0xd6, 0x79, 0x47,
+	0x73, 0xb1, 0xc3, 0x2a, 0xac, 0x73, 0x4d, 0xb3, 0x3e, 0xf7, 0x4d, 0xf6, 0x28, 0xfa, 0xb7, 0x4a,
+	0x9c, 0xfb, 0xed, 0xb6, 0xc2, 0x75, 0xd3, 0xe9, 0x78, 0x4d, 0xa7, 0xae, 0xbf, 0xae, 0xbf, 0x90,
+	0x1d, 0x41, 0x37, 0x5d, 0x17, 0x52, 0x14, 0xf6, 0x75, 0x60, 0x51, 0xc2, 0xec, 0xda, 0xe0, 0x6c,
+	0x78, 0x60, 0xc7, 0xaa, 0xe9, 0x80, 0x47, 0x3b, 0x63, 0x95, 0xdc, 0x6a, 0x66, 0xab, 0xc7, 0x33,
+	0x6c, 0xf0, 0xfc, 0x77, 0x00, 0x03, 0xdd, 0x16, 0xc7, 0x36, 0x40, 0xff, 0xfb, 0xba, 0x56, 0x7b,
+	0xb6, 0xdd, 0xf0, 0x6c, 0x73, 0x14, 0x74, 0x76, 0x46, 0x81, 0x09, 0x73, 0xb7, 0x0a, 0x73, 0x63,
+	0x18, 0xf5, 0xb6, 0x87, 0x51, 0x63, 0x69, 0xed, 0x6f, 0x2d, 0xad, 0xc9, 0x9f, 0x2a, 0xab, 0x3e,
+	0xde, 0xff, 0x9a, 0x3a, 0x85, 0x3b, 0x3a, 0x35, 0xa4, 0xb4, 0xb6, 0xa4, 0x6c, 0xbf, 0x41, 0xeb,
+	0x68, 0x76, 0xfc, 0x6e, 0xfa, 0xd7, 0x10, 0x62, 0xda, 0x4c, 0xe5, 0x19, 0x3d, 0x9e, 0x9f, 0x67,
+	0x0b, 0x85, 0xfb, 0xdf, 0x08, 0xb5, 0x9a, 0xe1, 0x47, 0xd4, 0x6c, 0xed, 0xa8, 0xe9, 0xde, 0x16,
+	0x6d, 0xef, 0x6d, 0xa1, 0xff, 0x30, 0x51, 0x5c, 0xad, 0xa5, 0xad, 0x33, 0x8b, 0xe8, 0x8d, 0xa4,
+	0x78, 0xa1, 0xbc, 0x56, 0x58, 0x13, 0x74, 0xe5, 0x62, 0x3e, 0x19, 0xd7, 0x2e, 0x77, 0xb0, 0x36,
+	0xae, 0x7f, 0xf0, 0xed, 0x10, 0xed, 0x79, 0x3b, 0xd8, 0xa4, 0x83, 0x46, 0xd2, 0x7d, 0x0b, 0x37,
+	0xed, 0x66, 0xdb, 0xf4, 0xc9, 0xde, 0x8d, 0xdc, 0xcb, 0x4e, 0x9a, 0xe5, 0xa6, 0x99, 0x2a, 0xbe,
+	0x70, 0xa3, 0x87, 0x80, 0x27, 0xab, 0xe5, 0xcb, 0x3a, 0xfb, 0xec, 0xd7, 0x3f, 0x9c, 0x65, 0x6a,
+	0xbe, 0xbe, 0x38, 0x49, 0xc5, 0xf2, 0xe1, 0xe9, 0x69, 0x9a, 0x3f, 0x4c, 0xe7, 0x3c, 0xcb, 0x4f,
+	0x4f, 0x1f, 0x12, 0xff, 0x8b, 0x2e, 0xfd, 0xa1, 0x78, 0xfa, 0xdf, 0x01, 0x00, 0xda, 0x4e, 0x85,
+	0x49, 0x7a, 0x14, 0x00, 0x00,
 }
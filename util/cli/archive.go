@@ -0,0 +1,121 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/33cn/chain33/blockchain"
+	dbm "github.com/33cn/chain33/common/db"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/consensus"
+	"github.com/33cn/chain33/executor"
+	"github.com/33cn/chain33/mempool"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/rpc"
+	"github.com/33cn/chain33/store"
+	"github.com/33cn/chain33/types"
+)
+
+//runSubCommand 处理 export-blocks, import-blocks, verify 子命令, 返回 false 表示不是子命令
+func runSubCommand(cfg *types.Config, sub *types.ConfigSubModule, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	var err error
+	switch args[0] {
+	case "export-blocks":
+		err = exportBlocks(cfg, args[1:])
+	case "import-blocks":
+		err = importBlocks(cfg, sub, args[1:])
+	case "verify":
+		err = verifyBlocks(cfg, sub, args[1:])
+	default:
+		return false
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, args[0], "error:", err)
+		os.Exit(1)
+	}
+	return true
+}
+
+//exportBlocks 导出区块到归档文件, 节点需要先停止
+func exportBlocks(cfg *types.Config, args []string) error {
+	fs := flag.NewFlagSet("export-blocks", flag.ExitOnError)
+	output := fs.String("o", "blocks.archive", "archive file")
+	start := fs.Int64("start", 0, "start height")
+	end := fs.Int64("end", -1, "end height, -1 means the last block")
+	fs.Parse(args)
+
+	db := dbm.NewDB("blockchain", cfg.BlockChain.Driver, cfg.BlockChain.DbPath, cfg.BlockChain.DbCache)
+	defer db.Close()
+	bs := blockchain.NewBlockStore(db, nil)
+	log.Info("export blocks", "file", *output, "start", *start, "end", *end, "height", bs.Height())
+	return bs.ExportBlocks(*output, *start, *end)
+}
+
+//importBlocks 从归档文件导入区块, 不启动 p2p, rpc 服务以及钱包, 共识模块不挖矿, 区块通过执行模块完整的验证
+func importBlocks(cfg *types.Config, sub *types.ConfigSubModule, args []string) error {
+	fs := flag.NewFlagSet("import-blocks", flag.ExitOnError)
+	input := fs.String("i", "blocks.archive", "archive file")
+	skipSign := fs.Bool("skipsign", false, "skip signature checks, only for trusted archive")
+	fs.Parse(args)
+
+	q := queue.New("channel")
+	exec := executor.New(cfg.Exec, sub.Exec)
+	exec.SetQueueClient(q.Client())
+	s := store.New(cfg.Store, sub.Store)
+	s.SetQueueClient(q.Client())
+	chain := blockchain.New(cfg.BlockChain)
+	chain.SetQueueClient(q.Client())
+	chain.UpgradeChain()
+	mem := mempool.New(cfg.MemPool)
+	mem.SetQueueClient(q.Client())
+	rpcapi := rpc.New(cfg.RPC)
+	rpcapi.SetQueueClientNoListen(q.Client())
+	//共识模块只用来生成创世区块以及执行区块时的 CheckBlock, 导入的时候不挖矿
+	cscfg := *cfg.Consensus
+	cscfg.Minerstart = false
+	cs := consensus.New(&cscfg, sub.Consensus)
+	cs.SetQueueClient(q.Client())
+	//不启动钱包, 丢弃区块链模块发给钱包的消息
+	discardTopics(q, "wallet")
+	go q.Start()
+	defer func() {
+		chain.Close()
+		mem.Close()
+		exec.Close()
+		s.Close()
+		cs.Close()
+		rpcapi.Close()
+		q.Close()
+	}()
+
+	//等待共识模块生成创世区块
+	for chain.GetBlockHeight() < 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+	log.Info("import blocks", "file", *input, "height", chain.GetBlockHeight(), "skipsign", *skipSign)
+	err := chain.ImportBlocks(*input, *skipSign)
+	log.Info("import blocks done", "height", chain.GetBlockHeight())
+	return err
+}
+
+//discardTopics 订阅没有启动的模块的消息并丢弃, 避免消息队列满了之后阻塞
+func discardTopics(q queue.Queue, topics ...string) {
+	for _, topic := range topics {
+		client := q.Client()
+		client.Sub(topic)
+		go func() {
+			for msg := range client.Recv() {
+				msg.Reply(client.NewMessage("", types.EventReply, &types.Reply{Msg: []byte(types.ErrNotSupport.Error())}))
+			}
+		}()
+	}
+}
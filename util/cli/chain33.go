@@ -126,6 +126,10 @@ func RunChain33(name string) {
 	version.SetLocalDBVersion(cfg.Store.LocalDBVersion)
 	version.SetAppVersion(cfg.Version)
 	log.Info(cfg.Title + "-app:" + version.GetAppVersion() + " chain33:" + version.GetVersion() + " localdb:" + version.GetLocalDBVersion())
+	//export-blocks, import-blocks 子命令执行完之后直接退出
+	if runSubCommand(cfg, sub, flag.Args()) {
+		return
+	}
 	log.Info("loading queue")
 	q := queue.New("channel")
 
@@ -18,8 +18,32 @@ import (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   types.GetTitle() + "-cli",
-	Short: types.GetTitle() + " client tools",
+	Use:              types.GetTitle() + "-cli",
+	Short:            types.GetTitle() + " client tools",
+	PersistentPreRun: setRPCClient,
+}
+
+// setRPCClient 设置连接节点的认证 token 和 tls 配置
+func setRPCClient(cmd *cobra.Command, args []string) {
+	token, _ := cmd.Flags().GetString("rpc_token")
+	jsonclient.SetToken(token)
+	setTLSConfig(cmd, args)
+}
+
+// setTLSConfig rpc_laddr 是 https 地址时, 用命令行参数中的证书连接节点
+func setTLSConfig(cmd *cobra.Command, args []string) {
+	caFile, _ := cmd.Flags().GetString("rpc_tls_ca")
+	certFile, _ := cmd.Flags().GetString("rpc_tls_cert")
+	keyFile, _ := cmd.Flags().GetString("rpc_tls_key")
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return
+	}
+	cfg, err := jsonclient.NewTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	jsonclient.SetTLSConfig(cfg)
 }
 
 var sendCmd = &cobra.Command{
@@ -68,6 +92,10 @@ func Run(RPCAddr, ParaName string) {
 	types.S("ParaName", ParaName)
 	rootCmd.PersistentFlags().String("rpc_laddr", types.GStr("RPCAddr"), "http url")
 	rootCmd.PersistentFlags().String("paraName", types.GStr("ParaName"), "parachain")
+	rootCmd.PersistentFlags().String("rpc_tls_ca", "", "CA certificate file to verify the https rpc server")
+	rootCmd.PersistentFlags().String("rpc_tls_cert", "", "client certificate file for mutual tls")
+	rootCmd.PersistentFlags().String("rpc_tls_key", "", "client private key file for mutual tls")
+	rootCmd.PersistentFlags().String("rpc_token", "", "api key or jwt for the rpc auth, admin methods need the admin role")
 	if len(os.Args) > 1 {
 		if os.Args[1] == "send" {
 			commands.OneStepSend(os.Args)
@@ -0,0 +1,81 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/33cn/chain33/blockchain"
+	dbm "github.com/33cn/chain33/common/db"
+	log "github.com/33cn/chain33/common/log/log15"
+	mavl "github.com/33cn/chain33/system/store/mavl/db"
+	"github.com/33cn/chain33/types"
+)
+
+//mavlPruneConfig mavl 状态裁剪的配置
+type mavlPruneConfig struct {
+	EnableMavlPrune bool  `json:"enableMavlPrune"`
+	PruneHeight     int64 `json:"pruneHeight"`
+}
+
+//verifyBlocks 检查区块数据的一致性, 节点需要先停止, 结果以 json 格式输出
+func verifyBlocks(cfg *types.Config, sub *types.ConfigSubModule, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	start := fs.Int64("start", 0, "start height")
+	end := fs.Int64("end", -1, "end height, -1 means the last block")
+	repair := fs.Bool("repair", false, "repair the height, hash and tx indexes")
+	output := fs.String("o", "", "report file, default stdout")
+	fs.Parse(args)
+
+	db := dbm.NewDB("blockchain", cfg.BlockChain.Driver, cfg.BlockChain.DbPath, cfg.BlockChain.DbCache)
+	defer db.Close()
+	bs := blockchain.NewBlockStore(db, nil)
+	types.S("quickIndex", cfg.BlockChain.EnableTxQuickIndex)
+	opts := &blockchain.VerifyOptions{
+		Start:    *start,
+		End:      *end,
+		Repair:   *repair,
+		Sequence: cfg.BlockChain.IsRecordBlockSequence,
+	}
+	//只有 mavl 可以检查状态根是否存在
+	if cfg.Store.Name == "mavl" {
+		storeDB := dbm.NewDB("store", cfg.Store.Driver, cfg.Store.DbPath, cfg.Store.DbCache)
+		defer storeDB.Close()
+		opts.HasState = func(stateHash []byte) bool {
+			return mavl.NewTree(storeDB, true).Load(stateHash) == nil
+		}
+		var prune mavlPruneConfig
+		if sub.Store["mavl"] != nil {
+			types.MustDecode(sub.Store["mavl"], &prune)
+		}
+		if prune.EnableMavlPrune {
+			if prune.PruneHeight <= 0 {
+				prune.PruneHeight = 10000
+			}
+			opts.StateStart = bs.Height() - prune.PruneHeight
+		}
+	}
+	log.Info("verify blocks", "start", *start, "end", *end, "height", bs.Height(), "repair", *repair)
+	report, err := bs.Verify(opts)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		fmt.Println(string(data))
+	} else if err = ioutil.WriteFile(*output, data, 0644); err != nil {
+		return err
+	}
+	if n := report.Unrepaired(); n > 0 {
+		return fmt.Errorf("%d issues not repaired", n)
+	}
+	return nil
+}
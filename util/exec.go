@@ -6,6 +6,7 @@ package util
 
 import (
 	"errors"
+	"time"
 
 	"github.com/33cn/chain33/common"
 	log "github.com/33cn/chain33/common/log/log15"
@@ -29,6 +30,24 @@ func CheckBlock(client queue.Client, block *types.BlockDetail) error {
 	return errors.New(string(reply.GetMsg()))
 }
 
+//CheckHeaders : To check the headers by consensus, headers.Items[0] is the trusted parent
+func CheckHeaders(client queue.Client, headers *types.Headers) error {
+	msg := client.NewMessage("consensus", types.EventCheckHeaders, headers)
+	err := client.SendTimeout(msg, true, time.Minute)
+	if err != nil {
+		return err
+	}
+	resp, err := client.WaitTimeout(msg, time.Minute)
+	if err != nil {
+		return err
+	}
+	reply := resp.GetData().(*types.Reply)
+	if reply.IsOk {
+		return nil
+	}
+	return errors.New(string(reply.GetMsg()))
+}
+
 //ExecTx : To send lists of txs within a block to exector for execution
 func ExecTx(client queue.Client, prevStateRoot []byte, block *types.Block) *types.Receipts {
 	list := &types.ExecTxList{